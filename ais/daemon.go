@@ -20,6 +20,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/replicate"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -186,6 +188,8 @@ func initDaemon(version, buildTime string) cos.Runner {
 	xreg.Init()
 	xs.Init()
 	space.Init()
+	scrub.Init()
+	replicate.Init()
 	downloader.Init()
 
 	// fork (proxy | target)
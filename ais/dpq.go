@@ -25,6 +25,9 @@ type dpq struct {
 	appendTy, appendHdl string // APPEND { apc.AppendOp, ... }
 	owt                 string // object write transaction { OwtPut, ... }
 	dontLookupRemoteBck string // (as the name implies)
+	objVer              string // previous object version
+	user, usig          string // AuthN user ID (rate limiting) and the proxy's signature
+	bypassGov           string // bypass object lock retention in governance mode
 }
 
 var (
@@ -76,7 +79,10 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 		case apc.QparamUnixTime:
 			dpq.ptime = value
 		case apc.QparamUUID:
-			dpq.uuid = value
+			// (may be an ETL pipeline - see etl.Pipeline)
+			if dpq.uuid, err = url.QueryUnescape(value); err != nil {
+				return
+			}
 		case apc.QparamArchpath:
 			if dpq.archpath, err = url.QueryUnescape(value); err != nil {
 				return
@@ -101,6 +107,16 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 			dpq.owt = value
 		case apc.QparamDontLookupRemoteBck:
 			dpq.dontLookupRemoteBck = value
+		case apc.QparamObjVersion:
+			dpq.objVer = value
+		case apc.QparamBypassGov:
+			dpq.bypassGov = value
+		case apc.QparamUserID:
+			if dpq.user, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamUserSig:
+			dpq.usig = value
 		default:
 			err = errors.New("failed to fast-parse [" + rawQuery + "]")
 			return
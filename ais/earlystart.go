@@ -251,6 +251,7 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 		// NOTE: use regpool to try to upgrade all the four revs: Smap, BMD, RMD, and global Config
 		before.Smap, before.BMD, before.RMD, before.EtlMD = clone, p.owner.bmd.get(), p.owner.rmd.get(), p.owner.etl.get()
 		before.Config, _ = p.owner.config.get()
+		before.SchedMD = p.owner.sched.get()
 
 		smap = p.regpoolMaxVer(&before, &after)
 
@@ -364,6 +365,9 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	if etlMD.Version > 0 {
 		_ = p.metasyncer.sync(revsPair{etlMD, aisMsg})
 	}
+	if schedMD := p.owner.sched.get(); schedMD.version() > 0 {
+		_ = p.metasyncer.sync(revsPair{schedMD, aisMsg})
+	}
 
 	// Clear regpool
 	p.reg.mtx.Lock()
@@ -850,6 +854,11 @@ func (p *proxy) regpoolMaxVer(before, after *cluMeta) (smap *smapX) {
 				after.Config = regReq.Config
 			}
 		}
+		if regReq.SchedMD != nil && regReq.SchedMD.version() > 0 {
+			if after.SchedMD == nil || after.SchedMD.version() < regReq.SchedMD.version() {
+				after.SchedMD = regReq.SchedMD
+			}
+		}
 	}
 	if after.BMD != before.BMD {
 		if err := p.owner.bmd.putPersist(after.BMD, nil); err != nil {
@@ -872,6 +881,11 @@ func (p *proxy) regpoolMaxVer(before, after *cluMeta) (smap *smapX) {
 			cos.ExitLogf("FATAL: %v", err)
 		}
 	}
+	if after.SchedMD != before.SchedMD {
+		if err := p.owner.sched.putPersist(after.SchedMD, nil); err != nil {
+			glog.Errorf("%s: failed to persist %s: %v - proceeding anyway...", p, after.SchedMD, err)
+		}
+	}
 
 	// TODO: implement EtlMD
 ret:
@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/repair"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/volume"
@@ -122,6 +123,8 @@ func (g *fsprungroup) doDD(action string, flags uint64, mpath string, dontResilv
 		glog.Infof("%s: %q %s but resilvering=(%t, %t)", g.t, action, rmi,
 			!dontResilver, cmn.GCO.Get().Resilver.Enabled)
 		g.postDD(rmi, action, nil /*xaction*/, nil /*error*/) // ditto (compare with the one below)
+		// without resilvering, restore (in priority order) the copies and slices that were lost
+		repair.Scan(fmt.Sprintf("%q %s", action, rmi))
 		return
 	}
 
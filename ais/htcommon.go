@@ -28,6 +28,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/tinylib/msgp/msgp"
@@ -117,6 +118,7 @@ type (
 		BMD            *bucketMD      `json:"bmd"`
 		RMD            *rebMD         `json:"rmd"`
 		EtlMD          *etlMD         `json:"etlMD"`
+		SchedMD        *schedMD       `json:"schedMD,omitempty"`
 		Config         *globalConfig  `json:"config"`
 		SI             *cluster.Snode `json:"si"`
 		RebInterrupted bool           `json:"reb_interrupted"`
@@ -131,6 +133,7 @@ type (
 		skipRMD       bool
 		skipConfig    bool
 		skipEtlMD     bool
+		skipSchedMD   bool
 		fillRebMarker bool
 	}
 
@@ -424,6 +427,7 @@ type (
 	cresEH struct{} // -> etl.PodHealthMsg
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
+	cresJH struct{} // -> []*xact.HistRecord
 
 	cresBsumm struct{} // -> cmn.BckSummaries
 )
@@ -439,6 +443,7 @@ var (
 	_ cresv = cresEH{}
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
+	_ cresv = cresJH{}
 	_ cresv = cresBsumm{}
 )
 
@@ -483,6 +488,9 @@ func (c cresIC) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBM) newV() interface{}                      { return &bucketMD{} }
 func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresJH) newV() interface{}                      { return &[]*xact.HistRecord{} }
+func (c cresJH) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 func (cresBsumm) newV() interface{}                      { return &cmn.BckSummaries{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
@@ -58,7 +58,8 @@ type htrun struct {
 		bmd    bmdOwner // interface with proxy and target impl-s
 		rmd    *rmdOwner
 		config *configOwner
-		etl    etlOwner // ditto
+		etl    etlOwner    // ditto
+		sched  *schedOwner // proxy only
 	}
 	startup struct {
 		cluster atomic.Bool // determines if the cluster has started up
@@ -68,6 +69,7 @@ type htrun struct {
 	smm                 *memsys.MMSA // system MMSA for small-size allocations
 	electable           electable
 	inPrimaryTransition atomic.Bool
+	ratelim             ratelim
 }
 
 ///////////
@@ -131,6 +133,9 @@ func (h *htrun) cluMeta(opts cmetaFillOpt) (*cluMeta, error) {
 	if !opts.skipEtlMD {
 		cm.EtlMD = h.owner.etl.get()
 	}
+	if h.owner.sched != nil && !opts.skipSchedMD {
+		cm.SchedMD = h.owner.sched.get()
+	}
 	if h.si.IsTarget() && opts.fillRebMarker {
 		rebMarked := xreg.GetRebMarked()
 		cm.RebInterrupted = rebMarked.Interrupted
@@ -1630,6 +1635,7 @@ func (h *htrun) registerToURL(url string, psi *cluster.Snode, tout time.Duration
 			skipRMD:       keepalive,
 			skipConfig:    keepalive,
 			skipEtlMD:     keepalive,
+			skipSchedMD:   keepalive,
 			fillRebMarker: !keepalive,
 		}
 	)
@@ -43,6 +43,7 @@ type (
 		smap  *smapX
 		query url.Values
 		msg   interface{}
+		user  string // initiating user (job history)
 	}
 
 	xactRegMsg struct {
@@ -320,6 +321,15 @@ func (ic *ic) handlePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ic *ic) registerEqual(a regIC) {
+	// job history: initiating request and user
+	switch msg := a.msg.(type) {
+	case *apc.ActionMsg:
+		a.nl.SetInitiator(msg, a.user)
+	case *aisMsg:
+		a.nl.SetInitiator(&msg.ActionMsg, a.user)
+	default:
+		a.nl.SetInitiator(nil, a.user)
+	}
 	if a.query != nil {
 		a.query.Set(apc.QparamNotifyMe, equalIC)
 	}
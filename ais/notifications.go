@@ -400,6 +400,7 @@ func (n *notifs) done(nl nl.NotifListener) {
 		}
 	}
 	nl.Callback(nl, time.Now().UnixNano())
+	n.p.jhist.record(nl)
 }
 
 //
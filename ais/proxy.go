@@ -31,6 +31,7 @@ import (
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/objwalk/where"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/xact"
@@ -76,11 +77,13 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		ic         ic
+		jhist      jobHist
 		reg        struct {
 			mtx  sync.RWMutex
 			pool nodeRegPool
 		}
-		qm lsobjMem
+		qm        lsobjMem
+		scheduler scheduler
 	}
 )
 
@@ -165,9 +168,11 @@ func (p *proxy) Run() error {
 	p.htrun.electable = p
 	p.owner.bmd = newBMDOwnerPrx(config)
 	p.owner.etl = newEtlMDOwnerPrx(config)
+	p.owner.sched = newSchedOwner(config)
 
-	p.owner.bmd.init() // initialize owner and load BMD
-	p.owner.etl.init() // initialize owner and load EtlMD
+	p.owner.bmd.init()   // initialize owner and load BMD
+	p.owner.etl.init()   // initialize owner and load EtlMD
+	p.owner.sched.init() // ditto SchedMD
 
 	cluster.Init(nil /*cluster.Target*/)
 
@@ -182,7 +187,10 @@ func (p *proxy) Run() error {
 
 	p.notifs.init(p)
 	p.ic.init(p)
+	p.jhist.init(p)
 	p.qm.init()
+	p.ratelim.init()
+	p.scheduler.init(p)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -196,6 +204,7 @@ func (p *proxy) Run() error {
 		{r: apc.Download, h: p.downloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
 		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.Schedule, h: p.scheduleHandler, net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
@@ -309,6 +318,16 @@ func (p *proxy) recvCluMeta(cluMeta *cluMeta, action, caller string) (err error)
 	} else {
 		glog.Infof("%s: synch %s", p, cluMeta.EtlMD)
 	}
+	// SchedMD
+	if cluMeta.SchedMD != nil {
+		if err = p.receiveSchedMD(cluMeta.SchedMD, msg, nil, caller); err != nil {
+			if !isErrDowngrade(err) {
+				glog.Error(cmn.NewErrFailedTo(p, "sync", cluMeta.SchedMD, err))
+			}
+		} else {
+			glog.Infof("%s: synch %s", p, cluMeta.SchedMD)
+		}
+	}
 	return
 }
 
@@ -329,6 +348,7 @@ func (p *proxy) Stop(err error) {
 	f("Stopping %s%s, err: %v", p.si, s, err)
 	xreg.AbortAll(errors.New("p-stop"))
 	p.htrun.stop(!isPrimary && smap.isValid() && !isErrNoUnregister(err) /* rm from Smap*/)
+	p.jhist.stop()
 }
 
 ////////////////////////////////////////
@@ -555,7 +575,13 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 		return
 	}
 
-	// 3. redirect
+	// 3. rate limit
+	user, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpGet)
+	if !ok {
+		return
+	}
+
+	// 4. redirect
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -565,10 +591,10 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("%s %s/%s => %s", r.Method, bck.Name, objName, si)
 	}
-	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraData)
+	redirectURL := p.redirectURL(r, si, time.Now() /*started*/, cmn.NetIntraData, user)
 	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
 
-	// 4. stats
+	// 5. stats
 	p.statsT.Add(stats.GetCount, 1)
 }
 
@@ -585,6 +611,7 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	appendTyProvided := apireq.dpq.appendTy != "" // apc.QparamAppendType
+	bypassGov := cos.IsParseBool(apireq.dpq.bypassGov)
 	if !appendTyProvided {
 		perms = apc.AcePUT
 	} else {
@@ -618,8 +645,17 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if err := p.checkBypassGov(w, r, bypassGov); err != nil {
+		return
+	}
+
+	// 3. rate limit
+	user, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpPut)
+	if !ok {
+		return
+	}
 
-	// 3. redirect
+	// 4. redirect
 	var (
 		si      *cluster.Snode
 		smap    = p.owner.smap.get()
@@ -642,10 +678,10 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("%s %s/%s => %s (append: %v)", r.Method, bck.Name, objName, si, appendTyProvided)
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, user)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
-	// 4. stats
+	// 5. stats
 	if !appendTyProvided {
 		p.statsT.Add(stats.PutCount, 1)
 	} else {
@@ -668,6 +704,12 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if err := p.checkBypassGov(w, r, cos.IsParseBool(r.URL.Query().Get(apc.QparamBypassGov))); err != nil {
+		return
+	}
+	if _, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpDelete); !ok {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -768,7 +810,7 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			p.writeErrf(w, r, fmtNotRemote, bck.Name)
 			return
 		}
-		if xactID, err = p.doListRange(r.Method, bck.Name, msg, apireq.query); err != nil {
+		if xactID, err = p.doListRange(r.Method, bck.Name, msg, apireq.query, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -814,6 +856,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		newBMD, msgBMD, errBMD       = p.extractBMD(payload, caller)
 		newRMD, msgRMD, errRMD       = p.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = p.extractEtlMD(payload, caller)
+		newSchedMD, msgSched, errSch = p.extractSchedMD(payload, caller)
 		revokedTokens, errTokens     = p.extractRevokedTokenList(payload, caller)
 	)
 	// 2. apply
@@ -832,19 +875,26 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	if errEtlMD == nil && newEtlMD != nil {
 		errEtlMD = p.receiveEtlMD(newEtlMD, msgEtlMD, payload, caller, nil)
 	}
+	if errSch == nil && newSchedMD != nil {
+		errSch = p.receiveSchedMD(newSchedMD, msgSched, payload, caller)
+	}
 	if errTokens == nil && revokedTokens != nil {
 		_ = p.authn.updateRevokedList(revokedTokens)
 	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil &&
+		errSch == nil {
 		return
 	}
 	cii.fill(&p.htrun)
-	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens)
+	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errSch, errTokens)
 	p.writeErr(w, r, errors.New(cos.MustMarshalToString(err)), http.StatusConflict)
 }
 
 func (p *proxy) syncNewICOwners(smap, newSmap *smapX) {
+	if !smap.IsIC(p.si) && newSmap.IsIC(p.si) {
+		go p.jhist.sync(smap, newSmap) // (catching up)
+	}
 	if !smap.IsIC(p.si) || !newSmap.IsIC(p.si) {
 		return
 	}
@@ -1066,7 +1116,7 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 		}
 		glog.Infof("%s bucket %s => %s", msg.Action, bckFrom, bckTo)
 		var xactID string
-		if xactID, err = p.renameBucket(bckFrom, bckTo, msg); err != nil {
+		if xactID, err = p.renameBucket(bckFrom, bckTo, msg, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1122,7 +1172,7 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		glog.Infof("%s bucket %s => %s", msg.Action, bck, bckTo)
-		if xactID, err = p.tcb(bck, bckTo, msg, tcbMsg.DryRun); err != nil {
+		if xactID, err = p.tcb(bck, bckTo, msg, tcbMsg.DryRun, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1175,7 +1225,7 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		var xactID string
-		if xactID, err = p.doListRange(r.Method, bucket, msg, query); err != nil {
+		if xactID, err = p.doListRange(r.Method, bucket, msg, query, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1184,14 +1234,14 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 		p.qm.c.invalidate(bck.Bucket())
 	case apc.ActMakeNCopies:
 		var xactID string
-		if xactID, err = p.makeNCopies(msg, bck); err != nil {
+		if xactID, err = p.makeNCopies(msg, bck, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
 		w.Write([]byte(xactID))
 	case apc.ActECEncode:
 		var xactID string
-		if xactID, err = p.ecEncode(bck, msg); err != nil {
+		if xactID, err = p.ecEncode(bck, msg, p.reqUser(r.Header)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1280,6 +1330,9 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *cluster
 		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, amsg.Action, amsg.Value, err)
 		return
 	}
+	if _, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpList); !ok {
+		return
+	}
 	if smap.CountActiveTargets() < 1 {
 		p.writeErrMsg(w, r, "no registered targets yet")
 		return
@@ -1295,6 +1348,16 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *cluster
 	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsPresent)
 	}
+	// Filter expressions are evaluated by targets against object metadata,
+	// and therefore apply only to objects present in the cluster.
+	if lsmsg.Where != "" {
+		if _, err := where.Parse(lsmsg.Where); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		lsmsg.SetFlag(apc.LsPresent)
+		lsmsg.ClearFlag(apc.LsNameOnly | apc.UseListObjsCache)
+	}
 
 	locationIsAIS := bck.IsAIS() || lsmsg.IsFlagSet(apc.LsPresent)
 	if lsmsg.UUID == "" {
@@ -1449,7 +1512,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	}
 	apireq := apiReqAlloc(1, apc.URLPathObjects.L, false)
 	defer apiReqFree(apireq)
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActRestoreObjVer {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1467,6 +1530,9 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		if err := p.checkACL(w, r, bck, apc.AceObjMOVE); err != nil {
 			return
 		}
+		if err := p.checkBypassGov(w, r, cos.IsParseBool(apireq.query.Get(apc.QparamBypassGov))); err != nil {
+			return
+		}
 		if bck.IsRemote() {
 			p.writeErrActf(w, r, msg.Action, "not supported for remote buckets (%s)", bck)
 			return
@@ -1477,6 +1543,20 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		}
 		p.objMv(w, r, bck, apireq.items[1], msg)
 		return
+	case apc.ActRestoreObjVer:
+		if err := p.checkACL(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if !bck.IsAIS() {
+			p.writeErrActf(w, r, msg.Action, "not supported for remote buckets (%s)", bck)
+			return
+		}
+		if msg.Name == "" {
+			p.writeErrActf(w, r, msg.Action, "object version is not specified")
+			return
+		}
+		p.objRestoreVer(w, r, bck, apireq.items[1], msg)
+		return
 	case apc.ActPromote:
 		if err := p.checkACL(w, r, bck, apc.AcePromote); err != nil {
 			return
@@ -1504,7 +1584,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
-		xactID, err := p.promote(bck, msg, tsi)
+		xactID, err := p.promote(bck, msg, tsi, p.reqUser(r.Header))
 		if err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -1643,7 +1723,18 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	if xactID, err = p.setBucketProps(msg, bck, nprops); err != nil {
+	if nprops.Replication.Bucket != "" && nprops.Replication.Bucket != bck.Props.Replication.Bucket {
+		// replication destination must exist as well (validated, see ReplicationConf.Dest)
+		dstBck, _ := nprops.Replication.Dest()
+		dst := cluster.CloneBck(&dstBck)
+		args := bckInitArgs{p: p, w: w, r: r, bck: dst, msg: msg, dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		args.lookupRemote = true
+		if _, err = args.initAndTry(dst.Name); err != nil {
+			return
+		}
+	}
+	if xactID, err = p.setBucketProps(msg, bck, nprops, p.reqUser(r.Header)); err != nil {
 		p.writeErr(w, r, err)
 		return
 	}
@@ -1887,7 +1978,8 @@ func (p *proxy) listBuckets(w http.ResponseWriter, r *http.Request, qbck *cmn.Qu
 	p.reverseNodeRequest(w, r, si)
 }
 
-func (p *proxy) redirectURL(r *http.Request, si *cluster.Snode, ts time.Time, netName string) (redirect string) {
+func (p *proxy) redirectURL(r *http.Request, si *cluster.Snode, ts time.Time, netName string,
+	user ...string) (redirect string) {
 	var (
 		nodeURL string
 		query   = url.Values{}
@@ -1914,8 +2006,16 @@ func (p *proxy) redirectURL(r *http.Request, si *cluster.Snode, ts time.Time, ne
 		redirect += r.URL.RawQuery + "&"
 	}
 
+	ptime := cos.UnixNano2S(ts.UnixNano())
 	query.Set(apc.QparamProxyID, p.si.ID())
-	query.Set(apc.QparamUnixTime, cos.UnixNano2S(ts.UnixNano()))
+	query.Set(apc.QparamUnixTime, ptime)
+	if len(user) > 0 {
+		// (for the target to enforce per-user rate limits)
+		if config := cmn.GCO.Get(); config.RateLimit.Enabled && config.RateLimit.ByUser() && config.Auth.Secret != "" {
+			query.Set(apc.QparamUserID, user[0])
+			query.Set(apc.QparamUserSig, userSig(config.Auth.Secret, user[0], ptime, r.URL.Path))
+		}
+	}
 	redirect += query.Encode()
 	return
 }
@@ -2173,7 +2273,23 @@ func (p *proxy) objMv(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
 	p.statsT.Add(stats.RenameCount, 1)
 }
 
-func (p *proxy) doListRange(method, bucket string, msg *apc.ActionMsg, query url.Values) (xactID string, err error) {
+func (p *proxy) objRestoreVer(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, msg *apc.ActionMsg) {
+	started := time.Now()
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%q %s/%s(ver %s) => %s", msg.Action, bck.Name, objName, msg.Name, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+func (p *proxy) doListRange(method, bucket string, msg *apc.ActionMsg, query url.Values,
+	user string) (xactID string, err error) {
 	var (
 		smap   = p.owner.smap.get()
 		aisMsg = p.newAmsg(msg, nil, cos.GenUUID())
@@ -2182,7 +2298,7 @@ func (p *proxy) doListRange(method, bucket string, msg *apc.ActionMsg, query url
 	)
 	nlb := xact.NewXactNL(aisMsg.UUID, aisMsg.Action, &smap.Smap, nil)
 	nlb.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{smap: smap, query: query, nl: nlb})
+	p.ic.registerEqual(regIC{smap: smap, query: query, nl: nlb, msg: msg, user: user})
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: method, Path: path, Query: query, Body: body}
 	args.smap = smap
@@ -2679,6 +2795,10 @@ func (p *proxy) _becomeFinal(ctx *smapModifier, clone *smapX) {
 		pairs = append(pairs, revsPair{etl, msg})
 		glog.Infof("%s: plus %s", p, etl)
 	}
+	if schedMD := p.owner.sched.get(); schedMD.version() > 0 {
+		pairs = append(pairs, revsPair{schedMD, msg})
+		glog.Infof("%s: plus %s", p, schedMD)
+	}
 	debug.Assert(clone._sgl != nil)
 	_ = p.metasyncer.sync(pairs...)
 	p.syncNewICOwners(ctx.smap, clone)
@@ -2733,6 +2853,12 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 		p.proxyStartSortHandler(w, r)
 	case http.MethodGet:
 		dsort.ProxyGetHandler(w, r)
+	case http.MethodPut:
+		if len(apiItems) == 1 && (apiItems[0] == apc.Pause || apiItems[0] == apc.Resume) {
+			dsort.ProxyPauseSortHandler(w, r, apiItems[0])
+		} else {
+			p.writeErrURL(w, r)
+		}
 	case http.MethodDelete:
 		if len(apiItems) == 1 && apiItems[0] == apc.Abort {
 			dsort.ProxyAbortSortHandler(w, r)
@@ -2742,7 +2868,7 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 			p.writeErrURL(w, r)
 		}
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
 	}
 }
 
@@ -5,8 +5,10 @@
 package ais
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
 )
 
 type (
@@ -33,7 +36,26 @@ type (
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
 		version       int64
+		keys          authKeys
 	}
+	// token-verifying keys: the secret and/or public keys loaded from the configured
+	// local file and fetched from the configured JWKS URL (see cmn.AuthConf);
+	// (re)loading is done by a single goroutine at a time, outside the mutex
+	authKeys struct {
+		mu      sync.Mutex
+		ks      *tok.KeySet
+		conf    cmn.AuthConf  // ks was built with
+		err     error         // last failure to load keys for errConf
+		errConf cmn.AuthConf  // ditto
+		fetched time.Time     // last time keys were (attempted to be) loaded
+		loading chan struct{} // non-nil while loading
+	}
+)
+
+const (
+	jwksRefreshTime    = 10 * time.Minute // periodically re-fetch JWKS (to pick up rotated keys)
+	jwksMinRefreshTime = time.Minute      // ditto, upon receiving token signed with unknown key
+	jwksWaitTime       = 3 * time.Second  // max time to wait for keys being loaded (in the request path)
 )
 
 /////////////////
@@ -45,7 +67,7 @@ func newAuthManager() *authManager {
 }
 
 // Add tokens to list of invalid ones. After that it cleans up the list
-// from expired tokens
+// from expired tokens (NOTE: decrypting outside the lock - see validateToken)
 func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *tokenList) {
 	a.Lock()
 	if newRevoked.Version == 0 {
@@ -63,27 +85,34 @@ func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *toke
 		a.revokedTokens[token] = true
 		delete(a.tkList, token)
 	}
-	allRevoked = &tokenList{
-		Tokens:  make([]string, 0, len(a.revokedTokens)),
-		Version: a.version,
+	tokens := make([]string, 0, len(a.revokedTokens))
+	for token := range a.revokedTokens {
+		tokens = append(tokens, token)
 	}
+	a.Unlock()
+
+	// (keeping the ones that cannot be verified - e.g., while JWKS is unavailable)
 	var (
-		now    = time.Now()
-		secret = cmn.GCO.Get().Auth.Secret
+		expired []string
+		now     = time.Now()
 	)
-	for token := range a.revokedTokens {
-		tk, err := tok.DecryptToken(token, secret)
-		debug.AssertNoErr(err)
-		if tk.Expires.Before(now) {
-			delete(a.revokedTokens, token)
-		} else {
+	for _, token := range tokens {
+		if tk, err := a.decrypt(token); err == nil && tk.Expires.Before(now) {
+			expired = append(expired, token)
+		}
+	}
+
+	a.Lock()
+	for _, token := range expired {
+		delete(a.revokedTokens, token)
+	}
+	if l := len(a.revokedTokens); l > 0 {
+		allRevoked = &tokenList{Tokens: make([]string, 0, l), Version: a.version}
+		for token := range a.revokedTokens {
 			allRevoked.Tokens = append(allRevoked.Tokens, token)
 		}
 	}
 	a.Unlock()
-	if len(allRevoked.Tokens) == 0 {
-		allRevoked = nil
-	}
 	return
 }
 
@@ -102,42 +131,185 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 	return
 }
 
+func (a *authManager) decrypt(token string) (*tok.Token, error) {
+	ks, err := a.keySet(false)
+	if err != nil {
+		return nil, err
+	}
+	tk, err := ks.Decrypt(token)
+	if err != nil && errors.Is(err, tok.ErrUnknownKey) {
+		// the key may have been rotated
+		if ks, errV := a.keySet(true); errV == nil {
+			tk, err = ks.Decrypt(token)
+		}
+	}
+	return tk, err
+}
+
+func (a *authManager) keySet(refresh bool) (*tok.KeySet, error) {
+	return a.keys.get(&cmn.GCO.Get().Auth, refresh)
+}
+
+//////////////
+// authKeys //
+//////////////
+
+// returns cached key set; when (the corresponding) config has changed, or upon `refresh`
+// (token signed with unknown key), waits - up to jwksWaitTime - for the keys to (re)load;
+// periodic JWKS refresh is done in the background
+func (k *authKeys) get(conf *cmn.AuthConf, refresh bool) (*tok.KeySet, error) {
+	k.mu.Lock()
+	var (
+		ks, err = k.ks, k.err
+		now     = time.Now()
+	)
+	switch {
+	case ks == nil || k.conf != *conf:
+		if err != nil && k.errConf == *conf && now.Sub(k.fetched) < jwksMinRefreshTime {
+			k.mu.Unlock()
+			return nil, err
+		}
+	case conf.JWKSURL == "":
+		k.mu.Unlock()
+		return ks, nil
+	case now.Sub(k.fetched) > jwksRefreshTime:
+		k._load(conf, now) // (in the background)
+		k.mu.Unlock()
+		return ks, nil
+	case refresh && now.Sub(k.fetched) > jwksMinRefreshTime:
+	default:
+		k.mu.Unlock()
+		return ks, nil
+	}
+	loading := k._load(conf, now)
+	k.mu.Unlock()
+
+	select {
+	case <-loading:
+	case <-time.After(jwksWaitTime):
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.ks != nil && k.conf == *conf {
+		return k.ks, nil
+	}
+	if k.err != nil && k.errConf == *conf {
+		return nil, k.err
+	}
+	return nil, errors.New("token-verifying keys are not loaded yet")
+}
+
+// starts loading unless already in progress (under lock)
+func (k *authKeys) _load(conf *cmn.AuthConf, now time.Time) chan struct{} {
+	if k.loading == nil {
+		k.loading = make(chan struct{})
+		k.fetched = now
+		go k.load(*conf, k.loading)
+	}
+	return k.loading
+}
+
+func (k *authKeys) load(conf cmn.AuthConf, loading chan struct{}) {
+	ks, err := loadKeySet(&conf)
+	k.mu.Lock()
+	switch {
+	case err == nil:
+		k.ks, k.conf, k.err = ks, conf, nil
+	case k.ks != nil && k.conf == conf:
+		// keep using the previously loaded keys (and retry later)
+		glog.Errorf("failed to refresh token-verifying keys: %v", err)
+	default:
+		k.err, k.errConf = err, conf
+	}
+	k.fetched = time.Now()
+	k.loading = nil
+	k.mu.Unlock()
+	close(loading)
+}
+
+func loadKeySet(conf *cmn.AuthConf) (*tok.KeySet, error) {
+	ks := tok.NewKeySet(conf.Secret, conf.Issuer, conf.Audience)
+	if conf.PubKeyFile != "" {
+		b, err := os.ReadFile(conf.PubKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.AddFile(b); err != nil {
+			return nil, fmt.Errorf("%s: %v", conf.PubKeyFile, err)
+		}
+	}
+	if conf.JWKSURL != "" {
+		jwks, err := fetchJWKS(conf.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.AddJWKS(jwks); err != nil {
+			return nil, fmt.Errorf("%s: %v", conf.JWKSURL, err)
+		}
+	}
+	return ks, nil
+}
+
+func fetchJWKS(url string) (*tok.JWKS, error) {
+	var (
+		config = cmn.GCO.Get()
+		client = cmn.NewClient(cmn.TransportArgs{
+			Timeout:    config.Client.Timeout.D(),
+			UseHTTPS:   strings.HasPrefix(url, "https://"),
+			SkipVerify: config.Net.HTTP.SkipVerify,
+		})
+		jwks = &tok.JWKS{}
+	)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS from %s: %s", url, resp.Status)
+	}
+	if err := jsoniter.NewDecoder(resp.Body).Decode(jwks); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS from %s: %v", url, err)
+	}
+	return jwks, nil
+}
+
 // Checks if a token is valid:
 //   - must not be revoked one
 //   - must not be expired
 //   - must have all mandatory fields: userID, creds, issued, expires
 // Returns decrypted token information if it is valid
-func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
+//
+// NOTE: a token that is not cached yet is decrypted (and verified) without holding the lock -
+// verification may entail (re)loading token-verifying keys (see authKeys.get)
+func (a *authManager) validateToken(token string) (*tok.Token, error) {
 	a.Lock()
 	if _, ok := a.revokedTokens[token]; ok {
-		tk, err = nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
-	} else {
-		tk, err = a.validateAddRm(token, time.Now())
+		a.Unlock()
+		return nil, tok.ErrTokenRevoked
 	}
+	tk, ok := a.tkList[token]
 	a.Unlock()
-	return
-}
 
-// Decrypts and validates token. Adds it to authManager.token if not found. Removes if expired.
-// Must be called under lock.
-func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, error) {
-	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
-		var (
-			err    error
-			secret = cmn.GCO.Get().Auth.Secret
-		)
-		if tk, err = tok.DecryptToken(token, secret); err != nil {
-			err = fmt.Errorf("%v: %v", tok.ErrInvalidToken, err)
-			return nil, err
+		var err error
+		if tk, err = a.decrypt(token); err != nil {
+			return nil, fmt.Errorf("%v: %v", tok.ErrInvalidToken, err)
 		}
-		a.tkList[token] = tk
 	}
 	debug.Assert(tk != nil)
-	if tk.Expires.Before(now) {
+
+	a.Lock()
+	defer a.Unlock()
+	if _, revoked := a.revokedTokens[token]; revoked { // (revoked in the meantime)
+		delete(a.tkList, token)
+		return nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
+	}
+	if tk.Expires.Before(time.Now()) {
 		delete(a.tkList, token)
 		return nil, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
 	}
+	a.tkList[token] = tk
 	return tk, nil
 }
 
@@ -200,6 +372,18 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	return auth, nil
 }
 
+// returns the requesting user (when AuthN is on) to attribute jobs and enforce rate limits
+func (p *proxy) reqUser(hdr http.Header) string {
+	if !cmn.GCO.Get().Auth.Enabled {
+		return ""
+	}
+	tk, err := p.validateToken(hdr)
+	if err != nil {
+		return ""
+	}
+	return tk.UserID
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
@@ -217,6 +401,14 @@ func (p *proxy) checkACL(w http.ResponseWriter, r *http.Request, bck *cluster.Bc
 	return err
 }
 
+// object lock: bypassing governance-mode retention requires admin access (see cmn.ObjectLockConf)
+func (p *proxy) checkBypassGov(w http.ResponseWriter, r *http.Request, bypass bool) error {
+	if !bypass {
+		return nil
+	}
+	return p.checkACL(w, r, nil, apc.AceAdmin)
+}
+
 func (*proxy) aclErrToCode(err error) int {
 	switch err {
 	case nil:
@@ -0,0 +1,170 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// fetching JWKS (that may take a while) does not block requests
+func TestAuthKeysRefresh(t *testing.T) {
+	priv, err := tok.GenerateKey(authn.SigningES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := tok.NewJWK(priv.Public(), "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		fetches atomic.Int32
+		hang    atomic.Bool
+		release = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Inc()
+		if hang.Load() {
+			<-release
+		}
+		w.Write(cos.MustMarshal(&tok.JWKS{Keys: []*tok.JWK{jwk}}))
+	}))
+	defer srv.Close()
+
+	var (
+		k    = &authKeys{}
+		conf = &cmn.AuthConf{JWKSURL: srv.URL}
+	)
+	ks, err := k.get(conf, false)
+	if err != nil || ks.Len() != 1 {
+		t.Fatalf("failed to load keys: %v", err)
+	}
+	// rate-limited
+	if _, err := k.get(conf, true); err != nil || fetches.Load() != 1 {
+		t.Fatalf("expected no re-fetch (err %v, fetches %d)", err, fetches.Load())
+	}
+
+	// JWKS server "hangs": periodic refresh is done in the background
+	hang.Store(true)
+	defer close(release)
+	k.mu.Lock()
+	k.fetched = time.Now().Add(-jwksRefreshTime - time.Second)
+	k.mu.Unlock()
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if ks, err = k.get(conf, false); err != nil || ks.Len() != 1 {
+			t.Fatalf("expected previously loaded keys: %v", err)
+		}
+	}
+	if d := time.Since(started); d > time.Second {
+		t.Fatalf("get took %v (expecting no wait)", d)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for fetches.Load() != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("expected a single background fetch, got %d", n-1)
+	}
+}
+
+// verifying a token that is not cached yet (and that may entail fetching JWKS) is done outside
+// the authManager lock and does not block requests with cached tokens
+func TestAuthValidateNoLock(t *testing.T) {
+	var (
+		signers = make([]*tok.Signer, 2)
+		jwks    = &tok.JWKS{}
+	)
+	for i := range signers {
+		priv, err := tok.GenerateKey(authn.SigningES256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signers[i], err = tok.NewSigner(priv, "k"+strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jwk, err := signers[0].JWK()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks.Keys = []*tok.JWK{jwk}
+	var (
+		fetches atomic.Int32
+		hang    atomic.Bool
+		release = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Inc()
+		if hang.Load() {
+			<-release
+		}
+		w.Write(cos.MustMarshal(jwks))
+	}))
+	defer srv.Close()
+
+	config := cmn.GCO.BeginUpdate()
+	prev := config.Auth
+	config.Auth.JWKSURL = srv.URL
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Auth = prev
+		cmn.GCO.CommitUpdate(config)
+	}()
+
+	expires := time.Now().Add(time.Hour)
+	known, err := signers[0].IssueAdminJWT(expires, "known")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := signers[1].IssueAdminJWT(expires, "unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newAuthManager()
+	if _, err := a.validateToken(known); err != nil {
+		t.Fatal(err)
+	}
+
+	// token signed with unknown key: JWKS re-fetch "hangs"
+	hang.Store(true)
+	defer close(release)
+	a.keys.mu.Lock()
+	a.keys.fetched = time.Now().Add(-jwksMinRefreshTime - time.Second)
+	a.keys.mu.Unlock()
+	done := make(chan error, 1)
+	go func() {
+		_, err := a.validateToken(unknown)
+		done <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for fetches.Load() != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("expected JWKS re-fetch, got %d fetches", n)
+	}
+
+	started := time.Now()
+	if _, err := a.validateToken(known); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(started); d > time.Second {
+		t.Fatalf("validating cached token took %v (expecting no wait)", d)
+	}
+	if err := <-done; err == nil {
+		t.Fatal("expected token signed with unknown key to fail validation")
+	}
+}
@@ -64,6 +64,10 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.ic.writeStatus(w, r)
 	case apc.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
+	case apc.GetWhatRepair, apc.GetWhatQuota:
+		p.queryClusterRepair(w, r, what)
+	case apc.GetWhatJobHistory:
+		p.queryJobHistory(w, r, what)
 	case apc.GetWhatRemoteAIS:
 		remoteAIS, err := p.getRemoteAISInfo()
 		if err != nil {
@@ -224,6 +228,14 @@ func (p *proxy) queryClusterMountpaths(w http.ResponseWriter, r *http.Request, w
 	_ = p.writeJSON(w, r, out, what)
 }
 
+func (p *proxy) queryClusterRepair(w http.ResponseWriter, r *http.Request, what string) {
+	targetRepair, erred := p._queryTargets(w, r)
+	if targetRepair == nil || erred {
+		return
+	}
+	_ = p.writeJSON(w, r, targetRepair, what)
+}
+
 // helper methods for querying targets
 
 func (p *proxy) _queryTargets(w http.ResponseWriter, r *http.Request) (cos.JSONRawMsgs, bool) {
@@ -582,6 +594,9 @@ func (p *proxy) _updFinal(ctx *smapModifier, clone *smapX) {
 	if etlMD != nil && etlMD.version() > 0 {
 		pairs = append(pairs, revsPair{etlMD, aisMsg})
 	}
+	if schedMD := p.owner.sched.get(); schedMD.version() > 0 {
+		pairs = append(pairs, revsPair{schedMD, aisMsg})
+	}
 	if ctx.rmd != nil && ctx.nsi.IsTarget() && mustRunRebalance(ctx, clone) {
 		pairs = append(pairs, revsPair{ctx.rmd, aisMsg})
 		nl := xact.NewXactNL(xact.RebID2S(ctx.rmd.version()), apc.ActRebalance, &clone.Smap, nil)
@@ -736,6 +751,8 @@ func (p *proxy) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		p.xactStart(w, r, msg)
 	case apc.ActXactStop:
 		p.xactStop(w, r, msg)
+	case apc.ActXactPause, apc.ActXactResume:
+		p.xactPause(w, r, msg)
 	case apc.ActSendOwnershipTbl:
 		p.sendOwnTbl(w, r, msg)
 	case apc.ActStartMaintenance, apc.ActDecommissionNode, apc.ActShutdownNode:
@@ -863,7 +880,7 @@ func (p *proxy) xactStart(w http.ResponseWriter, r *http.Request, msg *apc.Actio
 	freeBcastRes(results)
 	smap := p.owner.smap.get()
 	nl := xact.NewXactNL(xactMsg.ID, xactMsg.Kind, &smap.Smap, nil)
-	p.ic.registerEqual(regIC{smap: smap, nl: nl})
+	p.ic.registerEqual(regIC{smap: smap, nl: nl, msg: msg, user: p.reqUser(r.Header)})
 	w.Write([]byte(xactMsg.ID))
 }
 
@@ -890,6 +907,47 @@ func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.Action
 	freeBcastRes(results)
 }
 
+// NOTE: not finding the xaction on some of the targets is fine (it may have finished there)
+func (p *proxy) xactPause(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
+	xactMsg := xact.QueryMsg{}
+	if err := cos.MorphMarshal(msg.Value, &xactMsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if xactMsg.Kind != "" && !xact.Table[xactMsg.Kind].Pausable {
+		p.writeErrf(w, r, "%s: pausing (and resuming) %q is not supported", p.si, xactMsg.Kind)
+		return
+	}
+	body := cos.MustMarshal(apc.ActionMsg{Action: msg.Action, Value: xactMsg})
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: body}
+	args.to = cluster.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	defer freeBcastRes(results)
+
+	var found bool
+	for _, res := range results {
+		if res.status == http.StatusNotFound {
+			continue
+		}
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			return
+		}
+		found = true
+	}
+	if found {
+		return
+	}
+	var err error = cmn.NewErrXactNotFoundError(fmt.Sprintf("(ID=%q, kind=%q, running)", xactMsg.ID, xactMsg.Kind))
+	if msg.Action == apc.ActXactResume {
+		err = fmt.Errorf("%v: if the job was interrupted (e.g., by node restart), run it again "+
+			"with the same parameters and 'resume' option to continue from the last checkpoint", err)
+	}
+	p.writeErr(w, r, err, http.StatusNotFound)
+}
+
 func (p *proxy) rebalanceCluster(w http.ResponseWriter, r *http.Request) {
 	// note operational priority over config-disabled `errRebalanceDisabled`
 	if err := p.canRunRebalance(); err != nil && err != errRebalanceDisabled {
@@ -935,7 +993,7 @@ func (p *proxy) resilverOne(w http.ResponseWriter, r *http.Request, msg *apc.Act
 		p.writeErr(w, r, res.toErr())
 	} else {
 		nl := xact.NewXactNL(xactMsg.ID, xactMsg.Kind, &smap.Smap, nil)
-		p.ic.registerEqual(regIC{smap: smap, nl: nl})
+		p.ic.registerEqual(regIC{smap: smap, nl: nl, msg: msg, user: p.reqUser(r.Header)})
 		w.Write([]byte(xactMsg.ID))
 	}
 	freeCR(res)
@@ -253,9 +253,10 @@ func (p *proxy) httpDownloadPost(w http.ResponseWriter, r *http.Request) {
 		p.writeErrStatusf(w, r, errCode, "Error starting download: %v.", err.Error())
 		return
 	}
-	nl := downloader.NewDownloadNL(id, string(dlb.Type), &smap.Smap, progressInterval)
+	nl := downloader.NewDownloadNL(id, string(dlb.Type), &smap.Smap, progressInterval, &dlBase.Bck)
 	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	amsg := &apc.ActionMsg{Action: apc.ActDownload, Name: string(dlb.Type), Value: dlb.RawMessage}
+	p.ic.registerEqual(regIC{nl: nl, smap: smap, msg: amsg, user: p.reqUser(r.Header)})
 
 	_respWithID(w, id)
 }
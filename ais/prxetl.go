@@ -99,6 +99,9 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if err := p.checkLocalETL(w, r, initMsg); err != nil {
+		return
+	}
 
 	etlMD := p.owner.etl.get()
 	if etlMD.get(initMsg.ID()) != nil {
@@ -116,6 +119,22 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ETLs that run as local processes execute user-supplied code on the target hosts:
+// admin access required (and the local runtime must be enabled - see cmn.ETLConf)
+func (p *proxy) checkLocalETL(w http.ResponseWriter, r *http.Request, msg etl.InitMsg) error {
+	if !etl.IsLocal(msg) {
+		return nil
+	}
+	if err := p.checkACL(w, r, nil, apc.AceAdmin); err != nil {
+		return err
+	}
+	err := etl.CheckLocal(msg)
+	if err != nil {
+		p.writeErr(w, r, err)
+	}
+	return err
+}
+
 // POST /v1/etl/<uuid>/stop (or) /v1/etl/<uuid>/start
 //
 // handleETLPost handles start/stop ETL pods
@@ -140,6 +159,9 @@ func (p *proxy) handleETLPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if apiItems[1] == apc.ETLStart {
+		if err := p.checkLocalETL(w, r, etlMsg); err != nil {
+			return
+		}
 		p.startETL(w, etlMsg, false /*add to etlMD*/)
 		return
 	}
@@ -0,0 +1,202 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xhist"
+)
+
+// Job history: IC members (the primary included) monitor all registered jobs (see ic.go),
+// and each of them records every job it monitors upon completion (see notifs.done) - which
+// is how the history gets replicated. Records include the initiating request and user
+// (see regIC), timing, error (if any), and final per-target stats; they are stored in a
+// rotating set of files under the proxy's config directory (see xhist.Store).
+//
+// A proxy that joins IC (e.g., upon restart, or when IC membership changes after primary
+// failover) catches up: it fetches the records that completed while it wasn't a member
+// from another IC member and merges them (see jobHist.sync).
+//
+// Non-IC proxies redirect history queries to the primary.
+
+type jobHist struct {
+	p     *proxy
+	store *xhist.Store
+	mu    sync.Mutex
+}
+
+func (jh *jobHist) init(p *proxy) { jh.p = p }
+
+// (lazily) open the store upon first use
+func (jh *jobHist) get() (store *xhist.Store, err error) {
+	jh.mu.Lock()
+	if jh.store == nil {
+		dir := filepath.Join(cmn.GCO.Get().ConfigDir, cmn.JobHistDirName)
+		jh.store, err = xhist.Open(dir)
+	}
+	store = jh.store
+	jh.mu.Unlock()
+	return
+}
+
+func (jh *jobHist) record(nl nl.NotifListener) {
+	conf := cmn.GCO.Get().JobHistory.Defaults()
+	if !conf.Enabled || nl.Kind() == apc.ActList { // (list-objects is not a job)
+		return
+	}
+	rec := &xact.HistRecord{ID: nl.UUID(), Kind: nl.Kind(), Aborted: nl.Aborted()}
+	if _, ok := nl.(*downloader.NotifDownloadListerner); ok {
+		rec.Kind = apc.ActDownload // (the listener's kind is download type)
+	}
+	rec.Msg, rec.User = nl.Initiator()
+	for _, bck := range nl.Bcks() {
+		rec.Buckets = append(rec.Buckets, *bck)
+	}
+	if err := nl.Err(); err != nil {
+		rec.ErrMsg = err.Error()
+	}
+	nl.NodeStats().Range(func(tid string, v interface{}) bool {
+		if dl, ok := v.(*downloader.DlStatusResp); ok {
+			rec.Stats.Objs += int64(dl.FinishedCnt)
+			return true
+		}
+		snap, ok := v.(*xact.SnapExt)
+		if !ok {
+			return true
+		}
+		if rec.Snaps == nil {
+			rec.Snaps = make(map[string]*xact.SnapExt, 8)
+		}
+		rec.Snaps[tid] = snap
+		rec.Stats.Objs += snap.Stats.Objs
+		rec.Stats.Bytes += snap.Stats.Bytes
+		rec.Stats.OutObjs += snap.Stats.OutObjs
+		rec.Stats.OutBytes += snap.Stats.OutBytes
+		rec.Stats.InObjs += snap.Stats.InObjs
+		rec.Stats.InBytes += snap.Stats.InBytes
+		if rec.StartTime.IsZero() || (!snap.StartTime.IsZero() && snap.StartTime.Before(rec.StartTime)) {
+			rec.StartTime = snap.StartTime
+		}
+		return true
+	})
+	if started := nl.StartTime(); started != 0 {
+		rec.StartTime = time.Unix(0, started)
+	}
+	rec.EndTime = time.Now()
+	if ended := nl.EndTime(); ended != 0 {
+		rec.EndTime = time.Unix(0, ended)
+	}
+
+	store, err := jh.get()
+	if err == nil {
+		err = store.Add(rec, &conf)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to record %s in the job history: %v", jh.p, nl, err)
+	}
+}
+
+// upon joining IC: fetch (newer) records from another IC member
+func (jh *jobHist) sync(oldSmap, newSmap *smapX) {
+	conf := cmn.GCO.Get().JobHistory.Defaults()
+	if !conf.Enabled {
+		return
+	}
+	store, err := jh.get()
+	if err != nil {
+		glog.Errorf("%s: failed to sync job history: %v", jh.p, err)
+		return
+	}
+	query := &xact.HistQuery{Limit: conf.RecordsPerFile * conf.MaxFiles}
+	if recs, err := store.Query(&xact.HistQuery{Limit: 1}); err == nil && len(recs) > 0 {
+		query.Since = recs[0].EndTime
+	}
+	// prefer those that were IC members prior to this change
+	peers := make([]*cluster.Snode, 0, newSmap.ICCount())
+	for _, psi := range newSmap.Pmap {
+		if psi.ID() == jh.p.si.ID() || !newSmap.IsIC(psi) {
+			continue
+		}
+		if oldSmap.IsIC(psi) {
+			peers = append([]*cluster.Snode{psi}, peers...)
+		} else {
+			peers = append(peers, psi)
+		}
+	}
+	for _, psi := range peers {
+		cargs := allocCargs()
+		{
+			cargs.si = psi
+			cargs.req = cmn.HreqArgs{
+				Method: http.MethodGet,
+				Path:   apc.URLPathClu.S,
+				Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatJobHistory}},
+				Body:   cos.MustMarshal(query),
+			}
+			cargs.timeout = cmn.Timeout.MaxKeepalive()
+			cargs.cresv = cresJH{} // -> []*xact.HistRecord
+		}
+		res := jh.p.call(cargs)
+		freeCargs(cargs)
+		if res.err != nil {
+			glog.Warningf("%s: failed to fetch job history from %s: %v", jh.p, psi, res.err)
+			continue
+		}
+		n, err := store.Merge(*res.v.(*[]*xact.HistRecord), &conf)
+		if err != nil {
+			glog.Errorf("%s: failed to merge job history from %s: %v", jh.p, psi, err)
+		} else if n > 0 {
+			glog.Infof("%s: job history: merged %d record%s from %s", jh.p, n, cos.Plural(n), psi)
+		}
+		return
+	}
+}
+
+func (jh *jobHist) stop() {
+	jh.mu.Lock()
+	if jh.store != nil {
+		jh.store.Close()
+		jh.store = nil
+	}
+	jh.mu.Unlock()
+}
+
+// GET /v1/cluster?what=job_history
+func (p *proxy) queryJobHistory(w http.ResponseWriter, r *http.Request, what string) {
+	query := &xact.HistQuery{}
+	if err := cmn.ReadJSON(w, r, query); err != nil {
+		return
+	}
+	smap := p.owner.smap.get()
+	if !smap.IsIC(p.si) {
+		redirectURL := p.redirectURL(r, smap.Primary, time.Now(), cmn.NetIntraControl)
+		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+		return
+	}
+	store, err := p.jhist.get()
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	recs, err := store.Query(query)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	p.writeJSON(w, r, recs, what)
+}
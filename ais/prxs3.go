@@ -74,6 +74,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, uploads := q[s3compat.QparamMptUploads]; uploads {
+				p.listMptUploadsS3(w, r, apiItems[0], q)
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.bckListS3(w, r, apiItems[0])
 			return
@@ -97,11 +101,16 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		}
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
+		q := r.URL.Query()
+		if len(apiItems) > 1 && isS3MptRequest(q) {
+			// initiate or complete multipart upload
+			p.mptObjS3(w, r, apiItems, apc.AcePUT)
+			return
+		}
 		if len(apiItems) != 1 {
 			p.writeErr(w, r, errS3Req)
 			return
 		}
-		q := r.URL.Query()
 		if _, multiple := q[s3compat.QparamMultiDelete]; !multiple {
 			p.writeErr(w, r, errS3Req)
 			return
@@ -122,6 +131,11 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
+		if q := r.URL.Query(); isS3MptRequest(q) {
+			// abort multipart upload
+			p.mptObjS3(w, r, apiItems, apc.AcePUT)
+			return
+		}
 		p.delObjS3(w, r, apiItems)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
@@ -237,7 +251,7 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		p.writeErr(w, r, err)
 		return
 	}
-	if _, err := p.doListRange(r.Method, bucket, &msg2, query); err != nil {
+	if _, err := p.doListRange(r.Method, bucket, &msg2, query, p.reqUser(r.Header)); err != nil {
 		p.writeErr(w, r, err)
 	}
 }
@@ -272,6 +286,9 @@ func (p *proxy) bckListS3(w http.ResponseWriter, r *http.Request, bucket string)
 		p.writeErr(w, r, err)
 		return
 	}
+	if _, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpList); !ok {
+		return
+	}
 	lsmsg := apc.ListObjsMsg{UUID: cos.GenUUID(), TimeFormat: time.RFC3339}
 	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsVersion)
 	s3compat.FillMsgFromS3Query(r.URL.Query(), &lsmsg)
@@ -338,6 +355,10 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	user, ok := p.rateLimitOps(w, r, bckDst, cmn.RateLimitOpPut)
+	if !ok {
+		return
+	}
 	objName := strings.Trim(parts[1], "/")
 	si, err = cluster.HrwTarget(bckSrc.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -347,7 +368,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("AISS3 COPY: %s %s/%s => %s/%v %s", r.Method, bckSrc, objName, bckDst, items, si)
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, user)
 	p.s3Redirect(w, r, si, redirectURL, bckDst.Name)
 }
 
@@ -372,6 +393,13 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if err = p.checkBypassGov(w, r, s3BypassGov(r)); err != nil {
+		return
+	}
+	user, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpPut)
+	if !ok {
+		return
+	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -381,13 +409,13 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, user)
 	p.s3Redirect(w, r, si, redirectURL, bck.Name)
 }
 
 // PUT s3/bckName/objName
 func (p *proxy) putObjS3(w http.ResponseWriter, r *http.Request, items []string) {
-	if r.Header.Get(s3compat.HeaderObjSrc) == "" {
+	if r.Header.Get(s3compat.HeaderObjSrc) == "" || isS3MptRequest(r.URL.Query()) {
 		p.directPutObjS3(w, r, items)
 		return
 	}
@@ -415,6 +443,10 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	user, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpGet)
+	if !ok {
+		return
+	}
 	objName := path.Join(items[1:]...)
 
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -425,7 +457,7 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string)
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData, user)
 	p.s3Redirect(w, r, si, redirectURL, bck.Name)
 }
 
@@ -478,6 +510,12 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		p.writeErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if err = p.checkBypassGov(w, r, s3BypassGov(r)); err != nil {
+		return
+	}
+	if _, ok := p.rateLimitOps(w, r, bck, cmn.RateLimitOpDelete); !ok {
+		return
+	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -549,7 +587,7 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		p.writeErr(w, r, err)
 		return
 	}
-	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+	if _, err := p.setBucketProps(msg, bck, nprops, p.reqUser(r.Header)); err != nil {
 		p.writeErr(w, r, err)
 	}
 }
@@ -0,0 +1,106 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// POST|DELETE s3/bckName/objName?uploads|uploadId=<id>
+// (all parts of a given upload are staged on the object's HRW target)
+func (p *proxy) mptObjS3(w http.ResponseWriter, r *http.Request, items []string, ace apc.AccessAttrs) {
+	started := time.Now()
+	bck := cluster.NewBck(items[0], apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := bck.Allow(ace); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err := p.checkBypassGov(w, r, s3BypassGov(r)); err != nil {
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3 MPT: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	p.s3Redirect(w, r, si, redirectURL, bck.Name)
+}
+
+// GET s3/bckName?uploads
+// in-progress uploads are distributed across targets - collect and merge
+func (p *proxy) listMptUploadsS3(w http.ResponseWriter, r *http.Request, bucket string, q url.Values) {
+	bck := cluster.NewBck(bucket, apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := bck.Allow(apc.AceObjLIST); err != nil {
+		p.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	maxUploads, err := s3compat.ParseMaxUploads(q.Get(s3compat.QparamMptMaxUploads))
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathS3.Join(bck.Name),
+		Query:  url.Values{s3compat.QparamMptUploads: []string{""}},
+	}
+	args.network = cmn.NetIntraData // (targets serve /s3 on public and intra-data networks)
+	args.to = cluster.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	uploads := make([]*s3compat.UploadInfo, 0, 16)
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		tres := &s3compat.ListMptUploadsResult{}
+		if err := xml.NewDecoder(bytes.NewReader(res.bytes)).Decode(tres); err != nil {
+			p.writeErr(w, r, err)
+			freeBcastRes(results)
+			return
+		}
+		uploads = append(uploads, tres.Uploads...)
+	}
+	freeBcastRes(results)
+
+	idMarker := q.Get(s3compat.QparamMptUploadIDMarker)
+	result := s3compat.NewListMptUploadsResult(bck.Name, idMarker, maxUploads, uploads)
+	sgl := memsys.PageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
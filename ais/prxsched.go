@@ -0,0 +1,459 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/sched"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduler: cron-like schedules of cluster jobs (see package sched).
+//
+// Schedules are stored in SchedMD that the primary replicates to all proxies. Only
+// the primary fires, and it does so in two steps:
+// 1. advance the schedule's next time and record the (new) run in SchedMD - metasync
+//    and wait;
+// 2. start the job via the primary's own public API (as intra-cluster call) and record
+//    the resulting job ID (or error).
+// Since every proxy has the first update prior to the job being started, a newly elected
+// primary never fires the same scheduled run again; conversely, runs that were due while
+// the cluster had no primary are not lost: the new primary fires them (once) upon startup.
+
+const (
+	schedIdle      = 10 * time.Second // max time between checks; also non-primary
+	schedQueuePoll = 10 * time.Second // poll queued schedules (OverlapQueue) every so often
+	schedStartTime = time.Minute      // timeout to start a job
+)
+
+type scheduler struct {
+	p         *proxy
+	busy      atomic.Bool
+	lastQueue atomic.Int64
+}
+
+var errSchedNotDue = errors.New("schedule is not due")
+
+func (s *scheduler) init(p *proxy) {
+	s.p = p
+	hk.Reg("scheduler"+hk.NameSuffix, s.housekeep, schedIdle)
+}
+
+func (s *scheduler) housekeep() time.Duration {
+	p := s.p
+	if smap := p.owner.smap.get(); !smap.isPrimary(p.si) || !p.ClusterStarted() || p.inPrimaryTransition.Load() {
+		return schedIdle
+	}
+	var (
+		md        = p.owner.sched.get()
+		now       = time.Now().UnixNano()
+		next      = now + int64(schedIdle)
+		pollQueue = now-s.lastQueue.Load() > int64(schedQueuePoll)
+		due       []string
+	)
+	for name, e := range md.Entries {
+		if e.Due(now) {
+			if e.Queued != 0 && (e.Next == 0 || e.Next > now) && !pollQueue {
+				continue
+			}
+			due = append(due, name)
+		} else if !e.Disabled && e.Next != 0 && e.Next < next {
+			next = e.Next
+		}
+	}
+	if pollQueue {
+		s.lastQueue.Store(now)
+	}
+	if len(due) == 0 {
+		return cos.MaxDuration(time.Duration(next-now), time.Second)
+	}
+	if s.busy.CAS(false, true) {
+		go s.fire(due)
+	}
+	return time.Second
+}
+
+func (s *scheduler) fire(names []string) {
+	defer s.busy.Store(false)
+	for _, name := range names {
+		if smap := s.p.owner.smap.get(); !smap.isPrimary(s.p.si) {
+			return
+		}
+		e, ok := s.p.owner.sched.get().Get(name)
+		if !ok {
+			continue
+		}
+		if err := s.fireOne(e); err != nil && err != errSchedNotDue && !cmn.IsErrNotFound(err) {
+			glog.Errorf("%s: %s: %v", s.p, e, err)
+		}
+	}
+}
+
+func (s *scheduler) fireOne(e *sched.Entry) error {
+	var (
+		now       = time.Now()
+		scheduled = e.Next
+	)
+	if e.Queued != 0 {
+		scheduled = e.Queued
+	}
+	// 1. overlap
+	if last := e.Last(); last != nil && last.JobID != "" {
+		running, err := s.running(&e.Spec, last.JobID)
+		if err != nil {
+			glog.Warningf("%s: %s: failed to query job %s (assuming finished): %v", s.p, e, last.JobID, err)
+		}
+		if running {
+			switch e.Overlap {
+			case sched.OverlapQueue:
+				return s.update(e.Name, func(ne *sched.Entry) {
+					if ne.Queued == 0 {
+						ne.Queued = scheduled
+					}
+					if ne.Next != 0 && ne.Next <= now.UnixNano() {
+						ne.SetNext(now)
+					}
+				}, false /*wait*/)
+			case sched.OverlapCancel:
+				if err := s.abort(&e.Spec, last.JobID); err != nil {
+					glog.Errorf("%s: %s: failed to abort job %s: %v", s.p, e, last.JobID, err)
+				}
+			default:
+				if e.Queued != 0 { // (policy changed while queued)
+					return s.update(e.Name, func(ne *sched.Entry) { ne.Queued = 0 }, false)
+				}
+				return s.update(e.Name, func(ne *sched.Entry) {
+					ne.SetNext(now)
+					ne.AddRun(sched.Run{Scheduled: scheduled, Skipped: "previous run " + last.JobID + " is still running"})
+				}, false)
+			}
+		}
+	}
+
+	// 2. commit
+	started := now.UnixNano()
+	err := s.update(e.Name, func(ne *sched.Entry) {
+		ne.Queued = 0
+		if ne.Next != 0 && ne.Next <= started {
+			ne.SetNext(now)
+		}
+		ne.AddRun(sched.Run{Scheduled: scheduled, Started: started})
+	}, true /*wait*/)
+	if err != nil {
+		return err
+	}
+
+	// 3. start the job
+	jobID, err := s.start(&e.Spec)
+	if err != nil {
+		glog.Errorf("%s: %s: failed to start %q: %v", s.p, e, e.Action.Action, err)
+	} else {
+		glog.Infof("%s: %s: started %q, job ID %s", s.p, e, e.Action.Action, jobID)
+	}
+	return s.update(e.Name, func(ne *sched.Entry) {
+		for i := len(ne.History) - 1; i >= 0; i-- {
+			if ne.History[i].Started == started {
+				ne.History[i].JobID = jobID
+				if err != nil {
+					ne.History[i].Err = err.Error()
+				}
+				break
+			}
+		}
+	}, false)
+}
+
+// modify a given (copy-on-write) entry and metasync
+func (s *scheduler) update(name string, cb func(ne *sched.Entry), wait bool) error {
+	ctx := &schedModifier{
+		pre: func(_ *schedModifier, clone *schedMD) error {
+			e, ok := clone.Entries[name]
+			if !ok {
+				return cmn.NewErrNotFound("%s: schedule %q", s.p.si, name)
+			}
+			ne := e.Clone()
+			cb(ne)
+			clone.Entries[name] = ne
+			return nil
+		},
+		final: s.p._syncSchedFinal,
+		msg:   s.p.newAmsgStr("sched-run", nil),
+		wait:  wait,
+	}
+	_, err := s.p.owner.sched.modify(ctx)
+	return err
+}
+
+// start a job via the primary's own public API (intra-cluster call that bypasses access
+// control - in other words, schedules are created by admins only)
+func (s *scheduler) start(spec *sched.Spec) (jobID string, err error) {
+	var (
+		p      = s.p
+		action = spec.Action.Action
+		args   = &callArgs{si: p.si, timeout: schedStartTime}
+	)
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPost,
+		Base:   p.si.URL(cmn.NetPublic),
+		Header: http.Header{cmn.HdrContentType: []string{cmn.ContentJSON}},
+	}
+	switch {
+	case action == apc.ActXactStart:
+		var msg xact.QueryMsg
+		if err = cos.MorphMarshal(spec.Action.Value, &msg); err != nil {
+			return
+		}
+		args.req.Method = http.MethodPut
+		args.req.Path = apc.URLPathClu.S
+		args.req.Body = cos.MustMarshal(apc.ActionMsg{Action: action, Value: msg})
+		args.req.Query = msg.Bck.AddToQuery(nil)
+	case action == apc.ActDownload:
+		args.req.Path = apc.URLPathDownload.S
+		args.req.Body = cos.MustMarshal(spec.Action.Value)
+	case action == apc.ActDsort:
+		args.req.Path = apc.URLPathdSort.S
+		args.req.Body = cos.MustMarshal(spec.Action.Value)
+	default:
+		q := spec.Bck.AddToQuery(nil)
+		if !spec.BckTo.IsEmpty() {
+			q = spec.BckTo.AddUnameToQuery(q, apc.QparamBucketTo)
+		}
+		args.req.Path = apc.URLPathBuckets.Join(spec.Bck.Name)
+		args.req.Body = cos.MustMarshal(spec.Action)
+		args.req.Query = q
+	}
+	res := p.call(args)
+	defer freeCR(res)
+	if res.err != nil {
+		return "", res.toErr()
+	}
+	if action == apc.ActDownload {
+		var resp downloader.DlPostResp
+		err = jsoniter.Unmarshal(res.bytes, &resp)
+		return resp.ID, err
+	}
+	return strings.TrimSpace(string(res.bytes)), nil
+}
+
+func (s *scheduler) running(spec *sched.Spec, jobID string) (bool, error) {
+	var (
+		p    = s.p
+		args = &callArgs{si: p.si, timeout: cmn.Timeout.CplaneOperation()}
+	)
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Base: p.si.URL(cmn.NetPublic)}
+	if spec.JobKind() == sched.JobDsort {
+		args.req.Path = apc.URLPathdSort.S
+		args.req.Query = url.Values{apc.QparamUUID: []string{jobID}}
+	} else {
+		args.req.Path = apc.URLPathClu.S
+		args.req.Body = cos.MustMarshal(xact.QueryMsg{ID: jobID})
+		args.req.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatStatus}}
+	}
+	res := p.call(args)
+	defer freeCR(res)
+	if res.status == http.StatusNotFound {
+		return false, nil
+	}
+	if res.err != nil {
+		return false, res.toErr()
+	}
+	if spec.JobKind() == sched.JobDsort {
+		var (
+			metrics map[string]*dsort.Metrics
+			info    *dsort.JobInfo
+		)
+		if err := jsoniter.Unmarshal(res.bytes, &metrics); err != nil {
+			return false, err
+		}
+		for _, m := range metrics {
+			ji := m.ToJobInfo(jobID)
+			if info == nil {
+				info = &ji
+			} else {
+				info.Aggregate(&ji)
+			}
+		}
+		return info != nil && info.IsRunning(), nil
+	}
+	var status nl.NotifStatus
+	if err := jsoniter.Unmarshal(res.bytes, &status); err != nil {
+		return false, err
+	}
+	return status.FinTime == 0 && !status.AbortedX, nil
+}
+
+func (s *scheduler) abort(spec *sched.Spec, jobID string) error {
+	var (
+		p    = s.p
+		args = &callArgs{si: p.si, timeout: cmn.Timeout.MaxKeepalive()}
+	)
+	args.req = cmn.HreqArgs{Method: http.MethodDelete, Base: p.si.URL(cmn.NetPublic)}
+	switch spec.JobKind() {
+	case sched.JobDownload:
+		args.req.Path = apc.URLPathDownloadAbort.S
+		args.req.Body = cos.MustMarshal(downloader.DlAdminBody{ID: jobID})
+	case sched.JobDsort:
+		args.req.Path = apc.URLPathdSortAbort.S
+		args.req.Query = url.Values{apc.QparamUUID: []string{jobID}}
+	default:
+		args.req.Method = http.MethodPut
+		args.req.Path = apc.URLPathClu.S
+		args.req.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActXactStop, Value: xact.QueryMsg{ID: jobID}})
+	}
+	res := p.call(args)
+	defer freeCR(res)
+	if res.err != nil {
+		return res.toErr()
+	}
+	return nil
+}
+
+/////////////////////////
+// [METHOD] /v1/schedule //
+/////////////////////////
+
+func (p *proxy) scheduleHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.ClusterStartedWithRetry() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		p.getSchedule(w, r)
+	case http.MethodPut:
+		p.putSchedule(w, r)
+	case http.MethodDelete:
+		p.delSchedule(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPut)
+	}
+}
+
+// GET /v1/schedule[/<name>]
+func (p *proxy) getSchedule(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := p.checkRESTItems(w, r, 0, true, apc.URLPathSchedule.L)
+	if err != nil {
+		return
+	}
+	md := p.owner.sched.get()
+	if len(apiItems) == 0 {
+		p.writeJSON(w, r, md.List(), "list-schedules")
+		return
+	}
+	e, ok := md.Get(apiItems[0])
+	if !ok {
+		p.writeErr(w, r, cmn.NewErrNotFound("%s: schedule %q", p.si, apiItems[0]), http.StatusNotFound)
+		return
+	}
+	p.writeJSON(w, r, e, "get-schedule")
+}
+
+// PUT /v1/schedule (add or update)
+func (p *proxy) putSchedule(w http.ResponseWriter, r *http.Request) {
+	if _, err := p.checkRESTItems(w, r, 0, false, apc.URLPathSchedule.L); err != nil {
+		return
+	}
+	if err := p.checkACL(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	spec := &sched.Spec{}
+	if err := cmn.ReadJSON(w, r, spec); err != nil {
+		return
+	}
+	if p.forwardCP(w, r, nil, "schedule "+spec.Name, cos.MustMarshal(spec)) {
+		return
+	}
+	if err := spec.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	ctx := &schedModifier{
+		pre:   _putSchedPre,
+		final: p._syncSchedFinal,
+		spec:  spec,
+		msg:   p.newAmsgStr("sched-put", nil),
+		wait:  true,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		p.writeErr(w, r, err)
+	}
+}
+
+// (re)computes the next time to fire; keeps the history of runs
+func _putSchedPre(ctx *schedModifier, clone *schedMD) error {
+	now := time.Now()
+	e, ok := clone.Entries[ctx.spec.Name]
+	if !ok {
+		clone.Entries[ctx.spec.Name] = sched.NewEntry(ctx.spec, now)
+		return nil
+	}
+	ne := e.Clone()
+	ne.Spec = *ctx.spec
+	ne.Queued = 0
+	ne.SetNext(now)
+	clone.Entries[ctx.spec.Name] = ne
+	return nil
+}
+
+// DELETE /v1/schedule/<name>
+func (p *proxy) delSchedule(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := p.checkRESTItems(w, r, 1, false, apc.URLPathSchedule.L)
+	if err != nil {
+		return
+	}
+	if err := p.checkACL(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	if p.forwardCP(w, r, nil, "remove schedule "+apiItems[0]) {
+		return
+	}
+	ctx := &schedModifier{
+		pre:   p._delSchedPre,
+		final: p._syncSchedFinal,
+		name:  apiItems[0],
+		msg:   p.newAmsgStr("sched-del", nil),
+		wait:  true,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		if cmn.IsErrNotFound(err) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err)
+		}
+	}
+}
+
+func (p *proxy) _delSchedPre(ctx *schedModifier, clone *schedMD) error {
+	if _, ok := clone.Entries[ctx.name]; !ok {
+		return cmn.NewErrNotFound("%s: schedule %q", p.si, ctx.name)
+	}
+	delete(clone.Entries, ctx.name)
+	return nil
+}
+
+func (p *proxy) _syncSchedFinal(ctx *schedModifier, clone *schedMD) {
+	wg := p.metasyncer.sync(revsPair{clone, ctx.msg})
+	if ctx.wait {
+		wg.Wait()
+	}
+}
+
+func (s *scheduler) String() string { return fmt.Sprintf("scheduler[%s]", s.p) }
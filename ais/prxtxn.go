@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	notif "github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -224,7 +225,7 @@ func _destroyBMDPre(ctx *bmdModifier, clone *bucketMD) error {
 }
 
 // make-n-copies: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxy) makeNCopies(msg *apc.ActionMsg, bck *cluster.Bck) (xactID string, err error) {
+func (p *proxy) makeNCopies(msg *apc.ActionMsg, bck *cluster.Bck, user string) (xactID string, err error) {
 	copies, err := _parseNCopies(msg.Value)
 	if err != nil {
 		return
@@ -273,7 +274,7 @@ func (p *proxy) makeNCopies(msg *apc.ActionMsg, bck *cluster.Bck) (xactID string
 	// 4. IC
 	nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
 	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query, msg: msg, user: user})
 
 	// 5. commit
 	xactID, err = c.commit(bck, c.cmtTout(waitmsync))
@@ -303,7 +304,8 @@ func _mirrorBMDPre(ctx *bmdModifier, clone *bucketMD) error {
 }
 
 // set-bucket-props: { confirm existence -- begin -- apply props -- metasync -- commit }
-func (p *proxy) setBucketProps(msg *apc.ActionMsg, bck *cluster.Bck, nprops *cmn.BucketProps) (string /*xactID*/, error) {
+func (p *proxy) setBucketProps(msg *apc.ActionMsg, bck *cluster.Bck, nprops *cmn.BucketProps,
+	user string) (string /*xactID*/, error) {
 	// 1. confirm existence
 	bprops, present := p.owner.bmd.get().Get(bck)
 	if !present {
@@ -328,6 +330,9 @@ func (p *proxy) setBucketProps(msg *apc.ActionMsg, bck *cluster.Bck, nprops *cmn
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		if err := bck.Props.ObjectLock.ValidateUpdate(&nprops.ObjectLock, false /*bypass*/); err != nil {
+			return "", fmt.Errorf("%s: cannot reset %s props: %v", p.si, bck, err)
+		}
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -369,7 +374,7 @@ func (p *proxy) setBucketProps(msg *apc.ActionMsg, bck *cluster.Bck, nprops *cmn
 		}
 		nl := xact.NewXactNL(c.uuid, action, &c.smap.Smap, nil, bck.Bucket())
 		nl.SetOwner(equalIC)
-		p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+		p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query, msg: msg, user: user})
 	}
 
 	// 5. commit
@@ -394,7 +399,7 @@ func (p *proxy) _setPropsPre(ctx *bmdModifier, clone *bucketMD) (err error) {
 }
 
 // rename-bucket: { confirm existence -- begin -- RebID -- metasync -- commit -- wait for rebalance and unlock }
-func (p *proxy) renameBucket(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg) (xactID string, err error) {
+func (p *proxy) renameBucket(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg, user string) (xactID string, err error) {
 	if err = p.canRunRebalance(); err != nil {
 		err = cmn.NewErrFailedTo(p, "rename", bckFrom, err)
 		return
@@ -455,7 +460,7 @@ func (p *proxy) renameBucket(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg) (x
 	// 4. IC
 	nl := xact.NewXactNL(c.uuid, c.msg.Action, &c.smap.Smap, nil, bckFrom.Bucket(), bckTo.Bucket())
 	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{smap: c.smap, nl: nl, query: c.req.Query})
+	p.ic.registerEqual(regIC{smap: c.smap, nl: nl, query: c.req.Query, msg: msg, user: user})
 
 	// 5. commit
 	c.req.Body = cos.MustMarshal(c.msg)
@@ -501,7 +506,7 @@ func _renameBMDPre(ctx *bmdModifier, clone *bucketMD) error {
 
 // transform (or simply copy) bucket to another bucket
 // { confirm existence -- begin -- conditional metasync -- start waiting for operation done -- commit }
-func (p *proxy) tcb(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg, dryRun bool) (xactID string, err error) {
+func (p *proxy) tcb(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg, dryRun bool, user string) (xactID string, err error) {
 	// 1. confirm existence
 	bmd := p.owner.bmd.get()
 	if _, existsFrom := bmd.Get(bckFrom); !existsFrom {
@@ -557,7 +562,7 @@ func (p *proxy) tcb(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg, dryRun bool
 			}
 		}
 	}
-	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query, msg: msg, user: user})
 
 	// 5. commit
 	xactID, err = c.commit(bckFrom, c.cmtTout(waitmsync))
@@ -638,7 +643,7 @@ func parseECConf(value interface{}) (*cmn.ECConfToUpdate, error) {
 }
 
 // ec-encode: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, err error) {
+func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg, user string) (xactID string, err error) {
 	nlp := bck.GetNameLockPair()
 	ecConf, err := parseECConf(msg.Value)
 	if err != nil {
@@ -697,7 +702,7 @@ func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, e
 	// 5. IC
 	nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
 	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+	p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query, msg: msg, user: user})
 
 	// 6. commit
 	xactID, err = c.commit(bck, c.cmtTout(waitmsync))
@@ -889,7 +894,7 @@ func (p *proxy) destroyBucketData(msg *apc.ActionMsg, bck *cluster.Bck) error {
 // promote synchronously if the number of files (to promote) is less or equal
 const promoteNumSync = 16
 
-func (p *proxy) promote(bck *cluster.Bck, msg *apc.ActionMsg, tsi *cluster.Snode) (xactID string, err error) {
+func (p *proxy) promote(bck *cluster.Bck, msg *apc.ActionMsg, tsi *cluster.Snode, user string) (xactID string, err error) {
 	var (
 		totalN           int64
 		waitmsync        bool
@@ -924,7 +929,7 @@ func (p *proxy) promote(bck *cluster.Bck, msg *apc.ActionMsg, tsi *cluster.Snode
 	if !noXact {
 		nl := xact.NewXactNL(c.uuid, msg.Action, &c.smap.Smap, nil, bck.Bucket())
 		nl.SetOwner(equalIC)
-		p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query})
+		p.ic.registerEqual(regIC{nl: nl, smap: c.smap, query: c.req.Query, msg: msg, user: user})
 	}
 
 	// commit
@@ -1058,6 +1063,14 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 			nprops.EC.ParitySlices = 1
 		}
 	}
+	if err = bprops.ObjectLock.ValidateUpdate(&nprops.ObjectLock, propsToUpdate.Force); err != nil {
+		err = fmt.Errorf("%s: %s: %v", p.si, bck, err)
+		return
+	}
+	if nprops.Encryption.Enabled && cfg.KMS.Provider == "" {
+		err = fmt.Errorf("%s: cannot enable encryption at rest for %s: %v", p.si, bck, sse.ErrNoProvider)
+		return
+	}
 	if !bprops.Mirror.Enabled && nprops.Mirror.Enabled {
 		if nprops.Mirror.Copies == 1 {
 			nprops.Mirror.Copies = cos.MaxI64(cfg.Mirror.Copies, 2)
@@ -0,0 +1,218 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Rate limiting (see cmn.RateLimitConf):
+// - proxies enforce cmn.RateLimit.OpsPerSec when redirecting GET, PUT, and DELETE,
+//   and upon list-objects;
+// - targets enforce cmn.RateLimit.BytesPerSec upon GET and PUT (including S3 API);
+// - in both cases, each node enforces its own share of the limit: the limit divided
+//   by the number of active proxies or targets, respectively;
+// - each (user, bucket, op) request draws from all matching limits;
+// - the request that exceeds any one of them fails with 429 and Retry-After.
+//
+// Each limit is a token bucket with 1s burst - see cos.TokenBucket.
+//
+// Per-user limits on targets: the redirecting proxy vouches for the requesting (possibly,
+// anonymous) user by signing (user, proxy time, URL path) with the cluster's AuthN secret
+// (config.Auth.Secret) - see userSig. Targets never trust unsigned user IDs: when there
+// are per-user limits, a GET or PUT that wasn't redirected by a proxy (or carries invalid
+// signature) fails with 401. Signatures expire: the proxy time must be within userSigMaxAge
+// (to prevent replaying captured redirect URLs). Without the secret (e.g., AuthN configured with key pairs only)
+// per-user byte limits cannot be enforced by targets.
+
+const (
+	rlIdleTime    = 10 * time.Minute // remove token buckets that haven't been used for so long
+	userSigMaxAge = time.Minute      // max age of the redirect's user signature (both ways - clock skew)
+)
+
+type ratelim struct {
+	buckets map[string]*cos.TokenBucket // by cmn.RateLimit.Key
+	mu      sync.RWMutex
+}
+
+func (rl *ratelim) init() {
+	rl.buckets = make(map[string]*cos.TokenBucket, 8)
+	hk.Reg("ratelim"+hk.NameSuffix, rl.housekeep, rlIdleTime)
+}
+
+// acquire takes `n` tokens (ops or bytes) from each matching limit; returns the first limit
+// that's been exceeded, if any, and the time to wait
+func (rl *ratelim) acquire(conf *cmn.RateLimitConf, nodes int, user, bck, op string, n int64,
+	bytes bool) (*cmn.RateLimit, time.Duration) {
+	var (
+		now   = mono.NanoTime()
+		taken = make([]*cos.TokenBucket, 0, len(conf.Limits))
+	)
+	for i := range conf.Limits {
+		l := &conf.Limits[i]
+		limit := l.OpsPerSec
+		if bytes {
+			limit = int64(l.BytesPerSec)
+		}
+		if limit == 0 || !l.Match(user, bck, op) {
+			continue
+		}
+		rate := float64(limit) / float64(cos.Max(nodes, 1))
+		tb := rl.bucket(l.Key(user, bck, op), rate, now)
+		if ok, wait := tb.TryTake(float64(n), now); !ok {
+			for _, tb := range taken {
+				tb.Return(float64(n))
+			}
+			return l, wait
+		}
+		taken = append(taken, tb)
+	}
+	return nil, 0
+}
+
+func (rl *ratelim) bucket(key string, rate float64, now int64) (tb *cos.TokenBucket) {
+	burst := math.Max(rate, 1)
+	rl.mu.RLock()
+	tb = rl.buckets[key]
+	rl.mu.RUnlock()
+	if tb != nil {
+		tb.SetRate(rate, burst, now) // (in case config or cluster map changed)
+		return
+	}
+	rl.mu.Lock()
+	if tb = rl.buckets[key]; tb == nil {
+		tb = cos.NewTokenBucket(rate, burst, now)
+		rl.buckets[key] = tb
+	}
+	rl.mu.Unlock()
+	return
+}
+
+func (rl *ratelim) housekeep() time.Duration {
+	now := mono.NanoTime()
+	rl.mu.Lock()
+	for key, tb := range rl.buckets {
+		if tb.Idle(now) > rlIdleTime {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.mu.Unlock()
+	return rlIdleTime
+}
+
+// rateLimit returns false - having responded with 429 (Too Many Requests) - if
+// a given request exceeds any of the configured limits
+func (h *htrun) rateLimit(w http.ResponseWriter, r *http.Request, conf *cmn.RateLimitConf, user string,
+	bck *cluster.Bck, op string, n int64) bool {
+	var (
+		nodes int
+		bytes = h.si.IsTarget()
+		smap  = h.owner.smap.get()
+	)
+	if bytes {
+		nodes = smap.CountActiveTargets()
+	} else {
+		nodes = smap.CountActiveProxies()
+	}
+	l, wait := h.ratelim.acquire(conf, nodes, user, bck.String(), op, n, bytes)
+	if l == nil {
+		return true
+	}
+	h.statsT.AddMany(cos.NamedVal64{Name: stats.RateLimitedCount, NameSuffix: l.ID, Value: 1})
+	secs := cos.MaxI64(cos.DivCeil(int64(wait), int64(time.Second)), 1)
+	w.Header().Set(cmn.HdrRetryAfter, strconv.FormatInt(secs, 10))
+	h.writeErrSilent(w, r, cmn.NewErrRateLimited(h.si.String(), l.ID, wait), http.StatusTooManyRequests)
+	return false
+}
+
+// proxy: ops/s; returns the requesting user (if needed to enforce user limits downstream)
+func (p *proxy) rateLimitOps(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, op string) (user string, ok bool) {
+	config := cmn.GCO.Get()
+	if !config.RateLimit.Enabled || len(config.RateLimit.Limits) == 0 {
+		return "", true
+	}
+	if config.RateLimit.ByUser() {
+		user = p.reqUser(r.Header)
+	}
+	ok = p.rateLimit(w, r, &config.RateLimit, user, bck, op, 1)
+	return
+}
+
+// target: bytes/s; when not specified (negative) the size is the size of the
+// object (if present - cold GETs are not charged)
+func (t *target) rateLimitBytes(w http.ResponseWriter, r *http.Request, dpq *dpq, lom *cluster.LOM, op string,
+	size int64) bool {
+	config := cmn.GCO.Get()
+	if !config.RateLimit.Enabled || len(config.RateLimit.Limits) == 0 {
+		return true
+	}
+	user, err := reqUserSigned(r, dpq, config)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusUnauthorized)
+		return false
+	}
+	if size < 0 {
+		size = 0
+		if lom.Load(true /*cache it*/, false /*locked*/) == nil {
+			size = lom.SizeBytes()
+		}
+	}
+	return t.rateLimit(w, r, &config.RateLimit, user, lom.Bck(), op, size)
+}
+
+func userSig(secret, user, ptime, path string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(user))
+	mac.Write([]byte{0})
+	mac.Write([]byte(ptime))
+	mac.Write([]byte{0})
+	mac.Write([]byte(path))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// target: returns the requesting user as vouched for by the redirecting proxy
+func reqUserSigned(r *http.Request, dpq *dpq, config *cmn.Config) (string, error) {
+	if !config.Auth.Enabled || !config.RateLimit.ByUser() {
+		return "", nil
+	}
+	if config.Auth.Secret == "" {
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Warningln("no auth secret: cannot enforce per-user rate limits")
+		}
+		return "", nil
+	}
+	if dpq.usig == "" || dpq.ptime == "" {
+		return "", errors.New("per-user rate limits: expecting request redirected by AIS gateway")
+	}
+	sig := userSig(config.Auth.Secret, dpq.user, dpq.ptime, r.URL.Path)
+	if !hmac.Equal([]byte(sig), []byte(dpq.usig)) {
+		return "", errors.New("per-user rate limits: invalid user signature")
+	}
+	pts, err := cos.S2UnixNano(dpq.ptime)
+	if err != nil {
+		return "", errors.New("per-user rate limits: invalid redirect time")
+	}
+	if age := time.Duration(time.Now().UnixNano() - pts); age > userSigMaxAge || age < -userSigMaxAge {
+		return "", fmt.Errorf("per-user rate limits: user signature expired (age %v)", age)
+	}
+	return dpq.user, nil
+}
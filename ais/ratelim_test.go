@@ -0,0 +1,49 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+func TestReqUserSigned(t *testing.T) {
+	const (
+		secret = "secret"
+		path   = "/v1/objects/bck/obj"
+	)
+	config := &cmn.Config{}
+	config.Auth.Enabled = true
+	config.Auth.Secret = secret
+	config.RateLimit.Limits = []cmn.RateLimit{{ID: "l1", User: cmn.RateLimitEach, BytesPerSec: cos.MiB}}
+
+	r, err := http.NewRequest(http.MethodGet, "http://localhost"+path, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(user string, ptime time.Time) *dpq {
+		pt := cos.UnixNano2S(ptime.UnixNano())
+		return &dpq{user: user, ptime: pt, usig: userSig(secret, user, pt, path)}
+	}
+
+	if user, err := reqUserSigned(r, sign("u1", time.Now()), config); err != nil || user != "u1" {
+		t.Fatalf("expected user %q, got %q (err %v)", "u1", user, err)
+	}
+	forged := sign("u1", time.Now())
+	forged.user = "u2"
+	if _, err := reqUserSigned(r, forged, config); err == nil {
+		t.Fatal("expected invalid signature")
+	}
+	// (replayed)
+	for _, ptime := range []time.Time{time.Now().Add(-2 * userSigMaxAge), time.Now().Add(2 * userSigMaxAge)} {
+		if _, err := reqUserSigned(r, sign("u1", ptime), config); err == nil {
+			t.Fatalf("expected expired signature (%v)", ptime)
+		}
+	}
+}
@@ -16,12 +16,20 @@ const (
 	QparamACL         = "acl"
 	QparamMultiDelete = "delete"
 
+	// multipart upload
+	QparamMptUploads        = "uploads"
+	QparamMptUploadID       = "uploadId"
+	QparamMptPartNo         = "partNumber"
+	QparamMptMaxUploads     = "max-uploads"
+	QparamMptUploadIDMarker = "upload-id-marker"
+
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
 
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 
 	// Headers
-	headerETag   = "ETag"
-	HeaderObjSrc = "x-amz-copy-source"
+	headerETag      = "ETag"
+	HeaderObjSrc    = "x-amz-copy-source"
+	HeaderBypassGov = "x-amz-bypass-governance-retention"
 )
@@ -0,0 +1,379 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// NOTE: multipart uploads are staged on the HRW target (the one that will also store
+// the resulting object) and are tracked in memory; parts are stored as workfiles
+// and are therefore lost (and cleaned up) upon target restart. The same applies
+// to cluster membership changes: when the object's HRW target changes, subsequent
+// requests land on a target that knows nothing about the upload (NoSuchUpload),
+// while the original target eventually expires it (see UploadTTL).
+
+const (
+	MaxPartNum = 10000 // as per AWS
+
+	// in-progress uploads idle (no new parts) for longer than this are aborted
+	UploadTTL = 7 * 24 * time.Hour
+
+	// default page size for listing in-progress uploads
+	defaultMaxUploads = 1000
+)
+
+type (
+	// uploaded (staged) part
+	MptPart struct {
+		MD5  string // hex-encoded MD5 of the part's content
+		FQN  string // workfile containing the part
+		Size int64  // part size in bytes
+		Num  int64  // part number
+	}
+	// in-progress multipart upload
+	mpt struct {
+		bckName string
+		objName string
+		parts   []*MptPart // sorted by part number
+		ctime   time.Time  // when initiated
+		mtime   time.Time  // last activity (part uploaded)
+		mtx     sync.Mutex // serializes adding parts vs assembling the object (see LockUpload)
+	}
+	uploads map[string]*mpt // by upload ID
+)
+
+type (
+	// Response to initiate multipart upload
+	InitiateMptUploadResult struct {
+		Ns       string `xml:"xmlns,attr"`
+		Bucket   string `xml:"Bucket"`
+		Key      string `xml:"Key"`
+		UploadID string `xml:"UploadId"`
+	}
+
+	// Request to complete multipart upload
+	CompleteMptUpload struct {
+		Parts []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		ETag       string `xml:"ETag"`
+		PartNumber int64  `xml:"PartNumber"`
+		Size       int64  `xml:"Size,omitempty"`
+	}
+	// Response to complete multipart upload
+	CompleteMptUploadResult struct {
+		Ns     string `xml:"xmlns,attr"`
+		Bucket string `xml:"Bucket"`
+		Key    string `xml:"Key"`
+		ETag   string `xml:"ETag"`
+	}
+
+	// List parts of an in-progress upload
+	ListPartsResult struct {
+		Ns       string      `xml:"xmlns,attr"`
+		Bucket   string      `xml:"Bucket"`
+		Key      string      `xml:"Key"`
+		UploadID string      `xml:"UploadId"`
+		Parts    []*PartInfo `xml:"Part"`
+	}
+
+	// List in-progress uploads
+	ListMptUploadsResult struct {
+		Ns                 string        `xml:"xmlns,attr"`
+		Bucket             string        `xml:"Bucket"`
+		UploadIDMarker     string        `xml:"UploadIdMarker"`
+		NextUploadIDMarker string        `xml:"NextUploadIdMarker"`
+		MaxUploads         int           `xml:"MaxUploads"`
+		IsTruncated        bool          `xml:"IsTruncated"`
+		Uploads            []*UploadInfo `xml:"Upload"`
+	}
+	UploadInfo struct {
+		Key       string    `xml:"Key"`
+		UploadID  string    `xml:"UploadId"`
+		Initiated time.Time `xml:"Initiated"`
+	}
+)
+
+var (
+	ups = make(uploads, 8)
+	mu  sync.RWMutex
+)
+
+//
+// in-progress uploads
+//
+
+// Start multipart upload
+func InitUpload(id, bckName, objName string) {
+	now := time.Now()
+	mu.Lock()
+	ups[id] = &mpt{
+		bckName: bckName,
+		objName: objName,
+		parts:   make([]*MptPart, 0, 16),
+		ctime:   now,
+		mtime:   now,
+	}
+	mu.Unlock()
+}
+
+// Lock in-progress upload for the duration of adding a part or assembling
+// the resulting object - the latter must not race with a part being replaced
+// (and its workfile removed)
+func LockUpload(id string) (unlock func(), err error) {
+	mu.RLock()
+	upload, ok := ups[id]
+	mu.RUnlock()
+	if !ok {
+		return nil, NewErrNoSuchUpload(id)
+	}
+	upload.mtx.Lock()
+	return upload.mtx.Unlock, nil
+}
+
+// Add part to an in-progress upload; a re-uploaded part replaces
+// the previous one with the same number - the caller then removes
+// the returned (replaced) part's workfile
+func AddPart(id string, npart *MptPart) (prev *MptPart, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	upload, ok := ups[id]
+	if !ok {
+		return nil, NewErrNoSuchUpload(id)
+	}
+	upload.mtime = time.Now()
+	idx := sort.Search(len(upload.parts), func(i int) bool { return upload.parts[i].Num >= npart.Num })
+	if idx < len(upload.parts) && upload.parts[idx].Num == npart.Num {
+		prev = upload.parts[idx]
+		upload.parts[idx] = npart
+		return
+	}
+	upload.parts = append(upload.parts, nil)
+	copy(upload.parts[idx+1:], upload.parts[idx:])
+	upload.parts[idx] = npart
+	return
+}
+
+// Validate the list of parts sent by the client with its complete-upload request
+// and return the corresponding staged parts, in order
+func CheckParts(id string, parts []*PartInfo) ([]*MptPart, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	upload, ok := ups[id]
+	if !ok {
+		return nil, NewErrNoSuchUpload(id)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("upload %q: empty list of parts", id)
+	}
+	res := make([]*MptPart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, fmt.Errorf("upload %q: parts must be listed in ascending order (%d after %d)",
+				id, part.PartNumber, parts[i-1].PartNumber)
+		}
+		mptPart := upload.getPart(part.PartNumber)
+		if mptPart == nil {
+			return nil, fmt.Errorf("upload %q: part %d not found", id, part.PartNumber)
+		}
+		if etag := UnquoteETag(part.ETag); etag != "" && etag != mptPart.MD5 {
+			return nil, fmt.Errorf("upload %q: part %d ETag mismatch (%q vs %q)", id, part.PartNumber, etag, mptPart.MD5)
+		}
+		res = append(res, mptPart)
+	}
+	return res, nil
+}
+
+// Remove upload from the registry and return all its parts, for cleanup
+func FinishUpload(id string) (parts []*MptPart, exists bool) {
+	mu.Lock()
+	upload, ok := ups[id]
+	if ok {
+		delete(ups, id)
+		parts = upload.parts
+	}
+	mu.Unlock()
+	return parts, ok
+}
+
+// Remove uploads that have been idle for longer than UploadTTL and return
+// their parts, for cleanup; uploads that are currently locked are skipped
+func ExpireUploads(now time.Time) (expired map[string][]*MptPart) {
+	mu.Lock()
+	for id, upload := range ups {
+		if now.Sub(upload.mtime) < UploadTTL || !upload.mtx.TryLock() {
+			continue
+		}
+		if expired == nil {
+			expired = make(map[string][]*MptPart, 4)
+		}
+		expired[id] = upload.parts
+		delete(ups, id)
+		upload.mtx.Unlock()
+	}
+	mu.Unlock()
+	return
+}
+
+func UploadExists(id, bckName, objName string) (exists bool) {
+	mu.RLock()
+	upload, ok := ups[id]
+	exists = ok && upload.bckName == bckName && upload.objName == objName
+	mu.RUnlock()
+	return
+}
+
+func ListParts(id string) ([]*PartInfo, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	upload, ok := ups[id]
+	if !ok {
+		return nil, NewErrNoSuchUpload(id)
+	}
+	parts := make([]*PartInfo, 0, len(upload.parts))
+	for _, part := range upload.parts {
+		parts = append(parts, &PartInfo{ETag: QuoteETag(part.MD5), PartNumber: part.Num, Size: part.Size})
+	}
+	return parts, nil
+}
+
+// List (this target's) in-progress uploads in a given bucket
+func ListUploads(bckName, idMarker string, maxUploads int) *ListMptUploadsResult {
+	results := make([]*UploadInfo, 0, 16)
+	mu.RLock()
+	for id, upload := range ups {
+		if upload.bckName == bckName {
+			results = append(results, &UploadInfo{Key: upload.objName, UploadID: id, Initiated: upload.ctime})
+		}
+	}
+	mu.RUnlock()
+	return NewListMptUploadsResult(bckName, idMarker, maxUploads, results)
+}
+
+func (upload *mpt) getPart(num int64) *MptPart {
+	idx := sort.Search(len(upload.parts), func(i int) bool { return upload.parts[i].Num >= num })
+	if idx < len(upload.parts) && upload.parts[idx].Num == num {
+		return upload.parts[idx]
+	}
+	return nil
+}
+
+//
+// helpers
+//
+
+func ParsePartNum(s string) (int64, error) {
+	partNum, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid part number %q: %v", s, err)
+	}
+	if partNum < 1 || partNum > MaxPartNum {
+		return 0, fmt.Errorf("invalid part number %d (must be in the range [1, %d])", partNum, MaxPartNum)
+	}
+	return partNum, nil
+}
+
+func ParseMaxUploads(s string) (int, error) {
+	if s == "" {
+		return defaultMaxUploads, nil
+	}
+	maxUploads, err := strconv.Atoi(s)
+	if err != nil || maxUploads < 0 {
+		return 0, fmt.Errorf("invalid %s %q", QparamMptMaxUploads, s)
+	}
+	if maxUploads == 0 || maxUploads > defaultMaxUploads {
+		maxUploads = defaultMaxUploads
+	}
+	return maxUploads, nil
+}
+
+// Composite ETag of a multipart-uploaded object: MD5 of the concatenated
+// binary MD5s of its parts followed by "-<number of parts>", as per
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html
+func CompositeETag(parts []*MptPart) (string, error) {
+	h := cos.NewCksumHash(cos.ChecksumMD5)
+	for _, part := range parts {
+		b, err := hex.DecodeString(part.MD5)
+		if err != nil {
+			return "", fmt.Errorf("part %d: invalid MD5 %q: %v", part.Num, part.MD5, err)
+		}
+		h.H.Write(b)
+	}
+	h.Finalize()
+	return h.Value() + "-" + strconv.Itoa(len(parts)), nil
+}
+
+func QuoteETag(etag string) string   { return "\"" + etag + "\"" }
+func UnquoteETag(etag string) string { return strings.Trim(etag, "\"") }
+
+func NewErrNoSuchUpload(id string) error {
+	return cmn.NewErrNotFound("upload %q", id)
+}
+
+// merge (per-target) lists of in-progress uploads and return the requested page
+func NewListMptUploadsResult(bckName, idMarker string, maxUploads int, results []*UploadInfo) *ListMptUploadsResult {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Key != results[j].Key {
+			return results[i].Key < results[j].Key
+		}
+		return results[i].UploadID < results[j].UploadID
+	})
+	if idMarker != "" {
+		for i, ui := range results {
+			if ui.UploadID == idMarker {
+				results = results[i+1:]
+				break
+			}
+		}
+	}
+	r := &ListMptUploadsResult{
+		Ns:             s3Namespace,
+		Bucket:         bckName,
+		UploadIDMarker: idMarker,
+		MaxUploads:     maxUploads,
+		Uploads:        results,
+	}
+	if maxUploads > 0 && len(results) > maxUploads {
+		r.Uploads = results[:maxUploads]
+		r.IsTruncated = true
+		r.NextUploadIDMarker = r.Uploads[maxUploads-1].UploadID
+	}
+	return r
+}
+
+func NewInitiateMptUploadResult(bckName, objName, id string) *InitiateMptUploadResult {
+	return &InitiateMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, UploadID: id}
+}
+
+func NewCompleteMptUploadResult(bckName, objName, etag string) *CompleteMptUploadResult {
+	return &CompleteMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, ETag: QuoteETag(etag)}
+}
+
+func NewListPartsResult(bckName, objName, id string, parts []*PartInfo) *ListPartsResult {
+	return &ListPartsResult{Ns: s3Namespace, Bucket: bckName, Key: objName, UploadID: id, Parts: parts}
+}
+
+func (r *InitiateMptUploadResult) MustMarshal(sgl *memsys.SGL) { mustMarshal(sgl, r) }
+func (r *CompleteMptUploadResult) MustMarshal(sgl *memsys.SGL) { mustMarshal(sgl, r) }
+func (r *ListPartsResult) MustMarshal(sgl *memsys.SGL)         { mustMarshal(sgl, r) }
+func (r *ListMptUploadsResult) MustMarshal(sgl *memsys.SGL)    { mustMarshal(sgl, r) }
+
+func mustMarshal(sgl *memsys.SGL, v interface{}) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(v)
+	cos.AssertNoErr(err)
+}
@@ -0,0 +1,110 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestMptCompositeETag(t *testing.T) {
+	var (
+		parts  = make([]*MptPart, 0, 3)
+		concat = make([]byte, 0, 3*md5.Size)
+	)
+	for i, content := range []string{"part-one", "part-two", "part-three"} {
+		sum := md5.Sum([]byte(content))
+		concat = append(concat, sum[:]...)
+		parts = append(parts, &MptPart{MD5: hex.EncodeToString(sum[:]), Num: int64(i + 1)})
+	}
+	sum := md5.Sum(concat)
+	expected := hex.EncodeToString(sum[:]) + "-" + strconv.Itoa(len(parts))
+	etag, err := CompositeETag(parts)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, etag == expected, "expected %q, got %q", expected, etag)
+}
+
+func TestMptParts(t *testing.T) {
+	const id = "test-upload"
+	InitUpload(id, "bck", "obj")
+	defer FinishUpload(id)
+
+	for _, num := range []int64{3, 1, 2} {
+		_, err := AddPart(id, &MptPart{MD5: strconv.Itoa(int(num)), Num: num, FQN: "fqn-" + strconv.Itoa(int(num))})
+		tassert.CheckFatal(t, err)
+	}
+	// re-upload part #2
+	prev, err := AddPart(id, &MptPart{MD5: "two", Num: 2, FQN: "fqn-two"})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, prev != nil && prev.FQN == "fqn-2", "expected replaced part #2, got %+v", prev)
+
+	list, err := ListParts(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(list) == 3, "expected 3 parts, got %d", len(list))
+	for i, part := range list {
+		tassert.Errorf(t, part.PartNumber == int64(i+1), "parts out of order: %d at %d", part.PartNumber, i)
+	}
+
+	_, err = CheckParts(id, []*PartInfo{{PartNumber: 1, ETag: "\"1\""}, {PartNumber: 3}})
+	tassert.CheckError(t, err)
+	_, err = CheckParts(id, []*PartInfo{{PartNumber: 2}, {PartNumber: 1}})
+	tassert.Errorf(t, err != nil, "expected error on out-of-order parts")
+	_, err = CheckParts(id, []*PartInfo{{PartNumber: 2, ETag: "2"}})
+	tassert.Errorf(t, err != nil, "expected error on ETag mismatch")
+	_, err = CheckParts(id, []*PartInfo{{PartNumber: 4}})
+	tassert.Errorf(t, err != nil, "expected error on missing part")
+
+	parts, exists := FinishUpload(id)
+	tassert.Fatalf(t, exists && len(parts) == 3, "expected upload with 3 parts")
+	_, err = AddPart(id, &MptPart{Num: 1})
+	tassert.Errorf(t, err != nil, "expected error adding part to a finished upload")
+}
+
+func TestMptExpireUploads(t *testing.T) {
+	const (
+		idle   = "idle-upload"
+		locked = "locked-upload"
+	)
+	InitUpload(idle, "bck", "obj")
+	InitUpload(locked, "bck", "obj")
+	defer FinishUpload(locked)
+	_, err := AddPart(idle, &MptPart{MD5: "1", Num: 1, FQN: "fqn-1"})
+	tassert.CheckFatal(t, err)
+
+	expired := ExpireUploads(time.Now())
+	tassert.Errorf(t, len(expired) == 0, "expected no expired uploads, got %d", len(expired))
+
+	unlock, err := LockUpload(locked)
+	tassert.CheckFatal(t, err)
+	expired = ExpireUploads(time.Now().Add(UploadTTL + time.Minute))
+	unlock()
+	parts, ok := expired[idle]
+	tassert.Fatalf(t, ok && len(parts) == 1 && parts[0].FQN == "fqn-1", "expected %q to expire with its part", idle)
+	_, ok = expired[locked]
+	tassert.Errorf(t, !ok, "locked upload %q must not expire", locked)
+	tassert.Errorf(t, !UploadExists(idle, "bck", "obj"), "expired upload %q still exists", idle)
+	tassert.Errorf(t, UploadExists(locked, "bck", "obj"), "upload %q is gone", locked)
+}
+
+func TestMptListUploads(t *testing.T) {
+	uploads := []*UploadInfo{
+		{Key: "b", UploadID: "3"},
+		{Key: "a", UploadID: "2"},
+		{Key: "a", UploadID: "1"},
+	}
+	r := NewListMptUploadsResult("bck", "", 2, uploads)
+	tassert.Fatalf(t, len(r.Uploads) == 2 && r.IsTruncated, "expected truncated page of 2, got %d", len(r.Uploads))
+	tassert.Errorf(t, r.Uploads[0].UploadID == "1" && r.Uploads[1].UploadID == "2", "unexpected order")
+	tassert.Errorf(t, r.NextUploadIDMarker == "2", "unexpected marker %q", r.NextUploadIDMarker)
+
+	r = NewListMptUploadsResult("bck", r.NextUploadIDMarker, 2, uploads)
+	tassert.Fatalf(t, len(r.Uploads) == 1 && !r.IsTruncated, "expected last page of 1, got %d", len(r.Uploads))
+	tassert.Errorf(t, r.Uploads[0].Key == "b", "unexpected key %q", r.Uploads[0].Key)
+}
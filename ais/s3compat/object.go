@@ -120,7 +120,12 @@ func lomMD5(lom *cluster.LOM) string {
 			return v
 		}
 	}
-	if cksum := lom.Checksum(); cksum.Type() == cos.ChecksumMD5 {
+	// multipart-uploaded object (see CompositeETag)
+	if v, exists := lom.GetCustomKey(cmn.ETag); exists && lom.Bck().IsAIS() {
+		return v
+	}
+	// (when encrypted at rest, the checksum is computed over ciphertext)
+	if cksum := lom.Checksum(); cksum.Type() == cos.ChecksumMD5 && !lom.IsEncrypted() {
 		return cksum.Value()
 	}
 	return ""
@@ -0,0 +1,199 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sched"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SchedMD: job schedules along with their runtime state (next time to fire, history of runs).
+// Unlike other cluster-level metadata, SchedMD is only stored by proxies (targets ignore it).
+// See prxsched.go for the scheduler itself.
+
+var schedMDImmSize int64
+
+type (
+	schedMD struct {
+		sched.MD
+		_sgl *memsys.SGL
+	}
+	schedOwner struct {
+		sync.Mutex
+		schedMD atomic.Pointer
+		fpath   string
+	}
+	schedModifier struct {
+		pre   func(ctx *schedModifier, clone *schedMD) (err error)
+		final func(ctx *schedModifier, clone *schedMD)
+
+		spec *sched.Spec
+		name string
+		msg  *aisMsg
+		wait bool
+	}
+)
+
+// interface guard
+var _ revs = (*schedMD)(nil)
+
+// c-tor
+func newSchedMD() (md *schedMD) {
+	md = &schedMD{}
+	md.MD.Init(4)
+	return
+}
+
+// as revs
+func (*schedMD) tag() string       { return revsSchedTag }
+func (md *schedMD) version() int64 { return md.Version }
+func (*schedMD) jit(p *proxy) revs { return p.owner.sched.get() }
+func (*schedMD) sgl() *memsys.SGL  { return nil }
+
+func (md *schedMD) marshal() []byte {
+	if md._sgl != nil {
+		md._sgl.Free()
+	}
+	md._sgl = memsys.PageMM().NewSGL(schedMDImmSize)
+	err := jsp.Encode(md._sgl, md, md.JspOpts())
+	debug.AssertNoErr(err)
+	schedMDImmSize = cos.MaxI64(schedMDImmSize, md._sgl.Len())
+	return md._sgl.Bytes()
+}
+
+// NOTE: entries are copy-on-write (see sched.Entry.Clone)
+func (md *schedMD) clone() *schedMD {
+	dst := &schedMD{}
+	dst.Version = md.Version
+	dst.Ext = md.Ext
+	dst.Init(len(md.Entries))
+	for name, e := range md.Entries {
+		dst.Entries[name] = e
+	}
+	return dst
+}
+
+////////////////
+// schedOwner //
+////////////////
+
+func newSchedOwner(config *cmn.Config) *schedOwner {
+	return &schedOwner{fpath: filepath.Join(config.ConfigDir, cmn.SchedFname)}
+}
+
+func (so *schedOwner) get() *schedMD   { return (*schedMD)(so.schedMD.Load()) }
+func (so *schedOwner) put(md *schedMD) { so.schedMD.Store(unsafe.Pointer(md)) }
+
+func (so *schedOwner) init() {
+	md := newSchedMD()
+	_, err := jsp.LoadMeta(so.fpath, md)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("failed to load %s from %s, err: %v", md, so.fpath, err)
+		} else {
+			glog.Infof("%s does not exist at %s - initializing", md, so.fpath)
+		}
+	}
+	so.put(md)
+}
+
+func (so *schedOwner) putPersist(md *schedMD, payload msPayload) (err error) {
+	var wto io.WriterTo
+	if payload != nil {
+		if b := payload[revsSchedTag]; b != nil {
+			wto = bytes.NewBuffer(b) // write metasync-sent bytes directly (no json)
+		}
+	}
+	if err = jsp.SaveMeta(so.fpath, md, wto); err == nil {
+		so.put(md)
+	}
+	return
+}
+
+func (so *schedOwner) modify(ctx *schedModifier) (clone *schedMD, err error) {
+	so.Lock()
+	clone = so.get().clone()
+	if err = ctx.pre(ctx, clone); err != nil {
+		so.Unlock()
+		return
+	}
+	clone.Version++
+	err = so.putPersist(clone, nil)
+	so.Unlock()
+	if err == nil && ctx.final != nil {
+		ctx.final(ctx, clone)
+	}
+	return
+}
+
+///////////////////////////////
+// metasync: receiving side //
+///////////////////////////////
+
+func (p *proxy) extractSchedMD(payload msPayload, caller string) (newMD *schedMD, msg *aisMsg, err error) {
+	b, ok := payload[revsSchedTag]
+	if !ok {
+		return
+	}
+	newMD, msg = newSchedMD(), &aisMsg{}
+	if _, err1 := jsp.Decode(io.NopCloser(bytes.NewBuffer(b)), newMD, newMD.JspOpts(), "extractSchedMD"); err1 != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "new SchedMD", cmn.BytesHead(b), err1)
+		return
+	}
+	if msgValue, ok := payload[revsSchedTag+revsActionTag]; ok {
+		if err1 := jsoniter.Unmarshal(msgValue, msg); err1 != nil {
+			err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "action message", cmn.BytesHead(msgValue), err1)
+			return
+		}
+	}
+	md := p.owner.sched.get()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("extract %s%s", newMD, _msdetail(md.Version, msg, caller))
+	}
+	if newMD.version() <= md.version() {
+		if newMD.version() < md.version() {
+			err = newErrDowngrade(p.si, md.String(), newMD.String())
+		}
+		newMD = nil
+	}
+	return
+}
+
+func (p *proxy) receiveSchedMD(newMD *schedMD, msg *aisMsg, payload msPayload, caller string) (err error) {
+	if newMD == nil {
+		return
+	}
+	so := p.owner.sched
+	so.Lock()
+	md := so.get()
+	if newMD.version() <= md.version() {
+		so.Unlock()
+		if newMD.version() < md.version() {
+			err = newErrDowngrade(p.si, md.String(), newMD.String())
+		}
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("receive %s%s", newMD, _msdetail(md.Version, msg, caller))
+	}
+	err = so.putPersist(newMD, payload)
+	so.Unlock()
+	return
+}
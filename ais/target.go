@@ -34,12 +34,15 @@ import (
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/etl"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/repair"
+	"github.com/NVIDIA/aistore/replicate"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
@@ -69,6 +72,8 @@ type (
 		res          *res.Res
 		db           dbdriver.Driver
 		transactions transactions
+		quota        quotas   // storage quotas usage, see tgtquota.go
+		invRuns      invRuns  // periodic bucket inventory, see tgtinv.go
 		regstate     regstate // the state of being registered with the primary, can be (en/dis)abled via API
 	}
 )
@@ -303,13 +308,16 @@ func (t *target) Run() error {
 		glog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object type, workfile type, and previous object versions
 	if err := fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.Reg(fs.ObjVerType, &fs.ObjVerContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
@@ -387,6 +395,11 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	t.regLifecycleHK()
+	t.regInventoryHK()
+	t.regMptHK()
+	t.quota.init(t)
+	t.ratelim.init()
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -403,6 +416,15 @@ func (t *target) Run() error {
 	dsort.InitManagers(db)
 	dsort.RegisterNode(t.owner.smap, t.owner.bmd, t.si, t, t.statsT)
 
+	events.Init(t.SID(), db, t.statsT)
+	defer events.Stop()
+
+	replicate.Start(t, db, t.statsT)
+	defer replicate.Stop()
+
+	repair.Start(t, t.statsT)
+	defer repair.Stop()
+
 	defer etl.StopAll(t) // Always try to stop running ETLs.
 
 	err = t.htrun.run()
@@ -759,6 +781,10 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			nlp.Lock()
 			defer nlp.Unlock()
 
+			if err := t.checkBckLock(apireq.bck); err != nil {
+				t.writeErr(w, r, err, http.StatusForbidden)
+				return
+			}
 			err := fs.DestroyBucket(msg.Action, apireq.bck.Bucket(), apireq.bck.Props.BID)
 			if err != nil {
 				t.writeErr(w, r, err)
@@ -949,6 +975,19 @@ func (t *target) httpobjget(w http.ResponseWriter, r *http.Request) {
 	apiReqFree(apireq)
 }
 
+// GFN requests are issued by targets (see getFromNeighbor) - regardless of
+// feat.EnforceIntraClusterAccess
+func (t *target) checkGFN(hdr http.Header) error {
+	if err := t.isIntraCall(hdr, false /*from primary*/); err != nil {
+		return err
+	}
+	if smap := t.owner.smap.get(); smap.GetTarget(hdr.Get(apc.HdrCallerID)) == nil {
+		return fmt.Errorf("%s: get-from-neighbor request from an unknown target %q (%s)",
+			t.si, hdr.Get(apc.HdrCallerName), smap)
+	}
+	return nil
+}
+
 // getObject is main function to get the object. It doesn't check request origin,
 // so it must be done by the caller (if necessary).
 func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck *cluster.Bck, lom *cluster.LOM) *cluster.LOM {
@@ -967,6 +1006,20 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		t.doETL(w, r, dpq.uuid, bck, lom.ObjName)
 		return lom
 	}
+	// get-from-neighbor: ciphertext as is, not rate-limited - intra-cluster only
+	isGFN := cos.IsParseBool(dpq.isGFN)
+	if isGFN {
+		if err := t.checkGFN(r.Header); err != nil {
+			t.writeErr(w, r, err, http.StatusForbidden)
+			return lom
+		}
+	}
+	// rate limit (all but intra-cluster get-from-neighbor)
+	if !isGFN {
+		if !t.rateLimitBytes(w, r, dpq, lom, cmn.RateLimitOpGet, -1 /*size*/) {
+			return lom
+		}
+	}
 	filename := dpq.archpath // apc.QparamArchpath
 	if strings.HasPrefix(filename, lom.ObjName) {
 		if rel, err := filepath.Rel(lom.ObjName, filename); err == nil {
@@ -993,14 +1046,27 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 			filename: filename,
 			mime:     dpq.archmime, // query.Get(apc.QparamArchmime)
 		}
-		goi.isGFN = cos.IsParseBool(dpq.isGFN) // query.Get(apc.QparamIsGFNRequest)
+		goi.isGFN = isGFN
 		goi.chunked = cmn.GCO.Get().Net.HTTP.Chunked
 	}
 	if bck.IsHTTP() {
 		originalURL := dpq.origURL // query.Get(apc.QparamOrigURL)
 		goi.ctx = context.WithValue(goi.ctx, cos.CtxOriginalURL, originalURL)
 	}
-	if errCode, err := goi.getObject(); err != nil && err != errSendingResp {
+	var (
+		errCode int
+		err     error
+	)
+	if dpq.objVer != "" { // apc.QparamObjVersion
+		if !bck.IsAIS() {
+			errCode, err = http.StatusBadRequest, fmt.Errorf("%s: version history is only supported for ais:// buckets", bck)
+		} else {
+			errCode, err = goi.getVersion(dpq.objVer)
+		}
+	} else {
+		errCode, err = goi.getObject()
+	}
+	if err != nil && err != errSendingResp {
 		t.writeErr(w, r, err, errCode)
 	}
 	lom = goi.lom
@@ -1069,6 +1135,16 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, errdb)
 		return
 	}
+	// storage quota: fail early when the size is known (and see poi.fini)
+	if !t2tput {
+		if err := t.quotaPrecheck(r, lom, apireq.dpq); err != nil {
+			t.writeErr(w, r, err, http.StatusInsufficientStorage)
+			return
+		}
+		if !t.rateLimitBytes(w, r, apireq.dpq, lom, cmn.RateLimitOpPut, r.ContentLength) {
+			return
+		}
+	}
 
 	// do
 	var (
@@ -1078,6 +1154,11 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 		archPathProvided = apireq.dpq.archpath != "" // apc.QparamArchpath
 		appendTyProvided = apireq.dpq.appendTy != "" // apc.QparamAppendType
 	)
+	if (archPathProvided || appendTyProvided) && (lom.Bprops().Encryption.Enabled || lom.IsEncrypted()) {
+		err = fmt.Errorf("%s: cannot append to %s - not supported with encryption at rest", t, lom)
+		t.writeErr(w, r, err)
+		return
+	}
 	if archPathProvided {
 		// TODO: resolve non-empty dpq.uuid => xaction and pass it on
 		errCode, err = t.doAppendArch(r, lom, started, apireq.dpq)
@@ -1100,6 +1181,10 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 		}
 		errCode, err = poi.do(r, apireq.dpq)
 		freePutObjInfo(poi)
+		if err == nil {
+			events.Emit(cmn.EvPut, lom)
+			replicate.Emit(replicate.OpPut, lom)
+		}
 	}
 	if err != nil {
 		t.fsErr(err, lom.FQN)
@@ -1131,7 +1216,12 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	errCode, err := t.DeleteObject(lom, evict)
+	bypassGov := cos.IsParseBool(apireq.query.Get(apc.QparamBypassGov))
+	if ver := apireq.query.Get(apc.QparamObjVersion); ver != "" {
+		t.delObjVersion(w, r, lom, ver, bypassGov)
+		return
+	}
+	errCode, err := t.delObject(lom, evict, bypassGov)
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			t.writeErrSilentf(w, r, http.StatusNotFound, "object %s/%s doesn't exist", lom.Bucket(), lom.ObjName)
@@ -1158,6 +1248,13 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.objMv(w, r, msg)
+	case apc.ActRestoreObjVer:
+		query := r.URL.Query()
+		if isRedirect(query) == "" {
+			t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.objRestoreVer(w, r, msg)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -1244,6 +1341,9 @@ func (t *target) headObject(w http.ResponseWriter, r *http.Request, query url.Va
 		}
 		op.ObjAttrs = *objAttrs
 	}
+	if exists && lom.IsEncrypted() {
+		op.ObjAttrs = *lom.PlainAttrs()
+	}
 	if exists {
 		op.DaemonID = t.Snode().ID()
 		op.Mirror.Copies = lom.NumCopies()
@@ -1321,6 +1421,16 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if msg.Action == apc.ActLegalHold {
+		lom := cluster.AllocLOM(apireq.items[1] /*objName*/)
+		if err := lom.InitBck(apireq.bck.Bucket()); err != nil {
+			t.writeErr(w, r, err)
+		} else {
+			t.objLegalHold(w, r, lom, msg)
+		}
+		cluster.FreeLOM(lom)
+		return
+	}
 	custom := cos.SimpleKVs{}
 	if err := cos.MorphMarshal(msg.Value, &custom); err != nil {
 		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, "set-custom", msg.Value, err)
@@ -1340,8 +1450,19 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	for _, key := range cmn.SysObjMD {
+		if _, ok := custom[key]; ok {
+			t.writeErrf(w, r, "%s: custom key %q is reserved (system metadata)", t.si, key)
+			return
+		}
+	}
 	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
 	if delOldSetNew {
+		for _, key := range cmn.SysObjMD {
+			if md, ok := lom.GetCustomKey(key); ok {
+				custom[key] = md // (system key)
+			}
+		}
 		lom.SetCustomMD(custom)
 	} else {
 		for key, val := range custom {
@@ -1550,6 +1671,10 @@ func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Ti
 		}
 		return http.StatusInternalServerError, err
 	}
+	// object lock: appending to archive modifies the object in place (see tgtlock.go)
+	if err := lom.CheckObjLock(cos.IsParseBool(dpq.bypassGov)); err != nil {
+		return http.StatusForbidden, err
+	}
 	aaoi := &appendArchObjInfo{
 		started:  started,
 		t:        t,
@@ -1566,6 +1691,10 @@ func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Ti
 	if aaoi.size == 0 {
 		return http.StatusBadRequest, errors.New("size is not defined")
 	}
+	// storage quota (see tgtquota.go)
+	if err := t.quota.check(lom.Bck(), 0, aaoi.size); err != nil {
+		return http.StatusInsufficientStorage, err
+	}
 	return aaoi.appendObject()
 }
 
@@ -1593,6 +1722,10 @@ func (t *target) putMirror(lom *cluster.LOM) {
 }
 
 func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
+	return t.delObject(lom, evict, false /*bypass governance*/)
+}
+
+func (t *target) delObject(lom *cluster.LOM, evict, bypassGov bool) (int, error) {
 	var (
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
@@ -1603,6 +1736,9 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 
 	delFromBackend = lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if err := lom.CheckObjLock(bypassGov); err != nil {
+			return http.StatusForbidden, err
+		}
 		delFromAIS = true
 	} else if !cmn.IsObjNotExist(err) {
 		return 0, err
@@ -1620,7 +1756,26 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 		}
 	}
 	if delFromAIS {
-		size := lom.SizeBytes()
+		var (
+			size      = lom.SizeBytes()
+			quotaSize = size
+		)
+		// version history: the deleted version becomes the most recent previous one
+		// (to remove the history as well, see delObjVersion and apc.ObjVerAll)
+		if maxHistory := lom.VersionConf().MaxHistory; maxHistory > 0 && lom.Bck().IsAIS() && !evict {
+			verFQN, err := lom.ArchiveVersion()
+			if err != nil {
+				return 0, cmn.NewErrFailedTo(t, "archive version", lom, err)
+			}
+			if verFQN != "" {
+				quotaSize = 0 // (still on disk)
+			}
+			trimmed, err := lom.TrimVersions(maxHistory)
+			if err != nil {
+				glog.Errorf("%s: failed to trim version history: %v", lom, err)
+			}
+			quotaSize += trimmed
+		}
 		aisErr = lom.Remove()
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
@@ -1629,17 +1784,24 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 				}
 				return 0, aisErr
 			}
-		} else if evict {
-			cos.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			t.quota.add(lom.Bck(), -1, -quotaSize)
+			if evict {
+				cos.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
 		return backendErrCode, backendErr
 	}
+	if aisErr == nil && !evict {
+		events.Emit(cmn.EvDelete, lom)
+		replicate.Emit(replicate.OpDelete, lom)
+	}
 	return aisErrCode, aisErr
 }
 
@@ -1672,6 +1834,22 @@ func (t *target) objMv(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 		t.writeErrf(w, r, "%s: cannot rename/move object %s onto itself", t.si, lom)
 		return
 	}
+	// object lock: neither the source nor the (existing) destination can be locked
+	bypassGov := cos.IsParseBool(apireq.query.Get(apc.QparamBypassGov))
+	if err := t.checkObjLock(lom, bypassGov, false /*locked*/); err != nil {
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	dst := cluster.AllocLOM(msg.Name)
+	err := dst.InitBck(apireq.bck.Bucket())
+	if err == nil {
+		err = t.checkObjLock(dst, bypassGov, false /*locked*/)
+	}
+	cluster.FreeLOM(dst)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	buf, slab := t.gmm.Alloc()
 	coi := allocCopyObjInfo()
 	{
@@ -1680,7 +1858,7 @@ func (t *target) objMv(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 		coi.owt = cmn.OwtMigrate
 		coi.finalize = true
 	}
-	_, err := coi.copyObject(lom, msg.Name /* new object name */)
+	_, err = coi.copyObject(lom, msg.Name /* new object name */)
 	slab.Free(buf)
 	freeCopyObjInfo(coi)
 	if err != nil {
@@ -1691,6 +1869,8 @@ func (t *target) objMv(w http.ResponseWriter, r *http.Request, msg *apc.ActionMs
 	lom.Lock(true)
 	if err = lom.Remove(); err != nil {
 		glog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t, lom, msg.Name, err)
+	} else {
+		t.quota.add(lom.Bck(), -1, -lom.SizeBytes())
 	}
 	lom.Unlock(true)
 }
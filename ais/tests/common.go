@@ -1015,6 +1015,7 @@ func initFS() {
 	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
 	_ = fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{})
 	_ = fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVerType, &fs.ObjVerContentResolver{})
 }
 
 func initMountpaths(t *testing.T, proxyURL string) {
@@ -28,6 +28,7 @@ import (
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/repair"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
@@ -246,6 +247,10 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		t.writeJSON(w, r, tsysinfo, httpdaeWhat)
 	case apc.GetWhatMountpaths:
 		t.writeJSON(w, r, fs.MountpathsToLists(), httpdaeWhat)
+	case apc.GetWhatRepair:
+		t.writeJSON(w, r, repair.GetStatus(), httpdaeWhat)
+	case apc.GetWhatQuota:
+		t.writeJSON(w, r, t.quota.get(), httpdaeWhat)
 	case apc.GetWhatDaemonStatus:
 		var rebSnap *stats.RebalanceSnap
 		if entry := xreg.GetLatest(xreg.XactFilter{Kind: apc.ActRebalance}); entry != nil {
@@ -641,6 +646,10 @@ func (t *target) _postBMD(tag string, rmbcks []*cluster.Bck) {
 	if cs := fs.GetCapStatus(); cs.Err != nil {
 		_ = t.OOS(nil)
 	}
+	// buckets may have been created, or (re)configured with quotas
+	if t.ClusterStarted() {
+		t.quota.bmdChanged()
+	}
 }
 
 func (t *target) receiveRMD(newRMD *rebMD, msg *aisMsg, caller string) (err error) {
@@ -20,7 +20,7 @@ import (
 
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
+	if err := etl.CheckRuntime(); err != nil {
 		t.writeErrSilent(w, r, err)
 		return
 	}
@@ -63,9 +63,14 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 
 	switch msg := initMsg.(type) {
 	case *etl.InitSpecMsg:
-		err = etl.InitSpec(t, *msg, etl.StartOpts{})
+		// (containers only)
+		if err = k8s.Detect(); err == nil {
+			err = etl.InitSpec(t, *msg, etl.StartOpts{})
+		}
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(t, *msg)
+	case *etl.InitProcMsg:
+		err = etl.InitProc(t, *msg)
 	}
 	if err != nil {
 		t.writeErr(w, r, err)
@@ -214,6 +219,9 @@ func etlParseObjectReq(_ http.ResponseWriter, r *http.Request) (secret string, b
 // getObjectETL handles GET requests from ETL containers (K8s Pods).
 // getObjectETL validates the secret that was injected into a Pod during its initialization.
 func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
+	if etl.ServeStashed(w, r) { // input of a pipeline stage
+		return
+	}
 	secret, bck, objName, err := etlParseObjectReq(w, r)
 	if err != nil {
 		t.writeErr(w, r, err)
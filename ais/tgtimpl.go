@@ -68,6 +68,15 @@ func (t *target) PutObject(lom *cluster.LOM, params *cluster.PutObjectParams) er
 	if poi.owt != cmn.OwtPut {
 		poi.cksumToUse = params.Cksum
 	}
+	if poi.owt != cmn.OwtMigrate {
+		// new (plaintext) content - the object may've been loaded with its
+		// existing (encrypted) version
+		lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD)
+	}
+	if poi.newContent() {
+		// (multipart-uploaded) ETag of the existing version
+		lom.ObjAttrs().DelCustomKeys(cmn.ETag)
+	}
 	_, err := poi.putObject()
 	freePutObjInfo(poi)
 	return err
@@ -82,7 +91,7 @@ func (t *target) FinalizeObj(lom *cluster.LOM, workFQN string, xctn cluster.Xact
 		poi.owt = cmn.OwtFinalize
 		poi.xctn = xctn
 	}
-	errCode, err = poi.finalize()
+	errCode, err = poi.finalizePlain()
 	freePutObjInfo(poi)
 	return
 }
@@ -280,7 +289,7 @@ func (t *target) promoteLocal(params *cluster.PromoteParams, lom *cluster.LOM) (
 		poi.xctn = params.Xact
 	}
 	lom.SetSize(fileSize)
-	errCode, err := poi.finalize()
+	errCode, err := poi.finalizePlain()
 	freePutObjInfo(poi)
 	if err != nil {
 		return errCode, err
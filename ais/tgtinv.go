@@ -0,0 +1,72 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xs"
+)
+
+// Periodic bucket inventory (see cmn.InventoryConf): every `inventory.interval` all targets
+// generate their respective manifests. Intervals are aligned to absolute time (e.g., 24h
+// interval starts at midnight UTC), so that all targets name their manifests the same way
+// (see xs.InventoryRun) without coordinating.
+
+const invCheckInterval = time.Minute
+
+// bucket (uname) => start time of the current interval
+type invRuns map[string]time.Time
+
+func (t *target) regInventoryHK() {
+	t.invRuns = make(invRuns, 4)
+	hk.Reg(apc.ActInventory+hk.NameSuffix, t.inventoryHK, invCheckInterval)
+}
+
+func (t *target) inventoryHK() time.Duration {
+	if !t.ClusterStarted() || t.regstate.disabled.Load() {
+		return invCheckInterval
+	}
+	var (
+		now  = time.Now()
+		runs = make(invRuns, len(t.invRuns))
+	)
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		conf := &bck.Props.Inventory
+		if !conf.Enabled {
+			return false
+		}
+		var (
+			uname   = bck.MakeUname("")
+			started = now.Truncate(conf.Interval.D())
+		)
+		runs[uname] = started
+		// NOTE: not starting upon (re)start or enabling - only when the next interval begins
+		if prev, ok := t.invRuns[uname]; ok && prev.Before(started) {
+			t.runInventory(bck, xs.InventoryRun(started))
+		}
+		return false
+	})
+	t.invRuns = runs
+	return invCheckInterval
+}
+
+func (t *target) runInventory(bck *cluster.Bck, run string) {
+	rns := xreg.RenewInventory(t, cos.GenUUID(), bck, run)
+	if rns.Err != nil {
+		if !cmn.IsErrUsePrevXaction(rns.Err) {
+			glog.Errorf("%s: failed to start inventory of %s: %v", t, bck, rns.Err)
+		}
+		return
+	}
+	go rns.Entry.Get().Run(nil)
+}
@@ -0,0 +1,121 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// object lock (WORM): retention and legal hold (see cmn.ObjectLockConf)
+
+var errLockedFound = errors.New("locked object found")
+
+// checkObjLock checks the existing object (if any) against the bucket's object lock;
+// the object's metadata gets loaded into a separate LOM, so that the caller's in-memory
+// attributes (e.g., of the new content being written) remain intact
+func (*target) checkObjLock(lom *cluster.LOM, bypassGov, locked bool) error {
+	if !lom.Bprops().ObjectLock.Enabled {
+		return nil
+	}
+	cur := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(cur)
+	if err := cur.InitBck(lom.Bucket()); err != nil {
+		return nil
+	}
+	if err := cur.Load(false /*cache it*/, locked); err != nil {
+		return nil // doesn't exist (or can't be loaded - the caller's problem)
+	}
+	return cur.CheckObjLock(bypassGov)
+}
+
+// new content: start a new retention period (dropping the previous one and legal hold, if any)
+func setRetention(lom *cluster.LOM) {
+	conf := &lom.Bprops().ObjectLock
+	lom.ObjAttrs().DelCustomKeys(cmn.LegalHoldObjMD, cmn.RetainUntilObjMD)
+	if conf.Retention > 0 {
+		until := time.Now().Add(conf.Retention.D()).UTC()
+		lom.SetCustomKey(cmn.RetainUntilObjMD, until.Format(time.RFC3339))
+	}
+}
+
+// bucket cannot be destroyed or evicted while containing locked objects (no bypass);
+// in governance mode, the object lock can be disabled first (see proxy.makeNewBckProps)
+func (t *target) checkBckLock(bck *cluster.Bck) error {
+	if !bck.Props.ObjectLock.Enabled {
+		return nil
+	}
+	var locked error
+	cb := func(fqn string, _ fs.DirEntry) error {
+		lom := cluster.AllocLOM("")
+		defer cluster.FreeLOM(lom)
+		if err := lom.InitFQN(fqn, bck.Bucket()); err != nil {
+			return nil
+		}
+		if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+			return nil
+		}
+		if err := lom.CheckObjLock(false /*bypass*/); err != nil {
+			locked = err
+			return errLockedFound
+		}
+		return nil
+	}
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Callback: cb}
+		opts.Bck.Copy(bck.Bucket())
+		if err := fs.Walk(opts); err != nil {
+			if locked != nil {
+				return locked
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// PATCH /v1/objects/bucket-name/object-name {action: ActLegalHold, value: bool}
+func (t *target) objLegalHold(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, msg *apc.ActionMsg) {
+	hold, ok := msg.Value.(bool)
+	if !ok {
+		t.writeErrf(w, r, "%s: invalid legal hold value %v (expecting bool)", lom, msg.Value)
+		return
+	}
+	if !lom.Bprops().ObjectLock.Enabled {
+		t.writeErrf(w, r, "%s: object lock is disabled", lom.Bck())
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	if hold {
+		lom.SetCustomKey(cmn.LegalHoldObjMD, "true")
+	} else {
+		lom.ObjAttrs().DelCustomKeys(cmn.LegalHoldObjMD)
+	}
+	if err := lom.Persist(); err != nil {
+		t.writeErr(w, r, err)
+	}
+}
+
+// S3 `x-amz-bypass-governance-retention` header
+func s3BypassGov(r *http.Request) bool {
+	return cos.IsParseBool(r.Header.Get(s3compat.HeaderBypassGov))
+}
@@ -0,0 +1,86 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// appending to archive (PUT ?archpath=) modifies the object in place and is, therefore,
+// subject to the bucket's object lock
+func TestObjLockAppendArch(t *testing.T) {
+	var (
+		tgt = cluster.T.(*target)
+		bck = cluster.NewBck("bck-objlock", apc.ProviderAIS, cmn.NsGlobal)
+		bmd = tgt.owner.bmd.get().clone()
+	)
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+		ObjectLock: cmn.ObjectLockConf{Enabled: true, Mode: cmn.LockModeCompliance},
+	})
+	if err := tgt.owner.bmd.putPersist(bmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	fs.CreateBucket("test", bck.Bucket(), false /*nilbmd*/)
+
+	tests := []struct {
+		name     string
+		key, val string
+	}{
+		{"legal-hold", cmn.LegalHoldObjMD, "true"},
+		{"retention", cmn.RetainUntilObjMD, time.Now().Add(time.Hour).UTC().Format(time.RFC3339)},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lom := cluster.AllocLOM("objlock/arch" + strconv.Itoa(i) + ".tar")
+			defer cluster.FreeLOM(lom)
+			if err := lom.InitBck(bck.Bucket()); err != nil {
+				t.Fatal(err)
+			}
+			fh, err := cos.CreateFile(lom.FQN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tw := tar.NewWriter(fh)
+			tw.WriteHeader(&tar.Header{Name: "first.txt", Size: 1, Mode: 0o644, Typeflag: tar.TypeReg})
+			tw.Write([]byte("1"))
+			tw.Close()
+			finfo, _ := fh.Stat()
+			fh.Close()
+			defer os.Remove(lom.FQN)
+			size := finfo.Size()
+			lom.SetSize(size)
+			lom.SetCustomKey(test.key, test.val)
+			if err := lom.Persist(); err != nil {
+				t.Fatal(err)
+			}
+
+			body := bytes.Repeat([]byte("a"), 100)
+			r := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
+			r.Header.Set(cmn.HdrContentLength, strconv.Itoa(len(body)))
+			dpq := &dpq{archpath: "file.txt", archmime: cos.ExtTar, bypassGov: "true"} // (cannot bypass compliance mode)
+			errCode, err := tgt.doAppendArch(r, lom, time.Now(), dpq)
+			if err == nil || errCode != http.StatusForbidden {
+				t.Fatalf("expected %d, got %d (%v)", http.StatusForbidden, errCode, err)
+			}
+			if err := lom.Load(false, false); err != nil || lom.SizeBytes() != size {
+				t.Fatalf("expected %s to remain intact (size %d, err %v)", lom, lom.SizeBytes(), err)
+			}
+		})
+	}
+}
@@ -27,9 +27,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/repair"
+	"github.com/NVIDIA/aistore/replicate"
+	"github.com/NVIDIA/aistore/sse"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -56,6 +60,7 @@ type (
 		t2t        bool          // by another target
 		skipEC     bool          // do not erasure-encode when finalizing
 		skipVC     bool          // skip loading existing Version and skip comparing Checksums (skip VC)
+		bypassGov  bool          // bypass object lock retention in governance mode (see tgtlock.go)
 	}
 
 	getObjInfo struct {
@@ -134,12 +139,20 @@ func (poi *putObjInfo) do(r *http.Request, dpq *dpq) (int, error) {
 	{
 		poi.r = r.Body
 		poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, fs.WorkfilePut)
-		poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
 		poi.owt = cmn.OwtPut // default
 	}
 	if dpq.owt != "" {
 		poi.owt.FromS(dpq.owt)
 	}
+	// (may've been loaded with the existing object)
+	poi.lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD)
+	if poi.newContent() {
+		poi.lom.ObjAttrs().DelCustomKeys(cmn.ETag)
+	}
+	poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
+	if dpq.bypassGov != "" {
+		poi.bypassGov = cos.IsParseBool(dpq.bypassGov)
+	}
 	if dpq.uuid != "" {
 		// resolve cluster-wide xact "behind" this PUT (promote via a single target won't show up)
 		if xctn := xreg.GetXact(dpq.uuid); xctn != nil {
@@ -231,13 +244,82 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 	return
 }
 
+// same as above for the work file that contains new plaintext content (promote, archive)
+func (poi *putObjInfo) finalizePlain() (errCode int, err error) {
+	poi.lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD) // (may've been loaded with the existing object)
+	if poi.newContent() {
+		poi.lom.ObjAttrs().DelCustomKeys(cmn.ETag)
+	}
+	if poi.encrypt() {
+		if err = poi.encryptWorkfile(); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+	return poi.finalize()
+}
+
+// newContent returns true when the PUT brings new user content - as opposed to
+// cold GET (and other paths) where the backend populates object metadata
+func (poi *putObjInfo) newContent() bool {
+	switch poi.owt {
+	case cmn.OwtPut:
+		return true
+	case cmn.OwtMigrate:
+		return false
+	default:
+		return poi.lom.Bck().IsAIS()
+	}
+}
+
 // poi.workFQN => LOM
 func (poi *putObjInfo) fini() (errCode int, err error) {
 	var (
-		lom = poi.lom
-		bck = lom.Bck()
-		bmd = poi.t.owner.bmd.Get()
+		lom                  = poi.lom
+		bck                  = lom.Bck()
+		bmd                  = poi.t.owner.bmd.Get()
+		quotaObjs, quotaSize int64
+		quota                = quotaTracked(bck, cmn.GCO.Get())
+		objLock              = bck.Props.ObjectLock.Enabled && poi.wormEnforced()
 	)
+	// NOTE: see GetCold() implementation and cmn.OWT
+	switch poi.owt {
+	case cmn.OwtGetTryLock, cmn.OwtGetLock, cmn.OwtGet:
+		debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+	case cmn.OwtGetPrefetchLock:
+		if !lom.TryLock(true) {
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Warningf("(%s) is busy", poi.loghdr())
+			}
+			return 0, cmn.ErrSkip // e.g. prefetch can skip it and keep on going
+		}
+		defer lom.Unlock(true)
+	default:
+		lom.Lock(true)
+		defer lom.Unlock(true)
+	}
+
+	// object lock: cannot overwrite locked objects (see tgtlock.go)
+	if objLock {
+		if err = poi.t.checkObjLock(lom, poi.bypassGov, true /*locked*/); err != nil {
+			return http.StatusForbidden, err
+		}
+	}
+	// ais versioning: whether to keep the current version (if exists) in the history
+	var maxHistory int
+	if bck.IsAIS() && lom.VersionConf().Enabled {
+		if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
+			maxHistory = lom.VersionConf().MaxHistory
+		}
+	}
+	// storage quota (see tgtquota.go)
+	if quota {
+		quotaObjs, quotaSize = poi.quotaDelta(maxHistory > 0)
+		if poi.quotaEnforced() {
+			if err = poi.t.quota.check(bck, quotaObjs, quotaSize); err != nil {
+				return http.StatusInsufficientStorage, err
+			}
+		}
+	}
 	// remote versioning
 	if bck.IsRemote() && (poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote) {
 		errCode, err = poi.putRemote()
@@ -262,24 +344,8 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		return
 	}
 
-	// NOTE: see GetCold() implementation and cmn.OWT
-	switch poi.owt {
-	case cmn.OwtGetTryLock, cmn.OwtGetLock, cmn.OwtGet:
-		debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
-	case cmn.OwtGetPrefetchLock:
-		if !lom.TryLock(true) {
-			if glog.FastV(4, glog.SmoduleAIS) {
-				glog.Warningf("(%s) is busy", poi.loghdr())
-			}
-			return 0, cmn.ErrSkip // e.g. prefetch can skip it and keep on going
-		}
-		defer lom.Unlock(true)
-	default:
-		lom.Lock(true)
-		defer lom.Unlock(true)
-	}
-
 	// ais versioning
+	var verFQN string
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
 			if poi.skipVC {
@@ -292,10 +358,29 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 			}
 		}
 	}
+	// version history: keep the current version (if exists) as the most recent previous one
+	if maxHistory > 0 {
+		if verFQN, err = lom.ArchiveVersion(); err != nil {
+			err = cmn.NewErrFailedTo(poi.t, "archive version", lom, err)
+			return
+		}
+	}
 	if err = cos.Rename(poi.workFQN, lom.FQN); err != nil {
+		if verFQN != "" {
+			if errV := os.Rename(verFQN, lom.FQN); errV != nil {
+				glog.Errorf("PUT (%s): failed to restore current version: %v", poi.loghdr(), errV)
+			}
+		}
 		err = cmn.NewErrFailedTo(poi.t, "rename", lom, err)
 		return
 	}
+	if maxHistory > 0 {
+		trimmed, errV := lom.TrimVersions(maxHistory)
+		if errV != nil {
+			glog.Errorf("PUT (%s): failed to trim version history: %v", poi.loghdr(), errV)
+		}
+		quotaSize -= trimmed
+	}
 	if lom.HasCopies() {
 		if errdc := lom.DelAllCopies(); errdc != nil {
 			glog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
@@ -305,10 +390,73 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		lom.SetAtimeUnix(poi.atime.UnixNano())
 		debug.Assert(lom.AtimeUnix() != 0)
 	}
-	err = lom.Persist()
+	if objLock {
+		setRetention(lom)
+	}
+	if err = lom.Persist(); err == nil && quota {
+		poi.t.quota.add(bck, quotaObjs, quotaSize)
+	}
 	return
 }
 
+// storage quota: number of objects and size deltas that this PUT is about to introduce;
+// when archived, the current version remains on disk and continues to count
+// (until trimmed - see fini)
+func (poi *putObjInfo) quotaDelta(archive bool) (objs, size int64) {
+	if fi, err := os.Stat(poi.workFQN); err == nil {
+		size = fi.Size()
+	}
+	if fi, err := os.Stat(poi.lom.FQN); err == nil {
+		if !archive {
+			size -= fi.Size()
+		}
+	} else {
+		objs = 1
+	}
+	return
+}
+
+// quotas apply only to writes that create new content - not to cold GET,
+// rebalance, EC restore, etc.
+func (poi *putObjInfo) quotaEnforced() bool {
+	switch poi.owt {
+	case cmn.OwtPut, cmn.OwtPromote, cmn.OwtFinalize:
+		return true
+	case cmn.OwtMigrate:
+		if poi.t2t {
+			return true // via coi.put()
+		}
+		if poi.xctn != nil {
+			switch poi.xctn.Kind() {
+			case apc.ActCopyBck, apc.ActETLBck, apc.ActCopyObjects, apc.ActETLObjects:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// object lock (WORM) protects the existing object from being replaced with different
+// content: user writes and copies (including t2t and transformations) - but not
+// rebalance, resilver, EC restore, and other writes that relocate the object itself
+func (poi *putObjInfo) wormEnforced() bool {
+	switch poi.owt {
+	case cmn.OwtPut, cmn.OwtPromote, cmn.OwtFinalize:
+		return true
+	case cmn.OwtMigrate:
+		if poi.t2t {
+			return true // via coi.put()
+		}
+		if poi.xctn != nil {
+			switch poi.xctn.Kind() {
+			case apc.ActCopyBck, apc.ActETLBck, apc.ActCopyObjects, apc.ActETLObjects:
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // via backend.PutObj()
 func (poi *putObjInfo) putRemote() (errCode int, err error) {
 	var (
@@ -331,14 +479,27 @@ func (poi *putObjInfo) putRemote() (errCode int, err error) {
 	return
 }
 
+// encrypt at rest (see cmn.EncryptionConf) unless already encrypted (e.g., migrated
+// ciphertext) or restored from EC slices
+func (poi *putObjInfo) encrypt() bool {
+	if !poi.lom.Bprops().Encryption.Enabled || poi.lom.IsEncrypted() {
+		return false
+	}
+	return poi.owt != cmn.OwtMigrate || !poi.skipEC
+}
+
 // LOM is updated at the end of this call with size and checksum.
 // `poi.r` (reader) is also closed upon exit.
+// When encrypting, the stored checksum is computed over the ciphertext while the
+// checksum that's been provided (if any) is validated against the plaintext.
 func (poi *putObjInfo) write() (err error) {
 	var (
 		written int64
 		buf     []byte
 		slab    *memsys.Slab
 		lmfh    *os.File
+		encw    *sse.Writer
+		nonce   []byte
 		writer  io.Writer
 		writers = make([]io.Writer, 0, 4)
 		cksums  = struct {
@@ -346,7 +507,8 @@ func (poi *putObjInfo) write() (err error) {
 			given *cos.CksumHash // compute additionally
 			expct *cos.Cksum     // and validate against `expct` if required/available
 		}{}
-		ckconf = poi.lom.CksumConf()
+		ckconf  = poi.lom.CksumConf()
+		encrypt = poi.encrypt()
 	)
 	if lmfh, err = poi.lom.CreateFile(poi.workFQN); err != nil {
 		return
@@ -365,7 +527,7 @@ func (poi *putObjInfo) write() (err error) {
 		poi.lom.SetCksum(cos.NoneCksum)
 		goto write
 	}
-	if !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf) {
+	if !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf) && !encrypt {
 		// if the corresponding validation is not configured/enabled we just go ahead
 		// and use the checksum that has arrived with the object
 		poi.lom.SetCksum(poi.cksumToUse)
@@ -374,7 +536,9 @@ func (poi *putObjInfo) write() (err error) {
 
 	// compute checksum and save it as part of the object metadata
 	cksums.store = cos.NewCksumHash(ckconf.Type)
-	writers = append(writers, cksums.store.H)
+	if !encrypt {
+		writers = append(writers, cksums.store.H)
+	}
 	if !poi.skipVC && !poi.cksumToUse.IsEmpty() && poi.validateCksum(ckconf) {
 		// if validate-cold-get and the cksum is provided we should also check md5 hash (aws, gcp)
 		// or if the object is migrated, and `ckconf.ValidateObjMove` we should check with existing checksum
@@ -383,6 +547,16 @@ func (poi *putObjInfo) write() (err error) {
 		writers = append(writers, cksums.given.H)
 	}
 write:
+	if encrypt {
+		// plaintext => encryption => ciphertext (that gets checksummed and stored)
+		if cksums.store != nil {
+			writer = cos.NewWriterMulti(cksums.store.H, writer)
+		}
+		if encw, nonce, err = poi.encWriter(writer); err != nil {
+			return
+		}
+		writer = encw
+	}
 	if len(writers) == 0 {
 		written, err = io.CopyBuffer(writer, poi.r /*reader*/, buf)
 	} else {
@@ -405,6 +579,14 @@ write:
 		}
 	}
 
+	if encw != nil {
+		if err = encw.Close(); err != nil {
+			return
+		}
+		written = encw.Size()
+		poi.lom.SetCustomKey(cmn.SSEObjMD, sse.EncodeMD(poi.lom.Bprops().Encryption.KeyID, nonce))
+	}
+
 	// ok
 	cos.Close(lmfh)
 	lmfh = nil
@@ -416,6 +598,32 @@ write:
 	return
 }
 
+// (plaintext) work file => encrypted work file
+func (poi *putObjInfo) encryptWorkfile() (err error) {
+	fqn := poi.workFQN
+	if poi.r, err = cos.NewFileHandle(fqn); err != nil {
+		return
+	}
+	poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, fs.WorkfilePut)
+	if err = poi.write(); err == nil {
+		err = cos.RemoveFile(fqn)
+	}
+	return
+}
+
+func (poi *putObjInfo) encWriter(w io.Writer) (encw *sse.Writer, nonce []byte, err error) {
+	keyID := poi.lom.Bprops().Encryption.KeyID
+	key, err := sse.GetKey(&cmn.GCO.Get().KMS, keyID)
+	if err != nil {
+		return nil, nil, cmn.NewErrFailedTo(poi.t, "get encryption key for", poi.lom, err)
+	}
+	if nonce, err = sse.NewNonce(); err != nil {
+		return
+	}
+	encw, err = sse.NewWriter(w, key, nonce)
+	return
+}
+
 // post-write close & cleanup
 func (poi *putObjInfo) _cleanup(buf []byte, slab *memsys.Slab, lmfh *os.File, err error) {
 	if buf != nil {
@@ -465,6 +673,31 @@ func (goi *getObjInfo) getObject() (errCode int, err error) {
 	return errCode, err
 }
 
+// GET a given version of the object: current or one of the previous (see versioning.max_history)
+func (goi *getObjInfo) getVersion(ver string) (errCode int, err error) {
+	lom := goi.lom
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(true /*cache it*/, true /*locked*/); err == nil && lom.Version() == ver {
+		_, errCode, err = goi.finalize(false)
+		return
+	}
+	vlom, err := lom.LoadVersion(ver)
+	if err != nil {
+		if cmn.IsErrNotFound(err) {
+			errCode = http.StatusNotFound
+		}
+		return
+	}
+	// NOTE: coldGet=true to read the version file as is - no load balancing across mirrors
+	// and no atime updates
+	goi.lom = vlom
+	_, errCode, err = goi.finalize(true)
+	goi.lom = lom
+	cluster.FreeLOM(vlom)
+	return
+}
+
 // is under rlock
 func (goi *getObjInfo) get() (errCode int, err error) {
 	var (
@@ -563,6 +796,9 @@ do:
 
 	// read locally and stream back
 fin:
+	if !cold {
+		repair.CheckCopies(goi.lom, repair.SrcGet) // (cold GET mirrors on its own)
+	}
 	retry, errCode, err = goi.finalize(cold)
 	if retry && !retried {
 		debug.Assert(err != errSendingResp)
@@ -726,6 +962,7 @@ gfn:
 			if glog.FastV(4, glog.SmoduleAIS) {
 				glog.Infof("%s: EC-recovered %s", tname, goi.lom)
 			}
+			repair.CheckEC(goi.lom, repair.SrcGet)
 			return
 		}
 		err = cmn.NewErrFailedTo(tname, "load EC-recovered", goi.lom, ecErr)
@@ -827,10 +1064,12 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 
 	var (
 		rrange     *cmn.HTTPRange
-		reader     io.Reader = lmfh
-		size                 = goi.lom.SizeBytes()
-		cksumConf            = goi.lom.CksumConf()
+		reader     io.Reader          = lmfh
+		oah        cmn.ObjAttrsHolder = goi.lom
+		size                          = goi.lom.SizeBytes()
+		cksumConf                     = goi.lom.CksumConf()
 		cksumRange bool
+		decrypt    = goi.lom.IsEncrypted() && !goi.isGFN // (GFN: ciphertext as is)
 	)
 	defer func() {
 		if lmfh != nil {
@@ -840,6 +1079,16 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 			slab.Free(buf)
 		}
 	}()
+	if decrypt {
+		if goi.archive.filename != "" {
+			errCode = http.StatusBadRequest
+			err = fmt.Errorf("%s: cannot read %s from %s - not supported with encryption at rest",
+				goi.t, goi.archive.filename, goi.lom)
+			return
+		}
+		oah = goi.lom.PlainAttrs()
+		size = oah.SizeBytes()
+	}
 	// parse, validate, set response header
 	if hdr != nil {
 		// read range
@@ -856,11 +1105,25 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 				size = rrange.Length // Content-Length
 			}
 		}
-		cmn.ToHeader(goi.lom.ObjAttrs(), hdr)
+		cmn.ToHeader(oah, hdr)
 	}
 
 	// reader
 	w := goi.w
+	if decrypt {
+		start, length := int64(0), size
+		if rrange != nil {
+			start, length = rrange.Start, rrange.Length
+		}
+		if reader, err = goi.lom.NewPlainReader(lmfh, start, length); err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
+		if rrange == nil {
+			w = cos.WriterOnly{Writer: goi.w}
+			buf, slab = goi.t.gmm.AllocSize(size)
+		}
+	}
 	if rrange == nil {
 		if goi.archive.filename != "" {
 			var csl cos.ReadCloseSizer
@@ -887,7 +1150,9 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 		}
 	} else {
 		buf, slab = goi.t.gmm.AllocSize(rrange.Length)
-		reader = io.NewSectionReader(lmfh, rrange.Start, rrange.Length)
+		if !decrypt {
+			reader = io.NewSectionReader(lmfh, rrange.Start, rrange.Length)
+		}
 		if cksumRange {
 			var (
 				cksum *cos.CksumHash
@@ -929,6 +1194,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 	if !coldGet && !goi.isGFN {
 		goi.lom.Load(false /*cache it*/, true /*locked*/)
 		goi.lom.SetAtimeUnix(goi.atime)
+		goi.lom.IncAccessCnt()
 		goi.lom.ReCache(true) // GFN and cold GETs already did this
 	}
 
@@ -1054,6 +1320,10 @@ func (aoi *appendObjInfo) appendObject() (newHandle string, errCode int, err err
 		if errCode, err = aoi.t.Promote(params); err != nil {
 			return
 		}
+		if err := aoi.lom.Load(true /*cache it*/, false /*locked*/); err == nil {
+			events.Emit(cmn.EvAppend, aoi.lom)
+			replicate.Emit(replicate.OpPut, aoi.lom)
+		}
 	default:
 		debug.AssertMsg(false, aoi.op)
 	}
@@ -1106,7 +1376,9 @@ func combineAppendHandle(nodeID, filePath string, partialCksum *cos.CksumHash) s
 func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int64, err error) {
 	debug.Assert(coi.DP == nil)
 	// remote to remote: no need to create local copies - use copyReader
-	if lom.Bck().IsRemote() || coi.BckTo.IsRemote() {
+	// (ditto when encrypted at rest: decrypt and let the destination encrypt, if need be)
+	if lom.Bck().IsRemote() || coi.BckTo.IsRemote() ||
+		lom.Bprops().Encryption.Enabled || coi.BckTo.Props.Encryption.Enabled {
 		coi.DP = &cluster.LDP{}
 		return coi.copyReader(lom, objNameTo)
 	}
@@ -1156,20 +1428,37 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 		return
 	}
 	// w-lock the destination unless overwriting the source
+	var quotaObjs, quotaSize int64
 	if lom.Uname() != dst.Uname() {
 		dst.Lock(true)
 		defer dst.Unlock(true)
+		quotaObjs, quotaSize = 1, lom.SizeBytes()
 		if err = dst.Load(false /*cache it*/, true /*locked*/); err == nil {
 			if lom.EqCksum(dst.Checksum()) {
 				return
 			}
+			// object lock: cannot overwrite locked objects (see tgtlock.go)
+			if err = dst.CheckObjLock(false /*bypass*/); err != nil {
+				return
+			}
+			quotaObjs, quotaSize = 0, lom.SizeBytes()-dst.SizeBytes()
 		} else if cmn.IsErrBucketNought(err) {
 			return
 		}
 	}
+	// storage quota (renaming within the same bucket is exempt)
+	quota := quotaTracked(dst.Bck(), cmn.GCO.Get())
+	if quota && !lom.Bck().Equal(dst.Bck(), true, true) {
+		if err = coi.t.quota.check(dst.Bck(), quotaObjs, quotaSize); err != nil {
+			return
+		}
+	}
 	dst2, err2 := lom.Copy2FQN(dst.FQN, coi.Buf)
 	if err2 == nil {
 		size = lom.SizeBytes()
+		if quota {
+			coi.t.quota.add(dst.Bck(), quotaObjs, quotaSize)
+		}
 		if coi.finalize {
 			coi.t.putMirror(dst2)
 		}
@@ -1252,6 +1541,7 @@ func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (size int
 			params.OWT = cmn.OwtMigrate
 		}
 		params.Atime = lom.Atime()
+		params.Xact = coi.Xact
 	}
 	err = coi.t.PutObject(dst, params)
 	cluster.FreePutObjParams(params)
@@ -1314,12 +1604,17 @@ func (coi *copyObjInfo) doSend(lom *cluster.LOM, sargs *sendArgs) (size int64, e
 			}
 			if coi.dryRun {
 				lom.Unlock(false)
-				return lom.SizeBytes(), nil
+				return sse.Size(lom), nil
 			}
-			if reader, err = lom.NewDeferROC(); err != nil {
+			if lom.IsEncrypted() {
+				// (destination encrypts, if need be)
+				if reader, sargs.objAttrs, err = lom.NewDeferPlainROC(); err != nil {
+					return 0, err
+				}
+			} else if reader, err = lom.NewDeferROC(); err != nil {
 				return 0, err
 			}
-			size = lom.SizeBytes()
+			size = sse.Size(lom)
 		} else {
 			// promote
 			debug.Assert(!coi.dryRun)
@@ -1340,7 +1635,9 @@ func (coi *copyObjInfo) doSend(lom *cluster.LOM, sargs *sendArgs) (size int64, e
 			reader = fh
 		}
 		sargs.reader = reader
-		sargs.objAttrs = lom
+		if sargs.objAttrs == nil {
+			sargs.objAttrs = lom
+		}
 	} else {
 		if sargs.reader, sargs.objAttrs, err = coi.DP.Reader(lom); err != nil {
 			return
@@ -1413,8 +1710,14 @@ func (coi *copyObjInfo) put(sargs *sendArgs) error {
 	if err != nil {
 		return cmn.NewErrFailedTo(coi.t, "coi.put "+sargs.bckTo.Name+"/"+sargs.objNameTo, sargs.tsi, err)
 	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		// e.g. destination bucket out of quota (507)
+		b, _ := io.ReadAll(resp.Body)
+		err = fmt.Errorf("%s: status %d: %s", sargs.tsi, resp.StatusCode, strings.TrimSpace(string(b)))
+		return cmn.NewErrFailedTo(coi.t, "coi.put "+sargs.bckTo.Name+"/"+sargs.objNameTo, sargs.tsi, err)
+	}
 	cos.DrainReader(resp.Body)
-	resp.Body.Close()
 	return nil
 }
 
@@ -1496,12 +1799,16 @@ func (aaoi *appendArchObjInfo) appendObject() (errCode int, err error) {
 	if aaoi.mime != cos.ExtTar {
 		return http.StatusBadRequest, fmt.Errorf("append is supported only for %s archives", cos.ExtTar)
 	}
+	sizeBefore := aaoi.lom.SizeBytes()
 	workFQN, err := aaoi.begin()
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 	if err = aaoi.appendToArch(workFQN); err == nil {
 		if err = aaoi.finalize(workFQN); err == nil {
+			aaoi.t.quota.add(aaoi.lom.Bck(), 0, aaoi.lom.SizeBytes()-sizeBefore)
+			events.Emit(cmn.EvAppend, aaoi.lom)
+			replicate.Emit(replicate.OpPut, aaoi.lom)
 			return 0, nil
 		}
 	}
@@ -60,6 +60,7 @@ func TestMain(m *testing.M) {
 	fs.TestDisableValidation()
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVerType, &fs.ObjVerContentResolver{})
 
 	// target
 	config := cmn.GCO.Get()
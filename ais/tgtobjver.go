@@ -0,0 +1,129 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// version history of objects in ais:// buckets (see versioning.max_history):
+// - GET ?version=<ver> (see goi.getVersion)
+// - DELETE ?version=<ver> (or apc.ObjVerAll)
+// - POST {action: ActRestoreObjVer, name: <ver>}
+
+// DELETE /v1/objects/bucket-name/object-name?version=<ver>
+// (removes a given previous version or, if apc.ObjVerAll, all of them; the current version stays intact)
+func (t *target) delObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM, ver string, bypassGov bool) {
+	if !lom.Bck().IsAIS() {
+		t.writeErrf(w, r, "%s: version history is only supported for ais:// buckets", lom.Bck())
+		return
+	}
+	// previous versions are retained for as long as the bucket's object lock remains enabled
+	if conf := &lom.Bprops().ObjectLock; conf.Enabled && !(bypassGov && conf.Mode == cmn.LockModeGovernance) {
+		err := cmn.NewErrObjLocked(lom.Bck().String()+"/"+lom.ObjName+" version "+ver, conf.Mode+" mode")
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var (
+		size int64
+		err  error
+	)
+	lom.Lock(true)
+	if ver == apc.ObjVerAll {
+		size, err = lom.DelAllVersions()
+	} else {
+		size, err = lom.DelVersion(ver)
+	}
+	lom.Unlock(true)
+	t.quota.add(lom.Bck(), 0, -size)
+	if err == nil {
+		return
+	}
+	if cmn.IsErrNotFound(err) {
+		t.writeErrSilentf(w, r, http.StatusNotFound, "%v", err)
+	} else {
+		t.writeErr(w, r, err)
+	}
+}
+
+// POST /v1/objects/bucket-name/object-name {action: ActRestoreObjVer, name: <ver>}
+// (restoring is a regular PUT of the previous version's content: the object gets a new
+// version while the current one, in turn, becomes the most recent previous)
+func (t *target) objRestoreVer(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
+	apireq := apiReqAlloc(2, apc.URLPathObjects.L, false)
+	defer apiReqFree(apireq)
+	if err := t.parseReq(w, r, apireq); err != nil {
+		return
+	}
+	lom := cluster.AllocLOM(apireq.items[1])
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(apireq.bck.Bucket()); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if !lom.Bck().IsAIS() {
+		t.writeErrf(w, r, "%s: version history is only supported for ais:// buckets", lom.Bck())
+		return
+	}
+	roc, etag, err := t.openVersion(lom, msg.Name)
+	if err != nil {
+		if cmn.IsErrNotFound(err) {
+			t.writeErrSilentf(w, r, http.StatusNotFound, "%v", err)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	defer cos.Close(roc)
+
+	// (current version, if exists, is needed to increment it)
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil && !cmn.IsObjNotExist(err) {
+		t.writeErr(w, r, err)
+		return
+	}
+	// plaintext (see openVersion) of the restored version - with its own ETag, if any
+	lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD, cmn.ETag)
+	if etag != "" {
+		lom.SetCustomKey(cmn.ETag, etag)
+	}
+	poi := allocPutObjInfo()
+	{
+		poi.t = t
+		poi.lom = lom
+		poi.r = roc
+		poi.workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePut)
+		poi.atime = time.Now()
+		poi.owt = cmn.OwtPut
+	}
+	errCode, err := poi.putObject()
+	freePutObjInfo(poi)
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+		t.writeErr(w, r, cmn.NewErrFailedTo(t, "restore version "+msg.Name+" of", lom, err), errCode)
+	}
+}
+
+// open previous version for reading (decrypting if need be) and return it along with
+// its ETag (e.g., multipart-uploaded); the version file can be safely trimmed (removed)
+// once it is open
+func (*target) openVersion(lom *cluster.LOM, ver string) (roc cos.ReadOpenCloser, etag string, err error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	vlom, err := lom.LoadVersion(ver)
+	if err != nil {
+		return nil, "", err
+	}
+	etag, _ = vlom.GetCustomKey(cmn.ETag)
+	roc, _, err = vlom.PlainReader()
+	cluster.FreeLOM(vlom)
+	return
+}
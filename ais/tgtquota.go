@@ -0,0 +1,342 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	gatomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"golang.org/x/sync/errgroup"
+)
+
+// Storage quotas: per bucket (BucketProps.Quota) and per namespace (SpaceConf.NsQuota).
+//
+// Each target enforces its own share of a given quota - the quota divided by the number
+// of active targets - relying on HRW to distribute objects evenly across the cluster.
+// Local usage of the buckets that have quotas (or belong to namespaces that do) is:
+// - seeded in the background - upon startup, bucket creation, or quota configuration
+//   (and, just in case, upon first write) - by walking the bucket (see quotaWalk);
+// - updated incrementally upon PUT, APPEND, promote, copy, and delete;
+// - and periodically resynced (the same way it is seeded) to account for everything
+//   else (LRU eviction, rebalance, etc.)
+// Both the walk and the incremental updates count each object once (regardless of
+// mirror copies), by the size of its (main replica) file. Previous versions of objects
+// (see versioning.max_history) count towards the size, but not the number of objects.
+// Until seeded, the usage includes only incremental updates, and the quota is not enforced.
+//
+// Quotas are enforced only on writes that create new content (PUT, APPEND, promote,
+// archive, and copy/transform) and never on cold GET, rebalance, or resilver.
+
+const (
+	quotaResyncInterval = 10 * time.Minute
+	quotaStartupDelay   = 10 * time.Second // (waiting for the cluster to start)
+)
+
+type (
+	quotaUsage struct {
+		objs    atomic.Int64
+		size    atomic.Int64
+		seeded  atomic.Bool
+		walking atomic.Bool
+	}
+	quotas struct {
+		t         *target
+		bcks      map[string]*quotaUsage // by bucket uname
+		mu        sync.RWMutex
+		resyncing atomic.Bool
+	}
+)
+
+func (q *quotas) init(t *target) {
+	q.t = t
+	q.bcks = make(map[string]*quotaUsage, 8)
+	hk.Reg("quota"+hk.NameSuffix, q.housekeep, quotaStartupDelay)
+}
+
+// whether a given bucket is subject to bucket and/or namespace quota
+func quotaTracked(bck *cluster.Bck, config *cmn.Config) bool {
+	return bck.Props.Quota.IsSet() || config.Space.Quota(bck.Ns) != nil
+}
+
+// returns usage of a given bucket, starting to track (and seed) it if need be
+func (q *quotas) usage(bck *cluster.Bck) (u *quotaUsage) {
+	uname := bck.MakeUname("")
+	q.mu.RLock()
+	u = q.bcks[uname]
+	q.mu.RUnlock()
+	if u != nil {
+		return
+	}
+	q.mu.Lock()
+	if q.bcks == nil {
+		q.bcks = make(map[string]*quotaUsage, 8)
+	}
+	u = q.bcks[uname]
+	if u == nil {
+		u = &quotaUsage{}
+		q.bcks[uname] = u
+		go q.walk(bck, u) // seed (in the background)
+	}
+	q.mu.Unlock()
+	return
+}
+
+// add updates the usage (if tracked) by the respective deltas
+func (q *quotas) add(bck *cluster.Bck, objs, size int64) {
+	if objs == 0 && size == 0 {
+		return
+	}
+	q.mu.RLock()
+	u := q.bcks[bck.MakeUname("")]
+	q.mu.RUnlock()
+	if u != nil {
+		u.objs.Add(objs)
+		u.size.Add(size)
+	}
+}
+
+// get returns local usage of all tracked buckets
+func (q *quotas) get() cmn.QuotaUsages {
+	q.mu.RLock()
+	usages := make(cmn.QuotaUsages, len(q.bcks))
+	for uname, u := range q.bcks {
+		b, _ := cmn.ParseUname(uname)
+		usages[b.String()] = &cmn.QuotaUsage{Objs: u.objs.Load(), Size: u.size.Load(), Seeded: u.seeded.Load()}
+	}
+	q.mu.RUnlock()
+	return usages
+}
+
+// (re)compute local usage of a given bucket; is a no-op if already in progress
+func (q *quotas) walk(bck *cluster.Bck, u *quotaUsage) {
+	if !u.walking.CAS(false, true) {
+		return
+	}
+	defer u.walking.Store(false)
+	objs0, size0 := u.objs.Load(), u.size.Load()
+	objs, size, err := quotaWalk(bck)
+	if err != nil {
+		glog.Errorf("%s: failed to compute %s quota usage (will retry): %v", q.t, bck, err)
+		return
+	}
+	// plus updates made while walking
+	u.objs.Store(objs + u.objs.Load() - objs0)
+	u.size.Store(size + u.size.Load() - size0)
+	u.seeded.Store(true)
+}
+
+// Returns the number and total size of the objects in a given bucket, counting
+// each object once (i.e., skipping mirror copies), and by the size of its file -
+// the same way poi.quotaDelta does; the size includes previous versions.
+func quotaWalk(bck *cluster.Bck) (objs, size int64, err error) {
+	var (
+		avail    = fs.GetAvail()
+		group, _ = errgroup.WithContext(context.Background())
+	)
+	for _, mi := range avail {
+		mi := mi
+		group.Go(func() error {
+			var n, sz int64
+			cb := func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				lom := cluster.AllocLOM("")
+				defer cluster.FreeLOM(lom)
+				if lom.InitFQN(fqn, bck.Bucket()) != nil || !lom.IsHRW() {
+					return nil // (copy, misplaced, or not an object)
+				}
+				if fi, err := os.Stat(fqn); err == nil {
+					n++
+					sz += fi.Size()
+				}
+				return nil
+			}
+			opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Callback: cb}
+			opts.Bck.Copy(bck.Bucket())
+			if err := fs.Walk(opts); err != nil {
+				return err
+			}
+			if bck.IsAIS() {
+				vcb := func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					if fi, err := os.Stat(fqn); err == nil {
+						sz += fi.Size()
+					}
+					return nil
+				}
+				vopts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjVerType}, Callback: vcb}
+				vopts.Bck.Copy(bck.Bucket())
+				if err := fs.Walk(vopts); err != nil {
+					return err
+				}
+			}
+			gatomic.AddInt64(&objs, n)
+			gatomic.AddInt64(&size, sz)
+			return nil
+		})
+	}
+	err = group.Wait()
+	return
+}
+
+// check returns ErrQuotaExceeded if adding `objs` objects of total `size` to a given
+// bucket would exceed this target's share of the bucket's or its namespace's quota
+func (q *quotas) check(bck *cluster.Bck, objs, size int64) error {
+	if objs <= 0 && size <= 0 {
+		return nil
+	}
+	var (
+		config  = cmn.GCO.Get()
+		bquota  = &bck.Props.Quota
+		nsquota = config.Space.Quota(bck.Ns)
+	)
+	if !bquota.IsSet() && nsquota == nil {
+		return nil
+	}
+	nat := int64(q.t.owner.smap.get().CountActiveTargets())
+	if bquota.IsSet() {
+		u := q.usage(bck)
+		if !u.seeded.Load() {
+			return nil
+		}
+		if err := quotaCheck(bck.String(), bquota, nat, u.objs.Load(), u.size.Load(), objs, size); err != nil {
+			return err
+		}
+	}
+	if nsquota == nil {
+		return nil
+	}
+	var (
+		usedObjs, usedSize int64
+		seeded             = true
+	)
+	q.t.owner.bmd.get().Range(nil, &bck.Ns, func(b *cluster.Bck) bool {
+		u := q.usage(b)
+		usedObjs += u.objs.Load()
+		usedSize += u.size.Load()
+		seeded = seeded && u.seeded.Load()
+		return false
+	})
+	if !seeded {
+		return nil
+	}
+	return quotaCheck("namespace "+bck.Ns.String(), nsquota, nat, usedObjs, usedSize, objs, size)
+}
+
+func quotaCheck(what string, quota *cmn.QuotaConf, nat, usedObjs, usedSize, objs, size int64) error {
+	if quota.MaxSize > 0 && size > 0 {
+		if limit := quotaShare(int64(quota.MaxSize), nat); usedSize+size > limit {
+			return cmn.NewErrQuotaExceeded(what, cmn.QuotaSize, usedSize, limit)
+		}
+	}
+	if quota.MaxObjs > 0 && objs > 0 {
+		if limit := quotaShare(quota.MaxObjs, nat); usedObjs+objs > limit {
+			return cmn.NewErrQuotaExceeded(what, cmn.QuotaObjs, usedObjs, limit)
+		}
+	}
+	return nil
+}
+
+// this target's share of the cluster-wide quota (rounded up)
+func quotaShare(limit, nat int64) int64 {
+	if nat <= 1 {
+		return limit
+	}
+	return (limit + nat - 1) / nat
+}
+
+func (q *quotas) housekeep() time.Duration {
+	if !q.t.ClusterStarted() {
+		return quotaStartupDelay
+	}
+	if q.resyncing.CAS(false, true) {
+		go q.resyncAll()
+	}
+	return quotaResyncInterval
+}
+
+// upon BMD change: start tracking (and seed) newly created buckets, and the buckets
+// that got quotas configured
+func (q *quotas) bmdChanged() {
+	config := cmn.GCO.Get()
+	q.t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if quotaTracked(bck, config) {
+			q.usage(bck)
+		}
+		return false
+	})
+}
+
+// seed (or resync) all tracked buckets
+func (q *quotas) resyncAll() {
+	var (
+		config  = cmn.GCO.Get()
+		tracked = make(map[string]*cluster.Bck, 8)
+	)
+	defer q.resyncing.Store(false)
+	q.t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if quotaTracked(bck, config) {
+			tracked[bck.MakeUname("")] = bck
+		}
+		return false
+	})
+	// forget buckets that are no longer tracked (e.g., destroyed, or quota removed)
+	q.mu.Lock()
+	for uname := range q.bcks {
+		if _, ok := tracked[uname]; !ok {
+			delete(q.bcks, uname)
+		}
+	}
+	q.mu.Unlock()
+
+	for _, bck := range tracked {
+		q.walk(bck, q.usage(bck))
+	}
+}
+
+// PUT and APPEND: fail early - prior to receiving the payload
+// (compare with poi.fini, where the quota gets checked (again) and enforced)
+func (t *target) quotaPrecheck(r *http.Request, lom *cluster.LOM, dpq *dpq) error {
+	if !quotaTracked(lom.Bck(), cmn.GCO.Get()) {
+		return nil
+	}
+	var (
+		objs, size int64
+		exists     = lom.Load(true /*cache it*/, false /*locked*/) == nil
+	)
+	if r.ContentLength > 0 {
+		size = r.ContentLength
+	}
+	switch {
+	case dpq.archpath != "": // append to archive
+	case dpq.appendTy == apc.FlushOp:
+		return nil
+	case dpq.appendTy != "":
+		if !exists && dpq.appendHdl == "" {
+			objs = 1
+		}
+	default:
+		if exists {
+			size -= lom.SizeBytes()
+		} else {
+			objs = 1
+		}
+	}
+	return t.quota.check(lom.Bck(), objs, size)
+}
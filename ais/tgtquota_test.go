@@ -0,0 +1,144 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestQuotaWalk(t *testing.T) {
+	bck := cluster.NewBck(testBucket, apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(cluster.T.Bowner()); err != nil {
+		t.Fatal(err)
+	}
+	var fqns []string
+	defer func() {
+		for _, fqn := range fqns {
+			os.Remove(fqn)
+		}
+	}()
+	var expected int64
+	for i := 1; i <= 3; i++ {
+		lom := cluster.AllocLOM("quota/obj" + strconv.Itoa(i))
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			t.Fatal(err)
+		}
+		size := int64(i) * cos.KiB
+		fh, err := cos.CreateFile(lom.FQN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fh.Truncate(size)
+		fh.Close()
+		fqns = append(fqns, lom.FQN)
+		expected += size
+		// previous version counts towards the size (but not the number of objects)
+		verFQN := lom.VersionFQN(strconv.Itoa(i))
+		if fh, err = cos.CreateFile(verFQN); err != nil {
+			t.Fatal(err)
+		}
+		fh.Truncate(size)
+		fh.Close()
+		fqns = append(fqns, verFQN)
+		expected += size
+		cluster.FreeLOM(lom)
+	}
+	objs, size, err := quotaWalk(bck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objs != 3 || size != expected {
+		t.Fatalf("expected 3 objects (%d bytes), got %d (%d bytes)", expected, objs, size)
+	}
+}
+
+// appending to archive (PUT ?archpath=) is subject to storage quota
+func TestQuotaAppendArch(t *testing.T) {
+	var (
+		tgt   = cluster.T.(*target)
+		bck   = cluster.NewBck("bck-quota", apc.ProviderAIS, cmn.NsGlobal)
+		bmd   = tgt.owner.bmd.get().clone()
+		limit = int64(cos.KiB)
+	)
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum: cmn.CksumConf{Type: cos.ChecksumNone},
+		Quota: cmn.QuotaConf{MaxSize: cos.Size(limit)},
+	})
+	if err := tgt.owner.bmd.putPersist(bmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	fs.CreateBucket("test", bck.Bucket(), false /*nilbmd*/)
+	if err := bck.Init(tgt.Bowner()); err != nil {
+		t.Fatal(err)
+	}
+
+	lom := cluster.AllocLOM("quota/arch.tar")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		t.Fatal(err)
+	}
+	fh, err := cos.CreateFile(lom.FQN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(fh)
+	tw.WriteHeader(&tar.Header{Name: "first.txt", Size: 1, Mode: 0o644, Typeflag: tar.TypeReg})
+	tw.Write([]byte("1"))
+	tw.Close()
+	finfo, _ := fh.Stat()
+	fh.Close()
+	defer os.Remove(lom.FQN)
+	size := finfo.Size()
+	lom.SetSize(size)
+	if err := lom.Persist(); err != nil {
+		t.Fatal(err)
+	}
+
+	// (pre-seeded) usage: 50 bytes short of the quota
+	if tgt.quota.t == nil {
+		tgt.quota.t = tgt
+	}
+	if tgt.owner.smap.get() == nil {
+		tgt.owner.smap.put(newSmap())
+	}
+	u := &quotaUsage{}
+	u.objs.Store(1)
+	u.size.Store(limit - 50)
+	u.seeded.Store(true)
+	tgt.quota.mu.Lock()
+	if tgt.quota.bcks == nil {
+		tgt.quota.bcks = make(map[string]*quotaUsage, 8)
+	}
+	tgt.quota.bcks[bck.MakeUname("")] = u
+	tgt.quota.mu.Unlock()
+
+	body := bytes.Repeat([]byte("a"), 100)
+	r := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
+	r.Header.Set(cmn.HdrContentLength, strconv.Itoa(len(body)))
+	dpq := &dpq{archpath: "file.txt", archmime: cos.ExtTar}
+	errCode, err := tgt.doAppendArch(r, lom, time.Now(), dpq)
+	if err == nil || errCode != http.StatusInsufficientStorage {
+		t.Fatalf("expected %d, got %d (%v)", http.StatusInsufficientStorage, errCode, err)
+	}
+	if err := lom.Load(false, false); err != nil || lom.SizeBytes() != size {
+		t.Fatalf("expected %s to remain intact (size %d, err %v)", lom, lom.SizeBytes(), err)
+	}
+	if u.size.Load() != limit-50 {
+		t.Fatalf("expected usage to remain %d, got %d", limit-50, u.size.Load())
+	}
+}
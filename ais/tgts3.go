@@ -6,6 +6,7 @@ package ais
 
 import (
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 	"time"
@@ -17,8 +18,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/replicate"
 )
 
 // PUT s3/bckName/objName
@@ -28,6 +31,11 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	q := r.URL.Query()
+	if isS3MptRequest(q) {
+		t.mptHandlerS3(w, r, apiItems, q)
+		return
+	}
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
@@ -42,6 +50,35 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// [METHOD] s3/bckName[/objName]?uploads|uploadId=<id>
+func (t *target) mptHandlerS3(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	if len(items) == 0 {
+		t.writeErr(w, r, errS3Req)
+		return
+	}
+	_, uploads := q[s3compat.QparamMptUploads]
+	switch r.Method {
+	case http.MethodGet:
+		if len(items) == 1 && uploads {
+			t.listMptUploadsS3(w, r, items[0])
+			return
+		}
+		t.listMptPartsS3(w, r, items, q)
+	case http.MethodPut:
+		t.putMptPartS3(w, r, items, q)
+	case http.MethodPost:
+		if uploads {
+			t.startMptS3(w, r, items)
+			return
+		}
+		t.completeMptS3(w, r, items, q)
+	case http.MethodDelete:
+		t.abortMptS3(w, r, items, q)
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
+	}
+}
+
 func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if len(items) < 2 {
 		t.writeErr(w, r, errS3Obj)
@@ -96,7 +133,7 @@ func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	}
 
 	var cksumValue string
-	if cksum := lom.Checksum(); cksum.Type() == cos.ChecksumMD5 {
+	if cksum := lom.Checksum(); cksum.Type() == cos.ChecksumMD5 && !lom.IsEncrypted() {
 		cksumValue = cksum.Value()
 	}
 	result := s3compat.CopyObjectResult{
@@ -151,6 +188,9 @@ func (t *target) directPutObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.writeErr(w, r, err)
 		return
 	}
+	if !t.rateLimitBytes(w, r, dpq, lom, cmn.RateLimitOpPut, r.ContentLength) {
+		return
+	}
 	features := cmn.GCO.Get().Features
 	poi := allocPutObjInfo()
 	{
@@ -159,6 +199,7 @@ func (t *target) directPutObjS3(w http.ResponseWriter, r *http.Request, items []
 		poi.lom = lom
 		poi.skipVC = features.IsSet(feat.SkipVC) || cos.IsParseBool(dpq.skipVC) // apc.QparamSkipVC
 		poi.restful = true
+		poi.bypassGov = s3BypassGov(r)
 	}
 	errCode, err := poi.do(r, dpq)
 	freePutObjInfo(poi)
@@ -167,6 +208,8 @@ func (t *target) directPutObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.writeErr(w, r, err, errCode)
 		return
 	}
+	events.Emit(cmn.EvPut, lom)
+	replicate.Emit(replicate.OpPut, lom)
 	s3compat.SetETag(w.Header(), lom)
 }
 
@@ -240,7 +283,7 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		t.writeErr(w, r, err)
 		return
 	}
-	errCode, err := t.DeleteObject(lom, false)
+	errCode, err := t.delObject(lom, false /*evict*/, s3BypassGov(r))
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			err := cmn.NewErrNotFound("%s: %s", t.si, lom.FullName())
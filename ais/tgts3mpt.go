@@ -0,0 +1,396 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2021, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/events"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/replicate"
+)
+
+//
+// S3 multipart upload: parts are staged as workfiles on the HRW target
+// and get assembled into a single object upon completion
+//
+
+const mptHKInterval = time.Hour
+
+func (t *target) regMptHK() {
+	hk.Reg("s3-mpt"+hk.NameSuffix, t.mptHK, mptHKInterval)
+}
+
+func isS3MptRequest(q url.Values) (ok bool) {
+	_, ok = q[s3compat.QparamMptUploadID]
+	if !ok {
+		_, ok = q[s3compat.QparamMptUploads]
+	}
+	return
+}
+
+func (t *target) s3MptLOM(w http.ResponseWriter, r *http.Request, items []string) (lom *cluster.LOM) {
+	if len(items) < 2 {
+		t.writeErr(w, r, errS3Obj)
+		return
+	}
+	bck := cluster.NewBck(items[0], apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom = cluster.AllocLOM(path.Join(items[1:]...))
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		if cmn.IsErrRemoteBckNotFound(err) {
+			t.BMDVersionFixup(r)
+			err = lom.InitBck(bck.Bucket())
+		}
+		if err != nil {
+			cluster.FreeLOM(lom)
+			t.writeErr(w, r, err)
+			return nil
+		}
+	}
+	return
+}
+
+// POST s3/bckName/objName?uploads
+func (t *target) startMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.s3MptLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	id := cos.GenUUID()
+	s3compat.InitUpload(id, lom.Bck().Name, lom.ObjName)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: started multipart upload %q => %s", t, id, lom)
+	}
+	result := s3compat.NewInitiateMptUploadResult(lom.Bck().Name, lom.ObjName, id)
+	sgl := memsys.PageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT s3/bckName/objName?partNumber=<n>&uploadId=<id>
+func (t *target) putMptPartS3(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	defer cos.Close(r.Body)
+	if r.Header.Get(s3compat.HeaderObjSrc) != "" {
+		t.writeErrMsg(w, r, "upload-part-copy is not supported", http.StatusNotImplemented)
+		return
+	}
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.writeErr(w, r, cs.Err, http.StatusInsufficientStorage)
+		return
+	}
+	partNum, err := s3compat.ParsePartNum(q.Get(s3compat.QparamMptPartNo))
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom := t.s3MptLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	id := q.Get(s3compat.QparamMptUploadID)
+	if !s3compat.UploadExists(id, lom.Bck().Name, lom.ObjName) {
+		t.writeErr(w, r, s3compat.NewErrNoSuchUpload(id), http.StatusNotFound)
+		return
+	}
+	dpq := dpqAlloc()
+	defer dpqFree(dpq)
+	if err := dpq.fromRawQ(r.URL.RawQuery); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if !t.rateLimitBytes(w, r, dpq, lom, cmn.RateLimitOpPut, r.ContentLength) {
+		return
+	}
+	var (
+		prefix  = fs.WorkfileMptPart + "-" + id
+		partFQN = fs.CSM.Gen(lom, fs.WorkfileType, prefix)
+	)
+	part, err := t.writeMptPart(lom, r, partFQN)
+	if err != nil {
+		if nerr := cos.RemoveFile(partFQN); nerr != nil {
+			glog.Errorf(fmtNested, t, err, "remove", partFQN, nerr)
+		}
+		t.fsErr(err, partFQN)
+		t.writeErr(w, r, err)
+		return
+	}
+	part.Num = partNum
+	err = t.addMptPart(id, part)
+	if err != nil {
+		// aborted (or completed) while uploading
+		if nerr := cos.RemoveFile(partFQN); nerr != nil {
+			glog.Errorf(fmtNested, t, err, "remove", partFQN, nerr)
+		}
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.Header().Set(cmn.HdrETag, s3compat.QuoteETag(part.MD5))
+}
+
+// add (or replace) part under the upload's lock - see assembleMpt
+func (t *target) addMptPart(id string, part *s3compat.MptPart) error {
+	unlock, err := s3compat.LockUpload(id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	prev, err := s3compat.AddPart(id, part)
+	if err != nil || prev == nil {
+		return err
+	}
+	if nerr := cos.RemoveFile(prev.FQN); nerr != nil {
+		glog.Errorf("%s: failed to remove replaced part %d of %q: %v", t, prev.Num, id, nerr)
+	}
+	return nil
+}
+
+func (t *target) writeMptPart(lom *cluster.LOM, r *http.Request, partFQN string) (part *s3compat.MptPart, err error) {
+	fh, err := lom.CreateFile(partFQN)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		written   int64
+		buf, slab = t.gmm.Alloc()
+		md5       = cos.NewCksumHash(cos.ChecksumMD5)
+		mw        = cos.NewWriterMulti(md5.H, cos.WriterOnly{Writer: fh})
+	)
+	written, err = io.CopyBuffer(mw, r.Body, buf)
+	slab.Free(buf)
+	if nerr := fh.Close(); nerr != nil && err == nil {
+		err = nerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	md5.Finalize()
+	// optional: validate against the Content-MD5 provided by the client
+	if b64 := r.Header.Get(cmn.HdrContentMD5); b64 != "" {
+		expected, errDecode := base64.StdEncoding.DecodeString(b64)
+		if errDecode != nil {
+			return nil, fmt.Errorf("invalid %s header %q: %v", cmn.HdrContentMD5, b64, errDecode)
+		}
+		if hex.EncodeToString(expected) != md5.Value() {
+			exp := cos.NewCksum(cos.ChecksumMD5, hex.EncodeToString(expected))
+			return nil, cos.NewBadDataCksumError(exp, &md5.Cksum, lom.String())
+		}
+	}
+	part = &s3compat.MptPart{MD5: md5.Value(), FQN: partFQN, Size: written}
+	return part, nil
+}
+
+// POST s3/bckName/objName?uploadId=<id>
+func (t *target) completeMptS3(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	started := time.Now()
+	lom := t.s3MptLOM(w, r, items)
+	if lom == nil {
+		cos.Close(r.Body)
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	var (
+		id      = q.Get(s3compat.QparamMptUploadID)
+		partMsg = &s3compat.CompleteMptUpload{}
+	)
+	err := xml.NewDecoder(r.Body).Decode(partMsg)
+	cos.Close(r.Body)
+	if err != nil {
+		t.writeErr(w, r, fmt.Errorf(cmn.FmtErrUnmarshal, t, "complete-multipart-upload", "", err))
+		return
+	}
+	if !s3compat.UploadExists(id, lom.Bck().Name, lom.ObjName) {
+		t.writeErr(w, r, s3compat.NewErrNoSuchUpload(id), http.StatusNotFound)
+		return
+	}
+	// hold the upload's lock through assembly and cleanup (a concurrent re-upload
+	// of the same part would otherwise remove its workfile from under us)
+	unlock, err := s3compat.LockUpload(id)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	parts, err := s3compat.CheckParts(id, partMsg.Parts)
+	if err != nil {
+		unlock()
+		t.writeErr(w, r, err)
+		return
+	}
+	etag, err := s3compat.CompositeETag(parts)
+	if err != nil {
+		unlock()
+		t.writeErr(w, r, err)
+		return
+	}
+	errCode, err := t.assembleMpt(lom, parts, etag, started, s3BypassGov(r))
+	if err != nil {
+		unlock()
+		t.writeErr(w, r, err, errCode)
+		return
+	}
+	t.cleanupMpt(id)
+	unlock()
+	events.Emit(cmn.EvPut, lom)
+	replicate.Emit(replicate.OpPut, lom)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: completed multipart upload %q (%d parts) => %s", t, id, len(parts), lom)
+	}
+	result := s3compat.NewCompleteMptUploadResult(lom.Bck().Name, lom.ObjName, etag)
+	sgl := memsys.PageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	w.Header().Set(cmn.HdrETag, s3compat.QuoteETag(etag))
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// concatenate staged parts and PUT the result (via the regular PUT path that
+// also takes care of versioning, checksumming, mirroring, and EC)
+func (t *target) assembleMpt(lom *cluster.LOM, parts []*s3compat.MptPart, etag string,
+	started time.Time, bypassGov bool) (errCode int, err error) {
+	var (
+		size    int64
+		readers = make([]io.Reader, 0, len(parts))
+		files   = make([]*os.File, 0, len(parts))
+	)
+	defer func() {
+		for _, fh := range files {
+			cos.Close(fh)
+		}
+	}()
+	for _, part := range parts {
+		fh, err := os.Open(part.FQN)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		files = append(files, fh)
+		readers = append(readers, fh)
+		size += part.Size
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	lom.SetCustomKey(cmn.ETag, etag)
+	lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD) // (new content)
+	poi := allocPutObjInfo()
+	{
+		poi.atime = started
+		poi.t = t
+		poi.lom = lom
+		poi.r = io.NopCloser(io.MultiReader(readers...))
+		poi.size = size
+		poi.workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePut)
+		poi.owt = cmn.OwtPut
+		poi.restful = true
+		poi.skipVC = true // the object is new (or entirely overwritten)
+		poi.bypassGov = bypassGov
+	}
+	errCode, err = poi.putObject()
+	freePutObjInfo(poi)
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+	}
+	return
+}
+
+// DELETE s3/bckName/objName?uploadId=<id>
+func (t *target) abortMptS3(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	lom := t.s3MptLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	id := q.Get(s3compat.QparamMptUploadID)
+	if !s3compat.UploadExists(id, lom.Bck().Name, lom.ObjName) {
+		t.writeErr(w, r, s3compat.NewErrNoSuchUpload(id), http.StatusNotFound)
+		return
+	}
+	t.cleanupMpt(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (t *target) cleanupMpt(id string) {
+	parts, _ := s3compat.FinishUpload(id)
+	t.removeMptParts(id, parts)
+}
+
+func (t *target) removeMptParts(id string, parts []*s3compat.MptPart) {
+	for _, part := range parts {
+		if err := cos.RemoveFile(part.FQN); err != nil {
+			glog.Errorf("%s: failed to remove part %d of %q: %v", t, part.Num, id, err)
+		}
+	}
+}
+
+// abort abandoned uploads (see s3compat.UploadTTL) and remove their staged parts
+func (t *target) mptHK() time.Duration {
+	for id, parts := range s3compat.ExpireUploads(time.Now()) {
+		glog.Warningf("%s: multipart upload %q expired - aborting (%d staged parts)", t, id, len(parts))
+		t.removeMptParts(id, parts)
+	}
+	return mptHKInterval
+}
+
+// GET s3/bckName/objName?uploadId=<id>
+func (t *target) listMptPartsS3(w http.ResponseWriter, r *http.Request, items []string, q url.Values) {
+	lom := t.s3MptLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	id := q.Get(s3compat.QparamMptUploadID)
+	if !s3compat.UploadExists(id, lom.Bck().Name, lom.ObjName) {
+		t.writeErr(w, r, s3compat.NewErrNoSuchUpload(id), http.StatusNotFound)
+		return
+	}
+	parts, err := s3compat.ListParts(id)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	result := s3compat.NewListPartsResult(lom.Bck().Name, lom.ObjName, id, parts)
+	sgl := memsys.PageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// GET s3/bckName?uploads (intra-cluster: the proxy then merges all targets' results)
+func (t *target) listMptUploadsS3(w http.ResponseWriter, r *http.Request, bckName string) {
+	bck := cluster.NewBck(bckName, apc.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	result := s3compat.ListUploads(bck.Name, "", 0 /*all*/)
+	sgl := memsys.PageMM().NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cmn.HdrContentType, cmn.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
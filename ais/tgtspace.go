@@ -6,6 +6,7 @@ package ais
 
 import (
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
@@ -14,13 +15,19 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
+func (t *target) regLifecycleHK() {
+	hk.Reg(apc.ActLifecycle+hk.NameSuffix, t.lifecycleHK, cmn.GCO.Get().Space.LcInterval())
+}
+
 // triggers by an out-of-space condition or a suspicion of thereof
 func (t *target) OOS(csRefreshed *fs.CapStatus) (cs fs.CapStatus) {
 	var err error
@@ -40,13 +47,13 @@ func (t *target) OOS(csRefreshed *fs.CapStatus) (cs fs.CapStatus) {
 	go func() {
 		cs := t.runStoreCleanup("" /*uuid*/, nil /*wg*/)
 		if cs.Err != nil {
-			t.runLRU("" /*uuid*/, nil /*wg*/, false)
+			t.runLRU("" /*uuid*/, nil /*wg*/, false /*force*/, false /*dry-run*/)
 		}
 	}()
 	return
 }
 
-func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.Bck) {
+func (t *target) runLRU(id string, wg *sync.WaitGroup, force, dryRun bool, bcks ...cmn.Bck) {
 	regToIC := id == ""
 	if regToIC {
 		id = cos.GenUUID()
@@ -75,6 +82,7 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 		GetFSStats:          ios.GetFSStats,
 		WG:                  wg,
 		Force:               force,
+		DryRun:              dryRun,
 	}
 	xlru.AddNotif(&xact.NotifXact{
 		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
@@ -83,6 +91,90 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 	space.RunLRU(&ini)
 }
 
+func (t *target) runLifecycle(id string, wg *sync.WaitGroup, dryRun bool, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(id)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xlc := rns.Entry.Get()
+	if regToIC && xlc.ID() == id {
+		// pre-existing UUID: notify IC members
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActLifecycle, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := space.IniLifecycle{
+		T:       t,
+		Xaction: xlc.(*space.XactLifecycle),
+		Buckets: bcks,
+		WG:      wg,
+		DryRun:  dryRun,
+	}
+	xlc.AddNotif(&xact.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact:      xlc,
+	})
+	space.RunLifecycle(&ini)
+}
+
+// periodically evaluate lifecycle rules (housekeeping callback)
+func (t *target) lifecycleHK() time.Duration {
+	interval := cmn.GCO.Get().Space.LcInterval()
+	if !t.ClusterStarted() || t.regstate.disabled.Load() {
+		return interval
+	}
+	var enabled bool
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		enabled = bck.Props.Lifecycle.Enabled
+		return enabled
+	})
+	if enabled {
+		go t.runLifecycle("" /*uuid*/, nil /*wg*/, false /*dry-run*/)
+	}
+	return interval
+}
+
+func (t *target) runScrub(id string, wg *sync.WaitGroup, restart bool, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewScrub(id)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xscr := rns.Entry.Get()
+	if regToIC && xscr.ID() == id {
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActScrub, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := scrub.IniScrub{
+		T:       t,
+		Xaction: xscr.(*scrub.XactScrub),
+		Buckets: bcks,
+		WG:      wg,
+		Restart: restart,
+	}
+	xscr.AddNotif(&xact.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact:      xscr,
+	})
+	scrub.RunScrub(&ini)
+}
+
 func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) fs.CapStatus {
 	regToIC := id == ""
 	if regToIC {
@@ -20,7 +20,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/mirror"
@@ -483,7 +482,7 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *cluster.Bck, msg *aisMsg) err
 }
 
 func (t *target) etlDP(msg *apc.TCBMsg) (dp cluster.DP, err error) {
-	if err = k8s.Detect(); err != nil {
+	if err = etl.CheckRuntime(); err != nil {
 		return
 	}
 	if msg.ID == "" {
@@ -857,6 +856,11 @@ func (t *target) destroyBucket(c *txnServerCtx) error {
 		if !nlp.TryLock(c.timeout.netw / 2) {
 			return cmn.NewErrBckIsBusy(c.bck.Bucket())
 		}
+		// object lock (WORM): veto
+		if err := t.checkBckLock(c.bck); err != nil {
+			nlp.Unlock()
+			return err
+		}
 		txn := newTxnBckBase(c.bck)
 		txn.fillFromCtx(c)
 		if err := t.transactions.begin(txn); err != nil {
@@ -86,6 +86,15 @@ func (t *target) xactHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			flt := xreg.XactFilter{ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck}
 			xreg.DoAbort(flt, err)
+		case apc.ActXactPause, apc.ActXactResume:
+			flt := xreg.XactFilter{ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck}
+			if err := xreg.DoPause(flt, msg.Action == apc.ActXactResume); err != nil {
+				if _, ok := err.(*cmn.ErrXactionNotFound); ok {
+					t.writeErrSilent(w, r, err, http.StatusNotFound)
+				} else {
+					t.writeErr(w, r, err)
+				}
+			}
 		default:
 			t.writeErrAct(w, r, msg.Action)
 		}
@@ -150,7 +159,31 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 		}
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
-		go t.runLRU(xactMsg.ID, wg, ext.Force, xactMsg.Buckets...)
+		go t.runLRU(xactMsg.ID, wg, ext.Force, ext.DryRun, xactMsg.Buckets...)
+		wg.Wait()
+	case apc.ActLifecycle:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		ext := &xact.QueryMsgLifecycle{}
+		if err := cos.MorphMarshal(xactMsg.Ext, ext); err != nil {
+			return err
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runLifecycle(xactMsg.ID, wg, ext.DryRun, xactMsg.Buckets...)
+		wg.Wait()
+	case apc.ActScrub:
+		if bck != nil {
+			glog.Errorf(erfmb, xactMsg.Kind, bck)
+		}
+		ext := &xact.QueryMsgScrub{}
+		if err := cos.MorphMarshal(xactMsg.Ext, ext); err != nil {
+			return err
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runScrub(xactMsg.ID, wg, ext.Restart, xactMsg.Buckets...)
 		wg.Wait()
 	case apc.ActStoreCleanup:
 		wg := &sync.WaitGroup{}
@@ -186,6 +219,44 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 			Xact: xctn,
 		})
 		go xctn.Run(nil)
+	case apc.ActInventory:
+		if bck.Props.Inventory.Bucket == "" {
+			return fmt.Errorf("%s: inventory destination is not configured (see bucket property %q)",
+				bck, "inventory.bucket")
+		}
+		rns := xreg.RenewInventory(t, xactMsg.ID, bck, "" /*run: on demand*/)
+		if rns.Err != nil {
+			return rns.Err
+		}
+		xctn := rns.Entry.Get()
+		xctn.AddNotif(&xact.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xctn,
+		})
+		go xctn.Run(nil)
+	case apc.ActReplResync:
+		if bck.Props.Replication.Bucket == "" {
+			return fmt.Errorf("%s: replication destination is not configured (see bucket property %q)",
+				bck, "replication.bucket")
+		}
+		rns := xreg.RenewReplResync(t, xactMsg.ID, bck)
+		if rns.Err != nil {
+			return rns.Err
+		}
+		xctn := rns.Entry.Get()
+		xctn.AddNotif(&xact.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xctn,
+		})
+		go xctn.Run(nil)
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
 		return rns.Err
@@ -17,10 +17,17 @@ const (
 	CompressNever  = "never"
 )
 
-// sent via req.Header.Set(apc.HdrCompress, LZ4Compression)
-// (alternative to lz4 compressions upon popular request)
-const LZ4Compression = "lz4"
+// Compression codecs, sent via req.Header.Set(apc.HdrCompress, ...)
+// (see also config.Transport.Codec)
+const (
+	LZ4Compression  = "lz4" // (default)
+	ZstdCompression = "zstd"
+)
 
-var SupportedCompression = []string{CompressNever, CompressAlways}
+var (
+	SupportedCompression = []string{CompressNever, CompressAlways}
+	SupportedCodecs      = []string{LZ4Compression, ZstdCompression}
+)
 
 func IsValidCompression(c string) bool { return c == "" || cos.StringInSlice(c, SupportedCompression) }
+func IsValidCodec(c string) bool       { return c == "" || cos.StringInSlice(c, SupportedCodecs) }
@@ -18,6 +18,7 @@ const (
 	ActDestroyBck     = "destroy-bck"  // destroy bucket data and metadata
 	ActSummaryBck     = "summary-bck"
 	ActDownload       = "download"
+	ActDsort          = "dsort"
 	ActECEncode       = "ec-encode" // erasure code a bucket
 	ActECGet          = "ec-get"    // erasure decode objects
 	ActECPut          = "ec-put"    // erasure encode objects
@@ -27,7 +28,10 @@ const (
 	ActElection       = "election"
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
+	ActInventory      = "inventory" // generate bucket inventory (see cmn.InventoryConf)
 	ActLRU            = "lru"
+	ActLegalHold      = "legal-hold" // set or remove object's legal hold (see cmn.ObjectLockConf)
+	ActLifecycle      = "lifecycle"  // evaluate bucket lifecycle rules (see cmn.LifecycleConf)
 	ActList           = "list"
 	ActLoadLomCache   = "load-lom-cache"
 	ActMakeNCopies    = "make-n-copies"
@@ -37,10 +41,13 @@ const (
 	ActPutCopies      = "put-copies"
 	ActRebalance      = "rebalance"
 	ActRenameObject   = "rename-obj"
+	ActReplResync     = "repl-resync" // reconcile replicated bucket with its destination (see cmn.ReplicationConf)
 	ActResetBprops    = "reset-bprops"
 	ActResetConfig    = "reset-config"
 	ActResilver       = "resilver"
+	ActRestoreObjVer  = "restore-obj-ver" // restore previous object version (see versioning.max_history)
 	ActResyncBprops   = "resync-bprops"
+	ActScrub          = "scrub" // verify checksums of all objects and EC slices, repair corrupted ones
 	ActSetBprops      = "set-bprops"
 	ActSetConfig      = "set-config"
 	ActShutdown       = "shutdown"
@@ -86,8 +93,10 @@ const (
 	ActMountpathDisable = "disable-mp"
 
 	// Actions on xactions
-	ActXactStop  = Stop
-	ActXactStart = Start
+	ActXactStop   = Stop
+	ActXactStart  = Start
+	ActXactPause  = Pause
+	ActXactResume = Resume
 
 	// auxiliary
 	ActTransient = "transient" // transient - in-memory only
@@ -205,6 +214,11 @@ const (
 	// Object related query params.
 	QparamAppendType   = "append_type"
 	QparamAppendHandle = "append_handle"
+	QparamObjVersion   = "version"           // GET or DELETE a given (previous) version (see versioning.max_history)
+	QparamBypassGov    = "bypass_governance" // true: bypass object lock retention in governance mode (see cmn.ObjectLockConf)
+
+	// DELETE ?version=ObjVerAll removes all previous versions of the object
+	ObjVerAll = "*"
 
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
@@ -245,6 +259,8 @@ const (
 	QparamTaskAction       = "tac" // "start", "status", "result"
 	QparamClusterInfo      = "cii" // true: /Health to return cluster info and status
 	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
+	QparamUserID           = "uid" // AuthN user ID (redirecting proxy => target, for the target to enforce rate limits)
+	QparamUserSig          = "usg" // the redirecting proxy's signature of the above (see ais/ratelim.go)
 
 	// force the operation; allows to overcome certain restrictions (e.g., shutdown primary and the entire cluster)
 	// or errors (e.g., attach invalid mountpath)
@@ -293,8 +309,11 @@ const (
 	GetWhatClusterConfig = "cluster_config"
 	GetWhatDaemonStatus  = "status"
 	GetWhatDiskStats     = "disk"
+	GetWhatJobHistory    = "job_history" // finished jobs (see xact.HistQuery)
 	GetWhatMountpaths    = "mountpaths"
+	GetWhatQuota         = "quota" // storage quota usage (see cmn.QuotaUsage)
 	GetWhatRemoteAIS     = "remote"
+	GetWhatRepair        = "repair" // under-protected objects: queued and repaired
 	GetWhatSmap          = "smap"
 	GetWhatSmapVote      = "smapvote"
 	GetWhatSnode         = "snode"
@@ -333,8 +352,9 @@ const (
 	ObjStatusDeleted // TODO: reserved for future when we introduce delayed delete of the object/bucket
 
 	// Flags
-	EntryIsCached = 1 << (EntryStatusBits + 1)
-	EntryInArch   = 1 << (EntryStatusBits + 2)
+	EntryIsCached  = 1 << (EntryStatusBits + 1)
+	EntryInArch    = 1 << (EntryStatusBits + 2)
+	EntryIsPrevVer = 1 << (EntryStatusBits + 3) // previous (non-current) object version
 )
 
 // List objects default page size
@@ -368,7 +388,9 @@ const (
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	Keys      = "keys"     // AuthN (token signing keys)
 	IC        = "ic"       // information center
+	Schedule  = "schedule" // scheduled (recurring) jobs
 
 	// l3
 	SyncSmap = "syncsmap" // legacy
@@ -387,6 +409,8 @@ const (
 	Init     = "init"
 	Start    = "start"
 	Stop     = "stop"
+	Pause    = "pause"
+	Resume   = "resume"
 	Abort    = "abort"
 	Sort     = "sort"
 	Finished = "finished"
@@ -408,6 +432,7 @@ const (
 	ETL         = "etl"
 	ETLInitSpec = "init_spec"
 	ETLInitCode = "init_code"
+	ETLInitProc = "init_proc"
 	ETLInfo     = "info"
 	ETLList     = List
 	ETLLogs     = "logs"
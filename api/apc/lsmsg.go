@@ -21,8 +21,14 @@ const (
 
 	// cache list-objects results and use this cache to speed-up
 	UseListObjsCache
+
+	LsVerHistory // include previous object versions (see versioning.max_history and LsVerSepa)
 )
 
+// previous object versions are listed as "<object name><LsVerSepa><version>"
+// with the EntryIsPrevVer flag set (see LsVerHistory)
+const LsVerSepa = "#v"
+
 // ListObjsMsg and HEAD(object) enum
 // NOTE: compare with `ObjectProps` below and popular lists of selected props (below as well)
 const (
@@ -50,6 +56,7 @@ type (
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
 		Flags             uint64 `json:"flags,string"`       // enum {LsPresent, ...} - see above
 		PageSize          uint   `json:"pagesize"`           // max entries returned by list objects call
+		Where             string `json:"where,omitempty"`    // filter expression, e.g. `custom.label == "cat" && size > 1MiB`
 	}
 )
 
@@ -106,6 +113,7 @@ func (lsmsg *ListObjsMsg) PropsSet() (s cos.StringSet) {
 }
 
 func (lsmsg *ListObjsMsg) SetFlag(flag uint64)         { lsmsg.Flags |= flag }
+func (lsmsg *ListObjsMsg) ClearFlag(flag uint64)       { lsmsg.Flags &^= flag }
 func (lsmsg *ListObjsMsg) IsFlagSet(flags uint64) bool { return lsmsg.Flags&flags == flags }
 
 func (lsmsg *ListObjsMsg) Clone() *ListObjsMsg {
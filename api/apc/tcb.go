@@ -19,6 +19,7 @@ type (
 		Prefix string `json:"prefix"`  // Prefix added to each resulting object.
 		DryRun bool   `json:"dry_run"` // Don't perform any PUT
 		Force  bool   `json:"force"`   // Force running in presence of a potential "limited coexistence" type conflict
+		Resume bool   `json:"resume"`  // Continue from the checkpoint of the same (interrupted) job, if any
 	}
 	TCBMsg struct {
 		// Resulting objects names will have this extension. Warning: if in a source bucket exist two objects with the
@@ -58,6 +58,8 @@ var (
 	URLPathdSortStart   = urlpath(Version, Sort, Start)
 	URLPathdSortList    = urlpath(Version, Sort, List)
 	URLPathdSortAbort   = urlpath(Version, Sort, Abort)
+	URLPathdSortPause   = urlpath(Version, Sort, Pause)
+	URLPathdSortResume  = urlpath(Version, Sort, Resume)
 	URLPathdSortShards  = urlpath(Version, Sort, Shards)
 	URLPathdSortRecords = urlpath(Version, Sort, Records)
 	URLPathdSortMetrics = urlpath(Version, Sort, Metrics)
@@ -68,6 +70,8 @@ var (
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
 
+	URLPathSchedule = urlpath(Version, Schedule)
+
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
 
@@ -75,6 +79,8 @@ var (
 	URLPathUsers    = urlpath(Version, Users)
 	URLPathClusters = urlpath(Version, Clusters)
 	URLPathRoles    = urlpath(Version, Roles)
+	URLPathKeys     = urlpath(Version, Keys)
+	URLPathJWKS     = urlpath(".well-known", "jwks.json") // (standard location of the AuthN public keys)
 )
 
 func (u URLPath) Join(words ...string) string {
@@ -296,3 +296,18 @@ func SetConfig(baseParams api.BaseParams, conf *ConfigToUpdate) error {
 	}
 	return reqParams.DoHTTPRequest()
 }
+
+// RotateKey makes AuthN sign new tokens with a newly generated key (RS256, ES256);
+// returns the new key's ID
+func RotateKey(baseParams api.BaseParams) (kid string, err error) {
+	msg := &KeyMsg{}
+	baseParams.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathKeys.S
+	}
+	err = reqParams.DoHTTPReqResp(msg)
+	return msg.ID, err
+}
@@ -40,6 +40,11 @@ type (
 	ServerConf struct {
 		Secret       string       `json:"secret"`
 		ExpirePeriod cos.Duration `json:"expiration_time"`
+		// token signing: HS256 (default, uses the secret), RS256, or ES256
+		SigningMethod string `json:"signing_method,omitempty"`
+		// (optional) PEM-encoded private key to sign with (RS256, ES256);
+		// when omitted, AuthN generates the key itself
+		PrivateKey string `json:"private_key,omitempty"`
 	}
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
@@ -48,8 +53,9 @@ type (
 		Server *ServerConfToUpdate `json:"auth"`
 	}
 	ServerConfToUpdate struct {
-		Secret       *string `json:"secret"`
-		ExpirePeriod *string `json:"expiration_time"`
+		Secret        *string `json:"secret"`
+		ExpirePeriod  *string `json:"expiration_time"`
+		SigningMethod *string `json:"signing_method"`
 	}
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
@@ -58,6 +64,13 @@ type (
 	}
 )
 
+// token signing methods
+const (
+	SigningHS256 = "HS256"
+	SigningRS256 = "RS256"
+	SigningES256 = "ES256"
+)
+
 var (
 	_ jsp.Opts = (*Config)(nil)
 
@@ -93,5 +106,31 @@ func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
 		}
 		c.Server.ExpirePeriod = cos.Duration(dur)
 	}
+	if cu.Server.SigningMethod != nil {
+		if err := ValidateSigningMethod(*cu.Server.SigningMethod); err != nil {
+			return err
+		}
+		c.Server.SigningMethod = *cu.Server.SigningMethod
+	}
 	return nil
 }
+
+func (c *Config) SigningMethod() (method string) {
+	c.RLock()
+	method = c.Server.SigningMethod
+	c.RUnlock()
+	if method == "" {
+		method = SigningHS256
+	}
+	return
+}
+
+func ValidateSigningMethod(method string) error {
+	switch method {
+	case "", SigningHS256, SigningRS256, SigningES256:
+		return nil
+	default:
+		return fmt.Errorf("invalid signing method %q (expecting one of: %s, %s, %s)",
+			method, SigningHS256, SigningRS256, SigningES256)
+	}
+}
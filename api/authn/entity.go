@@ -37,6 +37,10 @@ type (
 	TokenMsg struct {
 		Token string `json:"token"`
 	}
+	// (rotated) token signing key
+	KeyMsg struct {
+		ID string `json:"kid"`
+	}
 	LoginMsg struct {
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
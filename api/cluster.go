@@ -434,3 +434,33 @@ func RemoveNodeFromSmap(baseParams BaseParams, sid string) error {
 	FreeRp(reqParams)
 	return err
 }
+
+// GetRepairStatus returns, for each target, the state of its repair queue: the numbers of
+// queued, repaired, and failed under-protected objects, and the most urgent queued objects.
+// GetQuotaUsage returns per-target usage of the buckets that are subject to storage
+// quota, as enforced (see cmn.QuotaUsages)
+func GetQuotaUsage(baseParams BaseParams) (usage map[string]cmn.QuotaUsages, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatQuota}}
+	}
+	err = reqParams.DoHTTPReqResp(&usage)
+	FreeRp(reqParams)
+	return
+}
+
+func GetRepairStatus(baseParams BaseParams) (status map[string]*cmn.RepairStatus, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatRepair}}
+	}
+	err = reqParams.DoHTTPReqResp(&status)
+	FreeRp(reqParams)
+	return
+}
@@ -42,6 +42,29 @@ func AbortDSort(baseParams BaseParams, managerUUID string) error {
 	return err
 }
 
+// PauseDSort pauses dSort job: shards that are being extracted (created) complete,
+// new ones won't be started until the job is resumed - see ResumeDSort.
+func PauseDSort(baseParams BaseParams, managerUUID string) error {
+	return pauseDSort(baseParams, apc.URLPathdSortPause.S, managerUUID)
+}
+
+func ResumeDSort(baseParams BaseParams, managerUUID string) error {
+	return pauseDSort(baseParams, apc.URLPathdSortResume.S, managerUUID)
+}
+
+func pauseDSort(baseParams BaseParams, path, managerUUID string) error {
+	baseParams.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = path
+		reqParams.Query = url.Values{apc.QparamUUID: []string{managerUUID}}
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 func MetricsDSort(baseParams BaseParams, managerUUID string) (metrics map[string]*dsort.Metrics, err error) {
 	baseParams.Method = http.MethodGet
 	reqParams := AllocRp()
@@ -5,7 +5,6 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,7 +14,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/etl"
-	jsoniter "github.com/json-iterator/go"
 )
 
 func ETLInit(baseParams BaseParams, msg etl.InitMsg) (id string, err error) {
@@ -107,23 +105,7 @@ func ETLGetInitMsg(params BaseParams, id string) (initMsg etl.InitMsg, err error
 		return nil, fmt.Errorf("failed to read response, err: %w", err)
 	}
 
-	var msgInf map[string]json.RawMessage
-	if err = jsoniter.Unmarshal(b, &msgInf); err != nil {
-		return
-	}
-	if _, ok := msgInf["code"]; ok {
-		initMsg = &etl.InitCodeMsg{}
-		err = jsoniter.Unmarshal(b, initMsg)
-		return
-	}
-
-	if _, ok := msgInf["spec"]; !ok {
-		err = fmt.Errorf("invalid response body: %s", b)
-		return
-	}
-	initMsg = &etl.InitSpecMsg{}
-	err = jsoniter.Unmarshal(b, initMsg)
-	return
+	return etl.UnmarshalInitMsg(b)
 }
 
 func ETLStop(baseParams BaseParams, id string) (err error) {
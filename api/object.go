@@ -223,6 +223,24 @@ func SetObjectCustomProps(baseParams BaseParams, bck cmn.Bck, object string, cus
 	return err
 }
 
+// SetObjectLegalHold places (or removes) legal hold on the object; objects under legal hold
+// cannot be overwritten, deleted, renamed, or evicted (see cmn.ObjectLockConf).
+func SetObjectLegalHold(baseParams BaseParams, bck cmn.Bck, object string, hold bool) error {
+	actMsg := apc.ActionMsg{Action: apc.ActLegalHold, Value: hold}
+	baseParams.Method = http.MethodPatch
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Body = cos.MustMarshal(actMsg)
+		reqParams.Header = http.Header{cmn.HdrContentType: []string{cmn.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // DeleteObject deletes an object specified by bucket/object.
 func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -237,6 +255,38 @@ func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	return err
 }
 
+// DeleteObjectVersion deletes a given previous version of the object or, if
+// apc.ObjVerAll, all previous versions (ais:// buckets with version history - see versioning.max_history).
+// Deleting the object itself keeps its previous versions.
+// To GET a previous version, use GetObject with the apc.QparamObjVersion query parameter.
+func DeleteObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) error {
+	baseParams.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Query = bck.AddToQuery(url.Values{apc.QparamObjVersion: []string{version}})
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// DeleteObjectBypassGov deletes an object that is still retained by the bucket's
+// object lock in governance mode (see cmn.ObjectLockConf); requires admin access.
+func DeleteObjectBypassGov(baseParams BaseParams, bck cmn.Bck, object string) error {
+	baseParams.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Query = bck.AddToQuery(url.Values{apc.QparamBypassGov: []string{"true"}})
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // EvictObject evicts an object specified by bucket/object.
 func EvictObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -514,6 +564,24 @@ func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) e
 	return err
 }
 
+// RestoreObjectVersion makes a given previous version of the object current again;
+// the restored object gets a new version while its (prior) current version becomes
+// the most recent previous one.
+func RestoreObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) error {
+	baseParams.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, object)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActRestoreObjVer, Name: version})
+		reqParams.Header = http.Header{cmn.HdrContentType: []string{cmn.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // promote files and directories to ais objects
 func Promote(args *PromoteArgs) (xactID string, err error) {
 	actMsg := apc.ActionMsg{Action: apc.ActPromote, Name: args.SrcFQN}
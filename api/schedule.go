@@ -0,0 +1,67 @@
+// Package api provides AIStore API over HTTP(S)
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sched"
+)
+
+// AddSchedule adds a new or updates an existing (named) schedule.
+// When updating, the history of runs is preserved.
+func AddSchedule(baseParams BaseParams, spec *sched.Spec) error {
+	baseParams.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathSchedule.S
+		reqParams.Body = cos.MustMarshal(spec)
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// GetSchedules returns all schedules sorted by name.
+func GetSchedules(baseParams BaseParams) (entries []*sched.Entry, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathSchedule.S
+	}
+	err = reqParams.DoHTTPReqResp(&entries)
+	FreeRp(reqParams)
+	return entries, err
+}
+
+// GetSchedule returns a given schedule including its history of runs.
+func GetSchedule(baseParams BaseParams, name string) (entry *sched.Entry, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathSchedule.Join(name)
+	}
+	entry = &sched.Entry{}
+	err = reqParams.DoHTTPReqResp(entry)
+	FreeRp(reqParams)
+	return entry, err
+}
+
+func RemoveSchedule(baseParams BaseParams, name string) error {
+	baseParams.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathSchedule.Join(name)
+	}
+	err := reqParams.DoHTTPRequest()
+	FreeRp(reqParams)
+	return err
+}
@@ -53,6 +53,7 @@ type (
 		// max time to wait and other "non-filters"
 		Timeout time.Duration
 		Force   bool // force
+		DryRun  bool // (lifecycle, LRU) report only
 		// more filters
 		OnlyRunning bool // look only for running xactions
 	}
@@ -65,12 +66,18 @@ func StartXaction(baseParams BaseParams, args XactReqArgs) (id string, err error
 	}
 	xactMsg := xact.QueryMsg{Kind: args.Kind, Bck: args.Bck, DaemonID: args.DaemonID}
 	if args.Kind == apc.ActLRU {
-		ext := &xact.QueryMsgLRU{}
+		ext := &xact.QueryMsgLRU{DryRun: args.DryRun}
 		if args.Buckets != nil {
 			xactMsg.Buckets = args.Buckets
 			ext.Force = args.Force
 		}
 		xactMsg.Ext = ext
+	} else if args.Kind == apc.ActLifecycle {
+		xactMsg.Buckets = args.Buckets
+		xactMsg.Ext = &xact.QueryMsgLifecycle{DryRun: args.DryRun}
+	} else if args.Kind == apc.ActScrub {
+		xactMsg.Buckets = args.Buckets
+		xactMsg.Ext = &xact.QueryMsgScrub{Restart: args.Force}
 	} else if args.Kind == apc.ActStoreCleanup && args.Buckets != nil {
 		xactMsg.Buckets = args.Buckets
 	}
@@ -92,8 +99,23 @@ func StartXaction(baseParams BaseParams, args XactReqArgs) (id string, err error
 
 // AbortXaction aborts a given xact.
 func AbortXaction(baseParams BaseParams, args XactReqArgs) error {
+	return xactAction(baseParams, apc.ActXactStop, args)
+}
+
+// PauseXaction pauses a given (pausable) xact, or all pausable xactions that match
+// the kind and/or bucket; see also ResumeXaction.
+func PauseXaction(baseParams BaseParams, args XactReqArgs) error {
+	return xactAction(baseParams, apc.ActXactPause, args)
+}
+
+// ResumeXaction resumes a given paused xact (or xactions).
+func ResumeXaction(baseParams BaseParams, args XactReqArgs) error {
+	return xactAction(baseParams, apc.ActXactResume, args)
+}
+
+func xactAction(baseParams BaseParams, action string, args XactReqArgs) error {
 	msg := apc.ActionMsg{
-		Action: apc.ActXactStop,
+		Action: action,
 		Value:  xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck},
 	}
 	baseParams.Method = http.MethodPut
@@ -140,6 +162,23 @@ func QueryXactionSnaps(baseParams BaseParams, args XactReqArgs) (xs NodesXactMul
 	return xs, err
 }
 
+// GetJobHistory returns finished jobs that match the query, newest first
+// (see xact.HistQuery and cmn.JobHistoryConf).
+func GetJobHistory(baseParams BaseParams, query *xact.HistQuery) (recs []*xact.HistRecord, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = baseParams
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(query)
+		reqParams.Header = http.Header{cmn.HdrContentType: []string{cmn.ContentJSON}}
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatJobHistory}}
+	}
+	err = reqParams.DoHTTPReqResp(&recs)
+	FreeRp(reqParams)
+	return
+}
+
 // GetXactionStatus retrieves the status of the xact.
 func GetXactionStatus(baseParams BaseParams, args XactReqArgs) (status *nl.NotifStatus, err error) {
 	baseParams.Method = http.MethodGet
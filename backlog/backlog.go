@@ -0,0 +1,358 @@
+// Package backlog provides persistent (database-backed) queues of pending operations,
+// executed asynchronously and in order - e.g., bucket replication and event notifications.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package backlog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Queue
+//
+// Each record is first persisted in the local database (outside of any locks), so
+// that pending records survive restarts. Records are then routed to lanes (e.g., one
+// lane per destination bucket or webhook URL); each lane has its own limit on the
+// number of queued records and its own workers, so that a failing (or slow) lane
+// does not affect the others. Records with the same lane and key are always executed
+// by the same worker, in order.
+//
+// A failed batch is retried with exponential backoff, up to Conf.MaxRetries times;
+// errors marked as permanent (see Permanent) are not retried. Either way, records that
+// cannot be executed get dropped (see Args.Drop), and the worker proceeds to the next
+// batch.
+
+const (
+	retryMin = time.Second
+	retryMax = time.Minute
+)
+
+type (
+	// Conf is (re)evaluated upon every Push and every batch, to pick up configuration updates
+	Conf struct {
+		MaxQueue   int64         // max number of queued records per lane
+		MaxRetries int           // max number of retries of a failed batch (0 - unlimited)
+		BatchSize  int           // max number of records per Exec (default 1)
+		BatchTime  time.Duration // max time to accumulate a batch
+		Workers    int           // number of workers per lane (default 1)
+	}
+	Args struct {
+		DB         dbdriver.Driver
+		Collection string
+		Tag        string // log prefix (e.g., target ID)
+		Conf       func() Conf
+		// New allocates a record to load from the database
+		New func() interface{}
+		// Route returns the record's lane and ordering key
+		Route func(rec interface{}) (lane, key string)
+		// Exec executes a batch of records of a given lane
+		Exec func(lane string, recs []interface{}) error
+		// optional callbacks
+		Stamp func(rec interface{}, seq int64)    // upon Push, prior to persisting
+		Done  func(recs []interface{})            // executed
+		Drop  func(recs []interface{}, err error) // dropped due to permanent error or max retries
+		Error func(err error)                     // failed to execute (will retry)
+	}
+	Queue struct {
+		args   Args
+		lanes  map[string]*lane
+		stopCh *cos.StopCh
+		seq    atomic.Int64 // last used sequence number
+		queued atomic.Int64 // total, all lanes
+		mu     sync.RWMutex
+		wg     sync.WaitGroup
+	}
+
+	item struct {
+		rec interface{}
+		key string // db key
+	}
+	lane struct {
+		q       *Queue
+		name    string
+		workers []*worker
+		queued  atomic.Int64
+	}
+	worker struct {
+		l       *lane
+		items   []*item
+		kick    chan struct{}
+		mu      sync.Mutex
+		retry   time.Duration
+		retries int
+	}
+
+	errPermanent struct {
+		err error
+	}
+)
+
+// Permanent marks the error returned by Args.Exec as non-retriable
+func Permanent(err error) error { return &errPermanent{err} }
+
+func IsPermanent(err error) bool {
+	var e *errPermanent
+	return errors.As(err, &e)
+}
+
+func (e *errPermanent) Error() string { return e.err.Error() }
+func (e *errPermanent) Unwrap() error { return e.err }
+
+// db key: zero-padded sequence number (to preserve the order)
+func DBKey(seq int64) string { return fmt.Sprintf("%020d", seq) }
+
+func New(args *Args) *Queue {
+	return &Queue{args: *args, lanes: make(map[string]*lane, 4), stopCh: cos.NewStopCh()}
+}
+
+// Load loads pending records (upon startup) and returns their number
+func (q *Queue) Load() (int, error) {
+	all, err := q.args.DB.GetAll(q.args.Collection, "")
+	if err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	keys := make([]string, 0, len(all))
+	for key := range all {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var n int
+	for _, k := range keys {
+		rec := q.args.New()
+		if err := jsoniter.UnmarshalFromString(all[k], rec); err != nil {
+			glog.Errorf("%s: failed to load %s record %q: %v", q.args.Tag, q.args.Collection, k, err)
+			continue
+		}
+		if seq, err := strconv.ParseInt(k, 10, 64); err == nil {
+			if seq > q.seq.Load() {
+				q.seq.Store(seq)
+			}
+			if q.args.Stamp != nil {
+				q.args.Stamp(rec, seq)
+			}
+		}
+		l, w := q.route(rec)
+		l.queued.Inc()
+		q.queued.Inc()
+		w.push(&item{rec: rec, key: k})
+		n++
+	}
+	if n > 0 {
+		q.mu.RLock()
+		for _, l := range q.lanes {
+			for _, w := range l.workers {
+				w.notify()
+			}
+		}
+		q.mu.RUnlock()
+	}
+	return n, nil
+}
+
+// Push persists and queues the record; returns false if the record was dropped
+// (the lane is full, or failed to persist)
+func (q *Queue) Push(rec interface{}) bool {
+	conf := q.args.Conf()
+	l, w := q.route(rec)
+	if n := l.queued.Inc(); conf.MaxQueue > 0 && n > conf.MaxQueue {
+		l.queued.Dec()
+		return false
+	}
+	seq := q.seq.Inc()
+	if q.args.Stamp != nil {
+		q.args.Stamp(rec, seq)
+	}
+	it := &item{rec: rec, key: DBKey(seq)}
+	if err := q.args.DB.Set(q.args.Collection, it.key, rec); err != nil {
+		l.queued.Dec()
+		glog.Errorf("%s: failed to persist %s record %s: %v", q.args.Tag, q.args.Collection, it.key, err)
+		return false
+	}
+	q.queued.Inc()
+	w.push(it)
+	w.notify()
+	return true
+}
+
+// Len returns the total number of queued records
+func (q *Queue) Len() int64 { return q.queued.Load() }
+
+func (q *Queue) Stop() {
+	q.stopCh.Close()
+	q.wg.Wait()
+}
+
+func (q *Queue) route(rec interface{}) (*lane, *worker) {
+	name, key := q.args.Route(rec)
+	q.mu.RLock()
+	l, ok := q.lanes[name]
+	q.mu.RUnlock()
+	if !ok {
+		q.mu.Lock()
+		if l, ok = q.lanes[name]; !ok {
+			l = q._newLane(name)
+		}
+		q.mu.Unlock()
+	}
+	return l, l.worker(key)
+}
+
+// under lock
+func (q *Queue) _newLane(name string) *lane {
+	num := cos.Max(q.args.Conf().Workers, 1)
+	l := &lane{q: q, name: name, workers: make([]*worker, num)}
+	for i := range l.workers {
+		l.workers[i] = &worker{l: l, kick: make(chan struct{}, 1)}
+		q.wg.Add(1)
+		go l.workers[i].run()
+	}
+	q.lanes[name] = l
+	return l
+}
+
+// remove executed (or dropped) records
+func (q *Queue) remove(l *lane, items []*item) {
+	for _, it := range items {
+		if err := q.args.DB.Delete(q.args.Collection, it.key); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to remove %s record %s: %v", q.args.Tag, q.args.Collection, it.key, err)
+		}
+	}
+	l.queued.Sub(int64(len(items)))
+	q.queued.Sub(int64(len(items)))
+}
+
+//////////
+// lane //
+//////////
+
+func (l *lane) worker(key string) *worker {
+	if len(l.workers) == 1 {
+		return l.workers[0]
+	}
+	i := xxhash.ChecksumString64S(key, cos.MLCG32) % uint64(len(l.workers))
+	return l.workers[i]
+}
+
+////////////
+// worker //
+////////////
+
+func (w *worker) notify() {
+	select {
+	case w.kick <- struct{}{}:
+	default:
+	}
+}
+
+func (w *worker) push(it *item) {
+	w.mu.Lock()
+	w.items = append(w.items, it)
+	w.mu.Unlock()
+}
+
+func (w *worker) len() int {
+	w.mu.Lock()
+	l := len(w.items)
+	w.mu.Unlock()
+	return l
+}
+
+func (w *worker) batch(size int) []*item {
+	w.mu.Lock()
+	items := w.items[:cos.Min(size, len(w.items))]
+	w.mu.Unlock()
+	return items
+}
+
+func (w *worker) pop(n int) {
+	w.mu.Lock()
+	for i := 0; i < n; i++ {
+		w.items[i] = nil
+	}
+	w.items = w.items[n:]
+	if len(w.items) == 0 {
+		w.items = nil // (release)
+	}
+	w.mu.Unlock()
+}
+
+func (w *worker) run() {
+	q := w.l.q
+	defer q.wg.Done()
+	for {
+		select {
+		case <-w.kick:
+		case <-q.stopCh.Listen():
+			return
+		}
+		for {
+			conf := q.args.Conf()
+			size := cos.Max(conf.BatchSize, 1)
+			// accumulate (up to batch size or batch time)
+			if size > 1 && conf.BatchTime > 0 && w.len() < size {
+				select {
+				case <-time.After(conf.BatchTime):
+				case <-q.stopCh.Listen():
+					return
+				}
+			}
+			items := w.batch(size)
+			if len(items) == 0 {
+				break
+			}
+			recs := make([]interface{}, len(items))
+			for i, it := range items {
+				recs[i] = it.rec
+			}
+			err := q.args.Exec(w.l.name, recs)
+			if err == nil {
+				w.retry, w.retries = 0, 0
+				q.remove(w.l, items)
+				w.pop(len(items))
+				if q.args.Done != nil {
+					q.args.Done(recs)
+				}
+				continue
+			}
+			if w.retries++; IsPermanent(err) || (conf.MaxRetries > 0 && w.retries > conf.MaxRetries) {
+				glog.Errorf("%s: dropping %d %s record%s (%s, attempts: %d): %v",
+					q.args.Tag, len(items), q.args.Collection, cos.Plural(len(items)), w.l.name, w.retries, err)
+				w.retry, w.retries = 0, 0
+				q.remove(w.l, items)
+				w.pop(len(items))
+				if q.args.Drop != nil {
+					q.args.Drop(recs, err)
+				}
+				continue
+			}
+			if q.args.Error != nil {
+				q.args.Error(err)
+			}
+			w.retry = cos.MinDuration(cos.MaxDuration(2*w.retry, retryMin), retryMax)
+			glog.Errorf("%s: failed to execute %d %s record%s (%s), retrying in %v: %v",
+				q.args.Tag, len(items), q.args.Collection, cos.Plural(len(items)), w.l.name, w.retry, err)
+			select {
+			case <-time.After(w.retry):
+			case <-q.stopCh.Listen():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package backlog provides persistent (database-backed) queues of pending operations,
+// executed asynchronously and in order - e.g., bucket replication and event notifications.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package backlog
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+const testCollection = "test"
+
+type (
+	testRec struct {
+		Lane string `json:"lane"`
+		Key  string `json:"key"`
+		N    int    `json:"n"`
+		seq  int64
+	}
+	// executes (or fails) test records
+	testExec struct {
+		fail    map[string]error // by lane
+		dropped atomic.Int64
+		mu      sync.Mutex
+		recs    []*testRec
+	}
+)
+
+func newDB(t *testing.T, dir string) dbdriver.Driver {
+	db, err := dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+	return db
+}
+
+func newQueue(db dbdriver.Driver, te *testExec, conf Conf) *Queue {
+	return New(&Args{
+		DB:         db,
+		Collection: testCollection,
+		Tag:        "t1",
+		Conf:       func() Conf { return conf },
+		New:        func() interface{} { return &testRec{} },
+		Route:      func(v interface{}) (string, string) { rec := v.(*testRec); return rec.Lane, rec.Key },
+		Exec:       te.exec,
+		Stamp:      func(v interface{}, seq int64) { v.(*testRec).seq = seq },
+		Drop:       func(recs []interface{}, _ error) { te.dropped.Add(int64(len(recs))) },
+	})
+}
+
+func (te *testExec) exec(lane string, recs []interface{}) error {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if err := te.fail[lane]; err != nil {
+		return err
+	}
+	for _, v := range recs {
+		te.recs = append(te.recs, v.(*testRec))
+	}
+	return nil
+}
+
+func (te *testExec) executed(lane string) (recs []*testRec) {
+	te.mu.Lock()
+	for _, rec := range te.recs {
+		if rec.Lane == lane {
+			recs = append(recs, rec)
+		}
+	}
+	te.mu.Unlock()
+	return
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	te := &testExec{}
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	q := newQueue(db, te, Conf{Workers: 4, BatchSize: 8, BatchTime: 10 * time.Millisecond})
+	for i := 0; i < 100; i++ {
+		tassert.Fatalf(t, q.Push(&testRec{Lane: "a", Key: strconv.Itoa(i % 5), N: i}), "failed to push %d", i)
+	}
+	waitFor(t, "100 records", func() bool { return len(te.executed("a")) == 100 })
+	q.Stop()
+
+	// per-key order
+	last := make(map[string]int, 5)
+	for _, rec := range te.executed("a") {
+		if prev, ok := last[rec.Key]; ok {
+			tassert.Errorf(t, prev < rec.N, "out of order: %d after %d (key %s)", rec.N, prev, rec.Key)
+		}
+		last[rec.Key] = rec.N
+	}
+	tassert.Errorf(t, q.Len() == 0, "expected empty queue, got %d", q.Len())
+	keys, err := db.List(testCollection, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected executed records to be removed, got %d", len(keys))
+}
+
+func TestQueueRestart(t *testing.T) {
+	te := &testExec{fail: map[string]error{"a": errors.New("unavailable")}}
+	dir := t.TempDir()
+
+	db := newDB(t, dir)
+	q := newQueue(db, te, Conf{MaxQueue: 10})
+	for i := 0; i < 15; i++ {
+		q.Push(&testRec{Lane: "a", N: i})
+	}
+	tassert.Errorf(t, q.Len() == 10, "expected 10 queued records, got %d", q.Len())
+	q.Stop()
+	tassert.CheckFatal(t, db.Close())
+
+	// "restart"
+	te = &testExec{}
+	db = newDB(t, dir)
+	defer db.Close()
+	q = newQueue(db, te, Conf{MaxQueue: 10})
+	n, err := q.Load()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 10, "expected 10 loaded records, got %d", n)
+	waitFor(t, "10 records", func() bool { return len(te.executed("a")) == 10 })
+
+	// sequence numbers continue where they left off
+	rec := &testRec{Lane: "a", N: 100}
+	q.Push(rec)
+	waitFor(t, "11 records", func() bool { return len(te.executed("a")) == 11 })
+	q.Stop()
+	for i, rec := range te.executed("a")[:10] {
+		tassert.Errorf(t, rec.N == i, "expected record %d, got %d", i, rec.N)
+	}
+	tassert.Errorf(t, rec.seq == 11, "unexpected sequence number %d", rec.seq)
+}
+
+func TestQueueDrop(t *testing.T) {
+	te := &testExec{fail: map[string]error{
+		"permanent": Permanent(errors.New("does not exist")),
+		"transient": errors.New("unavailable"),
+	}}
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	// (max retries: 1 => 2 attempts, 1s backoff)
+	q := newQueue(db, te, Conf{MaxRetries: 1})
+	for i := 0; i < 3; i++ {
+		q.Push(&testRec{Lane: "permanent", N: i})
+	}
+	q.Push(&testRec{Lane: "transient"})
+	q.Push(&testRec{Lane: "ok"})
+	waitFor(t, "dropped records", func() bool { return te.dropped.Load() == 4 })
+	q.Stop()
+
+	tassert.Errorf(t, len(te.executed("ok")) == 1, "expected healthy lane to be executed")
+	tassert.Errorf(t, q.Len() == 0, "expected empty queue, got %d", q.Len())
+	keys, err := db.List(testCollection, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected dropped records to be removed, got %d", len(keys))
+}
+
+// a failing lane (that has reached its max queue) does not affect the others
+func TestQueueLanes(t *testing.T) {
+	te := &testExec{fail: map[string]error{"dead": errors.New("unavailable")}}
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	q := newQueue(db, te, Conf{MaxQueue: 10})
+	for i := 0; i < 20; i++ {
+		q.Push(&testRec{Lane: "dead", N: i})
+	}
+	for i := 0; i < 10; i++ {
+		tassert.Fatalf(t, q.Push(&testRec{Lane: "ok", N: i}), "failed to push %d", i)
+	}
+	waitFor(t, "10 records", func() bool { return len(te.executed("ok")) == 10 })
+	q.Stop()
+	tassert.Errorf(t, q.Len() == 10, "expected 10 queued records, got %d", q.Len())
+}
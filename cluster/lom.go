@@ -42,6 +42,8 @@ type (
 		atimefs uint64 // high bit is reserved for `dirty`
 		bckID   uint64 // see ais/bucketmeta
 		copies  fs.MPI // ditto
+		acnt    uint64 // access count (warm GETs), see cmn.LRUPolicyLFU
+		acntfs  uint64 // access count as currently persisted
 	}
 	LOM struct {
 		md          lmeta             // local persistent metadata
@@ -119,6 +121,8 @@ func (lom *LOM) EqCksum(cksum *cos.Cksum) bool { return lom.md.Cksum.Equal(cksum
 func (lom *LOM) Atime() time.Time      { return time.Unix(0, lom.md.Atime) }
 func (lom *LOM) AtimeUnix() int64      { return lom.md.Atime }
 func (lom *LOM) SetAtimeUnix(tu int64) { lom.md.Atime = tu }
+func (lom *LOM) AccessCnt() uint64     { return lom.md.acnt }
+func (lom *LOM) IncAccessCnt()         { lom.md.acnt++ }
 
 // 946771140000000000 = time.Parse(time.RFC3339Nano, "2000-01-01T23:59:00Z").UnixNano()
 // and note that prefetch sets atime=-now
@@ -133,6 +137,31 @@ func (lom *LOM) SetCustomMD(md cos.SimpleKVs) { lom.md.SetCustomMD(md) }
 func (lom *LOM) GetCustomKey(key string) (string, bool) { return lom.md.GetCustomKey(key) }
 func (lom *LOM) SetCustomKey(key, value string)         { lom.md.SetCustomKey(key, value) }
 
+// object lock (see cmn.ObjectLockConf): returns cmn.ErrObjLocked if the (loaded) object is
+// under legal hold or unexpired retention - the latter can be bypassed in governance mode
+func (lom *LOM) CheckObjLock(bypass bool) error {
+	conf := &lom.Bprops().ObjectLock
+	if !conf.Enabled {
+		return nil
+	}
+	name := lom.bck.String() + "/" + lom.ObjName
+	if v, ok := lom.GetCustomKey(cmn.LegalHoldObjMD); ok && cos.IsParseBool(v) {
+		return cmn.NewErrObjLocked(name, "legal hold")
+	}
+	v, ok := lom.GetCustomKey(cmn.RetainUntilObjMD)
+	if !ok {
+		return nil
+	}
+	until, err := time.Parse(time.RFC3339, v)
+	if err != nil || !time.Now().Before(until) {
+		return nil
+	}
+	if bypass && conf.Mode == cmn.LockModeGovernance {
+		return nil
+	}
+	return cmn.NewErrObjLocked(name, conf.Mode+" retention until "+v)
+}
+
 // lom <= transport.ObjHdr (NOTE: caller must call freeLOM)
 func AllocLomFromHdr(hdr *transport.ObjHdr) (lom *LOM, err error) {
 	lom = AllocLOM(hdr.ObjName)
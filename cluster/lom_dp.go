@@ -49,11 +49,24 @@ func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
 	return nil, cmn.NewErrFailedTo(T, "open", lom.FQN, err)
 }
 
+// (encrypted) plaintext reader; is called under rlock
+func (lom *LOM) NewDeferPlainROC() (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
+	roc, oah, err := lom.PlainReader()
+	if err == nil {
+		return &deferROC{roc, lom.LIF()}, oah, nil
+	}
+	lom.Unlock(false)
+	return nil, nil, cmn.NewErrFailedTo(T, "open", lom, err)
+}
+
 // compare with etl/dp.go
 func (*LDP) Reader(lom *LOM) (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
 	lom.Lock(false)
 	loadErr := lom.Load(false /*cache it*/, true /*locked*/)
 	if loadErr == nil {
+		if lom.IsEncrypted() {
+			return lom.NewDeferPlainROC()
+		}
 		roc, err := lom.NewDeferROC()
 		return roc, lom, err
 	}
@@ -0,0 +1,114 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+// server-side encryption at rest (see package sse and cmn.EncryptionConf)
+
+// sseROC reads (and decrypts) the plaintext of an encrypted object
+type sseROC struct {
+	fh    *cos.FileHandle
+	r     *sse.Reader
+	fqn   string
+	key   []byte
+	nonce []byte
+	size  int64 // ciphertext
+}
+
+// interface guard
+var _ cos.ReadOpenCloser = (*sseROC)(nil)
+
+// IsEncrypted returns true if the object's content is encrypted at rest
+func (lom *LOM) IsEncrypted() bool {
+	_, ok := lom.GetCustomKey(cmn.SSEObjMD)
+	return ok
+}
+
+// SSEKey returns the encryption key and base nonce of the (encrypted) object
+func (lom *LOM) SSEKey() (key, nonce []byte, err error) {
+	var (
+		keyID string
+		md, _ = lom.GetCustomKey(cmn.SSEObjMD)
+	)
+	if keyID, nonce, err = sse.DecodeMD(md); err != nil {
+		return
+	}
+	key, err = sse.GetKey(&cmn.GCO.Get().KMS, keyID)
+	return
+}
+
+// PlainAttrs returns the attributes of the object's plaintext: size, no checksum
+// (the stored one is computed over ciphertext), and no encryption metadata
+func (lom *LOM) PlainAttrs() *cmn.ObjAttrs {
+	oa := &cmn.ObjAttrs{}
+	oa.CopyFrom(lom, true /*skip cksum*/)
+	oa.Size = sse.PlainSize(lom.SizeBytes())
+	oa.Cksum = cos.NoneCksum
+	oa.DelCustomKeys(cmn.SSEObjMD) // (a copy)
+	return oa
+}
+
+// NewPlainReader returns a reader of the plaintext range [off, off+length) of the
+// encrypted object; `ra` reads the object's (ciphertext) content
+func (lom *LOM) NewPlainReader(ra io.ReaderAt, off, length int64) (io.Reader, error) {
+	key, nonce, err := lom.SSEKey()
+	if err != nil {
+		return nil, err
+	}
+	return sse.NewReader(ra, lom.SizeBytes(), key, nonce, off, length)
+}
+
+// PlainReader returns a reader of the object's content - the plaintext if the object is
+// encrypted - and the corresponding attributes; is called under rlock
+func (lom *LOM) PlainReader() (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
+	if !lom.IsEncrypted() {
+		fh, err := cos.NewFileHandle(lom.FQN)
+		if err != nil {
+			return nil, nil, err
+		}
+		return fh, lom, nil
+	}
+	key, nonce, err := lom.SSEKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	roc := &sseROC{fqn: lom.FQN, key: key, nonce: nonce, size: lom.SizeBytes()}
+	if err := roc.open(); err != nil {
+		return nil, nil, err
+	}
+	return roc, lom.PlainAttrs(), nil
+}
+
+////////////
+// sseROC //
+////////////
+
+func (roc *sseROC) open() (err error) {
+	if roc.fh, err = cos.NewFileHandle(roc.fqn); err != nil {
+		return
+	}
+	if roc.r, err = sse.NewReader(roc.fh, roc.size, roc.key, roc.nonce, 0, -1); err != nil {
+		roc.fh.Close()
+	}
+	return
+}
+
+func (roc *sseROC) Read(p []byte) (int, error) { return roc.r.Read(p) }
+func (roc *sseROC) Close() error               { return roc.fh.Close() }
+
+func (roc *sseROC) Open() (cos.ReadOpenCloser, error) {
+	nroc := &sseROC{fqn: roc.fqn, key: roc.key, nonce: roc.nonce, size: roc.size}
+	if err := nroc.open(); err != nil {
+		return nil, err
+	}
+	return nroc, nil
+}
@@ -71,6 +71,7 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVerType, &fs.ObjVerContentResolver{})
 
 	bmd := mock.NewBaseBownerMock(
 		cluster.NewBck(
@@ -581,6 +582,98 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("Version history", func() {
+		testObject := "foldr/test-obj.ext"
+		localFQN := mis[0].MakePathFQN(&localBckA, fs.ObjectType, testObject)
+
+		// overwrite the object with a new version, keeping the current one in the history
+		putVersion := func(size int) *cluster.LOM {
+			lom := NewBasicLom(localFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			_, err := lom.ArchiveVersion()
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			createTestFile(localFQN, size)
+			lom.SetSize(int64(size))
+			Expect(lom.IncVersion()).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Uncache(true)
+			return lom
+		}
+
+		BeforeEach(func() {
+			fs.Disable(mpaths[1]) // Ensure that it matches localFQN
+			fs.Disable(mpaths[2])
+		})
+		AfterEach(func() {
+			fs.Enable(mpaths[1])
+			fs.Enable(mpaths[2])
+		})
+
+		It("should archive, list, and load previous versions", func() {
+			filePut(localFQN, 10)
+			putVersion(20)
+			lom := putVersion(30)
+			Expect(lom.Version()).To(Equal("3"))
+
+			vers, err := lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(Equal([]string{"2", "1"}))
+
+			vlom, err := lom.LoadVersion("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlom.Version()).To(Equal("1"))
+			Expect(vlom.SizeBytes()).To(BeEquivalentTo(10))
+			Expect(vlom.FQN).To(Equal(lom.VersionFQN("1")))
+			cluster.FreeLOM(vlom)
+
+			_, err = lom.LoadVersion("3")
+			Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+		})
+
+		It("should not list versions of other objects in the same directory", func() {
+			otherFQN := mis[0].MakePathFQN(&localBckA, fs.ObjectType, "foldr/test-obj")
+			other := filePut(otherFQN, 5)
+			other.Lock(true)
+			_, err := other.ArchiveVersion()
+			other.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			filePut(localFQN, 10)
+			lom := putVersion(20)
+			vers, err := lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(Equal([]string{"1"}))
+		})
+
+		It("should trim and delete versions", func() {
+			filePut(localFQN, 10)
+			for i := 0; i < 4; i++ {
+				putVersion(10 + i)
+			}
+			lom := NewBasicLom(localFQN)
+			size, err := lom.TrimVersions(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(10 + 10))
+			vers, err := lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(Equal([]string{"4", "3"}))
+
+			size, err = lom.DelVersion("3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(11))
+			_, err = lom.DelVersion("3")
+			Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+
+			_, err = lom.DelAllVersions()
+			Expect(err).NotTo(HaveOccurred())
+			vers, err = lom.ListVersions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vers).To(BeEmpty())
+		})
+	})
+
 	Describe("copy object methods", func() {
 		const (
 			testObjectName = "foldr/test-obj.ext"
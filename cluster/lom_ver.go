@@ -0,0 +1,215 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// LOM version history (ais:// buckets with versioning.max_history > 0)
+//
+// Previous versions are stored as fs.ObjVerType content on the same mountpath as the
+// object itself, each with its own (previous) metadata. Version history is neither
+// replicated nor erasure coded. Resilvering moves it to the object's (new) mountpath
+// (see MoveVersion), while rebalance does not: versions left behind on the object's
+// previous target are removed by the storage cleanup.
+//
+
+// unversioned objects (e.g., written prior to enabling versioning) are archived as "0"
+const lomUnversioned = "0"
+
+func (lom *LOM) VersionFQN(ver string) string { return fs.CSM.Gen(lom, fs.ObjVerType, ver) }
+
+// the directory that contains previous versions of the object and (all) its siblings
+func (lom *LOM) VersionDir() string { return filepath.Dir(lom.VersionFQN(lomUnversioned)) }
+
+// ListVersions returns previous versions of the object, newest first.
+// (to list versions of many objects, use VersionIndex instead)
+func (lom *LOM) ListVersions() (vers []string, err error) {
+	orig := filepath.Base(lom.ObjName)
+	err = readVersions(lom.VersionDir(), func(o, ver string) {
+		if o == orig {
+			vers = append(vers, ver)
+		}
+	})
+	sortVersions(vers)
+	return
+}
+
+// VersionIndex reads a given version directory (see VersionDir) in one pass and returns
+// previous versions of all the objects in it, indexed by object base name, newest first.
+func VersionIndex(dir string) (idx map[string][]string, err error) {
+	idx = make(map[string][]string)
+	err = readVersions(dir, func(o, ver string) {
+		idx[o] = append(idx[o], ver)
+	})
+	for _, vers := range idx {
+		sortVersions(vers)
+	}
+	return
+}
+
+func readVersions(dir string, cb func(orig, ver string)) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if o, ver, ok := fs.ParseObjVer(entry.Name()); ok {
+			cb(o, ver)
+		}
+	}
+	return nil
+}
+
+func sortVersions(vers []string) {
+	sort.Slice(vers, func(i, j int) bool { return verLess(vers[j], vers[i]) })
+}
+
+// LoadVersion returns previous version `ver` of the object; the caller must
+// take the object's lock and free the returned LOM.
+func (lom *LOM) LoadVersion(ver string) (*LOM, error) {
+	vlom := lom.CloneMD(lom.VersionFQN(ver))
+	vlom.md = lmeta{}
+	vlom.md.uname = lom.md.uname
+	if err := vlom.FromFS(); err != nil {
+		FreeLOM(vlom)
+		if os.IsNotExist(err) {
+			return nil, cmn.NewErrNotFound("%s: version %q", lom, ver)
+		}
+		return nil, err
+	}
+	vlom.md.bckID = lom.Bprops().BID
+	return vlom, nil
+}
+
+// ArchiveVersion moves the current version of the object (if exists) to the object's
+// version history; returns the resulting FQN, or empty string if there was nothing
+// to archive. The caller must hold the object's write lock.
+func (lom *LOM) ArchiveVersion() (verFQN string, err error) {
+	debug.AssertFunc(func() bool { _, exclusive := lom.IsLocked(); return exclusive })
+	var md *lmeta
+	if _, lmd := lom.fromCache(); lmd != nil {
+		md = &lmeta{}
+		*md = *lmd // (may be dirty)
+	} else if md, err = lom.lmfs(false); err != nil {
+		if os.IsNotExist(err) || cmn.IsErrLmetaNotFound(err) {
+			err = nil
+		}
+		return
+	}
+	ver := md.Ver
+	if ver == "" {
+		ver = lomUnversioned
+	}
+	md.copies = nil
+	verFQN = lom.VersionFQN(ver)
+	if err = cos.Rename(lom.FQN, verFQN); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return "", err
+	}
+	var (
+		mm  = T.ByteMM()
+		buf = md.marshal(mm, maxLmeta.Load())
+	)
+	err = fs.SetXattr(verFQN, XattrLOM, buf)
+	mm.Free(buf)
+	if err != nil {
+		// undo
+		if errN := os.Rename(verFQN, lom.FQN); errN != nil {
+			glog.Errorf("%s: failed to restore current version: %v", lom, errN)
+		}
+		return "", err
+	}
+	return
+}
+
+// TrimVersions removes all but `max` most recent previous versions
+// and returns their total size (e.g., for the storage quota).
+func (lom *LOM) TrimVersions(max int) (size int64, err error) {
+	vers, err := lom.ListVersions()
+	if err != nil || len(vers) <= max {
+		return
+	}
+	for _, ver := range vers[max:] {
+		n, errV := lom.DelVersion(ver)
+		if errV != nil && !cmn.IsErrNotFound(errV) {
+			err = errV
+		}
+		size += n
+	}
+	return
+}
+
+func (lom *LOM) DelVersion(ver string) (size int64, err error) {
+	fqn := lom.VersionFQN(ver)
+	fi, err := os.Stat(fqn)
+	if err == nil {
+		err = os.Remove(fqn)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, cmn.NewErrNotFound("%s: version %q", lom, ver)
+		}
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
+func (lom *LOM) DelAllVersions() (int64, error) { return lom.TrimVersions(0) }
+
+// ParseVerCT returns the name of the object and the version given fs.ObjVerType content.
+func ParseVerCT(ct *CT) (objName, ver string, ok bool) {
+	debug.Assert(ct.ContentType() == fs.ObjVerType)
+	dir, base := filepath.Split(ct.ObjectName())
+	orig, ver, ok := fs.ParseObjVer(base)
+	return dir + orig, ver, ok
+}
+
+// MoveVersion moves a single version file across mountpaths, along with its metadata;
+// the caller must hold the object's write lock.
+func MoveVersion(srcFQN, dstFQN string, buf []byte) error {
+	md, err := fs.GetXattr(srcFQN, XattrLOM)
+	if err != nil {
+		return err
+	}
+	if _, _, err = cos.CopyFile(srcFQN, dstFQN, buf, cos.ChecksumNone); err != nil {
+		return err
+	}
+	if err = fs.SetXattr(dstFQN, XattrLOM, md); err != nil {
+		if errN := cos.RemoveFile(dstFQN); errN != nil {
+			glog.Errorf("nested err: %v", errN)
+		}
+		return err
+	}
+	return cos.RemoveFile(srcFQN)
+}
+
+func verLess(a, b string) bool {
+	va, erra := strconv.ParseUint(a, 10, 64)
+	vb, errb := strconv.ParseUint(b, 10, 64)
+	if erra != nil || errb != nil {
+		return a < b
+	}
+	return va < vb
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -51,6 +52,9 @@ const (
 )
 
 // packing format internal attrs
+// (v1 fails to unmarshal unknown records, while v2 skips them; records added after v1
+// - e.g., access count - get written in v2 only, so that the absence of those keeps
+// the metadata readable by older binaries)
 const (
 	lomCksumType = iota
 	lomCksumValue
@@ -58,6 +62,7 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomAccessCnt
 )
 
 // packing format separators
@@ -72,6 +77,13 @@ const prefLen = 10 // 10B prefix [ version = 1 | checksum-type | 64-bit xxhash ]
 
 const getxattr = "getxattr" // syscall
 
+// persist access count (when uncaching, see flushCold) only if it has grown by at least
+// acntFlushMin, and by at least 1/acntFlushFrac of the (previously) persisted value
+const (
+	acntFlushMin  = 8
+	acntFlushFrac = 8
+)
+
 // used in tests
 func (lom *LOM) AcquireAtimefs() error {
 	_, atime, err := ios.FinfoAtime(lom.FQN)
@@ -179,6 +191,7 @@ func (lom *LOM) Persist() (err error) {
 		T.FSHC(err, lom.FQN)
 	} else {
 		lom.md.clearDirty()
+		lom.md.acntfs = lom.md.acnt
 		if lom.Bprops() != nil {
 			if !lom.IsCopy() {
 				lom.ReCache(lom.AtimeUnix() != 0)
@@ -210,7 +223,7 @@ func (lom *LOM) flushCold(md *lmeta, atime time.Time) {
 	if err := lom.flushAtime(atime); err != nil {
 		return
 	}
-	if !md.isDirty() || lom.WritePolicy() == apc.WriteNever {
+	if (!md.isDirty() && !md.acntChanged()) || lom.WritePolicy() == apc.WriteNever {
 		return
 	}
 	lom.md = *md
@@ -263,12 +276,20 @@ func (md *lmeta) makeDirty()    { md.atimefs |= lomDirtyMask }
 func (md *lmeta) clearDirty()   { md.atimefs &= ^lomDirtyMask }
 func (md *lmeta) isDirty() bool { return md.atimefs&lomDirtyMask == lomDirtyMask }
 
+func (md *lmeta) acntChanged() bool {
+	if md.acnt <= md.acntfs {
+		return false
+	}
+	delta := md.acnt - md.acntfs
+	return delta >= acntFlushMin && delta >= md.acntfs/acntFlushFrac
+}
+
 func (md *lmeta) pushrt() []uint64 {
-	return []uint64{uint64(md.Atime), md.atimefs, md.bckID}
+	return []uint64{uint64(md.Atime), md.atimefs, md.bckID, md.acnt}
 }
 
 func (md *lmeta) poprt(saved []uint64) {
-	md.Atime, md.atimefs, md.bckID = int64(saved[0]), saved[1], saved[2]
+	md.Atime, md.atimefs, md.bckID, md.acnt = int64(saved[0]), saved[1], saved[2], saved[3]
 }
 
 func (md *lmeta) unmarshal(buf []byte) error {
@@ -284,8 +305,9 @@ func (md *lmeta) unmarshal(buf []byte) error {
 	if len(buf) < prefLen {
 		return fmt.Errorf("%s: too short (%d)", invalid, len(buf))
 	}
-	if buf[0] != cmn.MetaverLOM {
-		return fmt.Errorf("%s: unknown version %d", invalid, buf[0])
+	ver := buf[0]
+	if ver != cmn.MetaverLOM && ver != cmn.MetaverLOMv2 {
+		return fmt.Errorf("%s: unknown version %d", invalid, ver)
 	}
 	if buf[1] != mdCksumTyXXHash {
 		return fmt.Errorf("%s: unknown checksum %d", invalid, buf[1])
@@ -369,8 +391,17 @@ func (md *lmeta) unmarshal(buf []byte) error {
 				custom[entries[i]] = entries[i+1]
 			}
 			md.SetCustomMD(custom)
+		case lomAccessCnt:
+			cnt, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return errors.New(invalid + " #6.1")
+			}
+			md.acnt, md.acntfs = cnt, cnt
 		default:
-			return errors.New(invalid + " #6")
+			if ver == cmn.MetaverLOM {
+				return errors.New(invalid + " #6")
+			}
+			// (skipping records added by newer versions)
 		}
 	}
 	if haveCksumType != haveCksumValue {
@@ -409,9 +440,16 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 		buf = _marshRecord(mm, buf, lomCustomMD, "", false)
 		buf = _marshCustomMD(mm, buf, custom)
 	}
+	if md.acnt > 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomAccessCnt, strconv.FormatUint(md.acnt, 10), false)
+	}
 
 	// checksum, prepend, and return
 	buf[0] = cmn.MetaverLOM
+	if md.acnt > 0 {
+		buf[0] = cmn.MetaverLOMv2
+	}
 	buf[1] = mdCksumTyXXHash
 	mdCksumValue := xxhash.Checksum64S(buf[prefLen:], cos.MLCG32)
 	binary.BigEndian.PutUint64(buf[2:], mdCksumValue)
@@ -470,4 +508,7 @@ func (md *lmeta) cpAtime(from *lmeta) {
 	if !isValidAtime(md.Atime) || (md.Atime > 0 && md.Atime < from.Atime) {
 		md.Atime = from.Atime
 	}
+	if md.acnt < from.acnt {
+		md.acnt = from.acnt
+	}
 }
@@ -5,6 +5,7 @@
 package cluster_test
 
 import (
+	"encoding/binary"
 	"os"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -13,6 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -269,6 +271,62 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom1.GetCopies()).To(BeEquivalentTo(lom2.GetCopies()))
 			})
 
+			It("should read access count from fs", func() {
+				createTestFile(localFQN, testFileSize)
+				lom1 := NewBasicLom(localFQN)
+				lom2 := NewBasicLom(localFQN)
+				lom1.Lock(true)
+				defer lom1.Unlock(true)
+				for i := 0; i < 300; i++ {
+					lom1.IncAccessCnt()
+				}
+				Expect(persist(lom1)).NotTo(HaveOccurred())
+
+				err := lom2.LoadMetaFromFS()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lom2.AccessCnt()).To(BeEquivalentTo(300))
+
+				// (older binaries must be able to read metadata without access count)
+				b, err := fs.GetXattr(localFQN, cluster.XattrLOM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(b[0]).To(BeEquivalentTo(cmn.MetaverLOMv2))
+			})
+
+			It("should write v1 metadata unless it has access count", func() {
+				createTestFile(localFQN, testFileSize)
+				lom := NewBasicLom(localFQN)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				lom.SetVersion("dummy_version")
+				Expect(persist(lom)).NotTo(HaveOccurred())
+
+				b, err := fs.GetXattr(localFQN, cluster.XattrLOM)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(b[0]).To(BeEquivalentTo(cmn.MetaverLOM))
+			})
+
+			It("should skip unknown records in v2 (only)", func() {
+				createTestFile(localFQN, testFileSize)
+				lom := NewBasicLom(localFQN)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				lom.SetVersion("dummy_version")
+				Expect(persist(lom)).NotTo(HaveOccurred())
+
+				b, err := fs.GetXattr(localFQN, cluster.XattrLOM)
+				Expect(err).NotTo(HaveOccurred())
+				b = append(b, "\xe3/\xbd\x00\x64future"...) // record separator, key 100, value
+				binary.BigEndian.PutUint64(b[2:], xxhash.Checksum64S(b[10:], cos.MLCG32))
+
+				Expect(fs.SetXattr(localFQN, cluster.XattrLOM, b)).NotTo(HaveOccurred())
+				Expect(lom.LoadMetaFromFS()).To(MatchError("invalid lmeta #6"))
+
+				b[0] = cmn.MetaverLOMv2
+				Expect(fs.SetXattr(localFQN, cluster.XattrLOM, b)).NotTo(HaveOccurred())
+				Expect(lom.LoadMetaFromFS()).NotTo(HaveOccurred())
+				Expect(lom.Version(true)).To(Equal("dummy_version"))
+			})
+
 			Describe("error cases", func() {
 				var lom *cluster.LOM
 
@@ -85,7 +85,7 @@ func (bd *DBDriver) List(collection, pattern string) ([]string, error) {
 		if strings.HasPrefix(k, filter) {
 			_, key := dbdriver.ParsePath(k)
 			if key != "" {
-				keys = append(keys, k)
+				keys = append(keys, key)
 			}
 		}
 	}
@@ -51,6 +51,11 @@ type (
 		Abort(error) bool
 		AddNotif(n Notif)
 
+		// pause/resume (see xact.Table: Pausable)
+		Pause() error
+		Resume() error
+		IsPaused() bool
+
 		// common stats
 		Objs() int64
 		ObjsAdd(int, int64)    // locally processed
@@ -21,6 +21,7 @@ type (
 		HeadObject(objName string) (obj *Object, exists bool, err error)
 		ListObjects(prefix, token string, pageSize uint) (objs []*Object, nextToken string, err error)
 		DeleteObject(objName string) (err error)
+		RenameObject(oldName, newName string) (err error)
 	}
 
 	bucketAPI struct {
@@ -87,3 +88,11 @@ func (bck *bucketAPI) DeleteObject(objName string) (err error) {
 	}
 	return
 }
+
+func (bck *bucketAPI) RenameObject(oldName, newName string) (err error) {
+	err = api.RenameObject(bck.apiParams, bck.Bck(), oldName, newName)
+	if err != nil {
+		err = newBucketIOError(err, "RenameObject", oldName)
+	}
+	return
+}
@@ -38,12 +38,13 @@ func NewObject(objName string, bucket Bucket, sizes ...int64) *Object {
 
 func (obj *Object) Bck() cmn.Bck { return obj.bck }
 
-func (obj *Object) Put(r cos.ReadOpenCloser) (err error) {
+func (obj *Object) Put(r cos.ReadOpenCloser, size int64) (err error) {
 	putArgs := api.PutObjectArgs{
 		BaseParams: obj.apiParams,
 		Bck:        obj.bck,
 		Object:     obj.Name,
 		Reader:     r,
+		Size:       uint64(size),
 	}
 	err = api.PutObject(putArgs)
 	if err != nil {
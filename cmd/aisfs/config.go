@@ -44,7 +44,8 @@ type (
 		DebugFile string `json:"debug_file"`
 	}
 	IOConfig struct {
-		WriteBufSize int64 `json:"write_buf_size"`
+		WriteBufSize   int64  `json:"write_buf_size"`
+		WriteCacheSize string `json:"write_cache_size"`
 	}
 )
 
@@ -71,6 +72,10 @@ var defaultConfig = Config{
 		// Determines the size of chunks that we write with append. The only exception
 		// when we write less is Flush (end-of-file).
 		WriteBufSize: cos.MiB,
+		// Max total size of the data written at random offsets (or overwritten in place)
+		// that is buffered in memory before being written back. Zero means no limit:
+		// dirty files are written back only upon fsync and close.
+		WriteCacheSize: "256MB",
 	},
 	// By default we allow unlimited memory to be used by the cache.
 	MemoryLimit: "0B",
@@ -95,6 +100,11 @@ func (c *Config) validate() (err error) {
 	if c.IO.WriteBufSize < 0 {
 		return fmt.Errorf("invalid io.write_buf_size value: %d: expected non-negative value", c.IO.WriteBufSize)
 	}
+	if v, err := cos.S2B(c.IO.WriteCacheSize); err != nil {
+		return fmt.Errorf("invalid io.write_cache_size value: %q: %v", c.IO.WriteCacheSize, err)
+	} else if v < 0 {
+		return fmt.Errorf("invalid io.write_cache_size value: %q: expected non-negative value", c.IO.WriteCacheSize)
+	}
 	if v, err := cos.S2B(c.MemoryLimit); err != nil {
 		return fmt.Errorf("invalid memory_limit value: %q: %v", c.MemoryLimit, err)
 	} else if v < 0 {
@@ -105,12 +115,14 @@ func (c *Config) validate() (err error) {
 
 func (c *Config) writeTo(srvCfg *fs.ServerConfig) {
 	memoryLimit, _ := cos.S2B(c.MemoryLimit)
+	writeCacheSize, _ := cos.S2B(c.IO.WriteCacheSize)
 	srvCfg.SkipVerifyCrt = c.Cluster.SkipVerifyCrt
 	srvCfg.TCPTimeout = c.Timeout.TCPTimeout
 	srvCfg.HTTPTimeout = c.Timeout.HTTPTimeout
 	srvCfg.SyncInterval.Store(c.Periodic.SyncInterval)
 	srvCfg.MemoryLimit.Store(uint64(memoryLimit))
 	srvCfg.MaxWriteBufSize.Store(c.IO.WriteBufSize)
+	srvCfg.WriteCacheSize.Store(writeCacheSize)
 }
 
 func loadConfig(bucket string) (cfg *Config, err error) {
@@ -51,6 +51,10 @@ func (b *blockBuffer) Free() {
 	b.sgl.Free()
 }
 
+func (b *blockBuffer) Invalidate() {
+	b.valid = false
+}
+
 func (b *blockBuffer) EnsureBlock(blockNo int64, loadBlock loadBlockFunc) (err error) {
 	cos.Assert(b.sgl != nil)
 	if !b.valid || b.blockNo != blockNo {
@@ -68,6 +68,7 @@ type (
 		SyncInterval    atomic.Duration
 		MemoryLimit     atomic.Uint64
 		MaxWriteBufSize atomic.Int64
+		WriteCacheSize  atomic.Int64 // max total size of dirty (not yet written back) data
 	}
 
 	// File system implementation.
@@ -302,6 +303,12 @@ func (fs *aisfs) SetInodeAttributes(_ context.Context, req *fuseops.SetInodeAttr
 	fs.mu.RUnlock()
 
 	inode.Lock()
+	if file, ok := inode.(*FileInode); ok && req.Size != nil {
+		if err = file.Truncate(int64(*req.Size)); err != nil {
+			inode.Unlock()
+			return fs.handleIOError(err)
+		}
+	}
 	updReq := &AttrUpdateReq{
 		Mode:  req.Mode,
 		Size:  req.Size,
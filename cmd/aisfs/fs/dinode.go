@@ -56,6 +56,14 @@ func (dir *DirectoryInode) UpdateAttributes(req *AttrUpdateReq) fuseops.InodeAtt
 	return attrs
 }
 
+// REQUIRES_LOCK(dir), REQUIRES_LOCK(fs.mu)
+func (dir *DirectoryInode) rename(path string, parent *DirectoryInode) {
+	dir.setPath(path)
+	if parent != nil {
+		dir.parent = parent
+	}
+}
+
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) NewFileEntry(entryName string, id fuseops.InodeID, object *ais.Object) {
 	entryName = path.Join(dir.Path(), entryName)
@@ -108,7 +116,7 @@ func (dir *DirectoryInode) InvalidateInode(entryName string, isDir bool) {
 
 func (dir *DirectoryInode) LinkNewFile(fileName string) (*ais.Object, error) {
 	obj := ais.NewObject(fileName, dir.bucket)
-	err := obj.Put(cos.NopOpener(io.NopCloser(bytes.NewReader([]byte{}))))
+	err := obj.Put(cos.NopOpener(io.NopCloser(bytes.NewReader([]byte{}))), 0)
 	if err != nil {
 		obj = nil
 	}
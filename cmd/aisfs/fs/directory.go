@@ -6,7 +6,12 @@ package fs
 
 import (
 	"context"
+	"path"
+	"sort"
+	"strings"
+	"syscall"
 
+	"github.com/NVIDIA/aistore/cmd/aisfs/ais"
 	"github.com/jacobsa/fuse"
 	"github.com/jacobsa/fuse/fuseops"
 	"github.com/jacobsa/fuse/fuseutil"
@@ -113,3 +118,192 @@ func (fs *aisfs) RmDir(_ context.Context, req *fuseops.RmDirOp) (err error) {
 	parent.ForgetDir(req.Name)
 	return
 }
+
+// Rename renames a file or a directory. Renaming a directory renames all the
+// objects under it one by one - the operation is not atomic.
+func (fs *aisfs) Rename(_ context.Context, req *fuseops.RenameOp) (err error) {
+	fs.mu.RLock()
+	oldParent := fs.lookupDirMustExist(req.OldParent)
+	newParent := fs.lookupDirMustExist(req.NewParent)
+	fs.mu.RUnlock()
+
+	src := oldParent.LookupEntry(req.OldName)
+	if src.NoEntry() {
+		return fuse.ENOENT
+	}
+	var (
+		isDir   = src.IsDir()
+		oldPath = path.Join(oldParent.Path(), req.OldName)
+		newPath = path.Join(newParent.Path(), req.NewName)
+	)
+	if isDir {
+		oldPath += separator
+		newPath += separator
+		if strings.HasPrefix(newPath, oldPath) {
+			// Cannot make a directory a subdirectory of itself.
+			return syscall.EINVAL
+		}
+	}
+	if dst := newParent.LookupEntry(req.NewName); !dst.NoEntry() {
+		switch {
+		case isDir && !dst.IsDir():
+			return fuse.ENOTDIR
+		case !isDir && dst.IsDir():
+			return syscall.EISDIR
+		case isDir && !isEmptyDir(newPath):
+			return fuse.ENOTEMPTY
+		}
+	}
+
+	// Write back dirty files before renaming the objects.
+	inodes := fs.lookupInodes(oldPath, isDir)
+	for _, inode := range inodes {
+		if file, ok := inode.(*FileInode); ok {
+			file.Lock()
+			err = file.WriteBack()
+			file.Unlock()
+			if err != nil {
+				return fs.handleIOError(err)
+			}
+		}
+	}
+
+	var objs []*ais.Object
+	if isDir {
+		objs, err = fs.renameDirObjects(oldPath, newPath)
+	} else {
+		err = fs.bck.RenameObject(oldPath, newPath)
+	}
+	if err != nil {
+		return fs.handleIOError(err)
+	}
+
+	// Update inodes that are known to the kernel: the renamed one and,
+	// in case of directory, all inodes under it.
+	ids := make(map[string]fuseops.InodeID, len(inodes))
+	for _, inode := range inodes {
+		inode.Lock()
+		fs.mu.Lock()
+		var (
+			p      = newPath + inode.Path()[len(oldPath):]
+			parent *DirectoryInode
+		)
+		if inode.Path() == oldPath {
+			parent = newParent
+		}
+		switch in := inode.(type) {
+		case *FileInode:
+			in.rename(p, parent)
+			if !isDir {
+				obj := in.object
+				objs = append(objs, &obj)
+			}
+		case *DirectoryInode:
+			in.rename(p, parent)
+		}
+		fs.mu.Unlock()
+		inode.Unlock()
+		ids[p] = inode.ID()
+	}
+	if !isDir && len(objs) == 0 {
+		obj := *src.Object
+		obj.Name = newPath
+		objs = append(objs, &obj)
+	}
+
+	// Update the namespace.
+	fs.lockParents(oldParent, newParent)
+	if isDir {
+		oldParent.ForgetDir(req.OldName)
+		newParent.NewDirEntry(req.NewName, idOrInvalid(ids, newPath))
+		for p, id := range ids {
+			if strings.HasSuffix(p, separator) && p != newPath {
+				ns.add(entryDirTy, dtAttrs{id: id, path: p})
+			}
+		}
+		for _, obj := range objs {
+			ns.add(entryFileTy, dtAttrs{id: idOrInvalid(ids, obj.Name), path: obj.Name, obj: obj})
+		}
+	} else {
+		oldParent.ForgetFile(req.OldName)
+		newParent.NewFileEntry(req.NewName, idOrInvalid(ids, newPath), objs[0])
+	}
+	fs.unlockParents(oldParent, newParent)
+	return
+}
+
+// Returns inodes (ordered by ID) that map to a given path or, in case of
+// directory, are located under it.
+// READ_LOCKS(fs.mu)
+func (fs *aisfs) lookupInodes(p string, isDir bool) (inodes []Inode) {
+	fs.mu.RLock()
+	for _, inode := range fs.inodeTable {
+		if inode.Path() == p || (isDir && strings.HasPrefix(inode.Path(), p)) {
+			inodes = append(inodes, inode)
+		}
+	}
+	fs.mu.RUnlock()
+	sort.Slice(inodes, func(i, j int) bool { return inodes[i].ID() < inodes[j].ID() })
+	return
+}
+
+// Renames all objects with prefix `oldPath`; returns renamed objects.
+func (fs *aisfs) renameDirObjects(oldPath, newPath string) ([]*ais.Object, error) {
+	var (
+		objs  []*ais.Object
+		token string
+	)
+	for {
+		page, nextToken, err := fs.bck.ListObjects(oldPath, token, listObjsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, page...)
+		if nextToken == "" {
+			break
+		}
+		token = nextToken
+	}
+	for _, obj := range objs {
+		newName := newPath + obj.Name[len(oldPath):]
+		if err := fs.bck.RenameObject(obj.Name, newName); err != nil {
+			return nil, err
+		}
+		obj.Name = newName
+	}
+	return objs, nil
+}
+
+// LOCKS(oldParent, newParent)
+func (*aisfs) lockParents(oldParent, newParent *DirectoryInode) {
+	switch {
+	case oldParent == newParent:
+		oldParent.Lock()
+	case oldParent.ID() < newParent.ID():
+		oldParent.Lock()
+		newParent.Lock()
+	default:
+		newParent.Lock()
+		oldParent.Lock()
+	}
+}
+
+func (*aisfs) unlockParents(oldParent, newParent *DirectoryInode) {
+	oldParent.Unlock()
+	if newParent != oldParent {
+		newParent.Unlock()
+	}
+}
+
+func isEmptyDir(p string) (empty bool) {
+	empty = true
+	ns.listEntries(p, func(nsEntry) { empty = false })
+	return
+}
+
+func idOrInvalid(ids map[string]fuseops.InodeID, p string) fuseops.InodeID {
+	if id, ok := ids[p]; ok {
+		return id
+	}
+	return invalidInodeID
+}
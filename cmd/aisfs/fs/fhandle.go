@@ -5,7 +5,6 @@
 package fs
 
 import (
-	"fmt"
 	"io"
 	"sync"
 
@@ -21,6 +20,7 @@ type fileHandle struct {
 	// File inode that this handle is tied to
 	file     *FileInode
 	fileSize int64
+	gen      uint64 // file's generation (see FileInode.Gen)
 
 	// Guard
 	mu sync.Mutex
@@ -41,6 +41,7 @@ func newFileHandle(id fuseops.HandleID, file *FileInode) *fileHandle {
 		id:       id,
 		file:     file,
 		fileSize: int64(file.Size()),
+		gen:      file.Gen(),
 	}
 }
 
@@ -77,16 +78,33 @@ func (fh *fileHandle) ensureReadBuffer() int64 {
 	return blockSize
 }
 
-// LOCKS(fh.mu)
+// LOCKS(fh.mu), READ_LOCKS(fh.file)
 func (fh *fileHandle) readChunk(dst []byte, offset int64) (n int, err error) {
-	if offset >= fh.fileSize {
-		return 0, io.EOF
-	}
-
 	// Lock the handler in order to read
 	fh.mu.Lock()
 	defer fh.mu.Unlock()
 
+	fh.file.RLock()
+	if fh.file.IsDirty() {
+		// Read through the write-back cache.
+		n, err = fh.file.ReadAt(dst, offset)
+		fh.file.RUnlock()
+		return
+	}
+	if gen := fh.file.Gen(); gen != fh.gen {
+		// The content has changed since the last read.
+		fh.gen = gen
+		fh.fileSize = int64(fh.file.Size())
+		if fh.readBuffer != nil {
+			fh.readBuffer.Invalidate()
+		}
+	}
+	fh.file.RUnlock()
+
+	if offset >= fh.fileSize {
+		return 0, io.EOF
+	}
+
 	// Ensure that buffer is ready for reading
 	blockSize := fh.ensureReadBuffer()
 	dstLen := len(dst)
@@ -167,25 +185,51 @@ func (fh *fileHandle) _writeChunk(data []byte, maxWriteBufSize int64, force bool
 	return nil
 }
 
-func (fh *fileHandle) writeChunk(data []byte, offset uint64, maxWriteBufSize int64) (err error) {
+// LOCKS(fh.mu), LOCKS(fh.file)
+func (fh *fileHandle) writeChunk(data []byte, offset uint64, maxWriteBufSize, maxCacheSize int64) (err error) {
 	fh.mu.Lock()
 	defer fh.mu.Unlock()
 
-	if offset != fh.wsize {
-		return fmt.Errorf("write file (inode %d): random access write not yet implemented", fh.file.ID())
+	// Sequential writes into an empty file are appended to the object as they come.
+	fh.file.RLock()
+	appending := !fh.file.IsDirty() && offset == fh.wsize && (fh.wsize > 0 || fh.file.Size() == 0)
+	fh.file.RUnlock()
+	if appending {
+		return fh._writeChunk(data, maxWriteBufSize, false /*force*/)
 	}
 
-	return fh._writeChunk(data, maxWriteBufSize, false /*force*/)
+	// Otherwise, finish appending (if started) and buffer the write
+	// in the file's write-back cache.
+	if err = fh._flush(); err != nil {
+		return err
+	}
+	fh.file.Lock()
+	err = fh.file.WriteAt(data, int64(offset), maxCacheSize)
+	fh.file.Unlock()
+	return err
 }
 
 /////////////////////////
 // READING AND WRITING //
 /////////////////////////
 
-func (fh *fileHandle) flush() error {
+// flush finishes appending (if started) and writes back the file (if dirty).
+// LOCKS(fh.mu), LOCKS(fh.file)
+func (fh *fileHandle) flush() (err error) {
 	fh.mu.Lock()
 	defer fh.mu.Unlock()
 
+	if err = fh._flush(); err != nil {
+		return err
+	}
+	fh.file.Lock()
+	err = fh.file.WriteBack()
+	fh.file.Unlock()
+	return err
+}
+
+// REQUIRES_LOCK(fh.mu)
+func (fh *fileHandle) _flush() error {
 	if !fh.dirty {
 		return nil
 	}
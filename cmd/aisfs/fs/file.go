@@ -99,7 +99,7 @@ func (fs *aisfs) WriteFile(_ context.Context, req *fuseops.WriteFileOp) (err err
 	handle := fs.lookupFhandleMustExist(req.Handle)
 	fs.mu.RUnlock()
 
-	err = handle.writeChunk(req.Data, uint64(req.Offset), fs.cfg.MaxWriteBufSize.Load())
+	err = handle.writeChunk(req.Data, uint64(req.Offset), fs.cfg.MaxWriteBufSize.Load(), fs.cfg.WriteCacheSize.Load())
 	if err != nil {
 		return fs.handleIOError(err)
 	}
@@ -117,6 +117,17 @@ func (fs *aisfs) FlushFile(_ context.Context, req *fuseops.FlushFileOp) (err err
 	return
 }
 
+func (fs *aisfs) SyncFile(_ context.Context, req *fuseops.SyncFileOp) (err error) {
+	fs.mu.RLock()
+	handle := fs.lookupFhandleMustExist(req.Handle)
+	fs.mu.RUnlock()
+
+	if err = handle.flush(); err != nil {
+		return fs.handleIOError(err)
+	}
+	return
+}
+
 func (fs *aisfs) ReleaseFileHandle(_ context.Context, req *fuseops.ReleaseFileHandleOp) (err error) {
 	fs.mu.Lock()
 
@@ -24,6 +24,13 @@ type FileInode struct {
 	// Object used by current inode. When possible it should be updated with
 	// newer version.
 	object ais.Object
+
+	// Dirty (not yet written back) content, if any.
+	wb *wbCache
+
+	// Incremented every time the content of the file changes, so that
+	// file handles can invalidate their read buffers.
+	gen uint64
 }
 
 func NewFileInode(id fuseops.InodeID, attrs fuseops.InodeAttributes, parent *DirectoryInode, object *ais.Object) Inode {
@@ -81,12 +88,34 @@ func (file *FileInode) UpdateAttributes(req *AttrUpdateReq) fuseops.InodeAttribu
 	return attrs
 }
 
+// REQUIRES_LOCK(file), REQUIRES_LOCK(fs.mu)
+func (file *FileInode) rename(path string, parent *DirectoryInode) {
+	file.setPath(path)
+	file.object.Name = path
+	if parent != nil {
+		file.parent = parent
+	}
+}
+
+// REQUIRES_READ_LOCK(file)
+func (file *FileInode) Gen() uint64 {
+	return file.gen
+}
+
+// REQUIRES_READ_LOCK(file)
+func (file *FileInode) IsDirty() bool {
+	return file.wb != nil
+}
+
 // REQUIRES_LOCK(file)
 func (file *FileInode) UpdateBackingObject(obj *ais.Object) {
-	// Only update object if it is newer
-	if file.object.Atime.After(obj.Atime) {
+	// Only update object if it is newer and the local content is not dirty
+	if file.object.Atime.After(obj.Atime) || file.wb != nil {
 		return
 	}
+	if file.object.Size != obj.Size || !file.object.Atime.Equal(obj.Atime) {
+		file.gen++
+	}
 
 	size := uint64(obj.Size)
 	updReq := &AttrUpdateReq{
@@ -100,6 +129,12 @@ func (file *FileInode) UpdateBackingObject(obj *ais.Object) {
 // READING //
 /////////////
 
+// ReadAt reads dirty file (see IsDirty).
+// REQUIRES_READ_LOCK(file)
+func (file *FileInode) ReadAt(p []byte, offset int64) (n int, err error) {
+	return file.wb.readAt(p, offset, file.object.GetChunk)
+}
+
 // REQUIRES_READ_LOCK(file)
 func (file *FileInode) Load(w io.Writer, offset, length int64) (n int64, err error) {
 	n, err = file.object.GetChunk(w, offset, length)
@@ -136,5 +171,66 @@ func (file *FileInode) Flush(handle string, cksum *cos.Cksum) error {
 	file.object.Atime = now
 	file.attrs.Atime = now
 	file.attrs.Mtime = now
+	file.gen++
+	return nil
+}
+
+// WriteAt buffers the data in the write-back cache; the file gets written back
+// when the total size of all dirty pages exceeds maxCacheSize (when positive).
+// REQUIRES_LOCK(file)
+func (file *FileInode) WriteAt(p []byte, offset, maxCacheSize int64) error {
+	if file.wb == nil {
+		file.wb = newWBCache(file.object.Size)
+	}
+	if err := file.wb.write(p, offset, file.object.GetChunk); err != nil {
+		return err
+	}
+	file.touch(file.wb.size)
+	if maxCacheSize > 0 && wbDirty.Load() > maxCacheSize {
+		return file.WriteBack()
+	}
+	return nil
+}
+
+// Truncate changes the size of the file and writes it back.
+// REQUIRES_LOCK(file)
+func (file *FileInode) Truncate(size int64) error {
+	if file.wb == nil {
+		if size == file.object.Size {
+			return nil
+		}
+		file.wb = newWBCache(file.object.Size)
+	}
+	file.wb.truncate(size)
+	file.touch(size)
+	return file.WriteBack()
+}
+
+// WriteBack puts the dirty file, if any, as a new version of the object.
+// REQUIRES_LOCK(file)
+func (file *FileInode) WriteBack() error {
+	c := file.wb
+	if c == nil {
+		return nil
+	}
+	r := &wbReader{c: c, load: file.object.GetChunk}
+	err := file.object.Put(r, c.size)
+	r.Close()
+	if err != nil {
+		return err
+	}
+	file.object.Size = c.size
+	file.object.Atime = time.Now()
+	c.free()
+	file.wb = nil
 	return nil
 }
+
+// REQUIRES_LOCK(file)
+func (file *FileInode) touch(size int64) {
+	now := time.Now()
+	file.attrs.Size = uint64(size)
+	file.attrs.Mtime = now
+	file.attrs.Ctime = now
+	file.gen++
+}
@@ -75,6 +75,11 @@ func (in *baseInode) Path() string {
 	return in.path
 }
 
+// REQUIRES_LOCK(in), REQUIRES_LOCK(fs.mu)
+func (in *baseInode) setPath(path string) {
+	in.path = path
+}
+
 // Attributes returns inode's attributes (mode, size, atime...).
 // REQUIRES_READ_LOCK(in)
 func (in *baseInode) Attributes() (attrs fuseops.InodeAttributes) {
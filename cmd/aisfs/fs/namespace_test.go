@@ -61,6 +61,12 @@ func (bm *bucketMock) DeleteObject(objName string) (err error) {
 	return nil
 }
 
+func (bm *bucketMock) RenameObject(oldName, newName string) (err error) {
+	delete(bm.objs, oldName)
+	bm.objs[newName] = struct{}{}
+	return nil
+}
+
 var _ = Describe("Namespace", func() {
 	var (
 		bck *bucketMock
@@ -0,0 +1,283 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Write-back cache
+//
+// Sequential writes into an empty (new or truncated) file are streamed to the
+// cluster as appends (see fileHandle). All other writes - writes at random
+// offsets, overwrites in place, and truncation - are buffered in memory, per
+// file, as fixed-size dirty pages. A page that is only partially written is
+// first loaded from the object (read-modify-write).
+//
+// Reads of a dirty file are served from the dirty pages and, for the clean
+// ranges, from the object itself. Writing back (on fsync, close, truncate, or
+// when the total size of all dirty pages exceeds the configured limit) puts the
+// entire file as a new version of the object: dirty pages merged with the clean
+// ranges that are streamed from the current version.
+
+const wbPageSize = memsys.DefaultBufSize * 2
+
+var (
+	wbSlab  *memsys.Slab
+	wbDirty atomic.Int64 // total size of dirty pages (all files)
+)
+
+func init() {
+	var err error
+	wbSlab, err = glMem2.GetSlab(wbPageSize)
+	cos.AssertNoErr(err)
+}
+
+type (
+	wbCache struct {
+		pages map[int64][]byte // page number => dirty page
+		size  int64            // file size
+		// object content at or beyond `valid` is either truncated or
+		// does not exist (reads as zeros)
+		valid int64
+	}
+
+	// streams the entire (dirty) file
+	wbReader struct {
+		c    *wbCache
+		load loadBlockFunc
+		off  int64
+		// clean range that is being streamed from the object
+		pr  *io.PipeReader
+		end int64
+	}
+
+	// writes into a fixed-size slice
+	sliceWriter struct {
+		b []byte
+		n int
+	}
+)
+
+// interface guard
+var _ cos.ReadOpenCloser = (*wbReader)(nil)
+
+/////////////
+// wbCache //
+/////////////
+
+func newWBCache(size int64) *wbCache {
+	return &wbCache{pages: make(map[int64][]byte, 4), size: size, valid: size}
+}
+
+func (c *wbCache) dirtySize() int64 { return int64(len(c.pages)) * wbPageSize }
+
+func (c *wbCache) free() {
+	wbDirty.Sub(c.dirtySize())
+	for pno, page := range c.pages {
+		wbSlab.Free(page)
+		delete(c.pages, pno)
+	}
+}
+
+func (c *wbCache) write(p []byte, off int64, load loadBlockFunc) error {
+	for len(p) > 0 {
+		pno, poff := off/wbPageSize, off%wbPageSize
+		page, ok := c.pages[pno]
+		if !ok {
+			page = wbSlab.Alloc()
+			// read-modify-write unless the entire page gets overwritten
+			if poff != 0 || int64(len(p)) < wbPageSize {
+				if err := c.loadPage(page, pno, load); err != nil {
+					wbSlab.Free(page)
+					return err
+				}
+			}
+			c.pages[pno] = page
+			wbDirty.Add(wbPageSize)
+		}
+		n := copy(page[poff:], p)
+		p = p[n:]
+		off += int64(n)
+		if off > c.size {
+			c.size = off
+		}
+	}
+	return nil
+}
+
+// NOTE: bytes at or beyond c.size are always zeros in all dirty pages
+func (c *wbCache) loadPage(page []byte, pno int64, load loadBlockFunc) error {
+	for i := range page {
+		page[i] = 0
+	}
+	start := pno * wbPageSize
+	if start >= c.valid {
+		return nil
+	}
+	length := cos.MinI64(wbPageSize, c.valid-start)
+	w := &sliceWriter{b: page[:length]}
+	if n, err := load(w, start, length); err != nil {
+		return err
+	} else if n != length {
+		return fmt.Errorf("load page %d: expected %d bytes, got %d", pno, length, n)
+	}
+	return nil
+}
+
+func (c *wbCache) truncate(size int64) {
+	if size < c.size {
+		for pno, page := range c.pages {
+			start := pno * wbPageSize
+			switch {
+			case start >= size:
+				wbSlab.Free(page)
+				delete(c.pages, pno)
+				wbDirty.Sub(wbPageSize)
+			case start+wbPageSize > size:
+				for i := size - start; i < wbPageSize; i++ {
+					page[i] = 0
+				}
+			}
+		}
+		if size < c.valid {
+			c.valid = size
+		}
+	}
+	c.size = size
+}
+
+// end of the clean range that starts at `off`: the next dirty page or end of file
+func (c *wbCache) cleanEnd(off int64) int64 {
+	for pno := off/wbPageSize + 1; pno*wbPageSize < c.size; pno++ {
+		if _, ok := c.pages[pno]; ok {
+			return pno * wbPageSize
+		}
+	}
+	return c.size
+}
+
+func (c *wbCache) readAt(p []byte, off int64, load loadBlockFunc) (n int, err error) {
+	if off >= c.size {
+		return 0, io.EOF
+	}
+	if rem := c.size - off; int64(len(p)) > rem {
+		p = p[:rem]
+	}
+	for n < len(p) {
+		pno, poff := off/wbPageSize, off%wbPageSize
+		if page, ok := c.pages[pno]; ok {
+			k := copy(p[n:], page[poff:])
+			n += k
+			off += int64(k)
+			continue
+		}
+		end := cos.MinI64(c.cleanEnd(off), off+int64(len(p)-n))
+		if off < c.valid {
+			length := cos.MinI64(end, c.valid) - off
+			w := &sliceWriter{b: p[n : n+int(length)]}
+			if _, err = load(w, off, length); err != nil {
+				return
+			}
+			if w.n != int(length) {
+				return n + w.n, io.ErrUnexpectedEOF
+			}
+			n += w.n
+			off += length
+		}
+		for ; off < end; off++ {
+			p[n] = 0
+			n++
+		}
+	}
+	return
+}
+
+//////////////
+// wbReader //
+//////////////
+
+func (r *wbReader) Read(p []byte) (n int, err error) {
+	c := r.c
+	if r.off >= c.size {
+		return 0, io.EOF
+	}
+	if r.pr != nil {
+		if rem := r.end - r.off; int64(len(p)) > rem {
+			p = p[:rem]
+		}
+		n, err = r.pr.Read(p)
+		r.off += int64(n)
+		if r.off == r.end {
+			r.pr.Close()
+			r.pr = nil
+			err = nil
+		} else if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+	pno, poff := r.off/wbPageSize, r.off%wbPageSize
+	if page, ok := c.pages[pno]; ok {
+		end := cos.MinI64(wbPageSize, c.size-pno*wbPageSize)
+		n = copy(p, page[poff:end])
+		r.off += int64(n)
+		return
+	}
+	end := c.cleanEnd(r.off)
+	if r.off >= c.valid {
+		if rem := end - r.off; int64(len(p)) > rem {
+			p = p[:rem]
+		}
+		for i := range p {
+			p[i] = 0
+		}
+		r.off += int64(len(p))
+		return len(p), nil
+	}
+	// stream the (entire) clean range
+	var (
+		pw     *io.PipeWriter
+		length = cos.MinI64(end, c.valid) - r.off
+	)
+	r.pr, pw = io.Pipe()
+	r.end = r.off + length
+	go func(off int64) {
+		_, err := r.load(pw, off, length)
+		pw.CloseWithError(err)
+	}(r.off)
+	return r.Read(p)
+}
+
+func (r *wbReader) Open() (cos.ReadOpenCloser, error) {
+	r.Close()
+	return &wbReader{c: r.c, load: r.load}, nil
+}
+
+func (r *wbReader) Close() error {
+	if r.pr != nil {
+		r.pr.Close()
+		r.pr = nil
+	}
+	return nil
+}
+
+/////////////////
+// sliceWriter //
+/////////////////
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	n := copy(w.b[w.n:], p)
+	w.n += n
+	if n < len(p) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
@@ -0,0 +1,159 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WriteBackCache", func() {
+	var (
+		object []byte // current version of the object
+		loads  int    // number of range reads from the object
+		c      *wbCache
+	)
+
+	load := func(w io.Writer, offset, length int64) (int64, error) {
+		loads++
+		end := cos.MinI64(offset+length, int64(len(object)))
+		n, err := w.Write(object[offset:end])
+		return int64(n), err
+	}
+
+	// reads the entire file: first with readAt, then with wbReader
+	readAll := func(expected []byte) {
+		b := make([]byte, c.size+wbPageSize)
+		n, err := c.readAt(b, 0, load)
+		if c.size == 0 {
+			Expect(err).To(Equal(io.EOF))
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(b[:n]).To(Equal(expected))
+
+		r := &wbReader{c: c, load: load}
+		b, err = io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Close()).NotTo(HaveOccurred())
+		Expect(b).To(Equal(expected))
+	}
+
+	pattern := func(size int, seed byte) []byte {
+		b := make([]byte, size)
+		for i := range b {
+			b[i] = seed + byte(i%251)
+		}
+		return b
+	}
+
+	BeforeEach(func() {
+		object = nil
+		loads = 0
+	})
+
+	AfterEach(func() {
+		c.free()
+		Expect(wbDirty.Load()).To(BeZero())
+	})
+
+	It("should write at random offsets into new file", func() {
+		c = newWBCache(0)
+		expected := make([]byte, 3*wbPageSize+100)
+
+		data := pattern(200, 1)
+		Expect(c.write(data, 3*wbPageSize-100, load)).NotTo(HaveOccurred())
+		copy(expected[3*wbPageSize-100:], data)
+
+		data = pattern(10, 2)
+		Expect(c.write(data, 5, load)).NotTo(HaveOccurred())
+		copy(expected[5:], data)
+
+		Expect(c.size).To(BeEquivalentTo(len(expected)))
+		Expect(c.pages).To(HaveLen(3))
+		Expect(wbDirty.Load()).To(BeEquivalentTo(3 * wbPageSize))
+		Expect(loads).To(BeZero())
+
+		readAll(expected)
+	})
+
+	It("should overwrite existing object in place", func() {
+		object = pattern(4*wbPageSize+10, 3)
+		c = newWBCache(int64(len(object)))
+		expected := append([]byte{}, object...)
+
+		// partial page: read-modify-write
+		data := bytes.Repeat([]byte{0xff}, 100)
+		Expect(c.write(data, wbPageSize+50, load)).NotTo(HaveOccurred())
+		copy(expected[wbPageSize+50:], data)
+		Expect(loads).To(Equal(1))
+
+		// entire page: no need to load
+		data = pattern(wbPageSize, 4)
+		Expect(c.write(data, 3*wbPageSize, load)).NotTo(HaveOccurred())
+		copy(expected[3*wbPageSize:], data)
+		Expect(loads).To(Equal(1))
+
+		// beyond the end
+		data = pattern(50, 5)
+		Expect(c.write(data, int64(len(object)), load)).NotTo(HaveOccurred())
+		expected = append(expected, data...)
+
+		Expect(c.size).To(BeEquivalentTo(len(expected)))
+		readAll(expected)
+	})
+
+	It("should truncate and extend", func() {
+		object = pattern(2*wbPageSize, 6)
+		c = newWBCache(int64(len(object)))
+
+		data := pattern(wbPageSize, 7)
+		Expect(c.write(data, wbPageSize+100, load)).NotTo(HaveOccurred())
+		Expect(c.pages).To(HaveLen(2))
+
+		// shrink: drops the last page and zeros the tail of the (new) last one
+		c.truncate(wbPageSize + 10)
+		Expect(c.pages).To(HaveLen(1))
+		Expect(c.valid).To(BeEquivalentTo(wbPageSize + 10))
+		expected := append([]byte{}, object[:wbPageSize+10]...)
+		readAll(expected)
+
+		// extend: reads as zeros (and not as the previously truncated content)
+		c.truncate(2 * wbPageSize)
+		expected = append(expected, make([]byte, wbPageSize-10)...)
+		readAll(expected)
+
+		// truncate to zero
+		c.truncate(0)
+		Expect(c.pages).To(BeEmpty())
+		readAll([]byte{})
+	})
+
+	It("should read clean ranges from the object", func() {
+		object = pattern(3*wbPageSize, 8)
+		c = newWBCache(int64(len(object)))
+		expected := append([]byte{}, object...)
+
+		data := pattern(10, 9)
+		Expect(c.write(data, wbPageSize+20, load)).NotTo(HaveOccurred())
+		copy(expected[wbPageSize+20:], data)
+
+		b := make([]byte, 100)
+		n, err := c.readAt(b, wbPageSize-50, load)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b[:n]).To(Equal(expected[wbPageSize-50 : wbPageSize+50]))
+
+		n, err = c.readAt(b, int64(len(object))-10, load)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b[:n]).To(Equal(expected[len(object)-10:]))
+
+		_, err = c.readAt(b, int64(len(object)), load)
+		Expect(err).To(Equal(io.EOF))
+	})
+})
@@ -17,6 +17,7 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	keysCollection     = "key" // token signing keys
 
 	adminUserID   = "admin"
 	adminUserPass = "admin"
@@ -0,0 +1,316 @@
+// Package authn provides AuthN server for AIStore.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/dbdriver"
+)
+
+// With asymmetric signing (RS256, ES256) AuthN signs tokens with its (active) private key
+// and publishes the corresponding public keys - see jwks(). Rotation replaces the active key
+// with a newly generated one; the replaced (retired) key remains published for as long as
+// there are unexpired tokens signed with it.
+//
+// Private keys are stored in the AuthN database. Alternatively, the (PEM-encoded) key
+// can be provided via configuration (`auth.private_key`), in which case it is the only
+// key AuthN signs with and the rotation is done by replacing the file.
+
+type (
+	signingKey struct {
+		ID      string    `json:"kid"`
+		Alg     string    `json:"alg"`
+		PEM     string    `json:"pem"`
+		Created time.Time `json:"created"`
+		Expires time.Time `json:"expires"` // expiration time of the latest token signed with this key
+		Retired bool      `json:"retired,omitempty"`
+	}
+	keyManager struct {
+		mu     sync.RWMutex
+		db     dbdriver.Driver
+		keys   []*signingKey // sorted by creation time; the active (not retired) key is the last
+		signer *tok.Signer   // nil when signing with the secret (HS256)
+		ks     *tok.KeySet
+		secret string // (ks was built with)
+	}
+)
+
+var errNoKeyRotation = errors.New("nothing to rotate: tokens are signed with the secret (HS256)")
+
+func newKeyManager(db dbdriver.Driver) (*keyManager, error) {
+	km := &keyManager{db: db}
+	return km, km.load()
+}
+
+// (re)initialize upon startup and whenever the signing method changes
+func (km *keyManager) load() error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	keys, err := km.loadKeys()
+	if err != nil {
+		return err
+	}
+	km.keys = keys
+
+	var (
+		method = Conf.SigningMethod()
+		active = km.active()
+	)
+	if method == authn.SigningHS256 {
+		if active != nil {
+			km.retire(active)
+		}
+		km.signer = nil
+		km.rebuild()
+		return nil
+	}
+
+	var priv *signingKey
+	if keyPath := Conf.Server.PrivateKey; keyPath != "" {
+		if priv, err = loadPrivateKey(keyPath, method); err != nil {
+			return err
+		}
+		if active != nil && active.ID == priv.ID {
+			priv = nil // already in use
+		}
+	} else if active == nil || active.Alg != method {
+		if priv, err = generateKey(method); err != nil {
+			return err
+		}
+	}
+	if priv != nil {
+		if err := km.activate(priv); err != nil {
+			return err
+		}
+	}
+	return km.initSigner()
+}
+
+func (km *keyManager) rotate() (kid string, err error) {
+	method := Conf.SigningMethod()
+	if method == authn.SigningHS256 {
+		return "", errNoKeyRotation
+	}
+	if keyPath := Conf.Server.PrivateKey; keyPath != "" {
+		return "", fmt.Errorf("cannot rotate signing key loaded from %q (replace the file instead)", keyPath)
+	}
+	priv, err := generateKey(method)
+	if err != nil {
+		return "", err
+	}
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if err = km.activate(priv); err != nil {
+		return "", err
+	}
+	if err = km.initSigner(); err != nil {
+		return "", err
+	}
+	glog.Infof("rotated signing key: new kid %q (%s)", priv.ID, priv.Alg)
+	return priv.ID, nil
+}
+
+// issue a token with the active key, or with the secret (HS256)
+func (km *keyManager) issue(secret string, expires time.Time, cb func(s *tok.Signer) (string, error)) (string, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if km.signer == nil {
+		return cb(tok.NewHMACSigner(secret))
+	}
+	token, err := cb(km.signer)
+	if err != nil {
+		return "", err
+	}
+	if active := km.active(); active != nil && expires.After(active.Expires) {
+		active.Expires = expires
+		if err := km.db.Set(keysCollection, active.ID, active); err != nil {
+			glog.Error(err)
+		}
+	}
+	return token, nil
+}
+
+// key set to verify all tokens issued by this AuthN
+func (km *keyManager) keySet() *tok.KeySet {
+	secret := Conf.Secret()
+	km.mu.RLock()
+	ks := km.ks
+	if km.secret == secret {
+		km.mu.RUnlock()
+		return ks
+	}
+	km.mu.RUnlock()
+
+	km.mu.Lock()
+	km.secret = secret
+	km.rebuild()
+	ks = km.ks
+	km.mu.Unlock()
+	return ks
+}
+
+func (km *keyManager) jwks() (*tok.JWKS, error) {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	jwks := &tok.JWKS{Keys: make([]*tok.JWK, 0, len(km.keys))}
+	for _, key := range km.keys {
+		priv, err := tok.ParsePrivateKey([]byte(key.PEM))
+		if err != nil {
+			return nil, err
+		}
+		jwk, err := tok.NewJWK(priv.Public(), key.ID)
+		if err != nil {
+			return nil, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks, nil
+}
+
+//
+// internal (all the methods below are called under lock)
+//
+
+func (km *keyManager) active() *signingKey {
+	if l := len(km.keys); l > 0 && !km.keys[l-1].Retired {
+		return km.keys[l-1]
+	}
+	return nil
+}
+
+// load stored keys, remove those that have no unexpired tokens
+func (km *keyManager) loadKeys() ([]*signingKey, error) {
+	kids, err := km.db.List(keysCollection, "")
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	var (
+		now  = time.Now()
+		keys = make([]*signingKey, 0, len(kids))
+	)
+	for _, kid := range kids {
+		key := &signingKey{}
+		if err := km.db.Get(keysCollection, kid, key); err != nil {
+			glog.Errorf("failed to load signing key %q: %v", kid, err)
+			continue
+		}
+		if key.Retired && key.Expires.Before(now) {
+			glog.Infof("removing expired signing key %q", kid)
+			km.db.Delete(keysCollection, kid)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Created.Before(keys[j].Created) })
+	// (at most one active key)
+	for i := 0; i < len(keys)-1; i++ {
+		if !keys[i].Retired {
+			km.retire(keys[i])
+		}
+	}
+	return keys, nil
+}
+
+func (km *keyManager) retire(key *signingKey) {
+	key.Retired = true
+	if err := km.db.Set(keysCollection, key.ID, key); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (km *keyManager) activate(key *signingKey) error {
+	if active := km.active(); active != nil {
+		km.retire(active)
+	}
+	for i, k := range km.keys {
+		if k.ID == key.ID { // (e.g., a previously retired key is back in the configuration)
+			key.Expires = k.Expires
+			km.keys = append(km.keys[:i], km.keys[i+1:]...)
+			break
+		}
+	}
+	if err := km.db.Set(keysCollection, key.ID, key); err != nil {
+		return err
+	}
+	km.keys = append(km.keys, key)
+	return nil
+}
+
+func (km *keyManager) initSigner() error {
+	active := km.active()
+	priv, err := tok.ParsePrivateKey([]byte(active.PEM))
+	if err != nil {
+		return err
+	}
+	if km.signer, err = tok.NewSigner(priv, active.ID); err != nil {
+		return err
+	}
+	km.rebuild()
+	return nil
+}
+
+func (km *keyManager) rebuild() {
+	ks := tok.NewKeySet(km.secret, "", "")
+	for _, key := range km.keys {
+		priv, err := tok.ParsePrivateKey([]byte(key.PEM))
+		if err == nil {
+			err = ks.Add(priv.Public(), key.ID)
+		}
+		if err != nil {
+			glog.Errorf("signing key %q: %v", key.ID, err)
+		}
+	}
+	km.ks = ks
+}
+
+func generateKey(method string) (*signingKey, error) {
+	priv, err := tok.GenerateKey(method)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(priv)
+}
+
+func loadPrivateKey(keyPath, method string) (*signingKey, error) {
+	b, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := tok.ParsePrivateKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", keyPath, err)
+	}
+	key, err := newSigningKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", keyPath, err)
+	}
+	if key.Alg != method {
+		return nil, fmt.Errorf("%s: cannot use %s key with signing method %s", keyPath, key.Alg, method)
+	}
+	return key, nil
+}
+
+func newSigningKey(priv crypto.Signer) (*signingKey, error) {
+	signer, err := tok.NewSigner(priv, "")
+	if err != nil {
+		return nil, err
+	}
+	b, err := tok.EncodePrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{ID: signer.KeyID(), Alg: signer.Alg(), PEM: string(b), Created: time.Now()}, nil
+}
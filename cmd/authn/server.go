@@ -14,7 +14,6 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
-	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -85,7 +84,9 @@ func (a *Server) registerPublicHandlers() {
 	a.registerHandler(apc.URLPathTokens.S, a.tokenHandler)
 	a.registerHandler(apc.URLPathClusters.S, a.clusterHandler)
 	a.registerHandler(apc.URLPathRoles.S, a.roleHandler)
-	a.registerHandler(apc.URLPathDae.S, configHandler)
+	a.registerHandler(apc.URLPathDae.S, a.configHandler)
+	a.registerHandler(apc.URLPathKeys.S, a.keysHandler)
+	a.mux.HandleFunc(apc.URLPathJWKS.S, a.httpJWKSGet)
 }
 
 func (a *Server) userHandler(w http.ResponseWriter, r *http.Request) {
@@ -140,8 +141,7 @@ func (a *Server) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty token")
 		return
 	}
-	secret := Conf.Secret()
-	_, err := tok.DecryptToken(msg.Token, secret)
+	_, err := a.users.keys.keySet().Decrypt(msg.Token)
 	if err != nil {
 		cmn.WriteErr(w, r, err)
 		return
@@ -155,7 +155,7 @@ func (a *Server) httpUserDel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	if err := a.users.delUser(apiItems[0]); err != nil {
@@ -180,7 +180,7 @@ func (a *Server) httpUserPut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 
@@ -202,7 +202,7 @@ func (a *Server) httpUserPut(w http.ResponseWriter, r *http.Request) {
 
 // Adds a new user to user list
 func (a *Server) userAdd(w http.ResponseWriter, r *http.Request) {
-	if err := checkAuthorization(w, r); err != nil {
+	if err := a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	info := &authn.User{}
@@ -264,15 +264,14 @@ func (a *Server) httpUserGet(w http.ResponseWriter, r *http.Request) {
 
 // Checks if the request header contains valid admin credentials.
 // (admin is created at deployment time and cannot be modified via API)
-func checkAuthorization(w http.ResponseWriter, r *http.Request) error {
+func (a *Server) checkAuthorization(w http.ResponseWriter, r *http.Request) error {
 	s := strings.SplitN(r.Header.Get(apc.HdrAuthorization), " ", 2)
 	if len(s) != 2 {
 		err := errors.New("not authorized: invalid header")
 		cmn.WriteErrMsg(w, r, err.Error(), http.StatusUnauthorized)
 		return err
 	}
-	secret := Conf.Secret()
-	tk, err := tok.DecryptToken(s[1], secret)
+	tk, err := a.users.keys.keySet().Decrypt(s[1])
 	if err != nil {
 		cmn.WriteErrMsg(w, r, err.Error(), http.StatusUnauthorized)
 		return err
@@ -346,7 +345,7 @@ func (a *Server) httpSrvPost(w http.ResponseWriter, r *http.Request) {
 	if _, err := checkRESTItems(w, r, 0, apc.URLPathClusters.L); err != nil {
 		return
 	}
-	if err := checkAuthorization(w, r); err != nil {
+	if err := a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	cluConf := &authn.CluACL{}
@@ -364,7 +363,7 @@ func (a *Server) httpSrvPut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err := checkAuthorization(w, r); err != nil {
+	if err := a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	cluConf := &authn.CluACL{}
@@ -382,7 +381,7 @@ func (a *Server) httpSrvDelete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 
@@ -488,7 +487,7 @@ func (a *Server) httpRoleDel(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 
@@ -503,7 +502,7 @@ func (a *Server) httpRolePost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	info := &authn.Role{}
@@ -520,7 +519,7 @@ func (a *Server) httpRolePut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = checkAuthorization(w, r); err != nil {
+	if err = a.checkAuthorization(w, r); err != nil {
 		return
 	}
 
@@ -543,19 +542,19 @@ func (a *Server) httpRolePut(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func configHandler(w http.ResponseWriter, r *http.Request) {
+func (a *Server) configHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		httpConfigGet(w, r)
+		a.httpConfigGet(w, r)
 	case http.MethodPut:
-		httpConfigPut(w, r)
+		a.httpConfigPut(w, r)
 	default:
 		cmn.WriteErr405(w, r, http.MethodPut, http.MethodGet)
 	}
 }
 
-func httpConfigGet(w http.ResponseWriter, r *http.Request) {
-	if err := checkAuthorization(w, r); err != nil {
+func (a *Server) httpConfigGet(w http.ResponseWriter, r *http.Request) {
+	if err := a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	Conf.RLock()
@@ -563,8 +562,8 @@ func httpConfigGet(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, Conf, "config")
 }
 
-func httpConfigPut(w http.ResponseWriter, r *http.Request) {
-	if err := checkAuthorization(w, r); err != nil {
+func (a *Server) httpConfigPut(w http.ResponseWriter, r *http.Request) {
+	if err := a.checkAuthorization(w, r); err != nil {
 		return
 	}
 	updateCfg := &authn.ConfigToUpdate{}
@@ -572,7 +571,56 @@ func httpConfigPut(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "Invalid request")
 		return
 	}
+	method := Conf.SigningMethod()
 	if err := Conf.ApplyUpdate(updateCfg); err != nil {
 		cmn.WriteErr(w, r, err)
+		return
+	}
+	if Conf.SigningMethod() == method {
+		return
+	}
+	if err := a.users.keys.load(); err != nil {
+		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+	}
+}
+
+func (a *Server) keysHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		a.httpJWKSGet(w, r)
+	case http.MethodPost:
+		a.httpKeyRotate(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPost)
+	}
+}
+
+// Returns public keys to verify tokens signed by this AuthN (no authorization required)
+func (a *Server) httpJWKSGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	jwks, err := a.users.keys.jwks()
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, jwks, "jwks")
+}
+
+// Generates a new signing key; tokens signed with the previous one remain valid until they expire
+func (a *Server) httpKeyRotate(w http.ResponseWriter, r *http.Request) {
+	if _, err := checkRESTItems(w, r, 0, apc.URLPathKeys.L); err != nil {
+		return
+	}
+	if err := a.checkAuthorization(w, r); err != nil {
+		return
+	}
+	kid, err := a.users.keys.rotate()
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
 	}
+	writeJSON(w, &authn.KeyMsg{ID: kid}, "rotate key")
 }
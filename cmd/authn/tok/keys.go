@@ -0,0 +1,482 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/golang-jwt/jwt/v4"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Tokens are signed either with a shared secret (HS256) or with a private key (RS256, ES256).
+// In the latter case, AuthN publishes the corresponding public keys as a JSON Web Key Set
+// (JWKS, RFC 7517) - each key with its ID (`kid`) that is also placed in the header of
+// every token signed with this key. AIS gateways verify tokens against a KeySet that
+// combines (any subset of) the secret, public keys loaded from a local file, and public keys
+// fetched from a JWKS URL - the latter includes external OIDC issuers.
+
+const rsaKeyBits = 2048
+
+type (
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid,omitempty"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		// RSA
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// EC
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+	JWKS struct {
+		Keys []*JWK `json:"keys"`
+	}
+
+	// Signer issues tokens
+	Signer struct {
+		method jwt.SigningMethod
+		key    interface{} // secret or private key
+		kid    string
+	}
+
+	// KeySet verifies token signatures
+	KeySet struct {
+		secret   []byte
+		pubs     map[string]crypto.PublicKey // by key ID
+		oidc     cos.StringSet               // IDs of the keys of external OIDC provider (see AddJWKS)
+		issuer   string                      // expected "iss" (required for OIDC tokens)
+		audience string                      // expected "aud" (ditto)
+	}
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// JSON names of the Token fields
+var tokenClaims = []string{"username", "expires", "token", "clusters", "buckets", "admin"}
+
+////////////
+// Signer //
+////////////
+
+func NewHMACSigner(secret string) *Signer {
+	return &Signer{method: jwt.SigningMethodHS256, key: []byte(secret)}
+}
+
+// NewSigner creates RS256 or ES256 signer; key ID defaults to the public key's thumbprint
+func NewSigner(priv crypto.Signer, kid string) (s *Signer, err error) {
+	s = &Signer{key: priv, kid: kid}
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		s.method = jwt.SigningMethodRS256
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported elliptic curve %s (expecting P-256)", k.Curve.Params().Name)
+		}
+		s.method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	if s.kid == "" {
+		s.kid, err = KeyID(priv.Public())
+	}
+	return
+}
+
+func (s *Signer) Alg() string   { return s.method.Alg() }
+func (s *Signer) KeyID() string { return s.kid }
+
+// JWK returns the signer's public key (nil for HS256)
+func (s *Signer) JWK() (*JWK, error) {
+	priv, ok := s.key.(crypto.Signer)
+	if !ok {
+		return nil, nil
+	}
+	return NewJWK(priv.Public(), s.kid)
+}
+
+func (s *Signer) IssueAdminJWT(expires time.Time, userID string) (string, error) {
+	return s.sign(jwt.MapClaims{
+		"expires":  expires,
+		"username": userID,
+		"admin":    true,
+	})
+}
+
+func (s *Signer) IssueJWT(expires time.Time, userID string, bucketACLs []*authn.BckACL,
+	clusterACLs []*authn.CluACL) (string, error) {
+	return s.sign(jwt.MapClaims{
+		"expires":  expires,
+		"username": userID,
+		"buckets":  bucketACLs,
+		"clusters": clusterACLs,
+	})
+}
+
+func (s *Signer) sign(claims jwt.MapClaims) (string, error) {
+	t := jwt.NewWithClaims(s.method, claims)
+	if s.kid != "" {
+		t.Header["kid"] = s.kid
+	}
+	return t.SignedString(s.key)
+}
+
+////////////
+// KeySet //
+////////////
+
+func NewKeySet(secret, issuer, audience string) *KeySet {
+	ks := &KeySet{
+		pubs:     make(map[string]crypto.PublicKey, 2),
+		oidc:     make(cos.StringSet, 2),
+		issuer:   issuer,
+		audience: audience,
+	}
+	if secret != "" {
+		ks.secret = []byte(secret)
+	}
+	return ks
+}
+
+func (ks *KeySet) Len() int { return len(ks.pubs) }
+
+func (ks *KeySet) Add(pub crypto.PublicKey, kid string) error { return ks.add(pub, kid, false) }
+
+func (ks *KeySet) add(pub crypto.PublicKey, kid string, oidc bool) (err error) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return fmt.Errorf("unsupported elliptic curve %s (expecting P-256)", k.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if kid == "" {
+		if kid, err = KeyID(pub); err != nil {
+			return
+		}
+	}
+	ks.pubs[kid] = pub
+	if oidc {
+		ks.oidc.Add(kid)
+	} else {
+		ks.oidc.Delete(kid)
+	}
+	return nil
+}
+
+// AddJWKS adds signature-verifying RSA and EC keys (and skips all other keys);
+// once the issuer is configured, the keys are assumed to belong to the (external)
+// OIDC provider, and tokens signed with them are subject to issuer and audience checks
+func (ks *KeySet) AddJWKS(jwks *JWKS) error { return ks.addJWKS(jwks, ks.issuer != "") }
+
+func (ks *KeySet) addJWKS(jwks *JWKS, oidc bool) error {
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Kty != "RSA" && jwk.Kty != "EC" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			return err
+		}
+		if err := ks.add(pub, jwk.Kid, oidc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddFile adds (AuthN) public key(s) from either a JWKS (JSON) or PEM-encoded content:
+// public keys (PKIX or PKCS #1) and/or certificates
+func (ks *KeySet) AddFile(b []byte) error {
+	if s := strings.TrimSpace(string(b)); strings.HasPrefix(s, "{") {
+		jwks := &JWKS{}
+		if err := jsoniter.Unmarshal(b, jwks); err != nil {
+			return err
+		}
+		return ks.addJWKS(jwks, false)
+	}
+	var cnt int
+	for {
+		var block *pem.Block
+		if block, b = pem.Decode(b); block == nil {
+			break
+		}
+		pub, err := parsePublicPEM(block)
+		if err != nil {
+			return err
+		}
+		if err := ks.Add(pub, ""); err != nil {
+			return err
+		}
+		cnt++
+	}
+	if cnt == 0 {
+		return errors.New("no public keys found")
+	}
+	return nil
+}
+
+// Decrypt verifies token's signature and returns the token (note: the caller
+// must check token's expiration - see Token.Expires)
+func (ks *KeySet) Decrypt(tokenStr string) (*Token, error) {
+	var oidc bool // signed with OIDC provider's key
+	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		key, kid, err := ks.keyFunc(t)
+		oidc = ks.oidc.Contains(kid)
+		return key, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !jwtToken.Valid {
+		return nil, ErrInvalidToken
+	}
+	if err := ks.verifyOIDC(claims, oidc); err != nil {
+		return nil, err
+	}
+	// (only AIS claims - see Token - while ignoring all other, e.g. registered JWT claims)
+	aisClaims := make(jwt.MapClaims, len(tokenClaims))
+	for _, name := range tokenClaims {
+		if v, ok := claims[name]; ok {
+			aisClaims[name] = v
+		}
+	}
+	tk := &Token{}
+	if err := cos.MorphMarshal(aisClaims, tk); err != nil {
+		return nil, ErrInvalidToken
+	}
+	// standard (OIDC) claims
+	if tk.UserID == "" {
+		if sub, ok := claims["sub"].(string); ok {
+			tk.UserID = sub
+		}
+	}
+	if tk.Expires.IsZero() {
+		if exp, ok := claims["exp"].(float64); ok {
+			tk.Expires = time.Unix(int64(exp), 0)
+		}
+	}
+	return tk, nil
+}
+
+// Tokens issued by AuthN carry AIS claims (notably, "username") and no "iss". All other
+// tokens - and all tokens signed with the keys of external OIDC provider (see AddJWKS) - must
+// be issued by the configured issuer for the configured audience. That is, AuthN (signing
+// with the secret or the keys from the local file) and OIDC provider work side by side.
+func (ks *KeySet) verifyOIDC(claims jwt.MapClaims, oidc bool) error {
+	_, native := claims["username"]
+	_, hasIss := claims["iss"]
+	if native && !hasIss && !oidc {
+		return nil
+	}
+	if ks.issuer == "" || ks.audience == "" {
+		return fmt.Errorf("%v: OIDC tokens require configured issuer and audience", ErrInvalidToken)
+	}
+	if !claims.VerifyIssuer(ks.issuer, true) {
+		return fmt.Errorf("%v: unexpected issuer %v", ErrInvalidToken, claims["iss"])
+	}
+	if !claims.VerifyAudience(ks.audience, true) {
+		return fmt.Errorf("%v: unexpected audience %v", ErrInvalidToken, claims["aud"])
+	}
+	return nil
+}
+
+// returns the key to verify a given token with, along with the key's ID
+func (ks *KeySet) keyFunc(t *jwt.Token) (key interface{}, kid string, err error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if ks.secret == nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrUnknownKey, t.Header["alg"])
+		}
+		return ks.secret, "", nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+	default:
+		return nil, "", fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	kid, _ = t.Header["kid"].(string)
+	if kid != "" {
+		pub, ok := ks.pubs[kid]
+		if !ok {
+			return nil, "", fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+		}
+		return pub, kid, nil
+	}
+	// no key ID: the only key of the matching type (if any)
+	var found crypto.PublicKey
+	for id, pub := range ks.pubs {
+		_, isRSA := pub.(*rsa.PublicKey)
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); ok != isRSA {
+			continue
+		}
+		if found != nil {
+			return nil, "", fmt.Errorf("%w: token without kid", ErrUnknownKey)
+		}
+		found, kid = pub, id
+	}
+	if found == nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnknownKey, t.Header["alg"])
+	}
+	return found, kid, nil
+}
+
+/////////
+// JWK //
+/////////
+
+func NewJWK(pub crypto.PublicKey, kid string) (*JWK, error) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA", Kid: kid, Use: "sig", Alg: authn.SigningRS256,
+			N: b64(k.N.Bytes()),
+			E: b64(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return &JWK{
+			Kty: "EC", Kid: kid, Use: "sig", Alg: authn.SigningES256,
+			Crv: k.Curve.Params().Name,
+			X:   b64(k.X.FillBytes(make([]byte, size))),
+			Y:   b64(k.Y.FillBytes(make([]byte, size))),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+func (jwk *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := unb64(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if jwk.Crv != elliptic.P256().Params().Name {
+			return nil, fmt.Errorf("JWK %q: unsupported curve %q", jwk.Kid, jwk.Crv)
+		}
+		x, err := unb64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("JWK %q: invalid EC point", jwk.Kid)
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("JWK %q: unsupported key type %q", jwk.Kid, jwk.Kty)
+	}
+}
+
+///////////////
+// key utils //
+///////////////
+
+func GenerateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case authn.SigningRS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case authn.SigningES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("cannot generate key for signing method %q", alg)
+	}
+}
+
+func ParsePrivateKey(b []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if priv, ok := key.(crypto.Signer); ok {
+			return priv, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if priv, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return priv, nil
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+func EncodePrivateKey(priv crypto.Signer) ([]byte, error) {
+	b, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), nil
+}
+
+// KeyID returns JWK thumbprint (RFC 7638) of the public key
+func KeyID(pub crypto.PublicKey) (string, error) {
+	jwk, err := NewJWK(pub, "")
+	if err != nil {
+		return "", err
+	}
+	var s string
+	// (required members only, in lexicographic order)
+	if jwk.Kty == "RSA" {
+		s = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	} else {
+		s = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	}
+	sum := sha256.Sum256([]byte(s))
+	return b64(sum[:]), nil
+}
+
+func parsePublicPEM(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func unb64(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
@@ -0,0 +1,190 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestSignVerifyJWKS(t *testing.T) {
+	for _, alg := range []string{authn.SigningRS256, authn.SigningES256} {
+		t.Run(alg, func(t *testing.T) {
+			priv, err := GenerateKey(alg)
+			tassert.CheckFatal(t, err)
+			signer, err := NewSigner(priv, "")
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, signer.Alg() == alg, "expected %s, got %s", alg, signer.Alg())
+
+			expires := time.Now().Add(time.Hour).Round(time.Second)
+			token, err := signer.IssueAdminJWT(expires, "admin")
+			tassert.CheckFatal(t, err)
+
+			// public key => JWK => public key
+			jwk, err := signer.JWK()
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, jwk.Kid == signer.KeyID(), "expected kid %q, got %q", signer.KeyID(), jwk.Kid)
+			ks := NewKeySet("", "", "")
+			tassert.CheckFatal(t, ks.AddJWKS(&JWKS{Keys: []*JWK{jwk}}))
+
+			tk, err := ks.Decrypt(token)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, tk.IsAdmin && tk.UserID == "admin", "unexpected token %s", tk)
+			tassert.Errorf(t, tk.Expires.Equal(expires), "expected expiration %v, got %v", expires, tk.Expires)
+
+			// tampered with
+			_, err = ks.Decrypt(token[:len(token)-4] + "AAAA")
+			tassert.Errorf(t, err != nil, "expected tampered token to fail verification")
+
+			// signed with another key
+			other, err := GenerateKey(alg)
+			tassert.CheckFatal(t, err)
+			otherSigner, err := NewSigner(other, "")
+			tassert.CheckFatal(t, err)
+			token, err = otherSigner.IssueAdminJWT(expires, "admin")
+			tassert.CheckFatal(t, err)
+			_, err = ks.Decrypt(token)
+			tassert.Errorf(t, errors.Is(err, ErrUnknownKey), "expected %v, got %v", ErrUnknownKey, err)
+
+			// HMAC-signed tokens require the secret
+			token, err = NewHMACSigner("secret").IssueAdminJWT(expires, "admin")
+			tassert.CheckFatal(t, err)
+			_, err = ks.Decrypt(token)
+			tassert.Errorf(t, errors.Is(err, ErrUnknownKey), "expected %v, got %v", ErrUnknownKey, err)
+		})
+	}
+}
+
+func TestKeySetFile(t *testing.T) {
+	priv, err := GenerateKey(authn.SigningRS256)
+	tassert.CheckFatal(t, err)
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	tassert.CheckFatal(t, err)
+	pemPub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	// private key PEM round-trip
+	b, err := EncodePrivateKey(priv)
+	tassert.CheckFatal(t, err)
+	priv2, err := ParsePrivateKey(b)
+	tassert.CheckFatal(t, err)
+	kid, _ := KeyID(priv.Public())
+	kid2, _ := KeyID(priv2.Public())
+	tassert.Fatalf(t, kid == kid2, "expected the same key ID: %q vs %q", kid, kid2)
+
+	ks := NewKeySet("secret", "", "")
+	tassert.CheckFatal(t, ks.AddFile(pemPub))
+	tassert.Fatalf(t, ks.Len() == 1, "expected 1 key, got %d", ks.Len())
+
+	// token without "kid": the only key of the matching type
+	claims := jwt.MapClaims{"username": "user", "expires": time.Now().Add(time.Hour)}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(priv)
+	tassert.CheckFatal(t, err)
+	tk, err := ks.Decrypt(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "user", "expected user, got %q", tk.UserID)
+
+	// and the secret keeps working
+	token, err = IssueJWT(time.Now().Add(time.Hour), "user", nil, nil, "secret")
+	tassert.CheckFatal(t, err)
+	_, err = ks.Decrypt(token)
+	tassert.CheckError(t, err)
+
+	tassert.Errorf(t, ks.AddFile([]byte("garbage")) != nil, "expected error for invalid key file")
+}
+
+// tokens issued by external OIDC provider
+func TestKeySetOIDC(t *testing.T) {
+	const (
+		issuer   = "https://oidc.example.com"
+		audience = "aistore"
+	)
+	priv, err := GenerateKey(authn.SigningES256)
+	tassert.CheckFatal(t, err)
+	jwk, err := NewJWK(priv.Public(), "oidc-key-1")
+	tassert.CheckFatal(t, err)
+	ks := NewKeySet("", issuer, audience)
+	tassert.CheckFatal(t, ks.AddJWKS(&JWKS{Keys: []*JWK{jwk, {Kty: "oct", Kid: "ignored"}}}))
+
+	sign := func(iss string, aud interface{}) string {
+		claims := jwt.MapClaims{
+			"sub":   "alice",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"admin": true,
+		}
+		if iss != "" {
+			claims["iss"] = iss
+		}
+		if aud != nil {
+			claims["aud"] = aud
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		token.Header["kid"] = "oidc-key-1"
+		s, err := token.SignedString(priv)
+		tassert.CheckFatal(t, err)
+		return s
+	}
+	tk, err := ks.Decrypt(sign(issuer, audience))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "alice", "expected user alice, got %q", tk.UserID)
+	tassert.Errorf(t, tk.IsAdmin, "expected admin")
+	tassert.Errorf(t, time.Until(tk.Expires) > 59*time.Minute, "unexpected expiration %v", tk.Expires)
+
+	_, err = ks.Decrypt(sign(issuer, []string{"other", audience}))
+	tassert.CheckError(t, err)
+
+	_, err = ks.Decrypt(sign("https://evil.example.com", audience))
+	tassert.Errorf(t, err != nil, "expected error for unexpected issuer")
+	_, err = ks.Decrypt(sign("", audience))
+	tassert.Errorf(t, err != nil, "expected error for missing issuer")
+	_, err = ks.Decrypt(sign(issuer, "other"))
+	tassert.Errorf(t, err != nil, "expected error for unexpected audience")
+	_, err = ks.Decrypt(sign(issuer, nil))
+	tassert.Errorf(t, err != nil, "expected error for missing audience")
+
+	// native (AuthN) tokens keep working side by side: signed with the secret or
+	// with the keys from the local file...
+	authnPriv, err := GenerateKey(authn.SigningRS256)
+	tassert.CheckFatal(t, err)
+	der, err := x509.MarshalPKIXPublicKey(authnPriv.Public())
+	tassert.CheckFatal(t, err)
+	ks = NewKeySet("secret", issuer, audience)
+	tassert.CheckFatal(t, ks.AddJWKS(&JWKS{Keys: []*JWK{jwk}}))
+	tassert.CheckFatal(t, ks.AddFile(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	token, err := IssueJWT(time.Now().Add(time.Hour), "bob", nil, nil, "secret")
+	tassert.CheckFatal(t, err)
+	_, err = ks.Decrypt(token)
+	tassert.CheckError(t, err)
+	authnSigner, err := NewSigner(authnPriv, "")
+	tassert.CheckFatal(t, err)
+	token, err = authnSigner.IssueAdminJWT(time.Now().Add(time.Hour), "admin")
+	tassert.CheckFatal(t, err)
+	_, err = ks.Decrypt(token)
+	tassert.CheckError(t, err)
+	_, err = ks.Decrypt(sign(issuer, audience))
+	tassert.CheckError(t, err)
+
+	// ...while the OIDC provider's keys cannot sign "native" tokens
+	claims := jwt.MapClaims{"username": "mallory", "admin": true, "expires": time.Now().Add(time.Hour)}
+	oidcNative := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	oidcNative.Header["kid"] = "oidc-key-1"
+	token, err = oidcNative.SignedString(priv)
+	tassert.CheckFatal(t, err)
+	_, err = ks.Decrypt(token)
+	tassert.Errorf(t, err != nil, "expected error for AuthN-like token signed by OIDC provider's key")
+
+	// not configured for OIDC: OIDC tokens are rejected
+	ks = NewKeySet("", "", "")
+	tassert.CheckFatal(t, ks.AddJWKS(&JWKS{Keys: []*JWK{jwk}}))
+	_, err = ks.Decrypt(sign(issuer, audience))
+	tassert.Errorf(t, err != nil, "expected error for OIDC token (issuer and audience not configured)")
+}
@@ -13,8 +13,6 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/golang-jwt/jwt/v4"
 )
 
 type Token struct {
@@ -35,44 +33,19 @@ var (
 )
 
 func IssueAdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"expires":  expires,
-		"username": userID,
-		"admin":    true,
-	})
-	return t.SignedString([]byte(secret))
+	return NewHMACSigner(secret).IssueAdminJWT(expires, userID)
 }
 
 func IssueJWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
 	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"expires":  expires,
-		"username": userID,
-		"buckets":  bucketACLs,
-		"clusters": clusterACLs,
-	})
-	return t.SignedString([]byte(secret))
+	return NewHMACSigner(secret).IssueJWT(expires, userID, bucketACLs, clusterACLs)
 }
 
+// DecryptToken verifies HS256-signed token (see also KeySet.Decrypt)
 func DecryptToken(tokenStr, secret string) (*Token, error) {
-	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	claims, ok := jwtToken.Claims.(jwt.MapClaims)
-	if !ok || !jwtToken.Valid {
-		return nil, ErrInvalidToken
-	}
-	tk := &Token{}
-	if err := cos.MorphMarshal(claims, tk); err != nil {
-		return nil, ErrInvalidToken
-	}
-	return tk, nil
+	ks := NewKeySet("", "", "")
+	ks.secret = []byte(secret)
+	return ks.Decrypt(tokenStr)
 }
 
 ///////////
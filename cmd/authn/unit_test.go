@@ -171,3 +171,65 @@ func TestToken(t *testing.T) {
 		t.Fatalf("Token must be expired: %s", token)
 	}
 }
+
+func TestTokenSigningKeys(t *testing.T) {
+	Conf.Server.SigningMethod = authn.SigningES256
+	defer func() { Conf.Server.SigningMethod = "" }()
+
+	driver := mock.NewDBDriver()
+	mgr, err := NewUserManager(driver)
+	tassert.CheckFatal(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, false, t)
+
+	// verify the way AIS proxies do - with the published keys only
+	verify := func(token string) (*tok.Token, error) {
+		jwks, err := mgr.keys.jwks()
+		tassert.CheckFatal(t, err)
+		ks := tok.NewKeySet("", "", "")
+		tassert.CheckFatal(t, ks.AddJWKS(jwks))
+		return ks.Decrypt(token)
+	}
+
+	token, err := mgr.issueToken(users[0], passs[0], nil)
+	tassert.CheckFatal(t, err)
+	tk, err := verify(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == users[0], "expected user %q, got %q", users[0], tk.UserID)
+
+	// rotate: both old and new tokens remain valid
+	kid, err := mgr.keys.rotate()
+	tassert.CheckFatal(t, err)
+	tokenNew, err := mgr.issueToken(users[1], passs[1], nil)
+	tassert.CheckFatal(t, err)
+	for _, token := range []string{token, tokenNew} {
+		_, err := verify(token)
+		tassert.CheckError(t, err)
+	}
+	jwks, err := mgr.keys.jwks()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(jwks.Keys) == 2, "expected 2 published keys, got %d", len(jwks.Keys))
+	tassert.Errorf(t, jwks.Keys[1].Kid == kid, "expected active key %q, got %q", kid, jwks.Keys[1].Kid)
+
+	// restart: keys are persistent
+	mgr, err = NewUserManager(driver)
+	tassert.CheckFatal(t, err)
+	_, err = mgr.keys.keySet().Decrypt(token)
+	tassert.CheckError(t, err)
+	tokenNew2, err := mgr.issueToken(users[1], passs[1], nil)
+	tassert.CheckFatal(t, err)
+	tk, err = verify(tokenNew2)
+	tassert.CheckFatal(t, err)
+
+	// switch to HS256: no rotation, yet previously issued tokens remain valid
+	Conf.Server.SigningMethod = authn.SigningHS256
+	tassert.CheckFatal(t, mgr.keys.load())
+	_, err = mgr.keys.rotate()
+	tassert.Errorf(t, err == errNoKeyRotation, "expected %v, got %v", errNoKeyRotation, err)
+	_, err = mgr.keys.keySet().Decrypt(tokenNew)
+	tassert.CheckError(t, err)
+	tokenHS, err := mgr.issueToken(users[2], passs[2], nil)
+	tassert.CheckFatal(t, err)
+	_, err = tok.DecryptToken(tokenHS, Conf.Server.Secret)
+	tassert.CheckError(t, err)
+}
@@ -28,6 +28,7 @@ type (
 		clientHTTP  *http.Client
 		clientHTTPS *http.Client
 		db          dbdriver.Driver
+		keys        *keyManager
 	}
 )
 
@@ -100,7 +101,11 @@ func NewUserManager(driver dbdriver.Driver) (*UserManager, error) {
 		clientHTTPS: clientHTTPS,
 		db:          driver,
 	}
-	err := initializeDB(driver)
+	if err := initializeDB(driver); err != nil {
+		return mgr, err
+	}
+	keys, err := newKeyManager(driver)
+	mgr.keys = keys
 	return mgr, err
 }
 
@@ -371,12 +376,15 @@ func (m *UserManager) issueToken(userID, pwd string, ttl *time.Duration) (string
 	// put all useful info into token: who owns the token, when it was issued,
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
-	if uInfo.IsAdmin() {
-		token, err = tok.IssueAdminJWT(expires, userID, Conf.Server.Secret)
-	} else {
+	if !uInfo.IsAdmin() {
 		m.fixClusterIDs(uInfo.ClusterACLs)
-		token, err = tok.IssueJWT(expires, userID, uInfo.BucketACLs, uInfo.ClusterACLs, Conf.Server.Secret)
 	}
+	token, err = m.keys.issue(Conf.Server.Secret, expires, func(s *tok.Signer) (string, error) {
+		if uInfo.IsAdmin() {
+			return s.IssueAdminJWT(expires, userID)
+		}
+		return s.IssueJWT(expires, userID, uInfo.BucketACLs, uInfo.ClusterACLs)
+	})
 	return token, err
 }
 
@@ -405,11 +413,11 @@ func (m *UserManager) generateRevokedTokenList() ([]string, error) {
 
 	now := time.Now()
 	revokeList := make([]string, 0, len(tokens))
-	secret := Conf.Secret()
+	ks := m.keys.keySet()
 	for _, token := range tokens {
-		tk, err := tok.DecryptToken(token, secret)
+		tk, err := ks.Decrypt(token)
 		if err != nil {
-			debug.AssertNoErr(err)
+			// (e.g., signed with a key that's been removed after all its tokens expired)
 			m.db.Delete(revokedCollection, token)
 			continue
 		}
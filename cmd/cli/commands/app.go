@@ -211,6 +211,7 @@ func (aisCLI *AISCLI) setupCommands() {
 		advancedCmd,
 		storageCmd,
 		archCmd,
+		syncCmd,
 		logCmd,
 		rebalanceCmd,
 		remClusterCmd,
@@ -204,6 +204,12 @@ var (
 				Usage:  "log out",
 				Action: wrapAuthN(logoutUserHandler),
 			},
+			// signing keys
+			{
+				Name:   subcmdAuthRotate,
+				Usage:  "generate new token signing key (RS256, ES256); tokens signed with the previous key remain valid until they expire",
+				Action: wrapAuthN(rotateKeyHandler),
+			},
 		},
 	}
 )
@@ -345,6 +351,15 @@ func logoutUserHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func rotateKeyHandler(c *cli.Context) error {
+	kid, err := authn.RotateKey(authParams)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "New signing key: %s\n", kid)
+	return nil
+}
+
 func addAuthClusterHandler(c *cli.Context) (err error) {
 	cluSpec, err := parseClusterSpecs(c)
 	if err != nil {
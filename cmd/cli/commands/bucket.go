@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/objwalk/where"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -204,6 +205,9 @@ func _doListObj(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) error
 	if listArch {
 		msg.SetFlag(apc.LsArchDir)
 	}
+	if flagIsSet(c, listVersionsFlag) {
+		msg.SetFlag(apc.LsVerHistory)
+	}
 	if flagIsSet(c, allItemsFlag) {
 		msg.SetFlag(apc.LsMisplaced)
 	}
@@ -231,6 +235,12 @@ func _doListObj(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) error
 	if flagIsSet(c, startAfterFlag) {
 		msg.StartAfter = parseStrFlag(c, startAfterFlag)
 	}
+	if flagIsSet(c, whereFlag) {
+		msg.Where = parseStrFlag(c, whereFlag)
+		if _, err := where.Parse(msg.Where); err != nil {
+			return err
+		}
+	}
 	pageSize := parseIntFlag(c, pageSizeFlag)
 	limit := parseIntFlag(c, objLimitFlag)
 	if pageSize < 0 {
@@ -406,10 +416,34 @@ func showBucketProps(c *cli.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	return printBckHeadTable(c, p, defProps, section)
+	// storage quota: show current usage as enforced by the targets (best effort)
+	var usage *cmn.QuotaUsage
+	if p.Quota.IsSet() {
+		if all, err := api.GetQuotaUsage(defaultAPIParams); err == nil {
+			usage = sumQuotaUsage(all, bck)
+		}
+	}
+	return printBckHeadTable(c, p, defProps, section, usage)
 }
 
-func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section string) error {
+// cluster-wide usage of a given bucket (nil if not tracked)
+func sumQuotaUsage(all map[string]cmn.QuotaUsages, bck cmn.Bck) (usage *cmn.QuotaUsage) {
+	for _, usages := range all {
+		u, ok := usages[bck.String()]
+		if !ok {
+			continue
+		}
+		if usage == nil {
+			usage = &cmn.QuotaUsage{Seeded: true}
+		}
+		usage.Objs += u.Objs
+		usage.Size += u.Size
+		usage.Seeded = usage.Seeded && u.Seeded
+	}
+	return
+}
+
+func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section string, usage ...*cmn.QuotaUsage) error {
 	var (
 		defList []prop
 		colored = !flagIsSet(c, noColorFlag)
@@ -418,6 +452,10 @@ func printBckHeadTable(c *cli.Context, props, defProps *cmn.BucketProps, section
 	// List instead of map to keep properties in the same order always.
 	// All names are one word ones - for easier parsing.
 	propList := bckPropList(props, !compact)
+	if len(usage) > 0 && usage[0] != nil {
+		propList = append(propList, prop{Name: "quota.usage", Value: quotaUsage(&props.Quota, usage[0])})
+		sort.Slice(propList, func(i, j int) bool { return propList[i].Name < propList[j].Name })
+	}
 	if section != "" {
 		tmpPropList := propList[:0]
 		for _, v := range propList {
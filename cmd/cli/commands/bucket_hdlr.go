@@ -38,6 +38,7 @@ var (
 		commandCopy: {
 			cpBckDryRunFlag,
 			cpBckPrefixFlag,
+			cpBckResumeFlag,
 			templateFlag,
 			listFlag,
 			waitFlag,
@@ -70,6 +71,8 @@ var (
 			listCachedFlag,
 			listArchFlag,
 			nameOnlyFlag,
+			listVersionsFlag,
+			whereFlag,
 		},
 		subcmdSummary: {
 			listCachedFlag,
@@ -331,6 +334,7 @@ func fullBckCopy(c *cli.Context, bckFrom, bckTo cmn.Bck) (err error) {
 		Prefix: parseStrFlag(c, cpBckPrefixFlag),
 		DryRun: flagIsSet(c, cpBckDryRunFlag),
 		Force:  flagIsSet(c, forceFlag),
+		Resume: flagIsSet(c, cpBckResumeFlag),
 	}
 
 	return copyBucket(c, bckFrom, bckTo, msg)
@@ -12,6 +12,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/xact"
 	"github.com/urfave/cli"
 )
 
@@ -45,14 +46,19 @@ const (
 	commandSetCustom = "set-custom"
 	commandRemove    = "rm"
 	commandRename    = "mv"
+	commandRestore   = "restore"
+	commandLegalHold = "legal-hold"
 	commandSet       = "set"
 	commandMirror    = "mirror"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
+	commandPause     = apc.ActXactPause
+	commandResume    = apc.ActXactResume
 	commandWait      = "wait"
 	commandAlias     = "alias"
 	commandStorage   = "storage"
 	commandArch      = "archive"
+	commandSync      = "sync"
 
 	commandGenShards = "gen-shards"
 
@@ -79,6 +85,8 @@ const (
 	subcmdStop       = "stop"
 	subcmdStart      = "start"
 	subcmdLRU        = apc.ActLRU
+	subcmdLifecycle  = apc.ActLifecycle
+	subcmdScrub      = apc.ActScrub
 	subcmdMembership = "add-remove-nodes"
 	subcmdShutdown   = "shutdown"
 	subcmdAttach     = "attach"
@@ -117,6 +125,7 @@ const (
 	subcmdShowRemoteAIS    = "remote-cluster"
 	subcmdShowCluster      = subcmdCluster
 	subcmdShowClusterStats = "stats"
+	subcmdShowRepair       = "repair"
 
 	subcmdShowStorage  = commandStorage
 	subcmdShowMpath    = subcmdMountpath
@@ -140,6 +149,10 @@ const (
 	subcmdStopDsort    = subcmdDsort
 	subcmdStopDownload = subcmdDownload
 
+	// Pause (and resume) subcommands
+	subcmdPauseXaction = subcmdXaction
+	subcmdPauseDsort   = subcmdDsort
+
 	// Bucket subcommands
 	subcmdSummary = "summary"
 
@@ -150,6 +163,14 @@ const (
 	// Archive subcommands
 	subcmdAppend = "append"
 
+	// Schedule subcommands
+	subcmdSchedule        = "schedule"
+	subcmdScheduleAdd     = "add"
+	subcmdScheduleRemove  = commandRemove
+	subcmdScheduleEnable  = "enable"
+	subcmdScheduleDisable = "disable"
+	subcmdShowSchedule    = subcmdSchedule
+
 	// Wait subcommands
 	subcmdWaitXaction  = subcmdXaction
 	subcmdWaitDownload = subcmdDownload
@@ -167,6 +188,7 @@ const (
 	subcmdAuthCluster = subcmdCluster
 	subcmdAuthToken   = "token"
 	subcmdAuthConfig  = subcmdConfig
+	subcmdAuthRotate  = "rotate-key"
 
 	// Warm up subcommands
 	subcmdPreload = "preload"
@@ -180,6 +202,7 @@ const (
 	subcmdInit = "init"
 	subcmdSpec = "spec"
 	subcmdCode = "code"
+	subcmdProc = "proc"
 
 	// CLI config subcommands
 	subcmdCLI           = "cli"
@@ -237,12 +260,18 @@ const (
 	detachRemoteAISArgument   = aliasArgument
 	joinNodeArgument          = "IP:PORT"
 	startDownloadArgument     = "SOURCE DESTINATION"
+	syncArgument              = "SRC_DIR|BUCKET[/PREFIX] DST_DIR|BUCKET[/PREFIX]"
 	jsonSpecArgument          = "JSON_SPECIFICATION"
 	showStatsArgument         = "[DAEMON_ID] [STATS_FILTER]"
 
 	// Xactions
 	xactionArgument = "XACTION_NAME"
 
+	// Schedules
+	addScheduleArgument      = "NAME CRON JOB [BUCKET [DST_BUCKET]]"
+	scheduleArgument         = "NAME"
+	optionalScheduleArgument = "[NAME]"
+
 	// List command
 	listCommandArgument = "[PROVIDER://][BUCKET_NAME]"
 
@@ -339,6 +368,7 @@ var (
 	listBucketsFlag   = cli.StringFlag{Name: "buckets", Usage: "comma-separated list of bucket names, e.g.: 'b1,b2,b3'"}
 	compactPropFlag   = cli.BoolFlag{Name: "compact,c", Usage: "display properties grouped in human-readable mode"}
 	nameOnlyFlag      = cli.BoolFlag{Name: "name-only", Usage: "show only object names"}
+	scrubRestartFlag  = cli.BoolFlag{Name: "restart", Usage: "discard saved progress and scrub everything from the beginning"}
 
 	// Config
 	configTypeFlag = cli.StringFlag{Name: "type", Usage: "show the specified configuration, one of: 'all','cluster','local'"}
@@ -447,6 +477,32 @@ var (
 	}
 	// end archive
 
+	// sync
+	syncCompareFlag = cli.StringFlag{
+		Name: "compare",
+		Usage: "how to detect changed files and objects: 'size' (size only), 'mtime' (size and modification time), " +
+			"or 'checksum' (size and checksum)",
+		Value: syncCmpMtime,
+	}
+	syncDeleteFlag = cli.BoolFlag{Name: "delete", Usage: "delete destination objects (files) that do not exist in the source"}
+	includeFlag    = cli.StringFlag{Name: "include", Usage: "comma-separated list of name patterns to include, e.g.: '*.jpg,train/*'"}
+	excludeFlag    = cli.StringFlag{Name: "exclude", Usage: "comma-separated list of name patterns to exclude, e.g.: '*.tmp,.git/*'"}
+
+	// version history (ais:// buckets with versioning.max_history > 0)
+	listVersionsFlag = cli.BoolFlag{Name: "versions", Usage: "list previous object versions"}
+	objVersionFlag   = cli.StringFlag{Name: "obj-version", Usage: "previous object version (when removing, '*' - all previous versions)"}
+	bypassGovFlag    = cli.BoolFlag{
+		Name:  "bypass-governance",
+		Usage: "bypass object lock retention in governance mode (requires admin access)",
+	}
+
+	// list objects filter (evaluated by targets)
+	whereFlag = cli.StringFlag{
+		Name: "where",
+		Usage: "list only objects that match filter expression over name, size, atime, age, version, and custom metadata, " +
+			"e.g.: 'custom.label == \"cat\" && size > 1MiB' (remote buckets: objects present in the cluster)",
+	}
+
 	sourceBckFlag = cli.StringFlag{Name: "source-bck", Usage: "source bucket"}
 
 	// AuthN
@@ -463,6 +519,10 @@ var (
 		Usage: "show total size of new objects without really creating them",
 	}
 	cpBckPrefixFlag = cli.StringFlag{Name: "prefix", Usage: "prefix added to every new object's name"}
+	cpBckResumeFlag = cli.BoolFlag{
+		Name:  "resume",
+		Usage: "continue the same interrupted job from its last checkpoint (default: start from scratch)",
+	}
 
 	// ETL
 	etlExtFlag = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
@@ -493,11 +553,40 @@ var (
 		Name:  "wait-timeout",
 		Usage: "determines how long ais target should wait for pod to become ready",
 	}
+	// ETL: local (Kubernetes-free) runtime
+	etlProcsFlag = cli.IntFlag{
+		Name:  "procs",
+		Usage: "number of transformer processes per target (io:// - max concurrent commands)",
+	}
+	etlListenFlag = cli.StringFlag{
+		Name:  "listen",
+		Usage: "transformer listens on: 'unix' (socket, default) or 'tcp' (loopback port)",
+	}
+	etlCPUFlag = cli.Float64Flag{
+		Name:  "cpu",
+		Usage: "CPU limit (number of cores) for each transformer, e.g. 0.5 (requires cgroup v2)",
+	}
+	etlMemFlag = cli.StringFlag{
+		Name:  "mem",
+		Usage: "memory limit for each transformer, e.g. 512MiB (requires cgroup v2)",
+	}
 	waitFlag = cli.BoolFlag{
 		Name:  "wait",
 		Usage: "wait until the operation is finished",
 	}
 
+	// Schedule
+	overlapFlag = cli.StringFlag{
+		Name:  "overlap",
+		Usage: "what to do when the previous run is still running, one of: 'skip', 'queue', 'cancel'",
+		Value: "skip",
+	}
+	jobValueFlag = cli.StringFlag{
+		Name:  "value",
+		Usage: "job specification (action message value): JSON or @FILE, e.g.: '{\"prefix\": \"logs/\"}', @dsort-spec.json",
+	}
+	disabledFlag = cli.BoolFlag{Name: "disabled", Usage: "add the schedule in disabled state"}
+
 	// Node
 	roleFlag = cli.StringFlag{
 		Name: "role", Required: true,
@@ -541,4 +630,20 @@ var (
 		Name:  "path",
 		Usage: "display path to the AIS CLI configuration",
 	}
+
+	// job history
+	jobHistoryFlag = cli.BoolFlag{
+		Name:  "history",
+		Usage: "show finished jobs recorded in the cluster's job history (newest first)",
+	}
+	jobUserFlag  = cli.StringFlag{Name: "user", Usage: "show only jobs started by this user"}
+	jobSinceFlag = cli.StringFlag{
+		Name:  "since",
+		Usage: "show only jobs that finished since this time (RFC3339) or within this duration, e.g. '24h'",
+	}
+	jobUntilFlag = cli.StringFlag{
+		Name:  "until",
+		Usage: "show only jobs that finished before this time (RFC3339) or this long ago, e.g. '1h'",
+	}
+	jobLimitFlag = cli.IntFlag{Name: "limit", Usage: "max number of jobs to show", Value: xact.HistDefaultLimit}
 )
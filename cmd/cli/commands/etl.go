@@ -33,6 +33,19 @@ var (
 			commTypeFlag,
 			waitTimeoutFlag,
 			etlUUID,
+			etlProcsFlag,
+			etlListenFlag,
+			etlCPUFlag,
+			etlMemFlag,
+		},
+		subcmdProc: {
+			commTypeFlag,
+			waitTimeoutFlag,
+			etlUUID,
+			etlProcsFlag,
+			etlListenFlag,
+			etlCPUFlag,
+			etlMemFlag,
 		},
 		subcmdSpec: {
 			fromFileFlag,
@@ -47,6 +60,7 @@ var (
 			etlExtFlag,
 			cpBckPrefixFlag,
 			cpBckDryRunFlag,
+			cpBckResumeFlag,
 			waitFlag,
 			etlBucketRequestTimeout,
 			templateFlag,
@@ -99,6 +113,13 @@ var (
 				Flags:  etlSubcmdsFlags[subcmdCode],
 				Action: etlInitCodeHandler,
 			},
+			{
+				Name:      subcmdProc,
+				Usage:     "start ETL job running the specified command as local process(es) on each target (no Kubernetes)",
+				ArgsUsage: "COMMAND [ARGS...]",
+				Flags:     etlSubcmdsFlags[subcmdProc],
+				Action:    etlInitProcHandler,
+			},
 		},
 	}
 	objCmdETL = cli.Command{
@@ -230,18 +251,41 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	}
 
 	msg.Runtime = parseStrFlag(c, runtimeFlag)
-	msg.CommTypeX = parseStrFlag(c, commTypeFlag)
-	if msg.CommTypeX != "" {
-		// Missing `/` at the end, eg. `hpush:/` (should be `hpush://`)
-		if strings.HasSuffix(msg.CommTypeX, ":/") {
-			msg.CommTypeX += "/"
-		}
-		// Missing `://` at the end, eg. `hpush` (should be `hpush://`)
-		if !strings.HasSuffix(msg.CommTypeX, "://") {
-			msg.CommTypeX += "://"
-		}
+	msg.CommTypeX = parseCommTypeFlag(c)
+	msg.WaitTimeout = cos.Duration(parseDurationFlag(c, waitTimeoutFlag))
+	if msg.ProcOpts, err = parseProcOpts(c); err != nil {
+		return
+	}
+
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	id, err := api.ETLInit(defaultAPIParams, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "%s\n", id)
+	return nil
+}
+
+func etlInitProcHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "COMMAND")
 	}
+	msg := &etl.InitProcMsg{Command: c.Args()}
+	msg.IDX = parseStrFlag(c, etlUUID)
+	if err = cos.ValidateEtlID(msg.ID()); err != nil {
+		return
+	}
+	if err = etlExists(msg.ID()); err != nil {
+		return
+	}
+	msg.CommTypeX = parseCommTypeFlag(c)
 	msg.WaitTimeout = cos.Duration(parseDurationFlag(c, waitTimeoutFlag))
+	if msg.ProcOpts, err = parseProcOpts(c); err != nil {
+		return
+	}
 
 	if err := msg.Validate(); err != nil {
 		return err
@@ -255,6 +299,31 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func parseCommTypeFlag(c *cli.Context) (commType string) {
+	commType = parseStrFlag(c, commTypeFlag)
+	if commType != "" {
+		// Missing `/` at the end, eg. `hpush:/` (should be `hpush://`)
+		if strings.HasSuffix(commType, ":/") {
+			commType += "/"
+		}
+		// Missing `://` at the end, eg. `hpush` (should be `hpush://`)
+		if !strings.HasSuffix(commType, "://") {
+			commType += "://"
+		}
+	}
+	return
+}
+
+func parseProcOpts(c *cli.Context) (opts etl.ProcOpts, err error) {
+	opts.Procs = parseIntFlag(c, etlProcsFlag)
+	opts.Listen = parseStrFlag(c, etlListenFlag)
+	opts.CPU = c.Float64(etlCPUFlag.Name)
+	if flagIsSet(c, etlMemFlag) {
+		opts.Mem, err = parseByteFlagToInt(c, etlMemFlag)
+	}
+	return
+}
+
 func etlListHandler(c *cli.Context) (err error) {
 	list, err := api.ETLList(defaultAPIParams)
 	if err != nil {
@@ -280,6 +349,10 @@ func etlSourceHandler(c *cli.Context) (err error) {
 		fmt.Fprintf(c.App.Writer, "%s\n", string(initMsg.Spec))
 		return
 	}
+	if initMsg, ok := msg.(*etl.InitProcMsg); ok {
+		fmt.Fprintf(c.App.Writer, "%s\n", strings.Join(initMsg.Command, " "))
+		return
+	}
 	return
 }
 
@@ -427,6 +500,7 @@ func etlBucketHandler(c *cli.Context) (err error) {
 		CopyBckMsg: apc.CopyBckMsg{
 			Prefix: parseStrFlag(c, cpBckPrefixFlag),
 			DryRun: flagIsSet(c, cpBckDryRunFlag),
+			Resume: flagIsSet(c, cpBckResumeFlag),
 		},
 	}
 
@@ -24,8 +24,11 @@ var (
 	jobSubcmds = []cli.Command{
 		jobStartSubcmds,
 		jobStopSubcmds,
+		jobPauseSubcmds,
+		jobResumeSubcmds,
 		jobWaitSubcmds,
 		jobRemoveSubcmds,
+		jobScheduleSubcmds,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
@@ -0,0 +1,116 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles the cluster's job history.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/urfave/cli"
+)
+
+const histTimeFmt = "01-02 15:04:05"
+
+func showJobHandler(c *cli.Context) error {
+	if !flagIsSet(c, jobHistoryFlag) {
+		if c.NArg() > 0 {
+			return commandNotFoundError(c, c.Args().First())
+		}
+		return cli.ShowSubcommandHelp(c)
+	}
+	query, err := parseHistQuery(c)
+	if err != nil {
+		return err
+	}
+	recs, err := api.GetJobHistory(defaultAPIParams, query)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(recs, c.App.Writer, "", true)
+	}
+	if len(recs) == 0 {
+		fmt.Fprintln(c.App.Writer, "No jobs found")
+		return nil
+	}
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "ID\tKIND\tBUCKET\tUSER\tSTART\tDURATION\tOBJECTS\tSIZE\tSTATUS")
+	}
+	for _, rec := range recs {
+		var (
+			bcks   = make([]string, 0, len(rec.Buckets))
+			user   = rec.User
+			status = "Finished"
+		)
+		for i := range rec.Buckets {
+			bcks = append(bcks, rec.Buckets[i].String())
+		}
+		if user == "" {
+			user = "-"
+		}
+		if len(bcks) == 0 {
+			bcks = append(bcks, "-")
+		}
+		if rec.Aborted {
+			status = "Aborted"
+		} else if rec.ErrMsg != "" {
+			status = "Failed"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", rec.ID, rec.Kind, strings.Join(bcks, " => "), user,
+			rec.StartTime.Format(histTimeFmt), rec.Duration().Round(time.Second),
+			rec.Stats.Objs, cos.B2S(rec.Stats.Bytes, 2), status)
+	}
+	return tw.Flush()
+}
+
+func parseHistQuery(c *cli.Context) (query *xact.HistQuery, err error) {
+	query = &xact.HistQuery{User: parseStrFlag(c, jobUserFlag), Limit: parseIntFlag(c, jobLimitFlag)}
+	if query.Since, err = parseHistTime(parseStrFlag(c, jobSinceFlag)); err != nil {
+		return
+	}
+	if query.Until, err = parseHistTime(parseStrFlag(c, jobUntilFlag)); err != nil {
+		return
+	}
+	uri := c.Args().Get(1)
+	switch kind := c.Args().First(); {
+	case kind == "":
+	case xact.IsValidKind(kind) || kind == apc.ActDownload:
+		query.Kind = kind
+	case strings.Contains(kind, apc.BckProviderSeparator):
+		uri = kind
+	default:
+		return nil, fmt.Errorf("invalid job kind %q", kind)
+	}
+	if uri != "" {
+		query.Bck, err = parseBckURI(c, uri)
+	}
+	return
+}
+
+// either absolute time or duration ("that long ago")
+func parseHistTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %q: expecting duration (e.g. '24h') or RFC3339 timestamp", s)
+	}
+	return t, nil
+}
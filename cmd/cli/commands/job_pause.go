@@ -0,0 +1,140 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that pause and resume running jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/dsort"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/urfave/cli"
+)
+
+var (
+	jobPauseSubcmds = cli.Command{
+		Name:  commandPause,
+		Usage: "pause jobs running in the cluster",
+		Subcommands: []cli.Command{
+			{
+				Name:         subcmdPauseXaction,
+				Usage:        "pause an xaction",
+				ArgsUsage:    "XACTION_ID|XACTION_NAME [BUCKET]",
+				Description:  pausableXactionsDesc(),
+				Action:       pauseXactionHandler,
+				BashComplete: xactionCompletions(apc.ActXactPause),
+			},
+			{
+				Name:         subcmdPauseDsort,
+				Usage:        fmt.Sprintf("pause a %s job with given ID", dsort.DSortName),
+				ArgsUsage:    jobIDArgument,
+				Action:       pauseDsortHandler,
+				BashComplete: dsortIDRunningCompletions,
+			},
+		},
+	}
+	jobResumeSubcmds = cli.Command{
+		Name:  commandResume,
+		Usage: "resume paused jobs",
+		Subcommands: []cli.Command{
+			{
+				Name:         subcmdPauseXaction,
+				Usage:        "resume a paused xaction",
+				ArgsUsage:    "XACTION_ID|XACTION_NAME [BUCKET]",
+				Description:  pausableXactionsDesc(),
+				Action:       resumeXactionHandler,
+				BashComplete: xactionCompletions(apc.ActXactResume),
+			},
+			{
+				Name:         subcmdPauseDsort,
+				Usage:        fmt.Sprintf("resume a paused %s job with given ID", dsort.DSortName),
+				ArgsUsage:    jobIDArgument,
+				Action:       resumeDsortHandler,
+				BashComplete: dsortIDRunningCompletions,
+			},
+		},
+	}
+)
+
+func pausableXactionsDesc() string {
+	kinds := make([]string, 0, 8)
+	for kind, dtor := range xact.Table {
+		if dtor.Pausable {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return fmt.Sprintf("%s can be one of: %q", xactionArgument, strings.Join(kinds, ", "))
+}
+
+func pauseXactionHandler(c *cli.Context) error  { return _pauseXaction(c, false /*resume*/) }
+func resumeXactionHandler(c *cli.Context) error { return _pauseXaction(c, true) }
+
+func _pauseXaction(c *cli.Context, resume bool) (err error) {
+	var sid string
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "xaction name or id")
+	}
+
+	_, xactID, xactKind, bck, err := parseXactionFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck}
+	verb := "Paused"
+	if resume {
+		verb = "Resumed"
+		err = api.ResumeXaction(defaultAPIParams, xactArgs)
+	} else {
+		err = api.PauseXaction(defaultAPIParams, xactArgs)
+	}
+	if err != nil {
+		return
+	}
+
+	if xactKind != "" && xactID != "" {
+		sid = fmt.Sprintf("%s, ID=%q", xactKind, xactID)
+	} else if xactKind != "" {
+		sid = xactKind
+	} else {
+		sid = fmt.Sprintf("xaction ID=%q", xactID)
+	}
+	if bck.IsEmpty() {
+		fmt.Fprintf(c.App.Writer, "%s %s\n", verb, sid)
+	} else {
+		fmt.Fprintf(c.App.Writer, "%s %s, bucket=%s\n", verb, sid, bck)
+	}
+	return
+}
+
+func pauseDsortHandler(c *cli.Context) error  { return _pauseDsort(c, false /*resume*/) }
+func resumeDsortHandler(c *cli.Context) error { return _pauseDsort(c, true) }
+
+func _pauseDsort(c *cli.Context, resume bool) (err error) {
+	id := c.Args().First()
+
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, dsort.DSortName+" job ID")
+	}
+
+	verb := "paused"
+	if resume {
+		verb = "resumed"
+		err = api.ResumeDSort(defaultAPIParams, id)
+	} else {
+		err = api.PauseDSort(defaultAPIParams, id)
+	}
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(c.App.Writer, "%s job %q successfully %s\n", dsort.DSortName, id, verb)
+	return
+}
@@ -56,6 +56,16 @@ var (
 		subcmdLRU: {
 			listBucketsFlag,
 			forceFlag,
+			dryRunFlag,
+		},
+		subcmdLifecycle: {
+			listBucketsFlag,
+			dryRunFlag,
+			waitTimeoutFlag,
+		},
+		subcmdScrub: {
+			listBucketsFlag,
+			scrubRestartFlag,
 		},
 	}
 
@@ -91,6 +101,18 @@ var (
 				Flags:  startCmdsFlags[subcmdLRU],
 				Action: startLRUHandler,
 			},
+			{
+				Name:   subcmdLifecycle,
+				Usage:  fmt.Sprintf("start %q xaction to apply bucket lifecycle rules (with --dry-run: report matching objects)", apc.ActLifecycle),
+				Flags:  startCmdsFlags[subcmdLifecycle],
+				Action: startLifecycleHandler,
+			},
+			{
+				Name:   subcmdScrub,
+				Usage:  fmt.Sprintf("start %q xaction to verify checksums of all objects and EC slices and repair corrupted ones", apc.ActScrub),
+				Flags:  startCmdsFlags[subcmdScrub],
+				Action: startScrubHandler,
+			},
 			{
 				Name:         subcmdStgCleanup,
 				Usage:        "perform storage cleanup: remove deleted objects and old/obsolete workfiles",
@@ -498,39 +520,118 @@ func startDsortHandler(c *cli.Context) (err error) {
 }
 
 func startLRUHandler(c *cli.Context) (err error) {
-	if !flagIsSet(c, listBucketsFlag) {
+	dryRun := flagIsSet(c, dryRunFlag)
+	if !flagIsSet(c, listBucketsFlag) && !dryRun {
 		return startXactionHandler(c)
 	}
 
-	if flagIsSet(c, forceFlag) {
+	if flagIsSet(c, forceFlag) && !dryRun {
 		warning := "Forcing LRU will evict any bucket ignoring `lru.enabled` property"
 		if ok := confirm(c, "Would you like to continue?", warning); !ok {
 			return
 		}
 	}
 
-	bckArgs := makeList(parseStrFlag(c, listBucketsFlag))
-	buckets := make([]cmn.Bck, len(bckArgs))
-	for idx, bckArg := range bckArgs {
-		bck, err := parseBckURI(c, bckArg)
-		if err != nil {
-			return err
+	var buckets []cmn.Bck
+	if flagIsSet(c, listBucketsFlag) {
+		bckArgs := makeList(parseStrFlag(c, listBucketsFlag))
+		buckets = make([]cmn.Bck, len(bckArgs))
+		for idx, bckArg := range bckArgs {
+			bck, err := parseBckURI(c, bckArg)
+			if err != nil {
+				return err
+			}
+			buckets[idx] = bck
 		}
-		buckets[idx] = bck
 	}
 
 	var (
 		id       string
-		xactArgs = api.XactReqArgs{Kind: apc.ActLRU, Buckets: buckets, Force: flagIsSet(c, forceFlag)}
+		xactArgs = api.XactReqArgs{Kind: apc.ActLRU, Buckets: buckets, Force: flagIsSet(c, forceFlag), DryRun: dryRun}
 	)
 	if id, err = api.StartXaction(defaultAPIParams, xactArgs); err != nil {
 		return
 	}
 
+	if dryRun {
+		fmt.Fprintf(c.App.Writer, "Started %s %q (dry-run: nothing will be evicted), %s\n", apc.ActLRU, id, xactProgressMsg(id))
+		return
+	}
 	fmt.Fprintf(c.App.Writer, "Started %s %q, %s\n", apc.ActLRU, id, xactProgressMsg(id))
 	return
 }
 
+func startLifecycleHandler(c *cli.Context) (err error) {
+	var (
+		id      string
+		buckets []cmn.Bck
+		dryRun  = flagIsSet(c, dryRunFlag)
+	)
+	if flagIsSet(c, listBucketsFlag) {
+		bckArgs := makeList(parseStrFlag(c, listBucketsFlag))
+		buckets = make([]cmn.Bck, len(bckArgs))
+		for idx, bckArg := range bckArgs {
+			bck, err := parseBckURI(c, bckArg)
+			if err != nil {
+				return err
+			}
+			buckets[idx] = bck
+		}
+	}
+	printDryRunHeader(c)
+
+	xactArgs := api.XactReqArgs{Kind: apc.ActLifecycle, Buckets: buckets, DryRun: dryRun}
+	if id, err = api.StartXaction(defaultAPIParams, xactArgs); err != nil {
+		return
+	}
+	if !dryRun {
+		fmt.Fprintf(c.App.Writer, "Started %s %q, %s\n", apc.ActLifecycle, id, xactProgressMsg(id))
+		return
+	}
+
+	// dry-run: wait for the xaction to finish and report the totals
+	wargs := api.XactReqArgs{ID: id, Kind: apc.ActLifecycle}
+	if flagIsSet(c, waitTimeoutFlag) {
+		wargs.Timeout = parseDurationFlag(c, waitTimeoutFlag)
+	}
+	if err = waitForXactionCompletion(defaultAPIParams, wargs); err != nil {
+		return
+	}
+	snaps, err := api.GetXactionSnapsByID(defaultAPIParams, id)
+	if err != nil {
+		return
+	}
+	objs, _, _ := snaps.ObjCounts()
+	size, _, _ := snaps.ByteCounts()
+	fmt.Fprintf(c.App.Writer, "%d object%s (total size %s) match lifecycle rules\n",
+		objs, cos.Plural(int(objs)), cos.B2S(size, 2))
+	return
+}
+
+func startScrubHandler(c *cli.Context) (err error) {
+	var (
+		id      string
+		buckets []cmn.Bck
+	)
+	if flagIsSet(c, listBucketsFlag) {
+		bckArgs := makeList(parseStrFlag(c, listBucketsFlag))
+		buckets = make([]cmn.Bck, len(bckArgs))
+		for idx, bckArg := range bckArgs {
+			bck, err := parseBckURI(c, bckArg)
+			if err != nil {
+				return err
+			}
+			buckets[idx] = bck
+		}
+	}
+	xactArgs := api.XactReqArgs{Kind: apc.ActScrub, Buckets: buckets, Force: flagIsSet(c, scrubRestartFlag)}
+	if id, err = api.StartXaction(defaultAPIParams, xactArgs); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "Started %s %q, %s\n", apc.ActScrub, id, xactProgressMsg(id))
+	return
+}
+
 func startPrefetchHandler(c *cli.Context) (err error) {
 	printDryRunHeader(c)
 
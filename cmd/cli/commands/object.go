@@ -117,6 +117,12 @@ func getObject(c *cli.Context, outFile string, silent bool) (err error) {
 		objArgs.Query = make(url.Values, 2)
 		objArgs.Query.Set(apc.QparamOrigURL, uri)
 	}
+	if flagIsSet(c, objVersionFlag) {
+		if objArgs.Query == nil {
+			objArgs.Query = make(url.Values, 1)
+		}
+		objArgs.Query.Set(apc.QparamObjVersion, parseStrFlag(c, objVersionFlag))
+	}
 	// TODO: validate
 	if archPath != "" {
 		if objArgs.Query == nil {
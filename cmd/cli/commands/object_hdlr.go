@@ -23,14 +23,19 @@ var (
 			rmRfFlag,
 			verboseFlag,
 			yesFlag,
+			objVersionFlag,
+			bypassGovFlag,
 		),
-		commandRename: {},
+		commandRename:    {},
+		commandRestore:   {},
+		commandLegalHold: {},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
 			archpathFlag,
 			cksumFlag,
 			checkCachedFlag,
+			objVersionFlag,
 		},
 		commandPut: append(
 			supportedCksumFlags,
@@ -121,6 +126,22 @@ var (
 				BashComplete: oldAndNewBucketCompletions(
 					[]cli.BashCompleteFunc{}, true /* separator */, apc.ProviderAIS),
 			},
+			{
+				Name:         commandRestore,
+				Usage:        "restore previous object version (ais:// buckets with version history)",
+				ArgsUsage:    objectArgument + " VERSION",
+				Flags:        objectCmdsFlags[commandRestore],
+				Action:       restoreObjVersionHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+			},
+			{
+				Name:         commandLegalHold,
+				Usage:        "place (\"on\") or remove (\"off\") object's legal hold (buckets with object lock)",
+				ArgsUsage:    objectArgument + " on|off",
+				Flags:        objectCmdsFlags[commandLegalHold],
+				Action:       legalHoldHandler,
+				BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+			},
 			{
 				Name:      commandRemove,
 				Usage:     "remove object(s) from the specified bucket",
@@ -205,10 +226,58 @@ func mvObjectHandler(c *cli.Context) (err error) {
 	return
 }
 
+func restoreObjVersionHandler(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return incorrectUsageMsg(c, "invalid number of arguments")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().Get(0))
+	if err != nil {
+		return
+	}
+	if !bck.IsAIS() {
+		return incorrectUsageMsg(c, "provider %q not supported", bck.Provider)
+	}
+	version := c.Args().Get(1)
+	if err = api.RestoreObjectVersion(defaultAPIParams, bck, objName, version); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%q: restored version %s\n", objName, version)
+	return
+}
+
+func legalHoldHandler(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return incorrectUsageMsg(c, "invalid number of arguments")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().Get(0))
+	if err != nil {
+		return
+	}
+	var hold bool
+	switch c.Args().Get(1) {
+	case "on":
+		hold = true
+	case "off":
+	default:
+		return incorrectUsageMsg(c, "invalid legal hold %q (expecting \"on\" or \"off\")", c.Args().Get(1))
+	}
+	if err = api.SetObjectLegalHold(defaultAPIParams, bck, objName, hold); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%q: legal hold %s\n", objName, c.Args().Get(1))
+	return
+}
+
 func removeObjectHandler(c *cli.Context) (err error) {
 	if c.NArg() == 0 {
 		return incorrectUsageMsg(c, "missing bucket")
 	}
+	if flagIsSet(c, objVersionFlag) {
+		return rmObjVersion(c)
+	}
+	if flagIsSet(c, bypassGovFlag) {
+		return rmObjBypassGov(c)
+	}
 
 	if c.NArg() == 1 {
 		uri := c.Args().First()
@@ -249,6 +318,43 @@ func removeObjectHandler(c *cli.Context) (err error) {
 	return multiObjOp(c, commandRemove)
 }
 
+// ais object rm --obj-version VERSION BUCKET/OBJECT_NAME
+func rmObjVersion(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return incorrectUsageMsg(c, "flag %q requires a single object name argument", objVersionFlag.Name)
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	version := parseStrFlag(c, objVersionFlag)
+	if err = api.DeleteObjectVersion(defaultAPIParams, bck, objName, version); err != nil {
+		return
+	}
+	if version == apc.ObjVerAll {
+		fmt.Fprintf(c.App.Writer, "%q: deleted all previous versions\n", objName)
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%q: deleted version %s\n", objName, version)
+	return
+}
+
+// ais object rm --bypass-governance BUCKET/OBJECT_NAME
+func rmObjBypassGov(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return incorrectUsageMsg(c, "flag %q requires a single object name argument", bypassGovFlag.Name)
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	if err = api.DeleteObjectBypassGov(defaultAPIParams, bck, objName); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "deleted %q from %s\n", objName, bck)
+	return
+}
+
 func getHandler(c *cli.Context) (err error) {
 	outFile := c.Args().Get(1) // empty string if arg not given
 	return getObject(c, outFile, false /*silent*/)
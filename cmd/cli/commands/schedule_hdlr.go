@@ -0,0 +1,336 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles commands that manage scheduled (recurring) jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sched"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/urfave/cli"
+)
+
+var (
+	scheduleCmdsFlags = map[string][]cli.Flag{
+		subcmdScheduleAdd: {
+			overlapFlag,
+			jobValueFlag,
+			disabledFlag,
+		},
+		subcmdShowSchedule: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+	}
+
+	jobScheduleSubcmds = cli.Command{
+		Name:  subcmdSchedule,
+		Usage: "manage scheduled (recurring) jobs",
+		Subcommands: []cli.Command{
+			{
+				Name: subcmdScheduleAdd,
+				Usage: "add (or update) a named schedule, where JOB is a startable xaction (e.g. 'lru'), " +
+					"a bucket action (e.g. 'copy-bck', 'prefetch-listrange'), 'download', or 'dsort'",
+				ArgsUsage:    addScheduleArgument,
+				Flags:        scheduleCmdsFlags[subcmdScheduleAdd],
+				Action:       addScheduleHandler,
+				BashComplete: scheduleJobCompletions,
+			},
+			{
+				Name:         subcmdScheduleRemove,
+				Usage:        "remove schedule",
+				ArgsUsage:    scheduleArgument,
+				Action:       removeScheduleHandler,
+				BashComplete: scheduleCompletions,
+			},
+			{
+				Name:         subcmdScheduleEnable,
+				Usage:        "enable schedule",
+				ArgsUsage:    scheduleArgument,
+				Action:       enableScheduleHandler,
+				BashComplete: scheduleCompletions,
+			},
+			{
+				Name:         subcmdScheduleDisable,
+				Usage:        "disable schedule (the history of runs is preserved)",
+				ArgsUsage:    scheduleArgument,
+				Action:       disableScheduleHandler,
+				BashComplete: scheduleCompletions,
+			},
+		},
+	}
+
+	showCmdSchedule = cli.Command{
+		Name:         subcmdShowSchedule,
+		Usage:        "show scheduled jobs or, given schedule name, its details and history of runs",
+		ArgsUsage:    optionalScheduleArgument,
+		Flags:        scheduleCmdsFlags[subcmdShowSchedule],
+		Action:       showScheduleHandler,
+		BashComplete: scheduleCompletions,
+	}
+)
+
+func addScheduleHandler(c *cli.Context) (err error) {
+	if c.NArg() < 3 {
+		return missingArgumentsError(c, "schedule name", "cron expression", "job")
+	}
+	var (
+		job  = c.Args().Get(2)
+		spec = &sched.Spec{
+			Name:     c.Args().Get(0),
+			Cron:     c.Args().Get(1),
+			Overlap:  parseStrFlag(c, overlapFlag),
+			Disabled: flagIsSet(c, disabledFlag),
+		}
+		value interface{}
+	)
+	if flagIsSet(c, jobValueFlag) {
+		if value, err = parseJobValue(parseStrFlag(c, jobValueFlag)); err != nil {
+			return err
+		}
+	}
+	if c.NArg() > 3 {
+		if spec.Bck, err = parseBckURI(c, c.Args().Get(3)); err != nil {
+			return err
+		}
+	}
+	if c.NArg() > 4 {
+		if spec.BckTo, err = parseBckURI(c, c.Args().Get(4)); err != nil {
+			return err
+		}
+	}
+	switch {
+	case job == apc.ActDownload || job == apc.ActDsort || sched.IsBckAction(job):
+		spec.Action = apc.ActionMsg{Action: job, Value: value}
+	case xact.Table[job].Startable:
+		msg := xact.QueryMsg{Kind: job, Bck: spec.Bck}
+		spec.Action = apc.ActionMsg{Action: apc.ActXactStart, Value: msg}
+	default:
+		return incorrectUsageMsg(c, "cannot schedule %q: expecting startable xaction, bucket action, %q, or %q",
+			job, apc.ActDownload, apc.ActDsort)
+	}
+	if err = spec.Validate(); err != nil {
+		return err
+	}
+	if err = api.AddSchedule(defaultAPIParams, spec); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "added schedule %q (%s)\n", spec.Name, spec.Cron)
+	return nil
+}
+
+// JSON or @FILE
+func parseJobValue(s string) (value interface{}, err error) {
+	b := []byte(s)
+	if strings.HasPrefix(s, "@") {
+		if b, err = os.ReadFile(s[1:]); err != nil {
+			return nil, err
+		}
+	}
+	if err = jsoniter.Unmarshal(b, &value); err != nil {
+		return nil, fmt.Errorf("invalid job specification %q: %v", s, err)
+	}
+	return value, nil
+}
+
+func removeScheduleHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "schedule name")
+	}
+	name := c.Args().First()
+	if err = api.RemoveSchedule(defaultAPIParams, name); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "removed schedule %q\n", name)
+	return nil
+}
+
+func enableScheduleHandler(c *cli.Context) error  { return _setScheduleDisabled(c, false) }
+func disableScheduleHandler(c *cli.Context) error { return _setScheduleDisabled(c, true) }
+
+func _setScheduleDisabled(c *cli.Context, disabled bool) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "schedule name")
+	}
+	entry, err := api.GetSchedule(defaultAPIParams, c.Args().First())
+	if err != nil {
+		return err
+	}
+	state := "enabled"
+	if disabled {
+		state = "disabled"
+	}
+	if entry.Disabled == disabled {
+		fmt.Fprintf(c.App.Writer, "schedule %q is already %s\n", entry.Name, state)
+		return nil
+	}
+	spec := entry.Spec
+	spec.Disabled = disabled
+	if err := api.AddSchedule(defaultAPIParams, &spec); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "schedule %q %s\n", entry.Name, state)
+	return nil
+}
+
+func showScheduleHandler(c *cli.Context) error {
+	if c.NArg() > 0 {
+		entry, err := api.GetSchedule(defaultAPIParams, c.Args().First())
+		if err != nil {
+			return err
+		}
+		if flagIsSet(c, jsonFlag) {
+			return templates.DisplayOutput(entry, c.App.Writer, "", true)
+		}
+		return showScheduleDetails(c, entry)
+	}
+	entries, err := api.GetSchedules(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(entries, c.App.Writer, "", true)
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "NAME\tCRON\tJOB\tBUCKET\tOVERLAP\tNEXT\tLAST RUN\tSTATE")
+	}
+	for _, e := range entries {
+		last := "-"
+		if run := e.Last(); run != nil {
+			last = fmtSchedTime(run.Started)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Name, e.Cron, scheduleJob(&e.Spec), scheduleBck(&e.Spec), e.Overlap, fmtSchedTime(e.Next), last, scheduleState(e))
+	}
+	tw.Flush()
+	return nil
+}
+
+func showScheduleDetails(c *cli.Context, e *sched.Entry) error {
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", e.Name)
+	fmt.Fprintf(tw, "Cron:\t%s\n", e.Cron)
+	fmt.Fprintf(tw, "Job:\t%s\n", scheduleJob(&e.Spec))
+	fmt.Fprintf(tw, "Bucket:\t%s\n", scheduleBck(&e.Spec))
+	fmt.Fprintf(tw, "Overlap:\t%s\n", e.Overlap)
+	fmt.Fprintf(tw, "State:\t%s\n", scheduleState(e))
+	fmt.Fprintf(tw, "Created:\t%s\n", fmtSchedTime(e.Created))
+	fmt.Fprintf(tw, "Next:\t%s\n", fmtSchedTime(e.Next))
+	tw.Flush()
+	if len(e.History) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.App.Writer)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "SCHEDULED\tSTARTED\tJOB ID\tRESULT")
+	}
+	for i := len(e.History) - 1; i >= 0; i-- {
+		var (
+			run    = &e.History[i]
+			jobID  = run.JobID
+			result = "started"
+		)
+		switch {
+		case run.Skipped != "":
+			result = "skipped: " + run.Skipped
+		case run.Err != "":
+			result = "failed: " + run.Err
+		case run.JobID == "":
+			result = "starting"
+		}
+		if jobID == "" {
+			jobID = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", fmtSchedTime(run.Scheduled), fmtSchedTime(run.Started), jobID, result)
+	}
+	tw.Flush()
+	return nil
+}
+
+func scheduleJob(spec *sched.Spec) string {
+	if spec.Action.Action == apc.ActXactStart {
+		var msg xact.QueryMsg
+		if err := cos.MorphMarshal(spec.Action.Value, &msg); err == nil {
+			return msg.Kind
+		}
+	}
+	return spec.Action.Action
+}
+
+func scheduleBck(spec *sched.Spec) string {
+	switch {
+	case spec.Bck.IsEmpty():
+		return "-"
+	case spec.BckTo.IsEmpty():
+		return spec.Bck.String()
+	default:
+		return spec.Bck.String() + " => " + spec.BckTo.String()
+	}
+}
+
+func scheduleState(e *sched.Entry) string {
+	switch {
+	case e.Disabled:
+		return "disabled"
+	case e.Queued != 0:
+		return "queued"
+	default:
+		return "enabled"
+	}
+}
+
+func fmtSchedTime(ns int64) string {
+	if ns == 0 {
+		return "-"
+	}
+	return time.Unix(0, ns).Format(time.RFC3339)
+}
+
+//
+// completions
+//
+
+func scheduleCompletions(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	entries, err := api.GetSchedules(defaultAPIParams)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		fmt.Println(e.Name)
+	}
+}
+
+func scheduleJobCompletions(c *cli.Context) {
+	switch c.NArg() {
+	case 2:
+		fmt.Println(apc.ActDownload)
+		fmt.Println(apc.ActDsort)
+		for _, action := range sched.BckActions() {
+			fmt.Println(action)
+		}
+		for _, kind := range listXactions(true) {
+			fmt.Println(kind)
+		}
+	case 3, 4:
+		bucketCompletions()(c)
+	}
+}
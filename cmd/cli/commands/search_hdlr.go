@@ -44,6 +44,7 @@ var (
 		commandBucket:    {"dir", "directory"},
 		commandJob:       {"xaction", "batch", "async"},
 		commandArch:      {"serialize", "format", "reformat", "tar", "zip", "gzip"},
+		commandSync:      {"mirror", "rsync", "upload", "download", "backup"},
 		//
 		subcmdAuthAdd:  {"register", "create"},
 		subcmdDownload: {"load"},
@@ -45,6 +45,7 @@ var (
 		"compression.checksum":                apc.SupportedCompression,
 		"rebalance.compression":               apc.SupportedCompression,
 		"distributed_sort.compression":        apc.SupportedCompression,
+		"transport.codec":                     apc.SupportedCodecs,
 		"distributed_sort.duplicated_records": cmn.SupportedReactions,
 		"distributed_sort.ekm_malformed_line": cmn.SupportedReactions,
 		"distributed_sort.ekm_missing_key":    cmn.SupportedReactions,
@@ -55,9 +56,17 @@ var (
 		"checksum.validate_warm_get":          supportedBool,
 		"checksum.validate_obj_move":          supportedBool,
 		"ec.enabled":                          supportedBool,
+		"encryption.enabled":                  supportedBool,
 		"fshc.enabled":                        supportedBool,
+		"inventory.enabled":                   supportedBool,
+		"events.enabled":                      supportedBool,
+		"object_lock.enabled":                 supportedBool,
+		"object_lock.mode":                    {cmn.LockModeGovernance, cmn.LockModeCompliance},
+		"replication.enabled":                 supportedBool,
 		"lru.enabled":                         supportedBool,
+		"lru.policy":                          cmn.SupportedLRUPolicies,
 		"mirror.enabled":                      supportedBool,
+		"rate_limit.enabled":                  supportedBool,
 		"rebalance.enabled":                   supportedBool,
 		"resilver.enabled":                    supportedBool,
 		"versioning.enabled":                  supportedBool,
@@ -474,7 +483,16 @@ func xactionCompletions(cmd string) func(ctx *cli.Context) {
 	return func(c *cli.Context) {
 		if c.NArg() == 0 {
 			for kind, dtor := range xact.Table {
-				if (cmd != apc.ActXactStart) || (cmd == apc.ActXactStart && dtor.Startable) {
+				switch cmd {
+				case apc.ActXactStart:
+					if dtor.Startable {
+						fmt.Println(kind)
+					}
+				case apc.ActXactPause, apc.ActXactResume:
+					if dtor.Pausable {
+						fmt.Println(kind)
+					}
+				default:
 					fmt.Println(kind)
 				}
 			}
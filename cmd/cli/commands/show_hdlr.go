@@ -112,6 +112,19 @@ var (
 			rawFlag,
 			refreshFlag,
 		},
+		subcmdShowRepair: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+		subcmdShowJob: {
+			jobHistoryFlag,
+			jobUserFlag,
+			jobSinceFlag,
+			jobUntilFlag,
+			jobLimitFlag,
+			jsonFlag,
+			noHeaderFlag,
+		},
 	}
 
 	showCmd = cli.Command{
@@ -128,6 +141,8 @@ var (
 			showCmdRemoteAIS,
 			showCmdStorage,
 			showCmdJob,
+			showCmdSchedule,
+			showCmdRepair,
 			showCmdLog,
 		},
 	}
@@ -245,9 +260,22 @@ var (
 		BashComplete: daemonCompletions(completeAllDaemons),
 	}
 
+	showCmdRepair = cli.Command{
+		Name: subcmdShowRepair,
+		Usage: "show under-protected objects (missing mirror copies or EC slices) queued for repair and repair counters; " +
+			"given target ID, list its most urgent queued objects",
+		ArgsUsage:    optionalTargetIDArgument,
+		Flags:        showCmdsFlags[subcmdShowRepair],
+		Action:       showRepairHandler,
+		BashComplete: daemonCompletions(completeTargets),
+	}
+
 	showCmdJob = cli.Command{
-		Name:  subcmdShowJob,
-		Usage: "show running and completed jobs (xactions)",
+		Name:      subcmdShowJob,
+		Usage:     "show running and completed jobs (xactions); with --history, show the cluster's job history",
+		ArgsUsage: "[XACTION_NAME] [BUCKET]",
+		Flags:     showCmdsFlags[subcmdShowJob],
+		Action:    showJobHandler,
 		Subcommands: []cli.Command{
 			showCmdDownload,
 			showCmdDsort,
@@ -474,6 +502,8 @@ func _showXactList(c *cli.Context, nodeID, xactID, xactKind string, bck cmn.Bck)
 		return templates.DisplayOutput(dts, c.App.Writer, templates.XactionECGetBodyTmpl, useJSON)
 	case apc.ActECPut:
 		return templates.DisplayOutput(dts, c.App.Writer, templates.XactionECPutBodyTmpl, useJSON)
+	case apc.ActScrub:
+		return templates.DisplayOutput(dts, c.App.Writer, templates.XactionScrubBodyTmpl, useJSON)
 	default:
 		return templates.DisplayOutput(dts, c.App.Writer, templates.XactionsBodyTmpl, useJSON)
 	}
@@ -804,3 +834,52 @@ func showClusterStatsHandler(c *cli.Context) (err error) {
 		time.Sleep(sleep)
 	}
 }
+
+func showRepairHandler(c *cli.Context) error {
+	daemonID := argDaemonID(c)
+	status, err := api.GetRepairStatus(defaultAPIParams)
+	if err != nil {
+		return err
+	}
+	if daemonID != "" {
+		st, ok := status[daemonID]
+		if !ok {
+			return fmt.Errorf("target ID %q invalid - no such target", daemonID)
+		}
+		status = map[string]*cmn.RepairStatus{daemonID: st}
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(status, c.App.Writer, "", true)
+	}
+	tids := make([]string, 0, len(status))
+	for tid := range status {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TARGET\tQUEUED\tREPAIRED\tFAILED\tDROPPED\tSCANNING")
+	}
+	for _, tid := range tids {
+		st := status[tid]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%t\n", tid, st.Queued, st.Repaired, st.Failed, st.Dropped, st.Scanning)
+	}
+	tw.Flush()
+	if daemonID == "" || len(status[daemonID].Top) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(c.App.Writer)
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "OBJECT\tKIND\tMISSING\tREDUNDANCY\tSOURCE\tQUEUED")
+	}
+	for _, it := range status[daemonID].Top {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\n", it.Bck.String()+"/"+it.ObjName, it.Kind, it.Missing, it.Redundancy,
+			it.Source, time.Unix(0, it.Added).Format(time.RFC3339))
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,674 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles `ais sync` - mirroring local directories and buckets.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/urfave/cli"
+)
+
+// Sync
+//
+// `ais sync SRC DST` makes DST a mirror of SRC, where each of the two is either a local
+// directory or a bucket (optionally, with a prefix that is then treated as a virtual
+// directory). Files and objects are named relative to their directory (prefix) and
+// compared by name and:
+// - size only;
+// - size and modification time (default): the source is transferred when it is newer;
+// - size and checksum, computed for local files as per the bucket's checksum type.
+//
+// AIS objects do not carry modification time: uploading a file stores its modification
+// time in the object's custom metadata (see syncMtimeKey), and that is what gets compared.
+// Objects with unknown modification time (e.g., objects that were not uploaded by `ais sync`)
+// or checksum (e.g., remote objects that are not present in the cluster) are always transferred.
+//
+// Transfers run in parallel: PUT (directory => bucket), GET (bucket => directory), or
+// multi-object copy (bucket => bucket).
+
+const (
+	syncCmpSize     = "size"
+	syncCmpMtime    = "mtime"
+	syncCmpChecksum = "checksum"
+
+	syncCopyBatch = 1000 // max number of objects in a single multi-object copy request
+
+	syncMtimeKey = "sync-mtime" // custom metadata: source file modification time (unix nano)
+)
+
+type (
+	// file or object, named relative to its directory (bucket prefix)
+	syncEntry struct {
+		name  string
+		size  int64
+		mtime int64 // unix nano; zero when unknown
+		cksum string
+	}
+	// local directory or bucket with optional prefix
+	syncPath struct {
+		bck       cmn.Bck
+		prefix    string
+		dir       string
+		cksumType string
+		exists    bool
+	}
+	syncFilter struct {
+		include []string
+		exclude []string
+	}
+	syncPlan struct {
+		xfer []*syncEntry // source entries to transfer
+		del  []*syncEntry // extraneous destination entries
+		same int          // number of unchanged entries
+		size int64        // total size to transfer
+	}
+)
+
+var (
+	syncCmdFlags = []cli.Flag{
+		syncCompareFlag,
+		syncDeleteFlag,
+		includeFlag,
+		excludeFlag,
+		concurrencyFlag,
+		dryRunFlag,
+		verboseFlag,
+		yesFlag,
+	}
+
+	syncCmd = cli.Command{
+		Name:         commandSync,
+		Usage:        "synchronize (mirror) local directory with bucket, bucket with local directory, or two buckets",
+		ArgsUsage:    syncArgument,
+		Flags:        syncCmdFlags,
+		Action:       syncHandler,
+		BashComplete: putPromoteObjectCompletions,
+	}
+)
+
+func syncHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "source", "destination")
+	}
+	compare := parseStrFlag(c, syncCompareFlag)
+	if compare != syncCmpSize && compare != syncCmpMtime && compare != syncCmpChecksum {
+		return incorrectUsageMsg(c, "invalid %s=%q: expecting one of %q, %q, %q",
+			syncCompareFlag.Name, compare, syncCmpSize, syncCmpMtime, syncCmpChecksum)
+	}
+	src, err := parseSyncPath(c, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	dst, err := parseSyncPath(c, c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	if src.dir != "" && dst.dir != "" {
+		return incorrectUsageMsg(c, "at least one of %q, %q must be a bucket (e.g., ais://%s)",
+			src, dst, filepath.Base(dst.dir))
+	}
+	if src.dir == "" && dst.dir == "" {
+		if err := checkSyncBuckets(c, src, dst); err != nil {
+			return err
+		}
+	}
+	if err := src.init(compare, true /*must exist*/); err != nil {
+		return err
+	}
+	// (multi-object copy creates destination bucket)
+	if err := dst.init(compare, dst.dir == "" && src.dir != ""); err != nil {
+		return err
+	}
+	if compare == syncCmpChecksum && src.cksumType != dst.cksumType {
+		return fmt.Errorf("cannot compare checksums: %s uses %q, %s - %q (hint: use '--%s %s')",
+			src, src.cksumType, dst, dst.cksumType, syncCompareFlag.Name, syncCmpMtime)
+	}
+
+	filter := newSyncFilter(parseStrFlag(c, includeFlag), parseStrFlag(c, excludeFlag))
+	srcEntries, err := src.list(filter)
+	if err != nil {
+		return err
+	}
+	dstEntries, err := dst.list(filter)
+	if err != nil {
+		return err
+	}
+	switch compare {
+	case syncCmpMtime:
+		if err := syncMtimes(c, src, dst, srcEntries, dstEntries); err != nil {
+			return err
+		}
+	case syncCmpChecksum:
+		if err := syncChecksums(c, src, dst, srcEntries, dstEntries); err != nil {
+			return err
+		}
+	}
+	plan := syncDiff(srcEntries, dstEntries, compare, flagIsSet(c, syncDeleteFlag))
+
+	if flagIsSet(c, dryRunFlag) {
+		printDryRunHeader(c)
+		syncDryRun(c, src, dst, plan)
+		return nil
+	}
+	if len(plan.del) > 0 && !flagIsSet(c, yesFlag) {
+		prompt := fmt.Sprintf("Delete %d extraneous object%s from %s?", len(plan.del), cos.Plural(len(plan.del)), dst)
+		if ok := confirm(c, prompt); !ok {
+			return errors.New("operation canceled")
+		}
+	}
+
+	started := time.Now()
+	xferred, failed := syncTransfer(c, src, dst, plan.xfer)
+	var deleted, delFailed int
+	if len(plan.del) > 0 {
+		deleted, delFailed = syncDelete(c, dst, plan.del)
+	}
+	fmt.Fprintf(c.App.Writer, "Synced %s => %s in %v:\n", src, dst, time.Since(started).Round(time.Millisecond))
+	fmt.Fprintf(c.App.Writer, "  transferred: %d (%s)\n", xferred, cos.B2S(plan.size, 2))
+	fmt.Fprintf(c.App.Writer, "  unchanged:   %d\n", plan.same)
+	if flagIsSet(c, syncDeleteFlag) {
+		fmt.Fprintf(c.App.Writer, "  deleted:     %d\n", deleted)
+	}
+	if failed+delFailed > 0 {
+		fmt.Fprintf(c.App.Writer, "  failed:      %d\n", failed+delFailed)
+		return fmt.Errorf("failed to sync %d object%s", failed+delFailed, cos.Plural(failed+delFailed))
+	}
+	return nil
+}
+
+// bucket => bucket: multi-object copy can only prepend (the destination) prefix
+func checkSyncBuckets(c *cli.Context, src, dst *syncPath) error {
+	if src.bck.Equal(&dst.bck) {
+		return incorrectUsageMsg(c, errFmtSameBucket, commandSync, dst.bck)
+	}
+	if src.prefix != "" && src.prefix != dst.prefix {
+		return incorrectUsageMsg(c, "cannot sync %s to %s: source and destination prefixes must be identical "+
+			"(or the source must have no prefix)", src, dst)
+	}
+	return nil
+}
+
+////////////////
+// syncFilter //
+////////////////
+
+func newSyncFilter(include, exclude string) *syncFilter {
+	f := &syncFilter{}
+	if include != "" {
+		f.include = splitCsv(include)
+	}
+	if exclude != "" {
+		f.exclude = splitCsv(exclude)
+	}
+	return f
+}
+
+func splitCsv(s string) (l []string) {
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			l = append(l, p)
+		}
+	}
+	return
+}
+
+// patterns with no '/' match the base name, all others - the entire (relative) name
+func syncMatch(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		name = name[strings.LastIndexByte(name, '/')+1:]
+	}
+	matched, _ := filepath.Match(pattern, name)
+	return matched
+}
+
+func (f *syncFilter) match(name string) bool {
+	if len(f.include) > 0 {
+		var included bool
+		for _, pattern := range f.include {
+			if included = syncMatch(pattern, name); included {
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, pattern := range f.exclude {
+		if syncMatch(pattern, name) {
+			return false
+		}
+	}
+	return true
+}
+
+//////////////
+// syncPath //
+//////////////
+
+// bucket URIs must include provider, e.g. `ais://nnn/ppp`; everything else is a local directory
+func parseSyncPath(c *cli.Context, arg string) (p *syncPath, err error) {
+	p = &syncPath{}
+	if !strings.Contains(arg, apc.BckProviderSeparator) {
+		p.dir, err = getPathFromFileName(arg)
+		return
+	}
+	if p.bck, p.prefix, err = parseBckObjectURI(c, arg, true /*optional objName*/); err != nil {
+		return
+	}
+	if p.prefix != "" && !strings.HasSuffix(p.prefix, "/") {
+		p.prefix += "/"
+	}
+	return
+}
+
+func (p *syncPath) String() string {
+	if p.dir != "" {
+		return p.dir
+	}
+	return p.bck.String() + "/" + p.prefix
+}
+
+func (p *syncPath) objName(name string) string { return p.prefix + name }
+func (p *syncPath) fqn(name string) string     { return filepath.Join(p.dir, filepath.FromSlash(name)) }
+
+func (p *syncPath) path(name string) string {
+	if p.dir != "" {
+		return p.fqn(name)
+	}
+	return p.bck.String() + "/" + p.objName(name)
+}
+
+func (p *syncPath) init(compare string, mustExist bool) error {
+	if p.dir != "" {
+		finfo, err := os.Stat(p.dir)
+		switch {
+		case err == nil && !finfo.IsDir():
+			return fmt.Errorf("%q is not a directory", p.dir)
+		case err == nil:
+			p.exists = true
+		case !os.IsNotExist(err) || mustExist:
+			return err
+		}
+		return nil
+	}
+	props, err := api.HeadBucket(defaultAPIParams, p.bck)
+	if err != nil {
+		if !mustExist && cmn.IsStatusNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	p.exists = true
+	if compare == syncCmpChecksum {
+		p.cksumType = props.Cksum.Type
+		if p.cksumType == "" || p.cksumType == cos.ChecksumNone {
+			return fmt.Errorf("cannot compare checksums: %s has checksumming disabled", p.bck)
+		}
+	}
+	return nil
+}
+
+// returns filtered entries of an existing directory (bucket), or none
+func (p *syncPath) list(filter *syncFilter) (entries map[string]*syncEntry, err error) {
+	entries = make(map[string]*syncEntry, 64)
+	if !p.exists {
+		return
+	}
+	if p.dir != "" {
+		err = filepath.Walk(p.dir, func(fqn string, finfo os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !finfo.Mode().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(p.dir, fqn)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+			if filter.match(name) {
+				entries[name] = &syncEntry{name: name, size: finfo.Size(), mtime: finfo.ModTime().UnixNano()}
+			}
+			return nil
+		})
+		return
+	}
+	msg := &apc.ListObjsMsg{
+		Prefix: p.prefix,
+		Props:  strings.Join([]string{apc.GetPropsName, apc.GetPropsSize, apc.GetPropsChecksum}, ","),
+	}
+	objList, err := api.ListObjects(defaultAPIParams, p.bck, msg, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, be := range objList.Entries {
+		name := strings.TrimPrefix(be.Name, p.prefix)
+		if name == "" || !filter.match(name) {
+			continue
+		}
+		entries[name] = &syncEntry{name: name, size: be.Size, cksum: be.Checksum}
+	}
+	return
+}
+
+///////////////
+// syncEntry //
+///////////////
+
+func (e *syncEntry) differs(dst *syncEntry, compare string) bool {
+	if e.size != dst.size {
+		return true
+	}
+	switch compare {
+	case syncCmpMtime:
+		return e.mtime == 0 || dst.mtime == 0 || e.mtime > dst.mtime
+	case syncCmpChecksum:
+		return e.cksum == "" || e.cksum != dst.cksum
+	default:
+		return false
+	}
+}
+
+// returns (sorted by name) source entries that are missing or differ at the destination
+// and, optionally, destination entries that do not exist in the source
+func syncDiff(src, dst map[string]*syncEntry, compare string, del bool) (plan *syncPlan) {
+	plan = &syncPlan{}
+	for name, e := range src {
+		if d, ok := dst[name]; ok && !e.differs(d, compare) {
+			plan.same++
+			continue
+		}
+		plan.xfer = append(plan.xfer, e)
+		plan.size += e.size
+	}
+	if del {
+		for name, d := range dst {
+			if _, ok := src[name]; !ok {
+				plan.del = append(plan.del, d)
+			}
+		}
+	}
+	sort.Slice(plan.xfer, func(i, j int) bool { return plan.xfer[i].name < plan.xfer[j].name })
+	sort.Slice(plan.del, func(i, j int) bool { return plan.del[i].name < plan.del[j].name })
+	return
+}
+
+// fetch (stored upon upload) modification times of the objects that cannot be told apart by size
+func syncMtimes(c *cli.Context, src, dst *syncPath, srcEntries, dstEntries map[string]*syncEntry) error {
+	var (
+		failed atomic.Int32
+		wg     = cos.NewLimitedWaitGroup(parseIntFlag(c, concurrencyFlag))
+	)
+	fetch := func(p *syncPath, e *syncEntry) {
+		defer wg.Done()
+		props, err := api.HeadObject(defaultAPIParams, p.bck, p.objName(e.name))
+		if err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "Failed to get %q properties: %v\n", p.path(e.name), err)
+			failed.Inc()
+			return
+		}
+		if v, ok := props.GetCustomKey(syncMtimeKey); ok {
+			e.mtime, _ = strconv.ParseInt(v, 10, 64) // (zero when invalid)
+		}
+	}
+	for name, e := range srcEntries {
+		d, ok := dstEntries[name]
+		if !ok || d.size != e.size {
+			continue
+		}
+		if src.dir == "" {
+			wg.Add(1)
+			go fetch(src, e)
+		}
+		if dst.dir == "" {
+			wg.Add(1)
+			go fetch(dst, d)
+		}
+	}
+	wg.Wait()
+	if n := int(failed.Load()); n > 0 {
+		return fmt.Errorf("failed to get properties of %d object%s", n, cos.Plural(n))
+	}
+	return nil
+}
+
+// compute checksums of the local files that cannot be told apart by size
+func syncChecksums(c *cli.Context, src, dst *syncPath, srcEntries, dstEntries map[string]*syncEntry) error {
+	var (
+		local, other = srcEntries, dstEntries
+		dir          = src
+		cksumType    = dst.cksumType
+		failed       atomic.Int32
+		wg           = cos.NewLimitedWaitGroup(parseIntFlag(c, concurrencyFlag))
+	)
+	switch {
+	case src.dir == "" && dst.dir == "":
+		return nil // (bucket => bucket)
+	case src.dir == "":
+		local, other, dir, cksumType = dstEntries, srcEntries, dst, src.cksumType
+	}
+	for name, e := range local {
+		if o, ok := other[name]; !ok || o.size != e.size {
+			continue
+		}
+		wg.Add(1)
+		go func(e *syncEntry) {
+			defer wg.Done()
+			fh, err := os.Open(dir.fqn(e.name))
+			if err == nil {
+				var cksum *cos.CksumHash
+				_, cksum, err = cos.CopyAndChecksum(io.Discard, fh, nil, cksumType)
+				fh.Close()
+				if err == nil {
+					e.cksum = cksum.Value()
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(c.App.ErrWriter, "Failed to checksum %q: %v\n", dir.fqn(e.name), err)
+				failed.Inc()
+			}
+		}(e)
+	}
+	wg.Wait()
+	if n := int(failed.Load()); n > 0 {
+		return fmt.Errorf("failed to checksum %d file%s", n, cos.Plural(n))
+	}
+	return nil
+}
+
+func syncDryRun(c *cli.Context, src, dst *syncPath, plan *syncPlan) {
+	var (
+		verbose = flagIsSet(c, verboseFlag)
+		op      = "PUT"
+	)
+	switch {
+	case src.dir == "" && dst.dir == "":
+		op = "COPY"
+	case src.dir == "":
+		op = "GET"
+	}
+	for i, e := range plan.xfer {
+		if i == dryRunExamplesCnt && !verbose {
+			fmt.Fprintf(c.App.Writer, "(and %d more)\n", len(plan.xfer)-i)
+			break
+		}
+		fmt.Fprintf(c.App.Writer, "%s %q => %q\n", op, src.path(e.name), dst.path(e.name))
+	}
+	for i, e := range plan.del {
+		if i == dryRunExamplesCnt && !verbose {
+			fmt.Fprintf(c.App.Writer, "(and %d more)\n", len(plan.del)-i)
+			break
+		}
+		fmt.Fprintf(c.App.Writer, "DELETE %q\n", dst.path(e.name))
+	}
+	fmt.Fprintf(c.App.Writer, "Total: transfer %d (%s), unchanged %d, delete %d\n",
+		len(plan.xfer), cos.B2S(plan.size, 2), plan.same, len(plan.del))
+}
+
+//
+// transfer and delete
+//
+
+func syncTransfer(c *cli.Context, src, dst *syncPath, entries []*syncEntry) (xferred, failed int) {
+	if len(entries) == 0 {
+		return
+	}
+	if src.dir == "" && dst.dir == "" {
+		return syncCopy(c, src, dst, entries)
+	}
+	var (
+		errCount atomic.Int32
+		verbose  = flagIsSet(c, verboseFlag)
+		wg       = cos.NewLimitedWaitGroup(parseIntFlag(c, concurrencyFlag))
+	)
+	for _, e := range entries {
+		wg.Add(1)
+		go func(e *syncEntry) {
+			var err error
+			defer wg.Done()
+			if src.dir != "" {
+				err = syncPut(src, dst, e)
+			} else {
+				err = syncGet(src, dst, e)
+			}
+			if err != nil {
+				fmt.Fprintf(c.App.ErrWriter, "Failed to sync %q => %q: %v\n", src.path(e.name), dst.path(e.name), err)
+				errCount.Inc()
+			} else if verbose {
+				fmt.Fprintf(c.App.Writer, "%s => %s\n", src.path(e.name), dst.path(e.name))
+			}
+		}(e)
+	}
+	wg.Wait()
+	failed = int(errCount.Load())
+	return len(entries) - failed, failed
+}
+
+func syncPut(src, dst *syncPath, e *syncEntry) error {
+	fh, err := cos.NewFileHandle(src.fqn(e.name))
+	if err != nil {
+		return err
+	}
+	putArgs := api.PutObjectArgs{
+		BaseParams: defaultAPIParams,
+		Bck:        dst.bck,
+		Object:     dst.objName(e.name),
+		Reader:     fh,
+		Size:       uint64(e.size),
+	}
+	if err := api.PutObject(putArgs); err != nil {
+		return err
+	}
+	custom := cos.SimpleKVs{syncMtimeKey: strconv.FormatInt(e.mtime, 10)}
+	return api.SetObjectCustomProps(defaultAPIParams, dst.bck, dst.objName(e.name), custom, false /*setNew*/)
+}
+
+// GET into a temporary file that then gets renamed; the resulting file is timestamped
+// _after_ the GET (so that it is not older than the source)
+func syncGet(src, dst *syncPath, e *syncEntry) error {
+	fqn := dst.fqn(e.name)
+	if err := cos.CreateDir(filepath.Dir(fqn)); err != nil {
+		return err
+	}
+	tmp := fqn + ".sync.tmp"
+	fh, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = api.GetObject(defaultAPIParams, src.bck, src.objName(e.name), api.GetObjectInput{Writer: fh})
+	if errClose := fh.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		now := time.Now()
+		if err = os.Chtimes(tmp, now, now); err == nil {
+			err = os.Rename(tmp, fqn)
+		}
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// bucket => bucket: multi-object copy, one batch at a time
+func syncCopy(c *cli.Context, src, dst *syncPath, entries []*syncEntry) (xferred, failed int) {
+	for i := 0; i < len(entries); i += syncCopyBatch {
+		batch := entries[i:cos.Min(i+syncCopyBatch, len(entries))]
+		msg := cmn.TCObjsMsg{ToBck: dst.bck, ContinueOnError: true}
+		msg.ObjNames = make([]string, 0, len(batch))
+		for _, e := range batch {
+			msg.ObjNames = append(msg.ObjNames, src.objName(e.name))
+		}
+		if src.prefix == "" {
+			msg.Prefix = dst.prefix
+		}
+		xactID, err := api.CopyMultiObj(defaultAPIParams, src.bck, msg)
+		if err == nil {
+			wargs := api.XactReqArgs{ID: xactID, Kind: apc.ActCopyObjects}
+			err = api.WaitForXactionIdle(defaultAPIParams, wargs)
+		}
+		if err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "Failed to copy %d object%s %s => %s: %v\n",
+				len(batch), cos.Plural(len(batch)), src, dst, err)
+			failed += len(batch)
+			continue
+		}
+		xferred += len(batch)
+		if flagIsSet(c, verboseFlag) {
+			fmt.Fprintf(c.App.Writer, "Copied %d object%s %s => %s\n", len(batch), cos.Plural(len(batch)), src, dst)
+		}
+	}
+	return
+}
+
+func syncDelete(c *cli.Context, dst *syncPath, entries []*syncEntry) (deleted, failed int) {
+	if dst.dir != "" {
+		for _, e := range entries {
+			if err := os.Remove(dst.fqn(e.name)); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(c.App.ErrWriter, "Failed to delete %q: %v\n", dst.fqn(e.name), err)
+				failed++
+				continue
+			}
+			deleted++
+		}
+		return
+	}
+	for i := 0; i < len(entries); i += syncCopyBatch {
+		batch := entries[i:cos.Min(i+syncCopyBatch, len(entries))]
+		names := make([]string, 0, len(batch))
+		for _, e := range batch {
+			names = append(names, dst.objName(e.name))
+		}
+		xactID, err := api.DeleteList(defaultAPIParams, dst.bck, names)
+		if err == nil {
+			wargs := api.XactReqArgs{ID: xactID, Kind: apc.ActDeleteObjects}
+			_, err = api.WaitForXactionIC(defaultAPIParams, wargs)
+		}
+		if err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "Failed to delete %d object%s from %s: %v\n",
+				len(batch), cos.Plural(len(batch)), dst, err)
+			failed += len(batch)
+			continue
+		}
+		deleted += len(batch)
+	}
+	return
+}
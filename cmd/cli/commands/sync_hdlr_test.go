@@ -0,0 +1,89 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestSyncDiff(t *testing.T) {
+	var (
+		src = map[string]*syncEntry{
+			"new":        {name: "new", size: 10, mtime: 100},
+			"same":       {name: "same", size: 10, mtime: 100, cksum: "aa"},
+			"resized":    {name: "resized", size: 20, mtime: 100},
+			"newer":      {name: "newer", size: 10, mtime: 200, cksum: "bb"},
+			"older":      {name: "older", size: 10, mtime: 100, cksum: "cc"},
+			"no-mtime":   {name: "no-mtime", size: 10, cksum: "dd"},
+			"other-data": {name: "other-data", size: 10, mtime: 100, cksum: "ee"},
+		}
+		dst = map[string]*syncEntry{
+			"same":       {name: "same", size: 10, mtime: 100, cksum: "aa"},
+			"resized":    {name: "resized", size: 10, mtime: 100},
+			"newer":      {name: "newer", size: 10, mtime: 100, cksum: "bb"},
+			"older":      {name: "older", size: 10, mtime: 200, cksum: "cc"},
+			"no-mtime":   {name: "no-mtime", size: 10, mtime: 100, cksum: "dd"},
+			"other-data": {name: "other-data", size: 10, mtime: 100, cksum: "ff"},
+			"extra":      {name: "extra", size: 10, mtime: 100},
+		}
+	)
+	tests := []struct {
+		compare string
+		del     bool
+		xfer    []string
+	}{
+		{compare: syncCmpSize, xfer: []string{"new", "resized"}},
+		{compare: syncCmpMtime, xfer: []string{"new", "newer", "no-mtime", "resized"}},
+		{compare: syncCmpChecksum, xfer: []string{"new", "other-data", "resized"}},
+		{compare: syncCmpSize, del: true, xfer: []string{"new", "resized"}},
+	}
+	for _, test := range tests {
+		plan := syncDiff(src, dst, test.compare, test.del)
+		tassert.Fatalf(t, len(plan.xfer) == len(test.xfer), "%s: expected %v to transfer, got %d",
+			test.compare, test.xfer, len(plan.xfer))
+		var size int64
+		for i, e := range plan.xfer {
+			tassert.Errorf(t, e.name == test.xfer[i], "%s: xfer[%d]: expected %q, got %q",
+				test.compare, i, test.xfer[i], e.name)
+			size += e.size
+		}
+		tassert.Errorf(t, plan.size == size, "%s: expected total size %d, got %d", test.compare, size, plan.size)
+		tassert.Errorf(t, plan.same == len(src)-len(test.xfer), "%s: expected %d unchanged, got %d",
+			test.compare, len(src)-len(test.xfer), plan.same)
+		if test.del {
+			tassert.Errorf(t, len(plan.del) == 1 && plan.del[0].name == "extra", "expected to delete %q, got %v",
+				"extra", plan.del)
+		} else {
+			tassert.Errorf(t, len(plan.del) == 0, "expected nothing to delete, got %v", plan.del)
+		}
+	}
+}
+
+func TestSyncFilter(t *testing.T) {
+	tests := []struct {
+		include, exclude string
+		name             string
+		match            bool
+	}{
+		{name: "a/b/c.jpg", match: true},
+		{include: "*.jpg", name: "a/b/c.jpg", match: true},
+		{include: "*.png, *.jpg", name: "c.jpg", match: true},
+		{include: "*.png", name: "a/b/c.jpg", match: false},
+		{include: "a/*", name: "a/c.jpg", match: true},
+		{include: "a/*", name: "a/b/c.jpg", match: false},
+		{include: "a/*/*", name: "a/b/c.jpg", match: true},
+		{exclude: "*.tmp", name: "a/b/c.tmp", match: false},
+		{exclude: "*.tmp", name: "a/b/c.jpg", match: true},
+		{include: "*.jpg", exclude: "b/*", name: "b/c.jpg", match: false},
+		{include: "*.jpg", exclude: "b/*", name: "a/b/c.jpg", match: true},
+	}
+	for _, test := range tests {
+		f := newSyncFilter(test.include, test.exclude)
+		tassert.Errorf(t, f.match(test.name) == test.match, "include %q, exclude %q: expected match(%q) = %t",
+			test.include, test.exclude, test.name, test.match)
+	}
+}
@@ -833,7 +833,13 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop) {
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
 			{"lru", props.LRU.String()},
+			{"lifecycle", props.Lifecycle.String()},
+			{"inventory", props.Inventory.String()},
+			{"events", props.Events.String()},
+			{"object_lock", props.ObjectLock.String()},
+			{"replication", props.Replication.String()},
 			{"versioning", props.Versioning.String()},
+			{"quota", props.Quota.String()},
 		}
 		if props.Provider == apc.ProviderHTTP {
 			origURL := props.Extra.HTTP.OrigURLBck
@@ -862,6 +868,24 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop) {
 	return
 }
 
+// e.g. "size 1.21GiB of 10GiB (12%), objects 100 of 1000 (10%)"
+func quotaUsage(quota *cmn.QuotaConf, usage *cmn.QuotaUsage) string {
+	var s []string
+	if quota.MaxSize > 0 {
+		pct := float64(usage.Size) * 100 / float64(quota.MaxSize)
+		s = append(s, fmt.Sprintf("%s %s of %s (%.0f%%)", cmn.QuotaSize,
+			cos.B2S(usage.Size, 2), quota.MaxSize, pct))
+	}
+	if quota.MaxObjs > 0 {
+		pct := float64(usage.Objs) * 100 / float64(quota.MaxObjs)
+		s = append(s, fmt.Sprintf("%s %d of %d (%.0f%%)", cmn.QuotaObjs, usage.Objs, quota.MaxObjs, pct))
+	}
+	if !usage.Seeded {
+		s = append(s, "(computing)")
+	}
+	return strings.Join(s, ", ")
+}
+
 func readValue(c *cli.Context, prompt string) string {
 	fmt.Fprintf(c.App.Writer, prompt+": ")
 	reader := bufio.NewReader(os.Stdin)
@@ -1275,22 +1299,32 @@ func flattenXactStats(snap *xact.SnapExt) []*prop {
 		&prop{Name: "out.obj.size", Value: formatStatHuman(".size", snap.Stats.OutBytes)},
 	)
 	if extStats, ok := snap.Ext.(map[string]interface{}); ok {
-		for k, v := range extStats {
-			var value string
-			if strings.HasSuffix(k, ".size") {
-				val := v.(string)
-				if i, err := strconv.ParseInt(val, 10, 64); err == nil {
-					value = cos.B2S(i, 2)
-				}
-			}
-			if value == "" {
-				value = fmt.Sprintf("%v", v)
-			}
-			props = append(props, &prop{Name: k, Value: value})
-		}
+		props = flattenExtStats(props, "", extStats)
 	}
 	sort.Slice(props, func(i, j int) bool {
 		return props[i].Name < props[j].Name
 	})
 	return props
 }
+
+// nested stats (e.g., scrub's per-bucket findings) are flattened as "<key>.<nested key>"
+func flattenExtStats(props []*prop, prefix string, extStats map[string]interface{}) []*prop {
+	for k, v := range extStats {
+		if nested, ok := v.(map[string]interface{}); ok {
+			props = flattenExtStats(props, prefix+k+".", nested)
+			continue
+		}
+		var value string
+		if strings.HasSuffix(k, ".size") {
+			val := v.(string)
+			if i, err := strconv.ParseInt(val, 10, 64); err == nil {
+				value = cos.B2S(i, 2)
+			}
+		}
+		if value == "" {
+			value = fmt.Sprintf("%v", v)
+		}
+		props = append(props, &prop{Name: prefix + k, Value: value})
+	}
+	return props
+}
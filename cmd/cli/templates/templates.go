@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
@@ -43,6 +44,7 @@ const (
 	xactStateRunning  = "Running"
 	xactStateIdle     = "Idle"
 	xactStateAborted  = "Aborted"
+	xactStatePaused   = "Paused"
 
 	// Smap
 	SmapHeader = "NODE\t TYPE\t PUBLIC URL" +
@@ -119,6 +121,7 @@ const (
 	DSortListBody   = "{{$value.ID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
 		"{{else if $value.Archived}}Finished" +
+		"{{else if $value.Paused}}Paused" +
 		"{{else}}Running" +
 		"{{end}}\t {{FormatTime $value.StartedTime}}\t {{FormatTime $value.FinishTime}} \t {{$value.Description}}\n"
 	DSortListTmpl = DSortListHeader + "{{ range $value := . }}" + DSortListBody + "{{end}}"
@@ -178,6 +181,30 @@ const (
 		"{{if (IsUnsetTime $xctn.EndTime)}}-{{else}}{{FormatTime $xctn.EndTime}}{{end}}\t " +
 		"{{$xctn.AbortedX}}\n"
 
+	// per-bucket findings
+	XactionScrubStatsHeader = "NODE\t ID\t BUCKET\t OBJECTS\t SIZE\t CORRUPTED\t REPAIRED\t UNREPAIRED\t EC SLICES\t BAD SLICES\t STATE\n"
+	XactionScrubBodyTmpl    = XactionScrubStatsHeader +
+		"{{range $daemon := . }}" + XactionScrubBody + "{{end}}"
+	XactionScrubBody = "{{range $key, $xctn := $daemon.XactSnaps}}" +
+		"{{ $ext := ExtScrubStats $xctn }}" +
+		"{{if $ext.Buckets}}" +
+		"{{range $bck, $st := $ext.Buckets}}" + XactionScrubStatsBody + "{{end}}" +
+		"{{else}}" +
+		"{{ $daemon.DaemonID }}\t {{$xctn.ID}}\t -\t -\t -\t -\t -\t -\t -\t -\t {{FormatXactState $xctn}}\n" +
+		"{{end}}" +
+		"{{end}}"
+	XactionScrubStatsBody = "{{ $daemon.DaemonID }}\t " +
+		"{{$xctn.ID}}\t " +
+		"{{$bck}}\t " +
+		"{{if (eq $st.Objs 0) }}-{{else}}{{$st.Objs}}{{end}}\t " +
+		"{{if (eq $st.Bytes 0) }}-{{else}}{{FormatBytesSigned $st.Bytes 2}}{{end}}\t " +
+		"{{if (eq $st.Corrupted 0) }}-{{else}}{{$st.Corrupted}}{{end}}\t " +
+		"{{if (eq $st.Repaired 0) }}-{{else}}{{$st.Repaired}}{{end}}\t " +
+		"{{if (eq $st.Unrepaired 0) }}-{{else}}{{$st.Unrepaired}}{{end}}\t " +
+		"{{if (eq $st.Slices 0) }}-{{else}}{{$st.Slices}}{{end}}\t " +
+		"{{if (eq $st.BadSlices 0) }}-{{else}}{{$st.BadSlices}}{{end}}\t " +
+		"{{FormatXactState $xctn}}\n"
+
 	// Buckets templates
 	BucketsSummariesFastTmpl = "NAME\t EST. OBJECTS\t EST. SIZE\t USED(%)\n" + bucketsSummariesBody
 	BucketsSummariesTmpl     = "NAME\t OBJECTS\t SIZE \t USED(%)\n" + bucketsSummariesBody
@@ -338,6 +365,7 @@ var (
 		"FormatACL":           fmtACL,
 		"ExtECGetStats":       extECGetStats,
 		"ExtECPutStats":       extECPutStats,
+		"ExtScrubStats":       extScrubStats,
 		"FormatNameArch":      fmtNameArch,
 		"FormatXactState":     fmtXactStatus,
 		// for all stats.DaemonStatus structs in `h`: select specific field
@@ -616,6 +644,14 @@ func extECPutStats(base *xact.SnapExt) *ec.ExtECPutStats {
 	return ecPut
 }
 
+func extScrubStats(base *xact.SnapExt) *scrub.ExtScrubStats {
+	ext := &scrub.ExtScrubStats{}
+	if err := cos.MorphMarshal(base.Ext, ext); err != nil {
+		return &scrub.ExtScrubStats{}
+	}
+	return ext
+}
+
 func fmtMilli(val cos.Duration) string {
 	return cos.FormatMilli(time.Duration(val))
 }
@@ -650,6 +686,9 @@ func fmtXactStatus(xctn *xact.SnapExt) string {
 	if !xctn.EndTime.IsZero() {
 		return xactStateFinished
 	}
+	if xctn.PausedX {
+		return xactStatePaused
+	}
 	if xctn.Idle() {
 		return xactStateIdle
 	}
@@ -6,9 +6,12 @@
 package cmn
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -54,6 +57,27 @@ type (
 		// EC defines erasure coding setting for the bucket
 		EC ECConf `json:"ec"`
 
+		// Lifecycle: object expiration (and eviction) rules, see LifecycleConf
+		Lifecycle LifecycleConf `json:"lifecycle"`
+
+		// Quota: storage quota (zero values - unlimited), see QuotaConf
+		Quota QuotaConf `json:"quota"`
+
+		// Encryption: server-side encryption at rest, see EncryptionConf
+		Encryption EncryptionConf `json:"encryption"`
+
+		// Inventory: bucket inventory (manifest) reports, see InventoryConf
+		Inventory InventoryConf `json:"inventory"`
+
+		// Events: object event notifications (webhooks), see EventsConf
+		Events EventsConf `json:"events"`
+
+		// ObjectLock: write-once-read-many (WORM) retention and legal hold, see ObjectLockConf
+		ObjectLock ObjectLockConf `json:"object_lock"`
+
+		// Replication: asynchronous replication to a remote AIS cluster, see ReplicationConf
+		Replication ReplicationConf `json:"replication"`
+
 		// Bucket access attributes - see Allow* above
 		Access apc.AccessAttrs `json:"access,string"`
 
@@ -71,6 +95,94 @@ type (
 		Renamed string `list:"omit"`
 	}
 
+	// LifecycleConf defines per-bucket object lifecycle: an ordered list of rules that
+	// get periodically evaluated by the `lifecycle` xaction (see space/lifecycle.go).
+	// An object is acted upon by the first rule that matches it.
+	LifecycleConf struct {
+		Rules   []LifecycleRule `json:"rules,omitempty" list:"omitempty"`
+		Enabled bool            `json:"enabled"`
+	}
+	LifecycleRule struct {
+		ID     string `json:"id,omitempty"`     // (optional) rule name
+		Prefix string `json:"prefix,omitempty"` // applies to objects with names that start with the prefix
+		Action string `json:"action"`           // enum { LcActDelete, LcActEvict }
+		// Age: minimum time since the object was created or last modified (overwritten,
+		// appended) - not accessed, so that frequently read objects do expire as well
+		Age cos.Duration `json:"age,omitempty"`
+		// size filters (inclusive, zero means "not set")
+		MinSize cos.Size `json:"min_size,omitempty"`
+		MaxSize cos.Size `json:"max_size,omitempty"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules   *[]LifecycleRule `json:"rules,omitempty"`
+		Enabled *bool            `json:"enabled,omitempty"`
+	}
+
+	// InventoryConf defines bucket inventory: each target walks its share of the bucket
+	// and stores a sorted manifest of the objects (see xs/inventory.go) in the destination
+	// bucket. Inventory runs every Interval when enabled, and on demand via API/CLI.
+	InventoryConf struct {
+		Bucket   string       `json:"bucket"`           // destination bucket, e.g. "ais://inventory"
+		Prefix   string       `json:"prefix,omitempty"` // manifest names' prefix (in the destination bucket)
+		Format   string       `json:"format"`           // enum { InvFormatCSV, InvFormatMsgpack }
+		Interval cos.Duration `json:"interval"`         // when enabled: how often to generate inventory
+		Enabled  bool         `json:"enabled"`
+	}
+	InventoryConfToUpdate struct {
+		Bucket   *string       `json:"bucket,omitempty"`
+		Prefix   *string       `json:"prefix,omitempty"`
+		Format   *string       `json:"format,omitempty"`
+		Interval *cos.Duration `json:"interval,omitempty"`
+		Enabled  *bool         `json:"enabled,omitempty"`
+	}
+
+	// EventsConf defines bucket event notifications: targets send (POST) events - object
+	// created, deleted, etc. - to HTTP webhook endpoints (see package events).
+	EventsConf struct {
+		Webhooks []Webhook `json:"webhooks,omitempty" list:"omitempty"`
+		Enabled  bool      `json:"enabled"`
+	}
+	Webhook struct {
+		ID     string   `json:"id,omitempty"`     // (optional) webhook name
+		URL    string   `json:"url"`              // HTTP(S) endpoint
+		Events []string `json:"events,omitempty"` // enum { Ev* }; empty: all events
+		Prefix string   `json:"prefix,omitempty"` // objects with names that start with the prefix
+	}
+	EventsConfToUpdate struct {
+		Webhooks *[]Webhook `json:"webhooks,omitempty"`
+		Enabled  *bool      `json:"enabled,omitempty"`
+	}
+
+	// ObjectLockConf makes objects immutable (write-once-read-many): when enabled, new objects
+	// get retained for the Retention period, during which they cannot be overwritten, deleted,
+	// renamed, or evicted. Objects under legal hold (see LegalHoldObjMD) are retained until the
+	// hold is removed. In governance mode, retention can be bypassed (see apc.QparamBypassGov);
+	// in compliance mode, it cannot - and the configuration itself cannot be relaxed.
+	ObjectLockConf struct {
+		Mode      string       `json:"mode"`      // enum { LockModeGovernance, LockModeCompliance }
+		Retention cos.Duration `json:"retention"` // default retention period of new objects
+		Enabled   bool         `json:"enabled"`
+	}
+	ObjectLockConfToUpdate struct {
+		Mode      *string       `json:"mode,omitempty"`
+		Retention *cos.Duration `json:"retention,omitempty"`
+		Enabled   *bool         `json:"enabled,omitempty"`
+	}
+
+	// ReplicationConf defines asynchronous (continuous) replication of the bucket to a bucket
+	// in an attached remote AIS cluster: targets forward PUTs, APPENDs, and DELETEs to the
+	// destination (see package replicate).
+	ReplicationConf struct {
+		Bucket  string `json:"bucket"`           // destination bucket, e.g. "ais://@remais/dr"
+		Prefix  string `json:"prefix,omitempty"` // replicate only objects with names that start with the prefix
+		Enabled bool   `json:"enabled"`
+	}
+	ReplicationConfToUpdate struct {
+		Bucket  *string `json:"bucket,omitempty"`
+		Prefix  *string `json:"prefix,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+
 	ExtraProps struct {
 		AWS  ExtraPropsAWS  `json:"aws,omitempty" list:"omitempty"`
 		HTTP ExtraPropsHTTP `json:"http,omitempty" list:"omitempty"`
@@ -121,6 +233,13 @@ type (
 		LRU         *LRUConfToUpdate         `json:"lru,omitempty"`
 		Mirror      *MirrorConfToUpdate      `json:"mirror,omitempty"`
 		EC          *ECConfToUpdate          `json:"ec,omitempty"`
+		Lifecycle   *LifecycleConfToUpdate   `json:"lifecycle,omitempty"`
+		Quota       *QuotaConfToUpdate       `json:"quota,omitempty"`
+		Encryption  *EncryptionConfToUpdate  `json:"encryption,omitempty"`
+		Inventory   *InventoryConfToUpdate   `json:"inventory,omitempty"`
+		Events      *EventsConfToUpdate      `json:"events,omitempty"`
+		ObjectLock  *ObjectLockConfToUpdate  `json:"object_lock,omitempty"`
+		Replication *ReplicationConfToUpdate `json:"replication,omitempty"`
 		Access      *apc.AccessAttrs         `json:"access,string,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Extra       *ExtraToUpdate           `json:"extra,omitempty"`
@@ -140,6 +259,7 @@ type (
 // * In addition, `api.CreateBucket` allows to specify (non-default) properties at bucket creation time.
 // * Inherited defaults include checksum, LRU, etc. configurations - see below.
 // * By default, LRU is disabled for AIS (`ais://`) buckets.
+// * Version history (versioning.max_history) is supported only for AIS buckets.
 //
 // See also:
 //    * github.com/NVIDIA/aistore/blob/master/docs/bucket.md#default-bucket-properties
@@ -156,6 +276,8 @@ func (bck *Bck) DefaultProps(cs ...*Config) *BucketProps {
 	}
 	if bck.IsAIS() {
 		c.LRU.Enabled = false
+	} else {
+		c.Versioning.MaxHistory = 0 // version history: ais:// buckets only
 	}
 	wp := c.WritePolicy
 	if wp.MD.IsImmediate() {
@@ -212,12 +334,22 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	validators := []PropsValidator{
+		&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Lifecycle, &bp.Versioning, &bp.Quota,
+		&bp.Encryption, &bp.Inventory, &bp.Events, &bp.ObjectLock, &bp.Replication,
+	}
+	for _, pv := range validators {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
+		} else if pv == &bp.Lifecycle {
+			err = bp.Lifecycle.ValidateAsProps(bp.isRemote())
+		} else if pv == &bp.Versioning {
+			err = bp.Versioning.ValidateAsProps(bp.isRemote())
+		} else if pv == &bp.Encryption {
+			err = bp.Encryption.ValidateAsProps(bp.isRemote())
 		} else {
 			err = pv.ValidateAsProps()
 		}
@@ -277,3 +409,373 @@ func (c *ExtraProps) ValidateAsProps(arg ...interface{}) error {
 	}
 	return nil
 }
+
+// (a bucket that has remote backend, possibly via `BackendBck`)
+func (bp *BucketProps) isRemote() bool {
+	return bp.Provider != apc.ProviderAIS || !bp.BackendBck.IsEmpty()
+}
+
+///////////////////
+// InventoryConf //
+///////////////////
+
+const (
+	InvFormatCSV     = "csv"     // header: name,size,checksum,atime,version
+	InvFormatMsgpack = "msgpack" // sequence of msgpack-encoded BucketEntry structures
+
+	InvMinInterval = 10 * time.Minute
+)
+
+func (c *InventoryConf) ValidateAsProps(...interface{}) error {
+	switch c.Format {
+	case "", InvFormatCSV, InvFormatMsgpack:
+	default:
+		return fmt.Errorf("inventory: invalid format %q (expecting one of: %q, %q)",
+			c.Format, InvFormatCSV, InvFormatMsgpack)
+	}
+	if c.Bucket != "" {
+		if _, err := c.Dest(); err != nil {
+			return err
+		}
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("inventory: invalid interval %s", c.Interval)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.Bucket == "" {
+		return errors.New("inventory: cannot enable inventory without destination bucket")
+	}
+	if c.Interval.D() < InvMinInterval {
+		return fmt.Errorf("inventory: interval %s is too short (minimum %v)", c.Interval, InvMinInterval)
+	}
+	return nil
+}
+
+// Dest returns the destination bucket
+func (c *InventoryConf) Dest() (bck Bck, err error) {
+	var objName string
+	bck, objName, err = ParseBckObjectURI(c.Bucket, ParseURIOpts{DefaultProvider: apc.ProviderAIS})
+	if err == nil && (bck.Name == "" || objName != "") {
+		err = fmt.Errorf("inventory: invalid destination bucket %q", c.Bucket)
+	}
+	if err == nil {
+		err = bck.Validate()
+	}
+	return
+}
+
+func (c *InventoryConf) Ext() string {
+	if c.Format == InvFormatMsgpack {
+		return cos.ExtMsgpack
+	}
+	return "." + InvFormatCSV
+}
+
+func (c *InventoryConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	format := c.Format
+	if format == "" {
+		format = InvFormatCSV
+	}
+	dst := c.Bucket
+	if c.Prefix != "" {
+		dst += "/" + c.Prefix
+	}
+	return fmt.Sprintf("every %s => %s (%s)", c.Interval, dst, format)
+}
+
+////////////////
+// EventsConf //
+////////////////
+
+// event kinds
+const (
+	EvPut       = "put"        // object PUT (including S3 PUT and multipart upload)
+	EvAppend    = "append"     // APPEND finalized (flushed), including APPEND to archive
+	EvDelete    = "delete"     // object DELETE
+	EvECRestore = "ec-restore" // object restored from EC slices (or replicas)
+	EvDownload  = "download"   // object downloaded (see downloader)
+)
+
+var SupportedEvents = []string{EvPut, EvAppend, EvDelete, EvECRestore, EvDownload}
+
+func (c *EventsConf) ValidateAsProps(...interface{}) error {
+	ids := make(cos.StringSet, len(c.Webhooks))
+	for i := range c.Webhooks {
+		wh := &c.Webhooks[i]
+		if wh.ID != "" {
+			if ids.Contains(wh.ID) {
+				return fmt.Errorf("events: duplicate webhook ID %q", wh.ID)
+			}
+			ids.Add(wh.ID)
+		}
+		if err := wh.validate(); err != nil {
+			return fmt.Errorf("events: webhook %q: %v", wh.URL, err)
+		}
+	}
+	if c.Enabled && len(c.Webhooks) == 0 {
+		return errors.New("events: cannot enable event notifications with no webhooks")
+	}
+	return nil
+}
+
+func (c *EventsConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	urls := make([]string, 0, len(c.Webhooks))
+	for i := range c.Webhooks {
+		urls = append(urls, c.Webhooks[i].URL)
+	}
+	return strings.Join(urls, ", ")
+}
+
+/////////////
+// Webhook //
+/////////////
+
+func (wh *Webhook) validate() error {
+	u, err := url.Parse(wh.URL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("expecting http(s)://host[:port][/path]")
+	}
+	for _, ev := range wh.Events {
+		if !cos.StringInSlice(ev, SupportedEvents) {
+			return fmt.Errorf("invalid event %q (expecting one of: %v)", ev, SupportedEvents)
+		}
+	}
+	return nil
+}
+
+// Match returns true if the webhook subscribes to the event (of a given kind and object)
+func (wh *Webhook) Match(kind, objName string) bool {
+	if wh.Prefix != "" && !strings.HasPrefix(objName, wh.Prefix) {
+		return false
+	}
+	return len(wh.Events) == 0 || cos.StringInSlice(kind, wh.Events)
+}
+
+/////////////////////
+// ReplicationConf //
+/////////////////////
+
+func (c *ReplicationConf) ValidateAsProps(...interface{}) error {
+	if c.Bucket != "" {
+		if _, err := c.Dest(); err != nil {
+			return err
+		}
+	}
+	if c.Enabled && c.Bucket == "" {
+		return errors.New("replication: cannot enable replication without destination bucket")
+	}
+	return nil
+}
+
+// Dest returns the destination bucket that must reside in a remote AIS cluster
+func (c *ReplicationConf) Dest() (bck Bck, err error) {
+	var objName string
+	bck, objName, err = ParseBckObjectURI(c.Bucket, ParseURIOpts{DefaultProvider: apc.ProviderAIS})
+	if err == nil && (bck.Name == "" || objName != "") {
+		err = fmt.Errorf("replication: invalid destination bucket %q", c.Bucket)
+	}
+	if err == nil {
+		err = bck.Validate()
+	}
+	if err == nil && !bck.IsRemoteAIS() {
+		err = fmt.Errorf("replication: destination bucket %q must be in a remote AIS cluster, e.g. \"ais://@remais/%s\"",
+			c.Bucket, bck.Name)
+	}
+	return
+}
+
+// Match returns true if the object is to be replicated
+func (c *ReplicationConf) Match(objName string) bool {
+	return c.Enabled && (c.Prefix == "" || strings.HasPrefix(objName, c.Prefix))
+}
+
+func (c *ReplicationConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Prefix != "" {
+		return c.Prefix + "* => " + c.Bucket
+	}
+	return "=> " + c.Bucket
+}
+
+////////////////////
+// ObjectLockConf //
+////////////////////
+
+const (
+	LockModeGovernance = "governance" // retention can be bypassed (see apc.QparamBypassGov)
+	LockModeCompliance = "compliance" // retention cannot be bypassed, and the config cannot be relaxed
+)
+
+func (c *ObjectLockConf) ValidateAsProps(...interface{}) error {
+	if c.Retention < 0 {
+		return fmt.Errorf("object lock: invalid (negative) retention %v", c.Retention)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.Mode != LockModeGovernance && c.Mode != LockModeCompliance {
+		return fmt.Errorf("object lock: invalid mode %q (expecting %q or %q)",
+			c.Mode, LockModeGovernance, LockModeCompliance)
+	}
+	return nil
+}
+
+// ValidateUpdate checks whether the (currently enabled) object lock can be relaxed
+// as specified: disabled, switched from compliance to governance mode, or given
+// shorter retention - all of the above being possible only in governance mode
+// and only when bypassing (`bypass`) the latter.
+func (c *ObjectLockConf) ValidateUpdate(nc *ObjectLockConf, bypass bool) error {
+	if !c.Enabled {
+		return nil
+	}
+	relax := !nc.Enabled || nc.Retention < c.Retention ||
+		(c.Mode == LockModeCompliance && nc.Mode != LockModeCompliance)
+	if !relax {
+		return nil
+	}
+	if c.Mode == LockModeCompliance {
+		return errors.New("object lock in compliance mode cannot be disabled, switched to governance, " +
+			"or have its retention shortened")
+	}
+	if !bypass {
+		return errors.New("object lock in governance mode can be relaxed only with \"force\" option")
+	}
+	return nil
+}
+
+func (c *ObjectLockConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%s, retention %v", c.Mode, c.Retention)
+}
+
+///////////////////
+// LifecycleConf //
+///////////////////
+
+const (
+	LcActDelete = "delete" // delete object (note: in remote buckets, deletes remote object as well)
+	LcActEvict  = "evict"  // evict cached copy of a remote object
+)
+
+func (c *LifecycleConf) ValidateAsProps(arg ...interface{}) error {
+	isRemote, ok := arg[0].(bool)
+	debug.Assert(ok)
+	ids := make(cos.StringSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.ID != "" {
+			if ids.Contains(rule.ID) {
+				return fmt.Errorf("lifecycle: duplicate rule ID %q", rule.ID)
+			}
+			ids.Add(rule.ID)
+		}
+		if err := rule.validate(isRemote); err != nil {
+			return fmt.Errorf("lifecycle: rule %s: %v", rule, err)
+		}
+	}
+	if c.Enabled && len(c.Rules) == 0 {
+		return errors.New("lifecycle: cannot enable lifecycle with no rules")
+	}
+	return nil
+}
+
+func (c *LifecycleConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	rules := make([]string, 0, len(c.Rules))
+	for i := range c.Rules {
+		rules = append(rules, c.Rules[i].String())
+	}
+	return strings.Join(rules, "; ")
+}
+
+// Match returns the first rule that applies to a given object, or nil
+func (c *LifecycleConf) Match(objName string, size, mtime, now int64) *LifecycleRule {
+	for i := range c.Rules {
+		if rule := &c.Rules[i]; rule.Match(objName, size, mtime, now) {
+			return rule
+		}
+	}
+	return nil
+}
+
+///////////////////
+// LifecycleRule //
+///////////////////
+
+func (rule *LifecycleRule) validate(isRemote bool) error {
+	switch rule.Action {
+	case LcActDelete:
+	case LcActEvict:
+		if !isRemote {
+			return fmt.Errorf("action %q applies only to buckets with remote backends", rule.Action)
+		}
+	default:
+		return fmt.Errorf("invalid action %q (expecting one of: %q, %q)", rule.Action, LcActDelete, LcActEvict)
+	}
+	if rule.Age < 0 || rule.MinSize < 0 || rule.MaxSize < 0 {
+		return errors.New("age and size filters cannot be negative")
+	}
+	if rule.MaxSize != 0 && rule.MinSize > rule.MaxSize {
+		return fmt.Errorf("min_size (%s) exceeds max_size (%s)", rule.MinSize, rule.MaxSize)
+	}
+	// guard against accidentally matching everything (under the prefix)
+	if rule.Age == 0 && rule.MinSize == 0 && rule.MaxSize == 0 {
+		return errors.New("at least one of age, min_size, or max_size must be specified")
+	}
+	return nil
+}
+
+// (mtime and now: Unix nanoseconds)
+func (rule *LifecycleRule) Match(objName string, size, mtime, now int64) bool {
+	if rule.Prefix != "" && !strings.HasPrefix(objName, rule.Prefix) {
+		return false
+	}
+	if rule.Age != 0 && mtime+int64(rule.Age) > now {
+		return false
+	}
+	if rule.MinSize != 0 && size < int64(rule.MinSize) {
+		return false
+	}
+	if rule.MaxSize != 0 && size > int64(rule.MaxSize) {
+		return false
+	}
+	return true
+}
+
+func (rule *LifecycleRule) String() string {
+	var sb strings.Builder
+	if rule.ID != "" {
+		sb.WriteString(rule.ID + ": ")
+	}
+	sb.WriteString(rule.Action)
+	if rule.Prefix != "" {
+		sb.WriteString(" prefix=" + rule.Prefix)
+	}
+	if rule.Age != 0 {
+		sb.WriteString(" age>=" + rule.Age.String())
+	}
+	if rule.MinSize != 0 {
+		sb.WriteString(" size>=" + rule.MinSize.String())
+	}
+	if rule.MaxSize != 0 {
+		sb.WriteString(" size<=" + rule.MaxSize.String())
+	}
+	return sb.String()
+}
@@ -0,0 +1,18 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+type (
+	// local (per-target) usage of a bucket that is subject to storage quota - the
+	// same counters the target enforces the quota with (see QuotaConf)
+	QuotaUsage struct {
+		Objs   int64 `json:"objects,string"`
+		Size   int64 `json:"size,string"` // including previous versions
+		Seeded bool  `json:"seeded"`      // false: initial usage is still being computed
+	}
+	// by bucket (Bck.String()); GET /v1/cluster?what=quota returns all targets
+	QuotaUsages map[string]*QuotaUsage
+)
@@ -0,0 +1,36 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+// under-protected objects (see package repair)
+
+// kinds
+const (
+	RepairCopies = "copies" // fewer mirror copies than configured
+	RepairEC     = "ec"     // lost EC slices (or replicas)
+)
+
+type (
+	// under-protected object queued for repair
+	RepairItem struct {
+		Bck        Bck    `json:"bck"`
+		ObjName    string `json:"name"`
+		Kind       string `json:"kind"`         // enum { RepairCopies, RepairEC }
+		Source     string `json:"source"`       // where detected: "get", "rebalance", or "mountpath"
+		Redundancy int    `json:"redundancy"`   // remaining: number of copies (or slices) that can still be lost
+		Missing    int    `json:"missing"`      // missing copies (or slices)
+		Added      int64  `json:"added,string"` // time queued
+	}
+	// per-target repair state (GET /v1/cluster?what=repair returns all targets)
+	RepairStatus struct {
+		Queued   int64        `json:"queued,string"`
+		Repaired int64        `json:"repaired,string"`
+		Failed   int64        `json:"failed,string"`
+		Dropped  int64        `json:"dropped,string"` // not queued (the queue was full)
+		Scanning bool         `json:"scanning"`       // scanning mountpaths
+		Top      []RepairItem `json:"top"`            // most urgent first
+	}
+)
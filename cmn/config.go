@@ -76,6 +76,12 @@ type (
 		Memsys      MemsysConf      `json:"memsys"`
 		TCB         TCBConf         `json:"tcb"` // transform/copy bucket
 		WritePolicy WritePolicyConf `json:"write_policy"`
+		RateLimit   RateLimitConf   `json:"rate_limit"`
+		KMS         KMSConf         `json:"kms"`
+		Webhook     WebhookConf     `json:"webhook"`
+		Replicator  ReplicatorConf  `json:"replicator"`
+		JobHistory  JobHistoryConf  `json:"job_history"`
+		ETL         ETLConf         `json:"etl"`
 		Features    feat.Flags      `json:"features,string" allow:"cluster"` // feature flags (to flip assorted defaults)
 		// read-only
 		LastUpdated string `json:"lastupdate_time"`       // timestamp
@@ -110,6 +116,12 @@ type (
 		Memsys      *MemsysConfToUpdate      `json:"memsys,omitempty"`
 		TCB         *TCBConfToUpdate         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
+		RateLimit   *RateLimitConfToUpdate   `json:"rate_limit,omitempty"`
+		KMS         *KMSConfToUpdate         `json:"kms,omitempty"`
+		Webhook     *WebhookConfToUpdate     `json:"webhook,omitempty"`
+		Replicator  *ReplicatorConfToUpdate  `json:"replicator,omitempty"`
+		JobHistory  *JobHistoryConfToUpdate  `json:"job_history,omitempty"`
+		ETL         *ETLConfToUpdate         `json:"etl,omitempty"`
 		Proxy       *ProxyConfToUpdate       `json:"proxy,omitempty"`
 		Features    *feat.Flags              `json:"features,string,omitempty"`
 
@@ -277,12 +289,108 @@ type (
 		// Out-of-Space: if exceeded, the target starts failing new PUTs and keeps
 		// failing them until its local used-cap gets back below HighWM (see above)
 		OOS int64 `json:"out_of_space"`
+
+		// LifecycleInterval: how often each target evaluates bucket lifecycle rules
+		// (see LifecycleConf); zero means default (see LifecycleInterval)
+		LifecycleInterval cos.Duration `json:"lifecycle_interval"`
+
+		// NsQuota: per-namespace storage quotas keyed by namespace (e.g. "#tenant");
+		// applies to all buckets in a given namespace combined (see also BucketProps.Quota)
+		NsQuota map[string]QuotaConf `json:"ns_quota,omitempty" list:"omitempty"`
 	}
 	SpaceConfToUpdate struct {
-		CleanupWM *int64 `json:"cleanupwm,omitempty"`
-		LowWM     *int64 `json:"lowwm,omitempty"`
-		HighWM    *int64 `json:"highwm,omitempty"`
-		OOS       *int64 `json:"out_of_space,omitempty"`
+		CleanupWM         *int64                `json:"cleanupwm,omitempty"`
+		LowWM             *int64                `json:"lowwm,omitempty"`
+		HighWM            *int64                `json:"highwm,omitempty"`
+		OOS               *int64                `json:"out_of_space,omitempty"`
+		LifecycleInterval *cos.Duration         `json:"lifecycle_interval,omitempty"`
+		NsQuota           *map[string]QuotaConf `json:"ns_quota,omitempty"`
+	}
+
+	// QuotaConf limits the total size and number of objects stored in a bucket
+	// (or namespace); zero means "unlimited". Writes that would exceed the quota
+	// fail with 507 (Insufficient Storage).
+	QuotaConf struct {
+		MaxSize cos.Size `json:"max_size"`
+		MaxObjs int64    `json:"max_objects"`
+	}
+	QuotaConfToUpdate struct {
+		MaxSize *cos.Size `json:"max_size,omitempty"`
+		MaxObjs *int64    `json:"max_objects,omitempty"`
+	}
+
+	// EncryptionConf: server-side encryption at rest (bucket property); objects are
+	// encrypted with the key identified by KeyID and obtained from the configured
+	// key provider (see KMSConf)
+	EncryptionConf struct {
+		KeyID   string `json:"key_id"`
+		Enabled bool   `json:"enabled"`
+	}
+	EncryptionConfToUpdate struct {
+		KeyID   *string `json:"key_id,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+	// KMSConf: encryption key provider (cluster-wide)
+	KMSConf struct {
+		Provider string `json:"provider"` // enum { "", KMSKeyfile }
+		Keyfile  string `json:"keyfile"`  // (KMSKeyfile): JSON file that maps key IDs to base64-encoded 256-bit keys
+	}
+	KMSConfToUpdate struct {
+		Provider *string `json:"provider,omitempty"`
+		Keyfile  *string `json:"keyfile,omitempty"`
+	}
+
+	// WebhookConf: delivery of bucket event notifications (see EventsConf);
+	// zero values mean defaults (see Webhook* constants)
+	WebhookConf struct {
+		Timeout    cos.Duration `json:"timeout"`     // HTTP request timeout
+		BatchTime  cos.Duration `json:"batch_time"`  // max time to accumulate events before sending
+		BatchSize  int          `json:"batch_size"`  // max number of events per request
+		MaxQueue   int64        `json:"max_queue"`   // max number of undelivered events (per target, per webhook URL)
+		MaxRetries int          `json:"max_retries"` // max number of retries (with exponential backoff) before dropping
+	}
+	WebhookConfToUpdate struct {
+		Timeout    *cos.Duration `json:"timeout,omitempty"`
+		BatchTime  *cos.Duration `json:"batch_time,omitempty"`
+		BatchSize  *int          `json:"batch_size,omitempty"`
+		MaxQueue   *int64        `json:"max_queue,omitempty"`
+		MaxRetries *int          `json:"max_retries,omitempty"`
+	}
+
+	// ReplicatorConf: asynchronous replication to remote AIS clusters (see ReplicationConf);
+	// zero values mean defaults (see Repl* constants)
+	ReplicatorConf struct {
+		Workers    int   `json:"workers"`     // max number of concurrent operations (per destination bucket)
+		MaxQueue   int64 `json:"max_queue"`   // max number of pending operations (per target, per destination bucket)
+		MaxRetries int   `json:"max_retries"` // max number of retries (with exponential backoff) before dropping
+	}
+	ReplicatorConfToUpdate struct {
+		Workers    *int   `json:"workers,omitempty"`
+		MaxQueue   *int64 `json:"max_queue,omitempty"`
+		MaxRetries *int   `json:"max_retries,omitempty"`
+	}
+
+	// JobHistoryConf: persistent history of finished jobs kept by IC proxies
+	// in a rotating set of files; zero values mean defaults (see JobHist* constants)
+	JobHistoryConf struct {
+		Enabled        bool `json:"enabled"`
+		RecordsPerFile int  `json:"records_per_file"` // rotate when the current file reaches this many records
+		MaxFiles       int  `json:"max_files"`        // max number of files to keep (the oldest get removed)
+	}
+	JobHistoryConfToUpdate struct {
+		Enabled        *bool `json:"enabled,omitempty"`
+		RecordsPerFile *int  `json:"records_per_file,omitempty"`
+		MaxFiles       *int  `json:"max_files,omitempty"`
+	}
+
+	// ETLConf: when not running in Kubernetes, targets run transformers as local processes
+	// (see etl/process.go) - that is, execute user-supplied code and commands on their hosts;
+	// disabled by default
+	ETLConf struct {
+		LocalRuntime bool `json:"local_runtime"`
+	}
+	ETLConfToUpdate struct {
+		LocalRuntime *bool `json:"local_runtime,omitempty"`
 	}
 
 	LRUConf struct {
@@ -295,11 +403,20 @@ type (
 
 		// Enabled: LRU will only run when set to true
 		Enabled bool `json:"enabled"`
+
+		// Policy: which objects to evict first (one of the LRUPolicy* enum); empty means LRUPolicyLRU
+		Policy string `json:"policy"`
+
+		// Priority: (bucket) eviction priority in the range [0, LRUMaxPriority];
+		// buckets with lower priority are evicted first
+		Priority int `json:"priority"`
 	}
 	LRUConfToUpdate struct {
 		DontEvictTime   *cos.Duration `json:"dont_evict_time,omitempty"`
 		CapacityUpdTime *cos.Duration `json:"capacity_upd_time,omitempty"`
 		Enabled         *bool         `json:"enabled,omitempty"`
+		Policy          *string       `json:"policy,omitempty"`
+		Priority        *int          `json:"priority,omitempty"`
 	}
 
 	DiskConf struct {
@@ -373,10 +490,15 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// Number of previous versions to keep for each object (ais:// buckets only;
+		// zero - keep the current version only).
+		MaxHistory int `json:"max_history"`
 	}
 	VersionConfToUpdate struct {
 		Enabled         *bool `json:"enabled,omitempty"`
 		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
+		MaxHistory      *int  `json:"max_history,omitempty"`
 	}
 
 	TestFSPConf struct {
@@ -429,13 +551,23 @@ type (
 		Enabled       *bool `json:"enabled,omitempty"`
 	}
 
+	// AuthConf: tokens signed with the secret (HS256) and/or with private keys (RS256, ES256)
+	// whose public counterparts are loaded from the local file and/or fetched from JWKS URL
 	AuthConf struct {
-		Secret  string `json:"secret"`
-		Enabled bool   `json:"enabled"`
+		Secret     string `json:"secret"`
+		Enabled    bool   `json:"enabled"`
+		JWKSURL    string `json:"jwks_url"`     // e.g. AuthN's http(s)://host:port/.well-known/jwks.json
+		PubKeyFile string `json:"pub_key_file"` // PEM-encoded public keys and/or certificates, or JWKS
+		Issuer     string `json:"issuer"`       // OIDC: tokens must have the same "iss" claim
+		Audience   string `json:"audience"`     // OIDC: tokens must have the same "aud" claim (one of)
 	}
 	AuthConfToUpdate struct {
-		Secret  *string `json:"secret,omitempty"`
-		Enabled *bool   `json:"enabled,omitempty"`
+		Secret     *string `json:"secret,omitempty"`
+		Enabled    *bool   `json:"enabled,omitempty"`
+		JWKSURL    *string `json:"jwks_url,omitempty"`
+		PubKeyFile *string `json:"pub_key_file,omitempty"`
+		Issuer     *string `json:"issuer,omitempty"`
+		Audience   *string `json:"audience,omitempty"`
 	}
 
 	// config for one keepalive tracker
@@ -463,10 +595,14 @@ type (
 	}
 
 	DownloaderConf struct {
-		Timeout cos.Duration `json:"timeout"`
+		Timeout      cos.Duration `json:"timeout"`
+		ChunkSize    cos.Size     `json:"chunk_size"`    // larger objects are downloaded in chunks (HTTP range requests) of this size
+		ChunkWorkers int          `json:"chunk_workers"` // max number of chunks of a given object downloaded in parallel
 	}
 	DownloaderConfToUpdate struct {
-		Timeout *cos.Duration `json:"timeout,omitempty"`
+		Timeout      *cos.Duration `json:"timeout,omitempty"`
+		ChunkSize    *cos.Size     `json:"chunk_size,omitempty"`
+		ChunkWorkers *int          `json:"chunk_workers,omitempty"`
 	}
 
 	DSortConf struct {
@@ -504,17 +640,24 @@ type (
 		// * QuiesceTime:  safe to terminate or transition to the next (in re: rebalance) stage
 		IdleTeardown cos.Duration `json:"idle_teardown"`
 		QuiesceTime  cos.Duration `json:"quiescent"`
+		// compression codec (of all compressed streams - see Compression in rebalance, ec, tcb, and dsort sections):
+		// lz4 (default) or zstd; the receiving side gets it with each stream (request) header
+		Codec string `json:"codec"`
 		// lz4
 		LZ4BlockMaxSize  cos.Size `json:"lz4_block"`          // max uncompressed block size, one of [64K, 256K(*), 1M, 4M]
 		LZ4FrameChecksum bool     `json:"lz4_frame_checksum"` // fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
+		// zstd
+		ZstdLevel int `json:"zstd_level"` // compression level [1, 22]; default 3 (higher is slower and compresses better)
 	}
 	TransportConfToUpdate struct {
 		MaxHeaderSize    *int          `json:"max_header,omitempty" list:"readonly"`
 		Burst            *int          `json:"burst_buffer,omitempty" list:"readonly"`
 		IdleTeardown     *cos.Duration `json:"idle_teardown,omitempty"`
 		QuiesceTime      *cos.Duration `json:"quiescent,omitempty"`
+		Codec            *string       `json:"codec,omitempty"`
 		LZ4BlockMaxSize  *cos.Size     `json:"lz4_block,omitempty"`
 		LZ4FrameChecksum *bool         `json:"lz4_frame_checksum,omitempty"`
+		ZstdLevel        *int          `json:"zstd_level,omitempty"`
 	}
 
 	MemsysConf struct {
@@ -551,8 +694,45 @@ type (
 		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
+
+	// RateLimitConf: token-bucket rate limits enforced by proxies (ops/s) and targets (bytes/s)
+	RateLimitConf struct {
+		Limits  []RateLimit `json:"limits,omitempty" list:"omitempty"`
+		Enabled bool        `json:"enabled"`
+	}
+	RateLimitConfToUpdate struct {
+		Limits  *[]RateLimit `json:"limits,omitempty"`
+		Enabled *bool        `json:"enabled,omitempty"`
+	}
+	// RateLimit applies to all requests that match its User, Bucket, and Op, whereby each
+	// of the three can be:
+	// - empty: matches all (users, buckets, ops) that then share a single (combined) limit;
+	// - RateLimitEach ("*"): matches all, with each user (bucket, op) limited separately;
+	// - specific AuthN user ID, bucket (e.g. "ais://abc"), or Op (enum RateLimitOp* below).
+	// Proxies enforce OpsPerSec, targets - BytesPerSec; each node enforces its own share
+	// of the limit (the limit divided by the number of active proxies or targets, respectively).
+	RateLimit struct {
+		ID          string   `json:"id"` // unique; identifies the limit in logs, errors, and stats
+		User        string   `json:"user,omitempty"`
+		Bucket      string   `json:"bucket,omitempty"`
+		Op          string   `json:"op,omitempty"`
+		OpsPerSec   int64    `json:"ops_per_sec,omitempty"`
+		BytesPerSec cos.Size `json:"bytes_per_sec,omitempty"`
+	}
 )
 
+// RateLimit.Op enum
+const (
+	RateLimitOpGet    = "get"
+	RateLimitOpPut    = "put"
+	RateLimitOpDelete = "delete"
+	RateLimitOpList   = "list"
+
+	RateLimitEach = "*"
+)
+
+var SupportedRateLimitOps = []string{RateLimitOpGet, RateLimitOpPut, RateLimitOpDelete, RateLimitOpList}
+
 const (
 	IgnoreReaction = "ignore"
 	WarnReaction   = "warn"
@@ -600,12 +780,21 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*RateLimitConf)(nil)
+	_ Validator = (*KMSConf)(nil)
+	_ Validator = (*WebhookConf)(nil)
+	_ Validator = (*ReplicatorConf)(nil)
+	_ Validator = (*JobHistoryConf)(nil)
+	_ Validator = (*LRUConf)(nil)
+	_ Validator = (*AuthConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
+	_ PropsValidator = (*LRUConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*EncryptionConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -932,27 +1121,169 @@ func (c *DiskConf) Validate() (err error) {
 
 func (c *SpaceConf) Validate() (err error) {
 	if c.CleanupWM <= 0 || c.LowWM < c.CleanupWM || c.HighWM < c.LowWM || c.OOS < c.HighWM || c.OOS > 100 {
-		err = fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+		return fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+	}
+	if c.LifecycleInterval < 0 {
+		return fmt.Errorf("invalid space.lifecycle_interval %v (expecting non-negative duration)", c.LifecycleInterval)
+	}
+	for nsname, quota := range c.NsQuota {
+		ns := ParseNsUname(nsname)
+		if ns.IsGlobal() || ns.String() != nsname {
+			return fmt.Errorf("invalid namespace quota: %q is not a valid (non-global) namespace", nsname)
+		}
+		if err = ns.Validate(); err != nil {
+			return fmt.Errorf("invalid namespace quota: %v", err)
+		}
+		if err = quota.Validate(); err != nil {
+			return fmt.Errorf("namespace %q: %v", nsname, err)
+		}
 	}
 	return
 }
 
 func (c *SpaceConf) ValidateAsProps(...interface{}) error { return c.Validate() }
 
+// default lifecycle interval (see SpaceConf.LifecycleInterval)
+const LifecycleInterval = time.Hour
+
+func (c *SpaceConf) LcInterval() time.Duration {
+	if c.LifecycleInterval > 0 {
+		return c.LifecycleInterval.D()
+	}
+	return LifecycleInterval
+}
+
 func (c *SpaceConf) String() string {
 	return fmt.Sprintf("space config: cleanup=%d%%, low=%d%%, high=%d%%, OOS=%d%%",
 		c.CleanupWM, c.LowWM, c.HighWM, c.OOS)
 }
 
+///////////////
+// QuotaConf //
+///////////////
+
+// quota kinds (see ErrQuotaExceeded)
+const (
+	QuotaSize = "size"
+	QuotaObjs = "objects"
+)
+
+func (c *QuotaConf) Validate() error {
+	if c.MaxSize < 0 || c.MaxObjs < 0 {
+		return fmt.Errorf("invalid quota (%s): expecting non-negative values", c)
+	}
+	return nil
+}
+
+func (c *QuotaConf) ValidateAsProps(...interface{}) error { return c.Validate() }
+
+func (c *QuotaConf) IsSet() bool { return c.MaxSize > 0 || c.MaxObjs > 0 }
+
+func (c *QuotaConf) String() string {
+	if !c.IsSet() {
+		return "Disabled"
+	}
+	var s []string
+	if c.MaxSize > 0 {
+		s = append(s, QuotaSize+" "+c.MaxSize.String())
+	}
+	if c.MaxObjs > 0 {
+		s = append(s, QuotaObjs+" "+strconv.FormatInt(c.MaxObjs, 10))
+	}
+	return strings.Join(s, ", ")
+}
+
+// Quota returns the storage quota of a given namespace, if configured
+func (c *SpaceConf) Quota(ns Ns) (quota *QuotaConf) {
+	if len(c.NsQuota) == 0 || ns.IsGlobal() {
+		return
+	}
+	if q, ok := c.NsQuota[ns.String()]; ok && q.IsSet() {
+		quota = &q
+	}
+	return
+}
+
+////////////////////
+// EncryptionConf //
+////////////////////
+
+func (c *EncryptionConf) ValidateAsProps(arg ...interface{}) error {
+	isRemote, ok := arg[0].(bool)
+	debug.Assert(ok)
+	if isRemote && c.Enabled {
+		return errors.New("encryption at rest is only supported for ais:// buckets")
+	}
+	if c.Enabled && c.KeyID == "" {
+		return errors.New("encryption enabled but key ID is not specified")
+	}
+	if c.KeyID != "" && !cos.IsAlphaPlus(c.KeyID, true /*with period*/) {
+		return fmt.Errorf("invalid encryption key ID %q: can only contain [A-Za-z0-9-_.]", c.KeyID)
+	}
+	return nil
+}
+
+func (c *EncryptionConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "key " + c.KeyID
+}
+
+const KMSKeyfile = "keyfile"
+
+func (c *KMSConf) Validate() error {
+	switch c.Provider {
+	case "":
+	case KMSKeyfile:
+		if c.Keyfile == "" {
+			return fmt.Errorf("invalid kms config: provider %q requires keyfile", c.Provider)
+		}
+	default:
+		return fmt.Errorf("invalid kms provider %q (expecting one of: %q)", c.Provider, []string{KMSKeyfile})
+	}
+	return nil
+}
+
 /////////////
 // LRUConf //
 /////////////
 
+// eviction policies
+const (
+	LRUPolicyLRU  = "lru"  // least recently used (oldest access time) first
+	LRUPolicyLFU  = "lfu"  // least frequently used (smallest access count) first, then oldest
+	LRUPolicySize = "size" // GDSF-like: lowest (access count / (size * age)) first
+
+	LRUMaxPriority = 100
+)
+
+var SupportedLRUPolicies = []string{LRUPolicyLRU, LRUPolicyLFU, LRUPolicySize}
+
+func (c *LRUConf) Validate() error {
+	if c.Policy != "" && !cos.StringInSlice(c.Policy, SupportedLRUPolicies) {
+		return fmt.Errorf("invalid lru.policy %q (expecting one of %v)", c.Policy, SupportedLRUPolicies)
+	}
+	if c.Priority < 0 || c.Priority > LRUMaxPriority {
+		return fmt.Errorf("invalid lru.priority %d (expecting range [0, %d])", c.Priority, LRUMaxPriority)
+	}
+	return nil
+}
+
+func (c *LRUConf) ValidateAsProps(...interface{}) error { return c.Validate() }
+
 func (c *LRUConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-	return fmt.Sprintf("LRU don't evict: %v", c.DontEvictTime)
+	s := fmt.Sprintf("LRU don't evict: %v", c.DontEvictTime)
+	if c.Policy != "" && c.Policy != LRUPolicyLRU {
+		s += ", policy: " + c.Policy
+	}
+	if c.Priority != 0 {
+		s += fmt.Sprintf(", priority: %d", c.Priority)
+	}
+	return s
 }
 
 ///////////////
@@ -999,9 +1330,24 @@ func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.MaxHistory < 0 {
+		return fmt.Errorf("invalid versioning.max_history %d (expecting non-negative integer)", c.MaxHistory)
+	}
+	if !c.Enabled && c.MaxHistory > 0 {
+		return errors.New("versioning.max_history requires versioning to be enabled")
+	}
 	return nil
 }
 
+func (c *VersionConf) ValidateAsProps(arg ...interface{}) error {
+	isRemote, ok := arg[0].(bool)
+	debug.Assert(ok)
+	if isRemote && c.MaxHistory > 0 {
+		return errors.New("versioning.max_history (version history) is only supported for ais:// buckets")
+	}
+	return c.Validate()
+}
+
 func (c *VersionConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -1013,6 +1359,9 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.MaxHistory > 0 {
+		text += " | History: " + strconv.Itoa(c.MaxHistory)
+	}
 
 	return text
 }
@@ -1131,6 +1480,203 @@ func (c *WritePolicyConf) Validate() (err error) {
 
 func (c *WritePolicyConf) ValidateAsProps(...interface{}) error { return c.Validate() }
 
+/////////////////
+// WebhookConf //
+/////////////////
+
+// WebhookConf defaults
+const (
+	WebhookTimeout    = 10 * time.Second
+	WebhookBatchTime  = time.Second
+	WebhookBatchSize  = 128
+	WebhookMaxQueue   = 1024 * 1024
+	WebhookMaxRetries = 60 // (with backoff capped at 1 minute: about an hour)
+)
+
+func (c *WebhookConf) Validate() error {
+	if c.Timeout < 0 || c.BatchTime < 0 || c.BatchSize < 0 || c.MaxQueue < 0 || c.MaxRetries < 0 {
+		return fmt.Errorf("invalid webhook config %+v: expecting non-negative values", *c)
+	}
+	return nil
+}
+
+func (c *AuthConf) Validate() error {
+	if (c.Issuer == "") != (c.Audience == "") {
+		return fmt.Errorf("invalid auth config: issuer %q and audience %q must be both set or both empty",
+			c.Issuer, c.Audience)
+	}
+	if c.JWKSURL == "" {
+		return nil
+	}
+	if u, err := url.Parse(c.JWKSURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid auth.jwks_url %q (expecting http(s)://host[:port]/path)", c.JWKSURL)
+	}
+	return nil
+}
+
+// Defaults returns a copy with zero values replaced by the defaults
+func (c *WebhookConf) Defaults() (conf WebhookConf) {
+	conf = *c
+	if conf.Timeout == 0 {
+		conf.Timeout = cos.Duration(WebhookTimeout)
+	}
+	if conf.BatchTime == 0 {
+		conf.BatchTime = cos.Duration(WebhookBatchTime)
+	}
+	if conf.BatchSize == 0 {
+		conf.BatchSize = WebhookBatchSize
+	}
+	if conf.MaxQueue == 0 {
+		conf.MaxQueue = WebhookMaxQueue
+	}
+	if conf.MaxRetries == 0 {
+		conf.MaxRetries = WebhookMaxRetries
+	}
+	return
+}
+
+////////////////////
+// ReplicatorConf //
+////////////////////
+
+// ReplicatorConf defaults
+const (
+	ReplWorkers    = 4
+	ReplMaxQueue   = 1024 * 1024
+	ReplMaxRetries = 120 // (with backoff capped at 1 minute: about two hours)
+)
+
+func (c *ReplicatorConf) Validate() error {
+	if c.Workers < 0 || c.MaxQueue < 0 || c.MaxRetries < 0 {
+		return fmt.Errorf("invalid replicator config %+v: expecting non-negative values", *c)
+	}
+	return nil
+}
+
+// Defaults returns a copy with zero values replaced by the defaults
+func (c *ReplicatorConf) Defaults() (conf ReplicatorConf) {
+	conf = *c
+	if conf.Workers == 0 {
+		conf.Workers = ReplWorkers
+	}
+	if conf.MaxQueue == 0 {
+		conf.MaxQueue = ReplMaxQueue
+	}
+	if conf.MaxRetries == 0 {
+		conf.MaxRetries = ReplMaxRetries
+	}
+	return
+}
+
+////////////////////
+// JobHistoryConf //
+////////////////////
+
+// JobHistoryConf defaults
+const (
+	JobHistRecordsPerFile = 10000
+	JobHistMaxFiles       = 10
+)
+
+func (c *JobHistoryConf) Validate() error {
+	if c.RecordsPerFile < 0 || c.MaxFiles < 0 {
+		return fmt.Errorf("invalid job_history config %+v: expecting non-negative values", *c)
+	}
+	return nil
+}
+
+// Defaults returns a copy with zero values replaced by the defaults
+func (c *JobHistoryConf) Defaults() (conf JobHistoryConf) {
+	conf = *c
+	if conf.RecordsPerFile == 0 {
+		conf.RecordsPerFile = JobHistRecordsPerFile
+	}
+	if conf.MaxFiles == 0 {
+		conf.MaxFiles = JobHistMaxFiles
+	}
+	return
+}
+
+///////////////////
+// RateLimitConf //
+///////////////////
+
+func (c *RateLimitConf) Validate() error {
+	ids := make(cos.StringSet, len(c.Limits))
+	for i := range c.Limits {
+		l := &c.Limits[i]
+		if err := l.Validate(); err != nil {
+			return err
+		}
+		if ids.Contains(l.ID) {
+			return fmt.Errorf("duplicate rate limit ID %q", l.ID)
+		}
+		ids.Add(l.ID)
+	}
+	return nil
+}
+
+// ByUser returns true if any of the limits applies to specific users (or each user separately)
+func (c *RateLimitConf) ByUser() bool {
+	for i := range c.Limits {
+		if c.Limits[i].User != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *RateLimit) Validate() error {
+	if l.ID == "" {
+		return fmt.Errorf("invalid rate limit %+v: ID must be non-empty", *l)
+	}
+	if !cos.IsAlphaPlus(l.ID, false /*with period*/) {
+		return fmt.Errorf("invalid rate limit ID %q: can only contain [A-Za-z0-9-_]", l.ID)
+	}
+	if l.OpsPerSec < 0 || l.BytesPerSec < 0 || (l.OpsPerSec == 0 && l.BytesPerSec == 0) {
+		return fmt.Errorf("invalid rate limit %q: expecting positive ops_per_sec and/or bytes_per_sec", l.ID)
+	}
+	if l.Op != "" && l.Op != RateLimitEach && !cos.StringInSlice(l.Op, SupportedRateLimitOps) {
+		return fmt.Errorf("invalid rate limit %q: op %q is not one of %v", l.ID, l.Op, SupportedRateLimitOps)
+	}
+	if l.Bucket != "" && l.Bucket != RateLimitEach {
+		bck, objName, err := ParseBckObjectURI(l.Bucket, ParseURIOpts{})
+		if err != nil {
+			return fmt.Errorf("invalid rate limit %q: %v", l.ID, err)
+		}
+		if bck.Name == "" || objName != "" || bck.String() != l.Bucket {
+			return fmt.Errorf("invalid rate limit %q: expecting bucket in the form provider://[@uuid#namespace/]name, got %q",
+				l.ID, l.Bucket)
+		}
+	}
+	return nil
+}
+
+// Match returns true if a given request (user, bucket, op) is subject to the limit
+func (l *RateLimit) Match(user, bck, op string) bool {
+	return rlmatch(l.User, user) && rlmatch(l.Bucket, bck) && rlmatch(l.Op, op)
+}
+
+// Key identifies the token bucket that a (matching) request draws from:
+// all requests share the limit's own bucket unless the limit is "per each"
+func (l *RateLimit) Key(user, bck, op string) string {
+	key := l.ID
+	if l.User == RateLimitEach {
+		key += "|u:" + user
+	}
+	if l.Bucket == RateLimitEach {
+		key += "|b:" + bck
+	}
+	if l.Op == RateLimitEach {
+		key += "|o:" + op
+	}
+	return key
+}
+
+func rlmatch(pattern, s string) bool {
+	return pattern == "" || pattern == RateLimitEach || pattern == s
+}
+
 ///////////////////
 // KeepaliveConf //
 ///////////////////
@@ -1468,6 +2014,12 @@ func (c *TransportConf) Validate() (err error) {
 	if c.MaxHeaderSize > 0 && c.MaxHeaderSize < 512 {
 		return fmt.Errorf("invalid transport.max_header: %v (expected >= 512)", c.MaxHeaderSize)
 	}
+	if !apc.IsValidCodec(c.Codec) {
+		return fmt.Errorf("invalid transport.codec %q (expected one of %v)", c.Codec, apc.SupportedCodecs)
+	}
+	if c.ZstdLevel < 0 || c.ZstdLevel > 22 {
+		return fmt.Errorf("invalid transport.zstd_level: %d (expected range [1, 22], or 0 for default)", c.ZstdLevel)
+	}
 	return nil
 }
 
@@ -1537,13 +2089,36 @@ func (d *timeout) MaxKeepalive() time.Duration    { return d.keepalive }
 // DownloaderConf //
 ////////////////////
 
+const (
+	DlChunkSize    = 64 * cos.MiB
+	DlChunkWorkers = 4
+)
+
 func (c *DownloaderConf) Validate() error {
 	if j := c.Timeout.D(); j < time.Second || j > time.Hour {
 		return fmt.Errorf("invalid downloader.timeout=%s (expected range [1s, 1h])", j)
 	}
+	if c.ChunkSize != 0 && c.ChunkSize < cos.MiB {
+		return fmt.Errorf("invalid downloader.chunk_size=%s (expecting at least 1MiB)", c.ChunkSize)
+	}
+	if c.ChunkWorkers < 0 || c.ChunkWorkers > 64 {
+		return fmt.Errorf("invalid downloader.chunk_workers=%d (expected range [0, 64])", c.ChunkWorkers)
+	}
 	return nil
 }
 
+// Defaults returns a copy with zero values replaced by the defaults
+func (c *DownloaderConf) Defaults() (conf DownloaderConf) {
+	conf = *c
+	if conf.ChunkSize == 0 {
+		conf.ChunkSize = DlChunkSize
+	}
+	if conf.ChunkWorkers == 0 {
+		conf.ChunkWorkers = DlChunkWorkers
+	}
+	return
+}
+
 ///////////////////
 // RebalanceConf //
 ///////////////////
@@ -0,0 +1,78 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket is a thread-safe token bucket that refills at a given rate (tokens per second)
+// up to a given burst. A request is admitted as long as the bucket is not empty - the
+// bucket may then go into debt, which is how requests larger than the burst (e.g., bytes
+// of a large object) get admitted while preserving the average rate.
+// All methods take the current (monotonic) time in nanoseconds - see mono.NanoTime.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   int64
+}
+
+// NewTokenBucket returns a full bucket
+func NewTokenBucket(rate, burst float64, now int64) *TokenBucket {
+	return &TokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+// SetRate changes rate and burst (no-op if unchanged)
+func (tb *TokenBucket) SetRate(rate, burst float64, now int64) {
+	tb.mu.Lock()
+	if tb.rate != rate || tb.burst != burst {
+		tb.refill(now)
+		tb.rate, tb.burst = rate, burst
+		if tb.tokens > burst {
+			tb.tokens = burst
+		}
+	}
+	tb.mu.Unlock()
+}
+
+// TryTake takes n tokens if the bucket is not empty; otherwise, returns false and
+// the time to wait until it won't be
+func (tb *TokenBucket) TryTake(n float64, now int64) (ok bool, wait time.Duration) {
+	tb.mu.Lock()
+	tb.refill(now)
+	if tb.tokens > 0 {
+		tb.tokens -= n
+		ok = true
+	} else {
+		wait = time.Duration((-tb.tokens + 1) / tb.rate * float64(time.Second))
+	}
+	tb.mu.Unlock()
+	return
+}
+
+// Return gives back n (previously taken) tokens
+func (tb *TokenBucket) Return(n float64) {
+	tb.mu.Lock()
+	tb.tokens = MinF64(tb.tokens+n, tb.burst)
+	tb.mu.Unlock()
+}
+
+// Idle returns the time since the bucket was last used
+func (tb *TokenBucket) Idle(now int64) time.Duration {
+	tb.mu.Lock()
+	idle := time.Duration(now - tb.last)
+	tb.mu.Unlock()
+	return idle
+}
+
+func (tb *TokenBucket) refill(now int64) {
+	if elapsed := now - tb.last; elapsed > 0 {
+		tb.tokens = MinF64(tb.tokens+tb.rate*float64(elapsed)/float64(time.Second), tb.burst)
+		tb.last = now
+	}
+}
@@ -73,6 +73,21 @@ type (
 		usedPct        int32
 		oos            bool
 	}
+	ErrQuotaExceeded struct {
+		what  string // bucket or namespace
+		quota string // e.g. "size" or "objects"
+		used  int64
+		limit int64 // this target's share of the quota
+	}
+	ErrObjLocked struct {
+		obj    string
+		reason string // legal hold or retention
+	}
+	ErrRateLimited struct {
+		node  string
+		limit string // cmn.RateLimit.ID
+		wait  time.Duration
+	}
 	ErrBucketAccessDenied struct{ errAccessDenied }
 	ErrObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied       struct {
@@ -349,6 +364,58 @@ func IsErrCapacityExceeded(err error) bool {
 	return ok
 }
 
+// ErrQuotaExceeded
+
+func NewErrQuotaExceeded(what, quota string, used, limit int64) *ErrQuotaExceeded {
+	return &ErrQuotaExceeded{what: what, quota: quota, used: used, limit: limit}
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.quota == QuotaSize {
+		return fmt.Sprintf("%s: storage quota exceeded (used %s, target's share of the size quota %s)",
+			e.what, cos.B2S(e.used, 2), cos.B2S(e.limit, 2))
+	}
+	return fmt.Sprintf("%s: storage quota exceeded (%d objects, target's share of the objects quota %d)",
+		e.what, e.used, e.limit)
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
+
+// ErrObjLocked
+
+func NewErrObjLocked(obj, reason string) *ErrObjLocked {
+	return &ErrObjLocked{obj: obj, reason: reason}
+}
+
+func (e *ErrObjLocked) Error() string {
+	return fmt.Sprintf("%s is locked (%s)", e.obj, e.reason)
+}
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
+
+// ErrRateLimited
+
+func NewErrRateLimited(node, limit string, wait time.Duration) *ErrRateLimited {
+	return &ErrRateLimited{node: node, limit: limit, wait: wait}
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("%s: rate limit %q exceeded, retry in %v", e.node, e.limit, e.wait)
+}
+
+func (e *ErrRateLimited) Wait() time.Duration { return e.wait }
+
+func IsErrRateLimited(err error) bool {
+	_, ok := err.(*ErrRateLimited)
+	return ok
+}
+
 // ErrInvalidCksum
 
 func (e *ErrInvalidCksum) Error() string {
@@ -19,6 +19,8 @@ const (
 	BmdPreviousFname = BmdFname + ".prev" // bmd previous version
 	VmdFname         = ".ais.vmd"         // vmd persistent file basename
 	EmdFname         = ".ais.emd"         // emd persistent file basename
+	SchedFname       = ".ais.sched"       // job schedules (proxy only)
+	JobHistDirName   = ".ais.jobs"        // job history (IC proxies only)
 
 	TokenFname     = "auth.token" // see jsp/app.go
 	CliConfigFname = "cli.json"   // ditto
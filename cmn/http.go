@@ -36,7 +36,11 @@ const (
 	HdrAccept                = "Accept"
 	HdrLocation              = "Location"
 	HdrETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+	HdrContentMD5            = "Content-MD5"
+	HdrLastModified          = "Last-Modified"
+	HdrIfRange               = "If-Range" // Ref: https://www.rfc-editor.org/rfc/rfc9110#field.if-range
 	HdrError                 = "Hdr-Error"
+	HdrRetryAfter            = "Retry-After" // Ref: https://www.rfc-editor.org/rfc/rfc9110#field.retry-after
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
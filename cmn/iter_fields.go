@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -378,6 +379,16 @@ func (f *field) SetValue(src interface{}, force ...bool) error {
 			dst = dst.Elem()                        // dereference pointer
 			goto reflectDst
 		case reflect.Slice:
+			// slices of structs (e.g. RateLimitConf.Limits) can be set using JSON
+			if dst.Type().Elem().Kind() == reflect.Struct {
+				if err := jsoniter.Unmarshal([]byte(s), dst.Addr().Interface()); err != nil {
+					return fmt.Errorf("property %q (%s): invalid JSON %q: %v", f.name, dst.Type(), s, err)
+				}
+				break
+			}
+			if dst.Type().Elem().Kind() != reflect.String {
+				return fmt.Errorf("property %q (%s) cannot be set from a string (use JSON instead)", f.name, dst.Type())
+			}
 			// A slice value looks like: "[value1 value2]"
 			s := strings.TrimPrefix(srcVal.String(), "[")
 			s = strings.TrimSuffix(s, "]")
@@ -394,7 +405,13 @@ func (f *field) SetValue(src interface{}, force ...bool) error {
 				dst.Set(lst)
 			}
 		case reflect.Map:
-			// do nothing (e.g. ObjAttrs.CustomMD)
+			// maps of structs (e.g. SpaceConf.NsQuota) can be set using JSON;
+			// otherwise, do nothing (e.g. ObjAttrs.CustomMD)
+			if dst.Type().Elem().Kind() == reflect.Struct && s != "" {
+				if err := jsoniter.Unmarshal([]byte(s), dst.Addr().Interface()); err != nil {
+					return fmt.Errorf("property %q (%s): invalid JSON %q: %v", f.name, dst.Type(), s, err)
+				}
+			}
 		default:
 			debug.Assertf(false, "field.name: %s, field.type: %s", f.listTag, dst.Kind())
 		}
@@ -32,8 +32,19 @@ const (
 	ETag         = "ETag"
 
 	OrigURLObjMD = "orig_url"
+
+	// server-side encryption at rest: "<key ID>:<base64 nonce>" (see package sse)
+	SSEObjMD = "sse"
+
+	// object lock (see ObjectLockConf): legal hold ("true") and
+	// the end of retention (RFC 3339 time)
+	LegalHoldObjMD   = "legal-hold"
+	RetainUntilObjMD = "retain-until"
 )
 
+// system custom keys that cannot be set (or removed) via api.SetObjectCustomProps
+var SysObjMD = []string{SSEObjMD, LegalHoldObjMD, RetainUntilObjMD}
+
 // provider-specific header keys
 const (
 	// https://cloud.google.com/storage/docs/xml-api/reference-headers
@@ -0,0 +1,86 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestEventsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     cmn.EventsConf
+		expected bool // valid
+	}{
+		{name: "empty", conf: cmn.EventsConf{}, expected: true},
+		{
+			name:     "enabled",
+			conf:     cmn.EventsConf{Webhooks: []cmn.Webhook{{URL: "http://example.com/hook"}}, Enabled: true},
+			expected: true,
+		},
+		{
+			name: "events-and-prefix",
+			conf: cmn.EventsConf{Webhooks: []cmn.Webhook{{
+				ID: "a", URL: "https://example.com", Events: []string{cmn.EvPut, cmn.EvDelete}, Prefix: "logs/",
+			}}},
+			expected: true,
+		},
+		{name: "enabled-no-webhooks", conf: cmn.EventsConf{Enabled: true}, expected: false},
+		{name: "no-url", conf: cmn.EventsConf{Webhooks: []cmn.Webhook{{ID: "a"}}}, expected: false},
+		{name: "invalid-scheme", conf: cmn.EventsConf{Webhooks: []cmn.Webhook{{URL: "ftp://example.com"}}}, expected: false},
+		{name: "no-host", conf: cmn.EventsConf{Webhooks: []cmn.Webhook{{URL: "http:///hook"}}}, expected: false},
+		{
+			name:     "invalid-event",
+			conf:     cmn.EventsConf{Webhooks: []cmn.Webhook{{URL: "http://example.com", Events: []string{"get"}}}},
+			expected: false,
+		},
+		{
+			name: "duplicate-id",
+			conf: cmn.EventsConf{Webhooks: []cmn.Webhook{
+				{ID: "a", URL: "http://example.com/1"}, {ID: "a", URL: "http://example.com/2"},
+			}},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.conf.ValidateAsProps()
+			if test.expected {
+				tassert.CheckError(t, err)
+			} else if err == nil {
+				t.Errorf("expected validation error for %+v", test.conf)
+			}
+		})
+	}
+}
+
+func TestWebhookMatch(t *testing.T) {
+	wh := cmn.Webhook{URL: "http://example.com"}
+	tassert.Errorf(t, wh.Match(cmn.EvPut, "a/b"), "expected any event to match")
+	tassert.Errorf(t, wh.Match(cmn.EvECRestore, "c"), "expected any event to match")
+
+	wh.Events = []string{cmn.EvDelete}
+	tassert.Errorf(t, wh.Match(cmn.EvDelete, "a/b"), "expected %q to match", cmn.EvDelete)
+	tassert.Errorf(t, !wh.Match(cmn.EvPut, "a/b"), "expected %q not to match", cmn.EvPut)
+
+	wh.Events, wh.Prefix = nil, "a/"
+	tassert.Errorf(t, wh.Match(cmn.EvAppend, "a/b"), "expected prefix to match")
+	tassert.Errorf(t, !wh.Match(cmn.EvAppend, "b/a"), "expected prefix not to match")
+}
+
+func TestWebhookConfDefaults(t *testing.T) {
+	conf := cmn.WebhookConf{BatchSize: 10}
+	tassert.CheckError(t, conf.Validate())
+	d := conf.Defaults()
+	tassert.Errorf(t, d.BatchSize == 10, "expected batch size 10, got %d", d.BatchSize)
+	tassert.Errorf(t, d.Timeout.D() == cmn.WebhookTimeout, "expected default timeout, got %v", d.Timeout)
+	tassert.Errorf(t, d.MaxQueue == cmn.WebhookMaxQueue, "expected default max queue, got %d", d.MaxQueue)
+
+	conf.BatchSize = -1
+	tassert.Errorf(t, conf.Validate() != nil, "expected validation error for negative batch size")
+}
@@ -0,0 +1,69 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestInventoryValidate(t *testing.T) {
+	day := cos.Duration(24 * time.Hour)
+	tests := []struct {
+		name     string
+		conf     cmn.InventoryConf
+		expected bool // valid
+	}{
+		{name: "empty", conf: cmn.InventoryConf{}, expected: true},
+		{name: "on-demand-only", conf: cmn.InventoryConf{Bucket: "ais://inv"}, expected: true},
+		{name: "enabled", conf: cmn.InventoryConf{Bucket: "ais://inv", Interval: day, Enabled: true}, expected: true},
+		{
+			name:     "msgpack",
+			conf:     cmn.InventoryConf{Bucket: "inv", Format: cmn.InvFormatMsgpack, Interval: day, Enabled: true},
+			expected: true,
+		},
+		{name: "enabled-no-bucket", conf: cmn.InventoryConf{Interval: day, Enabled: true}, expected: false},
+		{name: "enabled-no-interval", conf: cmn.InventoryConf{Bucket: "ais://inv", Enabled: true}, expected: false},
+		{
+			name:     "interval-too-short",
+			conf:     cmn.InventoryConf{Bucket: "ais://inv", Interval: cos.Duration(time.Minute), Enabled: true},
+			expected: false,
+		},
+		{name: "invalid-format", conf: cmn.InventoryConf{Bucket: "ais://inv", Format: "parquet"}, expected: false},
+		{name: "object-not-bucket", conf: cmn.InventoryConf{Bucket: "ais://inv/obj"}, expected: false},
+		{name: "invalid-provider", conf: cmn.InventoryConf{Bucket: "xyz://inv"}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.conf.ValidateAsProps()
+			if test.expected {
+				tassert.CheckError(t, err)
+			} else if err == nil {
+				t.Errorf("expected validation error for %+v", test.conf)
+			}
+		})
+	}
+}
+
+func TestInventoryDest(t *testing.T) {
+	conf := cmn.InventoryConf{Bucket: "inv"}
+	bck, err := conf.Dest()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bck.Equal(&cmn.Bck{Name: "inv", Provider: apc.ProviderAIS}), "unexpected %s", bck)
+
+	conf.Bucket = "s3://inv"
+	bck, err = conf.Dest()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bck.Provider == apc.ProviderAmazon, "unexpected %s", bck)
+
+	tassert.Errorf(t, conf.Ext() == ".csv", "unexpected %q", conf.Ext())
+	conf.Format = cmn.InvFormatMsgpack
+	tassert.Errorf(t, conf.Ext() == cos.ExtMsgpack, "unexpected %q", conf.Ext())
+}
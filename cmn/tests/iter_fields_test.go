@@ -80,6 +80,7 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.max_history":       0,
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -90,6 +91,31 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           false,
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
+					"lru.policy":            "",
+					"lru.priority":          0,
+
+					"lifecycle.enabled": false,
+
+					"quota.max_size":    cos.Size(0),
+					"quota.max_objects": int64(0),
+
+					"encryption.enabled": false,
+					"encryption.key_id":  "",
+
+					"inventory.bucket":   "",
+					"inventory.prefix":   "",
+					"inventory.format":   "",
+					"inventory.interval": cos.Duration(0),
+					"inventory.enabled":  false,
+					"events.enabled":     false,
+
+					"object_lock.mode":      "",
+					"object_lock.retention": cos.Duration(0),
+					"object_lock.enabled":   false,
+
+					"replication.bucket":  "",
+					"replication.prefix":  "",
+					"replication.enabled": false,
 
 					"extra.aws.cloud_region": "us-central",
 					"extra.aws.endpoint":     "",
@@ -134,6 +160,7 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.max_history":       (*int)(nil),
 
 					"checksum.type":              api.String(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -144,6 +171,33 @@ var _ = Describe("IterFields", func() {
 					"lru.enabled":           (*bool)(nil),
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
+					"lru.policy":            (*string)(nil),
+					"lru.priority":          (*int)(nil),
+
+					"lifecycle.rules":   (*[]cmn.LifecycleRule)(nil),
+					"lifecycle.enabled": (*bool)(nil),
+
+					"quota.max_size":    (*cos.Size)(nil),
+					"quota.max_objects": (*int64)(nil),
+
+					"encryption.enabled": (*bool)(nil),
+					"encryption.key_id":  (*string)(nil),
+
+					"inventory.bucket":   (*string)(nil),
+					"inventory.prefix":   (*string)(nil),
+					"inventory.format":   (*string)(nil),
+					"inventory.interval": (*cos.Duration)(nil),
+					"inventory.enabled":  (*bool)(nil),
+					"events.webhooks":    (*[]cmn.Webhook)(nil),
+					"events.enabled":     (*bool)(nil),
+
+					"object_lock.mode":      (*string)(nil),
+					"object_lock.retention": (*cos.Duration)(nil),
+					"object_lock.enabled":   (*bool)(nil),
+
+					"replication.bucket":  (*string)(nil),
+					"replication.prefix":  (*string)(nil),
+					"replication.enabled": (*bool)(nil),
 
 					"access": api.AccessAttrs(1024),
 
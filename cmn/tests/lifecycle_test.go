@@ -0,0 +1,141 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestLifecycleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		lc       cmn.LifecycleConf
+		remote   bool
+		expected bool // valid
+	}{
+		{name: "empty", lc: cmn.LifecycleConf{}, expected: true},
+		{name: "enabled-no-rules", lc: cmn.LifecycleConf{Enabled: true}, expected: false},
+		{
+			name: "delete-by-age",
+			lc: cmn.LifecycleConf{Enabled: true, Rules: []cmn.LifecycleRule{
+				{Prefix: "tmp/", Action: cmn.LcActDelete, Age: cos.Duration(7 * 24 * time.Hour)},
+			}},
+			expected: true,
+		},
+		{
+			name: "evict-ais-bucket",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{Action: cmn.LcActEvict, Age: cos.Duration(time.Hour)},
+			}},
+			expected: false,
+		},
+		{
+			name: "evict-remote-bucket",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{Action: cmn.LcActEvict, Age: cos.Duration(time.Hour)},
+			}},
+			remote:   true,
+			expected: true,
+		},
+		{
+			name: "invalid-action",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{Action: "archive", Age: cos.Duration(time.Hour)},
+			}},
+			expected: false,
+		},
+		{
+			name: "no-filters",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{Prefix: "tmp/", Action: cmn.LcActDelete},
+			}},
+			expected: false,
+		},
+		{
+			name: "min-exceeds-max",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{Action: cmn.LcActDelete, MinSize: 2 * cos.MiB, MaxSize: cos.MiB},
+			}},
+			expected: false,
+		},
+		{
+			name: "duplicate-id",
+			lc: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+				{ID: "r1", Action: cmn.LcActDelete, Age: cos.Duration(time.Hour)},
+				{ID: "r1", Action: cmn.LcActDelete, MinSize: cos.GiB},
+			}},
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.lc.ValidateAsProps(test.remote)
+			if test.expected {
+				tassert.CheckFatal(t, err)
+			} else if err == nil {
+				t.Fatalf("expected validation error for %+v", test.lc)
+			}
+		})
+	}
+}
+
+func TestLifecycleMatch(t *testing.T) {
+	var (
+		now   = time.Now().UnixNano()
+		day   = int64(24 * time.Hour)
+		rules = []cmn.LifecycleRule{
+			{ID: "tmp", Prefix: "tmp/", Action: cmn.LcActDelete, Age: cos.Duration(7 * day)},
+			{ID: "large", Action: cmn.LcActEvict, MinSize: cos.GiB},
+			{ID: "small-logs", Prefix: "logs/", Action: cmn.LcActDelete, MaxSize: cos.KiB, Age: cos.Duration(day)},
+		}
+		lc = cmn.LifecycleConf{Enabled: true, Rules: rules}
+	)
+	tests := []struct {
+		objName string
+		size    int64
+		mtime   int64
+		rule    string // expected matching rule ("" - none)
+	}{
+		{objName: "tmp/a", size: cos.KiB, mtime: now - 8*day, rule: "tmp"},
+		{objName: "tmp/b", size: cos.KiB, mtime: now - 6*day, rule: ""},
+		{objName: "data/c", size: 2 * cos.GiB, mtime: now, rule: "large"},
+		{objName: "tmp/d", size: 2 * cos.GiB, mtime: now - 30*day, rule: "tmp"}, // first match wins
+		{objName: "logs/e", size: 100, mtime: now - 2*day, rule: "small-logs"},
+		{objName: "logs/f", size: 2 * cos.KiB, mtime: now - 2*day, rule: ""},
+		{objName: "logs/g", size: 100, mtime: now, rule: ""},
+	}
+	for _, test := range tests {
+		rule := lc.Match(test.objName, test.size, test.mtime, now)
+		switch {
+		case test.rule == "" && rule != nil:
+			t.Errorf("%s: unexpected match %q", test.objName, rule)
+		case test.rule != "" && rule == nil:
+			t.Errorf("%s: expected match %q, got none", test.objName, test.rule)
+		case rule != nil && rule.ID != test.rule:
+			t.Errorf("%s: expected match %q, got %q", test.objName, test.rule, rule)
+		}
+	}
+}
+
+func TestLifecycleBucketProps(t *testing.T) {
+	bck := cmn.Bck{Name: "lc", Provider: apc.ProviderAIS}
+	bp := bck.DefaultProps()
+	bp.SetProvider(bck.Provider)
+	bp.Lifecycle = cmn.LifecycleConf{Enabled: true, Rules: []cmn.LifecycleRule{
+		{Action: cmn.LcActEvict, Age: cos.Duration(time.Hour)},
+	}}
+	// eviction requires remote backend
+	if err := bp.Validate(1); err == nil {
+		t.Fatal("expected validation error: evicting from ais bucket")
+	}
+	bp.Lifecycle.Rules[0].Action = cmn.LcActDelete
+	tassert.CheckFatal(t, bp.Validate(1))
+}
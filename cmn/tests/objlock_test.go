@@ -0,0 +1,76 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestObjectLockValidate(t *testing.T) {
+	day := cos.Duration(24 * time.Hour)
+	tests := []struct {
+		name     string
+		conf     cmn.ObjectLockConf
+		expected bool // valid
+	}{
+		{name: "disabled", conf: cmn.ObjectLockConf{}, expected: true},
+		{name: "governance", conf: cmn.ObjectLockConf{Mode: cmn.LockModeGovernance, Retention: day, Enabled: true}, expected: true},
+		{name: "compliance", conf: cmn.ObjectLockConf{Mode: cmn.LockModeCompliance, Enabled: true}, expected: true},
+		{name: "no-mode", conf: cmn.ObjectLockConf{Retention: day, Enabled: true}, expected: false},
+		{name: "invalid-mode", conf: cmn.ObjectLockConf{Mode: "legal", Enabled: true}, expected: false},
+		{name: "negative-retention", conf: cmn.ObjectLockConf{Mode: cmn.LockModeGovernance, Retention: -day}, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.conf.ValidateAsProps()
+			if test.expected {
+				tassert.CheckError(t, err)
+			} else if err == nil {
+				t.Errorf("expected validation error for %+v", test.conf)
+			}
+		})
+	}
+}
+
+func TestObjectLockValidateUpdate(t *testing.T) {
+	var (
+		day  = cos.Duration(24 * time.Hour)
+		gov  = cmn.ObjectLockConf{Mode: cmn.LockModeGovernance, Retention: day, Enabled: true}
+		comp = cmn.ObjectLockConf{Mode: cmn.LockModeCompliance, Retention: day, Enabled: true}
+		off  = cmn.ObjectLockConf{}
+	)
+	tests := []struct {
+		name     string
+		from, to cmn.ObjectLockConf
+		bypass   bool
+		expected bool // allowed
+	}{
+		{name: "enable", from: off, to: comp, expected: true},
+		{name: "extend-governance", from: gov, to: cmn.ObjectLockConf{Mode: cmn.LockModeGovernance, Retention: 2 * day, Enabled: true}, expected: true},
+		{name: "extend-compliance", from: comp, to: cmn.ObjectLockConf{Mode: cmn.LockModeCompliance, Retention: 2 * day, Enabled: true}, expected: true},
+		{name: "governance-to-compliance", from: gov, to: comp, expected: true},
+		{name: "disable-governance", from: gov, to: off, expected: false},
+		{name: "disable-governance-force", from: gov, to: off, bypass: true, expected: true},
+		{name: "shorten-governance-force", from: gov, to: cmn.ObjectLockConf{Mode: cmn.LockModeGovernance, Enabled: true}, bypass: true, expected: true},
+		{name: "disable-compliance", from: comp, to: off, bypass: true, expected: false},
+		{name: "compliance-to-governance", from: comp, to: gov, bypass: true, expected: false},
+		{name: "shorten-compliance", from: comp, to: cmn.ObjectLockConf{Mode: cmn.LockModeCompliance, Enabled: true}, bypass: true, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.from.ValidateUpdate(&test.to, test.bypass)
+			if test.expected {
+				tassert.CheckError(t, err)
+			} else if err == nil {
+				t.Errorf("expected %+v => %+v (bypass %t) to fail", test.from, test.to, test.bypass)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestQuotaBucketProps(t *testing.T) {
+	bck := cmn.Bck{Name: "quota", Provider: apc.ProviderAIS}
+	bp := bck.DefaultProps()
+	bp.SetProvider(bck.Provider)
+	tassert.Fatalf(t, !bp.Quota.IsSet(), "expecting no quota by default, got %s", &bp.Quota)
+
+	bp.Quota = cmn.QuotaConf{MaxSize: -cos.GiB}
+	if err := bp.Validate(1); err == nil {
+		t.Fatal("expected validation error: negative quota")
+	}
+	bp.Quota = cmn.QuotaConf{MaxSize: 10 * cos.GiB, MaxObjs: 1000}
+	tassert.CheckFatal(t, bp.Validate(1))
+
+	// set via (string) name-value pairs, as in `ais bucket props set`
+	nprops, err := cmn.NewBucketPropsToUpdate(cos.SimpleKVs{"quota.max_size": "1TiB", "quota.max_objects": "10"})
+	tassert.CheckFatal(t, err)
+	bp.Apply(nprops)
+	tassert.Errorf(t, bp.Quota.MaxSize == cos.TiB && bp.Quota.MaxObjs == 10, "unexpected quota %s", &bp.Quota)
+}
+
+func TestNsQuotaValidate(t *testing.T) {
+	tests := []struct {
+		nsname   string
+		quota    cmn.QuotaConf
+		expected bool // valid
+	}{
+		{nsname: "#tenant", quota: cmn.QuotaConf{MaxSize: cos.TiB}, expected: true},
+		{nsname: "@uuid#tenant", quota: cmn.QuotaConf{MaxObjs: 100}, expected: true},
+		{nsname: "tenant", quota: cmn.QuotaConf{MaxSize: cos.TiB}, expected: false},
+		{nsname: "", quota: cmn.QuotaConf{MaxSize: cos.TiB}, expected: false},
+		{nsname: "#ten/ant", quota: cmn.QuotaConf{MaxSize: cos.TiB}, expected: false},
+		{nsname: "#tenant", quota: cmn.QuotaConf{MaxObjs: -1}, expected: false},
+	}
+	for _, test := range tests {
+		c := cmn.SpaceConf{CleanupWM: 65, LowWM: 75, HighWM: 90, OOS: 95}
+		c.NsQuota = map[string]cmn.QuotaConf{test.nsname: test.quota}
+		err := c.Validate()
+		if test.expected {
+			tassert.CheckError(t, err)
+		} else if err == nil {
+			t.Errorf("expected validation error for namespace %q, quota %s", test.nsname, &test.quota)
+		}
+	}
+}
+
+func TestNsQuotaConfigUpdate(t *testing.T) {
+	var (
+		config   = &cmn.Config{}
+		toUpdate = &cmn.ConfigToUpdate{}
+	)
+	err := toUpdate.FillFromKVS([]string{`space.ns_quota={"#tenant": {"max_size": "10GiB", "max_objects": 1000}}`})
+	tassert.CheckFatal(t, err)
+	err = config.Apply(*toUpdate, apc.Cluster)
+	tassert.CheckFatal(t, err)
+
+	quota := config.Space.Quota(cmn.Ns{Name: "tenant"})
+	tassert.Fatalf(t, quota != nil, "expecting namespace quota")
+	tassert.Errorf(t, quota.MaxSize == 10*cos.GiB && quota.MaxObjs == 1000, "unexpected quota %s", quota)
+	tassert.Errorf(t, config.Space.Quota(cmn.Ns{Name: "other"}) == nil, "expecting no quota")
+	tassert.Errorf(t, config.Space.Quota(cmn.NsGlobal) == nil, "expecting no quota")
+}
@@ -0,0 +1,124 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestRateLimitValidate(t *testing.T) {
+	tests := []struct {
+		limit    cmn.RateLimit
+		expected bool // valid
+	}{
+		{limit: cmn.RateLimit{ID: "all", OpsPerSec: 1000}, expected: true},
+		{limit: cmn.RateLimit{ID: "u1", User: "u1", BytesPerSec: cos.GiB}, expected: true},
+		{limit: cmn.RateLimit{ID: "each-user", User: "*", Op: "get", OpsPerSec: 10}, expected: true},
+		{limit: cmn.RateLimit{ID: "bck", Bucket: "ais://abc", OpsPerSec: 10}, expected: true},
+		{limit: cmn.RateLimit{ID: "nsbck", Bucket: "ais://#ns/abc", OpsPerSec: 10}, expected: true},
+		{limit: cmn.RateLimit{ID: "", OpsPerSec: 1000}, expected: false},
+		{limit: cmn.RateLimit{ID: "a.b", OpsPerSec: 1000}, expected: false},
+		{limit: cmn.RateLimit{ID: "zero"}, expected: false},
+		{limit: cmn.RateLimit{ID: "neg", OpsPerSec: -1}, expected: false},
+		{limit: cmn.RateLimit{ID: "op", Op: "head", OpsPerSec: 10}, expected: false},
+		{limit: cmn.RateLimit{ID: "noprov", Bucket: "abc", OpsPerSec: 10}, expected: false},
+		{limit: cmn.RateLimit{ID: "obj", Bucket: "ais://abc/obj", OpsPerSec: 10}, expected: false},
+	}
+	for _, test := range tests {
+		c := cmn.RateLimitConf{Limits: []cmn.RateLimit{test.limit}}
+		err := c.Validate()
+		if test.expected {
+			tassert.CheckError(t, err)
+		} else if err == nil {
+			t.Errorf("expected validation error for %+v", test.limit)
+		}
+	}
+
+	c := cmn.RateLimitConf{Limits: []cmn.RateLimit{{ID: "dup", OpsPerSec: 1}, {ID: "dup", BytesPerSec: 1}}}
+	tassert.Errorf(t, c.Validate() != nil, "expected duplicate ID error")
+}
+
+func TestRateLimitMatch(t *testing.T) {
+	var (
+		all  = cmn.RateLimit{ID: "all", OpsPerSec: 1}
+		each = cmn.RateLimit{ID: "each", User: "*", Bucket: "ais://abc", OpsPerSec: 1}
+		u1   = cmn.RateLimit{ID: "u1", User: "u1", Op: "put", OpsPerSec: 1}
+	)
+	tassert.Errorf(t, all.Match("u1", "ais://abc", "get") && all.Match("", "aws://xyz", "list"), "expecting match")
+	tassert.Errorf(t, all.Key("u1", "ais://abc", "get") == all.Key("u2", "aws://xyz", "put"), "expecting shared key")
+
+	tassert.Errorf(t, each.Match("u1", "ais://abc", "get") && !each.Match("u1", "ais://xyz", "get"), "wrong match")
+	tassert.Errorf(t, each.Key("u1", "ais://abc", "get") != each.Key("u2", "ais://abc", "get"),
+		"expecting separate keys for each user")
+	tassert.Errorf(t, each.Key("u1", "ais://abc", "get") == each.Key("u1", "ais://abc", "put"),
+		"expecting shared key for all ops")
+
+	tassert.Errorf(t, u1.Match("u1", "ais://abc", "put") && !u1.Match("u2", "ais://abc", "put") &&
+		!u1.Match("u1", "ais://abc", "get"), "wrong match")
+}
+
+func TestRateLimitConfigUpdate(t *testing.T) {
+	var (
+		config   = &cmn.Config{}
+		toUpdate = &cmn.ConfigToUpdate{}
+	)
+	err := toUpdate.FillFromKVS([]string{
+		"rate_limit.enabled=true",
+		`rate_limit.limits=[{"id": "users", "user": "*", "ops_per_sec": 100}, {"id": "bw", "bytes_per_sec": "1GiB"}]`,
+	})
+	tassert.CheckFatal(t, err)
+	err = config.Apply(*toUpdate, apc.Cluster)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, config.RateLimit.Validate())
+
+	tassert.Fatalf(t, config.RateLimit.Enabled && len(config.RateLimit.Limits) == 2, "unexpected %+v", config.RateLimit)
+	tassert.Errorf(t, config.RateLimit.Limits[1].BytesPerSec == cos.GiB, "unexpected %+v", config.RateLimit.Limits[1])
+	tassert.Errorf(t, config.RateLimit.ByUser(), "expecting per-user limits")
+}
+
+func TestTokenBucket(t *testing.T) {
+	var (
+		now = time.Now().UnixNano()
+		tb  = cos.NewTokenBucket(10 /*rate*/, 10 /*burst*/, now)
+	)
+	// burst
+	for i := 0; i < 10; i++ {
+		ok, _ := tb.TryTake(1, now)
+		tassert.Fatalf(t, ok, "expecting success (%d)", i)
+	}
+	ok, wait := tb.TryTake(1, now)
+	tassert.Fatalf(t, !ok, "expecting rate limited")
+	tassert.Errorf(t, wait > 0 && wait <= 100*time.Millisecond, "unexpected wait %v", wait)
+
+	// refill
+	now += int64(100 * time.Millisecond)
+	ok, _ = tb.TryTake(1, now)
+	tassert.Fatalf(t, ok, "expecting success upon refill")
+
+	// debt: large requests are admitted as long as the bucket isn't empty...
+	now += int64(time.Second)
+	ok, _ = tb.TryTake(30, now)
+	tassert.Fatalf(t, ok, "expecting success")
+	// ...and paid off later
+	ok, wait = tb.TryTake(1, now)
+	tassert.Fatalf(t, !ok, "expecting rate limited")
+	tassert.Errorf(t, wait > 2*time.Second, "unexpected wait %v", wait)
+
+	// returning tokens never exceeds the burst
+	tb.Return(100)
+	now += int64(time.Millisecond)
+	for i := 0; i < 10; i++ {
+		ok, _ := tb.TryTake(1, now)
+		tassert.Fatalf(t, ok, "expecting success (%d)", i)
+	}
+	ok, _ = tb.TryTake(1, now)
+	tassert.Errorf(t, !ok, "expecting rate limited")
+}
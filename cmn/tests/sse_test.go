@@ -0,0 +1,64 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestEncryptionBucketProps(t *testing.T) {
+	bck := cmn.Bck{Name: "sse", Provider: apc.ProviderAIS}
+	bp := bck.DefaultProps()
+	bp.SetProvider(bck.Provider)
+	tassert.Fatalf(t, !bp.Encryption.Enabled, "expecting encryption disabled by default")
+
+	bp.Encryption = cmn.EncryptionConf{Enabled: true}
+	if err := bp.Validate(1); err == nil {
+		t.Fatal("expected validation error: no key ID")
+	}
+	bp.Encryption = cmn.EncryptionConf{Enabled: true, KeyID: "bad/key"}
+	if err := bp.Validate(1); err == nil {
+		t.Fatal("expected validation error: invalid key ID")
+	}
+	nprops, err := cmn.NewBucketPropsToUpdate(cos.SimpleKVs{"encryption.enabled": "true", "encryption.key_id": "key-1"})
+	tassert.CheckFatal(t, err)
+	bp.Apply(nprops)
+	tassert.CheckFatal(t, bp.Validate(1))
+	tassert.Errorf(t, bp.Encryption.Enabled && bp.Encryption.KeyID == "key-1", "unexpected %s", &bp.Encryption)
+
+	// remote buckets
+	bck = cmn.Bck{Name: "sse", Provider: apc.ProviderAmazon}
+	bp = bck.DefaultProps()
+	bp.SetProvider(bck.Provider)
+	bp.Encryption = cmn.EncryptionConf{Enabled: true, KeyID: "key-1"}
+	if err := bp.Validate(1); err == nil {
+		t.Fatal("expected validation error: encryption of a remote bucket")
+	}
+}
+
+func TestKMSConfigValidate(t *testing.T) {
+	tests := []struct {
+		conf     cmn.KMSConf
+		expected bool // valid
+	}{
+		{conf: cmn.KMSConf{}, expected: true},
+		{conf: cmn.KMSConf{Provider: cmn.KMSKeyfile, Keyfile: "/etc/ais/keys.json"}, expected: true},
+		{conf: cmn.KMSConf{Provider: cmn.KMSKeyfile}, expected: false},
+		{conf: cmn.KMSConf{Provider: "vault"}, expected: false},
+	}
+	for _, test := range tests {
+		err := test.conf.Validate()
+		if test.expected {
+			tassert.CheckError(t, err)
+		} else if err == nil {
+			t.Errorf("expected validation error for %+v", test.conf)
+		}
+	}
+}
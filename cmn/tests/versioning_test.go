@@ -0,0 +1,60 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+func TestVersionHistoryValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     cmn.VersionConf
+		remote   bool
+		expected bool // valid
+	}{
+		{name: "disabled", conf: cmn.VersionConf{}, expected: true},
+		{name: "no-history", conf: cmn.VersionConf{Enabled: true}, expected: true},
+		{name: "history", conf: cmn.VersionConf{Enabled: true, MaxHistory: 5}, expected: true},
+		{name: "negative-history", conf: cmn.VersionConf{Enabled: true, MaxHistory: -1}, expected: false},
+		{name: "history-versioning-disabled", conf: cmn.VersionConf{MaxHistory: 5}, expected: false},
+		{name: "history-remote", conf: cmn.VersionConf{Enabled: true, MaxHistory: 5}, remote: true, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.conf.ValidateAsProps(test.remote)
+			if test.expected {
+				tassert.CheckFatal(t, err)
+			} else if err == nil {
+				t.Fatalf("expected validation error for %+v", test.conf)
+			}
+		})
+	}
+}
+
+func TestVersionHistoryDefaultProps(t *testing.T) {
+	config := cmn.GCO.Clone()
+	config.Cksum.Type = cos.ChecksumXXHash
+	config.Versioning = cmn.VersionConf{Enabled: true, MaxHistory: 3}
+	for _, bck := range []cmn.Bck{
+		{Name: "vh", Provider: apc.ProviderAIS},
+		{Name: "vh", Provider: apc.ProviderAmazon},
+	} {
+		bp := bck.DefaultProps(config)
+		bp.SetProvider(bck.Provider)
+		expected := 3
+		if !bck.IsAIS() {
+			expected = 0 // version history is only supported for ais:// buckets
+		}
+		tassert.Errorf(t, bp.Versioning.MaxHistory == expected, "%s: expected max_history %d, got %d",
+			bck, expected, bp.Versioning.MaxHistory)
+		tassert.CheckError(t, bp.Validate(1))
+	}
+}
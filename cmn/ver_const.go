@@ -26,13 +26,15 @@ import "github.com/NVIDIA/aistore/cmn/jsp"
 const AIStoreSoftwareVersion = "3.10"
 
 const (
-	MetaverSmap  = 1 // Smap (cluster map) formatting version (jsp)
-	MetaverBMD   = 2 // BMD (bucket metadata) --/-- (jsp)
-	MetaverRMD   = 1 // Rebalance MD (jsp)
-	MetaverVMD   = 1 // Volume MD (jsp)
-	MetaverEtlMD = 1 // ETL MD (jsp)
-
-	MetaverLOM = 1 // LOM
+	MetaverSmap    = 1 // Smap (cluster map) formatting version (jsp)
+	MetaverBMD     = 2 // BMD (bucket metadata) --/-- (jsp)
+	MetaverRMD     = 1 // Rebalance MD (jsp)
+	MetaverVMD     = 1 // Volume MD (jsp)
+	MetaverEtlMD   = 1 // ETL MD (jsp)
+	MetaverSchedMD = 1 // job schedules MD (jsp)
+
+	MetaverLOM   = 1 // LOM
+	MetaverLOMv2 = 2 // LOM that has access count (and, generally, records that v1 does not know)
 
 	MetaverConfig      = 2 // Global Configuration (jsp)
 	MetaverAuthNConfig = 1 // Authn config (jsp) // ditto
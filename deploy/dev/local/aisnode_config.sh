@@ -57,15 +57,18 @@ cat > $AIS_CONF_FILE <<EOL
 		"non_electable": ${AIS_NON_ELECTABLE:-false}
 	},
 	"space": {
-		"cleanupwm":         65,
-		"lowwm":             75,
-		"highwm":            90,
-		"out_of_space":      95
+		"cleanupwm":          65,
+		"lowwm":              75,
+		"highwm":             90,
+		"out_of_space":       95,
+		"lifecycle_interval": "1h"
 	},
 	"lru": {
 		"dont_evict_time":   "120m",
 		"capacity_upd_time": "10m",
-		"enabled":           true
+		"enabled":           true,
+		"policy":            "lru",
+		"priority":          0
 	},
 	"disk":{
 	    "iostat_time_long":  "${AIS_IOSTAT_TIME_LONG:-2s}",
@@ -95,8 +98,10 @@ cat > $AIS_CONF_FILE <<EOL
 		"burst_buffer":		32,
 		"idle_teardown":	"${AIS_TRANSPORT_IDLE_TEARDOWN:-4s}",
 		"quiescent":		"${AIS_TRANSPORT_QUIESCENT:-10s}",
+		"codec":		"${AIS_TRANSPORT_CODEC:-lz4}",
 		"lz4_block":		"${AIS_TRANSPORT_LZ4_BLOCK:-256kb}",
-		"lz4_frame_checksum":	${AIS_TRANSPORT_LZ4_FRAME_CHECKSUM:-false}
+		"lz4_frame_checksum":	${AIS_TRANSPORT_LZ4_FRAME_CHECKSUM:-false},
+		"zstd_level":		${AIS_TRANSPORT_ZSTD_LEVEL:-3}
 	},
 	"memsys": {
 		"min_free":		"2gb",
@@ -131,8 +136,12 @@ cat > $AIS_CONF_FILE <<EOL
 		"error_limit": 2
 	},
 	"auth": {
-		"secret":      "$AIS_SECRET_KEY",
-		"enabled":     ${AIS_AUTHN_ENABLED:-false}
+		"secret":       "$AIS_SECRET_KEY",
+		"enabled":      ${AIS_AUTHN_ENABLED:-false},
+		"jwks_url":     "${AIS_AUTHN_JWKS_URL}",
+		"pub_key_file": "",
+		"issuer":       "",
+		"audience":     ""
 	},
 	"keepalivetracker": {
 		"proxy": {
@@ -148,7 +157,9 @@ cat > $AIS_CONF_FILE <<EOL
 		"retry_factor":   5
 	},
 	"downloader": {
-		"timeout": "1h"
+		"timeout":       "1h",
+		"chunk_size":    "64mb",
+		"chunk_workers": 4
 	},
 	"distributed_sort": {
 		"duplicated_records":    "ignore",
@@ -169,6 +180,33 @@ cat > $AIS_CONF_FILE <<EOL
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
 	},
+	"rate_limit": {
+		"enabled": false
+	},
+	"kms": {
+		"provider": "${AIS_KMS_PROVIDER:-}",
+		"keyfile":  "${AIS_KMS_KEYFILE:-}"
+	},
+	"webhook": {
+		"timeout":     "10s",
+		"batch_time":  "1s",
+		"batch_size":  128,
+		"max_queue":   1048576,
+		"max_retries": 60
+	},
+	"replicator": {
+		"workers":     4,
+		"max_queue":   1048576,
+		"max_retries": 120
+	},
+	"job_history": {
+		"enabled":          true,
+		"records_per_file": 10000,
+		"max_files":        10
+	},
+	"etl": {
+		"local_runtime": false
+	},
 	"features": "0"
 }
 EOL
@@ -15,7 +15,8 @@ cat > $AIS_AUTHN_CONF_FILE <<EOL
 	},
 	"auth": {
 		"secret": "$AIS_SECRET_KEY",
-		"expiration_time": "${AIS_AUTHN_TTL:-24h}"
+		"expiration_time": "${AIS_AUTHN_TTL:-24h}",
+		"signing_method": "${AIS_AUTHN_SIGNING_METHOD:-HS256}"
 	},
 	"timeout": {
 		"default_timeout": "30s"
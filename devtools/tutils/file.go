@@ -150,6 +150,7 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{})
 	_ = fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVerType, &fs.ObjVerContentResolver{})
 
 	dir := t.TempDir()
 
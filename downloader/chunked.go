@@ -0,0 +1,379 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"golang.org/x/sync/errgroup"
+)
+
+// Chunked download
+//
+// Objects larger than `downloader.chunk_size` are downloaded in chunks - HTTP range
+// requests - provided the source advertises support for ranges (`Accept-Ranges: bytes`).
+// The decision is made upon receiving the response to the regular GET (that's then
+// closed), so that small objects don't incur an extra round trip.
+// Up to `downloader.chunk_workers` chunks are downloaded in parallel, each chunk is
+// written directly into its place in a work file. Upon completion of each chunk the
+// progress (see dlProgress) is stored in the downloader's database, so that a failed
+// download - including the one interrupted by target restart - resumes where it left
+// off, with only the missing chunks downloaded.
+//
+// Resuming requires the source to remain unchanged: its size, ETag, and Last-Modified
+// are stored with the progress and compared; range requests also carry `If-Range`.
+//
+// Both chunked and regular downloads are verified against the MD5 provided by the
+// source (Content-MD5, x-goog-hash, or ETag - if the latter is a plain MD5).
+
+type (
+	// persistent state of a chunked download
+	dlProgress struct {
+		Link         string `json:"link"`
+		WorkFQN      string `json:"work_fqn"`
+		ETag         string `json:"etag,omitempty"`
+		LastModified string `json:"last_modified,omitempty"`
+		Size         int64  `json:"size,string"`
+		ChunkSize    int64  `json:"chunk_size,string"`
+		Done         []bool `json:"done"`    // per chunk
+		Updated      int64  `json:"updated"` // Unix nanoseconds
+	}
+	chunkedDl struct {
+		store   *downloaderDB
+		prog    *dlProgress
+		wrap    func(ctx context.Context, r io.ReadCloser) io.ReadCloser
+		key     string // (see progressKey)
+		workers int
+		mu      sync.Mutex
+	}
+	// validates MD5 of the content upon reaching EOF
+	md5Reader struct {
+		r        io.ReadCloser
+		h        hash.Hash
+		expected string // hex
+		name     string
+	}
+	offsetWriter struct {
+		fh  *os.File
+		off int64
+	}
+)
+
+var errSrcChanged = errors.New("source has changed")
+
+// interface guard
+var _ io.ReadCloser = (*md5Reader)(nil)
+
+// newProgress validates the source's response and returns the initial
+// state of the chunked download, or nil if the object is not to be chunked
+func newProgress(link string, resp *http.Response, chunkSize int64) *dlProgress {
+	size := resp.ContentLength
+	if size <= chunkSize || resp.Header.Get(cmn.HdrAcceptRanges) != "bytes" {
+		return nil
+	}
+	cnt := (size + chunkSize - 1) / chunkSize
+	return &dlProgress{
+		Link:         link,
+		ETag:         resp.Header.Get(cmn.HdrETag),
+		LastModified: resp.Header.Get(cmn.HdrLastModified),
+		Size:         size,
+		ChunkSize:    chunkSize,
+		Done:         make([]bool, cnt),
+	}
+}
+
+////////////////
+// dlProgress //
+////////////////
+
+// same source and same chunking?
+func (p *dlProgress) sameAs(other *dlProgress) bool {
+	return p.Link == other.Link && p.Size == other.Size && p.ChunkSize == other.ChunkSize &&
+		p.ETag == other.ETag && p.LastModified == other.LastModified && len(p.Done) == len(other.Done)
+}
+
+func (p *dlProgress) doneSize() (size int64) {
+	for i, done := range p.Done {
+		if done {
+			_, n := p.chunk(i)
+			size += n
+		}
+	}
+	return
+}
+
+func (p *dlProgress) chunk(i int) (off, size int64) {
+	off = int64(i) * p.ChunkSize
+	size = cos.MinI64(p.ChunkSize, p.Size-off)
+	return
+}
+
+// If-Range requires strong validator
+func (p *dlProgress) ifRange() string {
+	if p.ETag != "" && !strings.HasPrefix(p.ETag, "W/") {
+		return p.ETag
+	}
+	return p.LastModified
+}
+
+///////////////
+// chunkedDl //
+///////////////
+
+// resume (if possible) or start anew; returns the number of bytes already downloaded
+func (c *chunkedDl) init(newFQN func() string) (int64, error) {
+	prev, err := c.store.getProgress(c.key)
+	if err != nil {
+		return 0, err
+	}
+	if prev != nil {
+		if prev.sameAs(c.prog) {
+			if fi, err := os.Stat(prev.WorkFQN); err == nil && fi.Size() == prev.Size {
+				c.prog = prev
+				return prev.doneSize(), nil
+			}
+		} else {
+			glog.Warningf("%s: source %s has changed - restarting download from scratch", c.key, c.prog.Link)
+		}
+		c.discard(prev)
+	}
+	c.prog.WorkFQN = newFQN()
+	fh, err := cos.CreateFile(c.prog.WorkFQN)
+	if err != nil {
+		return 0, err
+	}
+	err = fh.Truncate(c.prog.Size)
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = c.persist()
+	}
+	if err != nil {
+		c.discard(c.prog)
+	}
+	return 0, err
+}
+
+// download all missing chunks
+func (c *chunkedDl) run(ctx context.Context, timeout time.Duration) error {
+	fh, err := os.OpenFile(c.prog.WorkFQN, os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	var (
+		group, gctx = errgroup.WithContext(ctx)
+		chunkCh     = make(chan int, len(c.prog.Done))
+	)
+	for i, done := range c.prog.Done {
+		if !done {
+			chunkCh <- i
+		}
+	}
+	close(chunkCh)
+	for i := 0; i < cos.Min(c.workers, len(chunkCh)); i++ {
+		group.Go(func() error {
+			for i := range chunkCh {
+				if err := c.fetch(gctx, fh, i, timeout); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	err = group.Wait()
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func (c *chunkedDl) fetch(ctx context.Context, fh *os.File, i int, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	off, size := c.prog.chunk(i)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.prog.Link, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cmn.HdrRange, fmt.Sprintf("%s%d-%d", cmn.HdrRangeValPrefix, off, off+size-1))
+	if v := c.prog.ifRange(); v != "" {
+		req.Header.Set(cmn.HdrIfRange, v)
+	}
+	if cos.IsGoogleStorageURL(req.URL) {
+		req.Header.Add("User-Agent", gcsUA)
+	}
+	resp, err := clientForURL(c.prog.Link).Do(req)
+	if err != nil {
+		return err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return cmn.NewErrHTTP(req, "", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		// the source ignored the range, or returned the entire (new) content (If-Range)
+		return fmt.Errorf("%w: %s (status %d)", errSrcChanged, c.prog.Link, resp.StatusCode)
+	}
+	n, err := io.Copy(&offsetWriter{fh: fh, off: off}, io.LimitReader(c.wrap(ctx, resp.Body), size))
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("%s: chunk %d: %w (%d/%d)", c.prog.Link, i, io.ErrUnexpectedEOF, n, size)
+	}
+	c.mu.Lock()
+	c.prog.Done[i] = true
+	err = c.persist()
+	c.mu.Unlock()
+	return err
+}
+
+func (c *chunkedDl) persist() error {
+	c.prog.Updated = time.Now().UnixNano()
+	return c.store.persistProgress(c.key, c.prog)
+}
+
+// remove the progress and the work file
+func (c *chunkedDl) discard(prog *dlProgress) {
+	if prog.WorkFQN != "" {
+		if err := cos.RemoveFile(prog.WorkFQN); err != nil {
+			glog.Errorf("%s: failed to remove %s: %v", c.key, prog.WorkFQN, err)
+		}
+	}
+	c.store.deleteProgress(c.key)
+}
+
+//////////////////////////////
+// singleObjectTask: chunked //
+//////////////////////////////
+
+// newChunked returns non-nil if the object is large enough to be downloaded
+// in chunks, and the source supports range requests
+func (t *singleObjectTask) newChunked(lom *cluster.LOM, resp *http.Response) *chunkedDl {
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	conf := cmn.GCO.Get().Downloader.Defaults()
+	prog := newProgress(t.obj.link, resp, int64(conf.ChunkSize))
+	if prog == nil {
+		return nil
+	}
+	t.head = resp
+	return &chunkedDl{
+		store:   dlStore.downloaderDB,
+		prog:    prog,
+		wrap:    t.wrapReader,
+		key:     progressKey(lom.Uname()),
+		workers: conf.ChunkWorkers,
+	}
+}
+
+func (t *singleObjectTask) tryDownloadChunked(lom *cluster.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
+	c := t.chunked
+	done, err := c.init(func() string { return fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileDownload) })
+	if err != nil {
+		return true, err
+	}
+	t.setTotalSize(c.prog.Size)
+	t.currentSize.Store(done)
+	if err := c.run(t.downloadCtx, timeout); err != nil {
+		if errors.Is(err, errSrcChanged) {
+			c.discard(c.prog)
+			return true, err
+		}
+		return false, err
+	}
+
+	// all chunks are in place
+	fh, err := cos.NewFileHandle(c.prog.WorkFQN)
+	if err != nil {
+		return true, err
+	}
+	attrsFromLink(t.obj.link, t.head, lom)
+	err = t.put(lom, newMD5Reader(fh, expectedMD5(t.head.Header), t.obj.link))
+	if err == nil || cos.IsErrBadCksum(err) {
+		c.discard(c.prog)
+	}
+	return err != nil, err
+}
+
+///////////////
+// md5Reader //
+///////////////
+
+// expectedMD5 returns MD5 (hex) of the content, if provided by the source
+func expectedMD5(hdr http.Header) string {
+	if v := hdr.Get(cmn.HdrContentMD5); v != "" {
+		if b, err := base64.StdEncoding.DecodeString(v); err == nil && len(b) == md5.Size {
+			return hex.EncodeToString(b)
+		}
+	}
+	for _, v := range hdr.Values(cmn.GsCksumHeader) { // e.g. "crc32c=n03x6A==,md5=Ojk9c3dhfxgoKVVHYwFbHQ=="
+		for _, kv := range strings.Split(v, ",") {
+			if entry := strings.SplitN(strings.TrimSpace(kv), "=", 2); len(entry) == 2 && entry[0] == cos.ChecksumMD5 {
+				if b, err := base64.StdEncoding.DecodeString(entry[1]); err == nil && len(b) == md5.Size {
+					return hex.EncodeToString(b)
+				}
+			}
+		}
+	}
+	// NOTE: ETag is MD5 of the content only if it looks like one (e.g., not S3 multipart)
+	etag := strings.Trim(hdr.Get(cmn.HdrETag), "\"")
+	if len(etag) == 2*md5.Size {
+		if _, err := hex.DecodeString(etag); err == nil {
+			return strings.ToLower(etag)
+		}
+	}
+	return ""
+}
+
+func newMD5Reader(r io.ReadCloser, expected, name string) io.ReadCloser {
+	if expected == "" {
+		return r
+	}
+	return &md5Reader{r: r, h: md5.New(), expected: expected, name: name}
+}
+
+func (r *md5Reader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	r.h.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.h.Sum(nil)); actual != r.expected {
+			err = cos.NewBadDataCksumError(cos.NewCksum(cos.ChecksumMD5, r.expected),
+				cos.NewCksum(cos.ChecksumMD5, actual), r.name)
+		}
+	}
+	return
+}
+
+func (r *md5Reader) Close() error { return r.r.Close() }
+
+//////////////////
+// offsetWriter //
+//////////////////
+
+func (w *offsetWriter) Write(p []byte) (n int, err error) {
+	n, err = w.fh.WriteAt(p, w.off)
+	w.off += int64(n)
+	return
+}
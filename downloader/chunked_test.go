@@ -0,0 +1,180 @@
+// Package downloader implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+const testChunkSize = 4 * cos.KiB
+
+type rangeSrv struct {
+	srv     *httptest.Server
+	content []byte
+	etag    string
+	mu      sync.Mutex
+	ranges  []string // received range requests
+	fail    func(rng string) bool
+}
+
+func newRangeSrv(size int) *rangeSrv {
+	s := &rangeSrv{content: make([]byte, size), etag: `"v1"`}
+	rand.Read(s.content)
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get(cmn.HdrRange)
+		s.mu.Lock()
+		s.ranges = append(s.ranges, rng)
+		fail := s.fail != nil && s.fail(rng)
+		etag := s.etag
+		s.mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(cmn.HdrETag, etag)
+		http.ServeContent(w, r, "obj", time.Time{}, bytes.NewReader(s.content))
+	}))
+	return s
+}
+
+func (s *rangeSrv) head(t *testing.T) *http.Response {
+	resp, err := http.Head(s.srv.URL)
+	tassert.CheckFatal(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func (s *rangeSrv) numRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.ranges)
+}
+
+func newTestChunked(t *testing.T, s *rangeSrv, db dbdriver.Driver) *chunkedDl {
+	prog := newProgress(s.srv.URL, s.head(t), testChunkSize)
+	tassert.Fatalf(t, prog != nil, "expected chunked download")
+	return &chunkedDl{
+		store:   newDownloadDB(db),
+		prog:    prog,
+		wrap:    func(_ context.Context, r io.ReadCloser) io.ReadCloser { return r },
+		key:     progressKey("ais/@#/bck/obj"),
+		workers: 3,
+	}
+}
+
+func TestChunkedResume(t *testing.T) {
+	const size = 10*testChunkSize + 100
+	s := newRangeSrv(size)
+	defer s.srv.Close()
+	dir := t.TempDir()
+	workFQN := filepath.Join(dir, "work")
+	db, err := dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+
+	// network "blip": fail the 3rd chunk (sequential download)
+	s.fail = func(rng string) bool {
+		n, _ := cmn.ParseMultiRange(rng, size)
+		return len(n) > 0 && n[0].Start == 2*testChunkSize
+	}
+	c := newTestChunked(t, s, db)
+	c.workers = 1
+	done, err := c.init(func() string { return workFQN })
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, done == 0, "expected nothing downloaded, got %d", done)
+	err = c.run(context.Background(), time.Minute)
+	tassert.Fatalf(t, err != nil, "expected the download to fail")
+	tassert.CheckFatal(t, db.Close())
+
+	// "restart"
+	s.mu.Lock()
+	s.fail, s.ranges = nil, nil
+	s.mu.Unlock()
+	db, err = dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+	defer db.Close()
+	c = newTestChunked(t, s, db)
+	done, err = c.init(func() string { t.Fatal("expected to resume"); return "" })
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, done == 2*testChunkSize, "expected 2 chunks to be downloaded, got %d", done)
+	tassert.CheckFatal(t, c.run(context.Background(), time.Minute))
+	tassert.Errorf(t, s.numRequests() == 1+9, // HEAD + missing chunks
+		"expected only missing chunks to be requested, got %d requests", s.numRequests())
+
+	b, err := os.ReadFile(workFQN)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(b, s.content), "downloaded content differs")
+}
+
+func TestChunkedSrcChanged(t *testing.T) {
+	s := newRangeSrv(5 * testChunkSize)
+	defer s.srv.Close()
+	dir := t.TempDir()
+	db, err := dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+	defer db.Close()
+
+	c := newTestChunked(t, s, db)
+	_, err = c.init(func() string { return filepath.Join(dir, "work") })
+	tassert.CheckFatal(t, err)
+	s.mu.Lock()
+	s.etag = `"v2"`
+	s.mu.Unlock()
+	err = c.run(context.Background(), time.Minute)
+	tassert.Fatalf(t, errors.Is(err, errSrcChanged), "expected %v, got %v", errSrcChanged, err)
+}
+
+func TestExpectedMD5(t *testing.T) {
+	var (
+		content = []byte("the quick brown fox")
+		sum     = md5.Sum(content)
+		md5hex  = hex.EncodeToString(sum[:])
+		md5b64  = base64.StdEncoding.EncodeToString(sum[:])
+	)
+	tests := []struct {
+		key, value string
+		expected   string
+	}{
+		{cmn.HdrContentMD5, md5b64, md5hex},
+		{cmn.GsCksumHeader, "crc32c=n03x6A==,md5=" + md5b64, md5hex},
+		{cmn.HdrETag, `"` + strings.ToUpper(md5hex) + `"`, md5hex},
+		{cmn.HdrETag, `"` + md5hex + `-12"`, ""}, // S3 multipart
+		{cmn.HdrETag, "0x8D9F2B3C4D5E6F7", ""},
+		{cmn.HdrContentType, cmn.ContentBinary, ""},
+	}
+	for _, test := range tests {
+		hdr := make(http.Header, 1)
+		hdr.Set(test.key, test.value)
+		actual := expectedMD5(hdr)
+		tassert.Errorf(t, actual == test.expected, "%v: expected %q, got %q", hdr, test.expected, actual)
+	}
+
+	r := newMD5Reader(io.NopCloser(bytes.NewReader(content)), md5hex, "obj")
+	_, err := io.ReadAll(r)
+	tassert.CheckError(t, err)
+
+	r = newMD5Reader(io.NopCloser(bytes.NewReader(content[1:])), md5hex, "obj")
+	_, err = io.ReadAll(r)
+	tassert.Errorf(t, cos.IsErrBadCksum(err), "expected bad checksum error, got %v", err)
+}
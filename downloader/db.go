@@ -8,14 +8,18 @@ import (
 	"errors"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/dbdriver"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderProgress   = "progress" // chunked downloads, see dlProgress
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -182,3 +186,51 @@ func (db *downloaderDB) delete(id string) {
 	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
+
+func progressKey(uname string) string { return path.Join(downloaderProgress, uname) }
+
+// returns (nil, nil) if not found
+func (db *downloaderDB) getProgress(key string) (*dlProgress, error) {
+	prog := &dlProgress{}
+	if err := db.driver.Get(downloaderCollection, key, prog); err != nil {
+		if dbdriver.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return prog, nil
+}
+
+func (db *downloaderDB) persistProgress(key string, prog *dlProgress) error {
+	return db.driver.Set(downloaderCollection, key, prog)
+}
+
+func (db *downloaderDB) deleteProgress(key string) {
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
+// remove chunked downloads that haven't progressed for a given time (along with their work files)
+func (db *downloaderDB) cleanupProgress(maxAge time.Duration) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderProgress+"/")
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	now := time.Now().UnixNano()
+	for key, val := range all {
+		prog := &dlProgress{}
+		if err := jsoniter.UnmarshalFromString(val, prog); err == nil && now-prog.Updated < maxAge.Nanoseconds() {
+			continue
+		}
+		if prog.WorkFQN != "" {
+			if err := cos.RemoveFile(prog.WorkFQN); err != nil {
+				glog.Error(err)
+			}
+		}
+		db.deleteProgress(key)
+	}
+}
@@ -41,6 +41,7 @@ type (
 
 	BackendResource struct {
 		ObjName string
+		Attrs   *cmn.ObjAttrs // (optional) attributes, if listed with the name
 	}
 
 	WebResource struct {
@@ -52,6 +53,7 @@ type (
 		ObjName string
 		Version string
 		Link    string
+		Attrs   *cmn.ObjAttrs
 	}
 
 	DiffResolverResult struct {
@@ -162,6 +164,7 @@ func (dr *DiffResolver) PushDst(v interface{}) {
 	case *BackendResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
+			Attrs:   x.Attrs,
 		}
 	case *WebResource:
 		d = &DstElement{
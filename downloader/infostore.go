@@ -150,5 +150,7 @@ func (is *infoStore) housekeep() time.Duration {
 	}
 	is.Unlock()
 
+	is.cleanupProgress(interval)
+
 	return interval
 }
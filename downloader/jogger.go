@@ -29,6 +29,7 @@ type (
 	jogger struct {
 		mpath       string
 		terminateCh *cos.StopCh // synchronizes termination
+		stopCh      *cos.StopCh // (to stop waiting while paused)
 		parent      *dispatcher
 
 		q *queue
@@ -46,6 +47,7 @@ func newJogger(d *dispatcher, mpath string) *jogger {
 		parent:      d,
 		q:           newQueue(),
 		terminateCh: cos.NewStopCh(),
+		stopCh:      cos.NewStopCh(),
 	}
 }
 
@@ -56,6 +58,14 @@ func (j *jogger) jog() {
 		if t == nil {
 			break
 		}
+		// when paused, wait to be resumed before starting the next download
+		// (the current one, if any, completes)
+		if ch := j.parent.parent.ChanResume(); ch != nil {
+			select {
+			case <-ch:
+			case <-j.stopCh.Listen():
+			}
+		}
 
 		j.mtx.Lock()
 		// Check if the tasks exists to ensure that the job wasn't removed while
@@ -108,6 +118,7 @@ func (j *jogger) stop() {
 		j.task.cancel() // Stops running task (cancels download).
 	}
 	j.mtx.Unlock()
+	j.stopCh.Close()
 	j.q.close()
 
 	<-j.terminateCh.Listen()
@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 )
@@ -57,6 +58,9 @@ type (
 		currentSize atomic.Int64 // The current size of the file (updated as the download progresses).
 		totalSize   atomic.Int64 // The total size of the file (nonzero only if Content-Length header was provided by the source of the file).
 
+		chunked *chunkedDl     // non-nil when downloading in chunks (see chunked.go)
+		head    *http.Response // (chunked) the source's response that has started it all
+
 		downloadCtx context.Context    // Context with cancel function.
 		cancel      context.CancelFunc // Used to cancel the download after the request commences.
 	}
@@ -109,6 +113,9 @@ func (t *singleObjectTask) download() {
 }
 
 func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
+	if t.chunked != nil {
+		return t.tryDownloadChunked(lom, timeout)
+	}
 	ctx, cancel := context.WithTimeout(t.downloadCtx, timeout)
 	defer cancel()
 
@@ -132,11 +139,23 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	if resp.StatusCode >= http.StatusBadRequest {
 		return false, cmn.NewErrHTTP(req, "", resp.StatusCode)
 	}
+	// large object: switch to downloading in chunks
+	if t.chunked = t.newChunked(lom, resp); t.chunked != nil {
+		resp.Body.Close()
+		return t.tryDownloadChunked(lom, timeout)
+	}
 
-	r := t.wrapReader(ctx, resp.Body)
+	r := newMD5Reader(t.wrapReader(ctx, resp.Body), expectedMD5(resp.Header), t.obj.link)
 	size := attrsFromLink(t.obj.link, resp, lom)
 	t.setTotalSize(size)
 
+	if err := t.put(lom, r); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+func (t *singleObjectTask) put(lom *cluster.LOM, r io.ReadCloser) error {
 	params := cluster.AllocPutObjParams()
 	{
 		params.WorkTag = "dl"
@@ -148,12 +167,13 @@ func (t *singleObjectTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Durat
 	erp := t.parent.t.PutObject(lom, params)
 	cluster.FreePutObjParams(params)
 	if erp != nil {
-		return true, erp
+		return erp
 	}
 	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
-		return true, err
+		return err
 	}
-	return false, nil
+	events.Emit(cmn.EvDownload, lom)
+	return nil
 }
 
 func (t *singleObjectTask) downloadLocal(lom *cluster.LOM) (err error) {
@@ -227,8 +247,11 @@ func (t *singleObjectTask) downloadRemote(lom *cluster.LOM) error {
 	ctx = context.WithValue(ctx, cos.CtxSetSize, cos.SetSizeFunc(t.setTotalSize))
 
 	// Do final GET (prefetch) request.
-	_, err := t.parent.t.GetCold(ctx, lom, cmn.OwtGetTryLock)
-	return err
+	if _, err := t.parent.t.GetCold(ctx, lom, cmn.OwtGetTryLock); err != nil {
+		return err
+	}
+	events.Emit(cmn.EvDownload, lom)
+	return nil
 }
 
 func (t *singleObjectTask) initialTimeout() time.Duration {
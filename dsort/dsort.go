@@ -105,7 +105,7 @@ func (m *Manager) start() (err error) {
 	// Phase 3. - run only by the final target
 	if curTargetIsFinal {
 		shardSize := m.rs.OutputShardSize
-		if m.extractCreator.UsingCompression() {
+		if m.extractCreator.UsingCompression() && m.rs.SamplesPerShard == 0 {
 			// By making the assumption that the input content is reasonably
 			// uniform across all shards, the output shard size required (such
 			// that each gzip compressed output shard will have a size close to
@@ -187,6 +187,10 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 			}
 			return err
 		}
+		if lom.IsEncrypted() {
+			// (extraction reads records in place, by their offsets in the shard)
+			return errors.Errorf("shard %s is encrypted at rest - not supported", lom)
+		}
 
 		phaseInfo.adjuster.acquireSema(lom.MpathInfo())
 		if m.aborted() {
@@ -302,6 +306,10 @@ ExtractAllShards:
 			break ExtractAllShards // context was canceled, therefore we have an error
 		default:
 		}
+		if err := m.waitResumed(); err != nil {
+			group.Wait()
+			return err
+		}
 
 		phaseInfo.adjuster.acquireGoroutineSema()
 		group.Go(m.extractShard(name, metrics))
@@ -336,6 +344,9 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	}
 	lom.SetAtimeUnix(time.Now().UnixNano())
 
+	if err := m.waitResumed(); err != nil {
+		return err
+	}
 	if m.aborted() {
 		return newDSortAbortedError(m.ManagerUUID)
 	}
@@ -432,7 +443,8 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 			goto exit
 		}
 
-		file, err := cos.NewFileHandle(lom.FQN)
+		// (plaintext if encrypted at rest - the destination encrypts)
+		file, oah, err := lom.PlainReader()
 		if err != nil {
 			return err
 		}
@@ -440,7 +452,7 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 		o := transport.AllocSend()
 		o.Hdr = transport.ObjHdr{
 			ObjName:  shardName,
-			ObjAttrs: cmn.ObjAttrs{Size: lom.SizeBytes(), Cksum: lom.Checksum()},
+			ObjAttrs: cmn.ObjAttrs{Size: oah.SizeBytes(), Cksum: oah.Checksum()},
 		}
 		o.Hdr.Bck.Copy(lom.Bucket())
 
@@ -617,12 +629,26 @@ func (m *Manager) participateInRecordDistribution(targetOrder cluster.Nodes) (cu
 		m.recManager.MergeEnqueuedRecords()
 	}
 
+	if m.rs.RecordGrouping == RecordGroupingSample {
+		// Components of the same sample may come from different input shards
+		// (and targets) - group them so that they are never split.
+		for _, name := range m.recManager.Records.GroupBySample() {
+			msg := fmt.Sprintf("record %q has been duplicated", name)
+			if err = m.react(m.rs.DuplicatedRecords, msg); err != nil {
+				return true, err
+			}
+		}
+	}
+
 	err = sortRecords(m.recManager.Records, m.rs.Algorithm)
 	m.dsorter.postRecordDistribution()
 	return true, err
 }
 
 func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, error) {
+	if m.rs.SamplesPerShard > 0 {
+		return m.generateShardsWithSampleCount()
+	}
 	var (
 		n               = m.recManager.Records.Len()
 		pt              = m.rs.OutputFormat.Template
@@ -669,6 +695,34 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 	return shards, nil
 }
 
+// generateShardsWithSampleCount cuts output shards by the number of records
+// (samples) rather than by size; the last shard may contain fewer records.
+func (m *Manager) generateShardsWithSampleCount() ([]*extract.Shard, error) {
+	var (
+		n          = m.recManager.Records.Len()
+		pt         = m.rs.OutputFormat.Template
+		shardCount = pt.Count()
+		perShard   = m.rs.SamplesPerShard
+		shards     = make([]*extract.Shard, 0, (n+perShard-1)/perShard)
+	)
+	pt.InitIter()
+	for start := 0; start < n; start += perShard {
+		name, hasNext := pt.Next()
+		if !hasNext {
+			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
+		}
+		shard := &extract.Shard{
+			Name:    name + m.rs.Extension,
+			Records: m.recManager.Records.Slice(start, cos.Min(start+perShard, n)),
+		}
+		for _, r := range shard.Records.All() {
+			shard.Size += r.TotalSize()
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
 func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*extract.Shard, error) {
 	var (
 		shards         = make([]*extract.Shard, 0)
@@ -841,15 +895,17 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 		if m.dsorter.name() == DSorterMemType {
 			singleSendOrder := make(map[string]*extract.Shard)
 			for _, record := range s.Records.All() {
-				shard, ok := singleSendOrder[record.DaemonID]
-				if !ok {
-					shard = &extract.Shard{
-						Name:    s.Name,
-						Records: extract.NewRecords(100),
+				for daemonID, rec := range record.SplitByDaemon() {
+					shard, ok := singleSendOrder[daemonID]
+					if !ok {
+						shard = &extract.Shard{
+							Name:    s.Name,
+							Records: extract.NewRecords(100),
+						}
+						singleSendOrder[daemonID] = shard
 					}
-					singleSendOrder[record.DaemonID] = shard
+					shard.Records.Insert(rec)
 				}
-				shard.Records.Insert(record)
 			}
 
 			for daemonID, shard := range singleSendOrder {
@@ -459,8 +459,8 @@ func (ds *dsorterGeneral) loadContent() extract.LoadContentFunc {
 			return 0, newDSortAbortedError(ds.m.ManagerUUID)
 		}
 
-		if rec.DaemonID != ds.m.ctx.node.ID() { // File source contents are located on a different target.
-			return loadRemote(w, rec.DaemonID)
+		if daemonID := rec.ObjDaemonID(obj); daemonID != ds.m.ctx.node.ID() { // File source contents are located on a different target.
+			return loadRemote(w, daemonID)
 		}
 
 		// Load from local source
@@ -7,6 +7,8 @@ package extract
 import (
 	"encoding/json"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -59,6 +61,10 @@ type (
 		MetadataSize int64  `msg:"ms" json:"ms,string"`
 		Size         int64  `msg:"s" json:"s,string"`
 		Extension    string `msg:"e" json:"e"`
+
+		// ID of the target which maintains the contents of this object - set
+		// only when different from the record's (see `Records.GroupBySample`).
+		DaemonID string `msg:"d,omitempty" json:"d,omitempty"`
 	}
 
 	// Record represents the metadata corresponding to a single file from an archive file.
@@ -127,6 +133,51 @@ func (r *Record) MakeUniqueName(obj *RecordObj) string {
 	return r.Name + obj.Extension
 }
 
+// ObjDaemonID returns ID of the target which maintains the contents of the object.
+func (r *Record) ObjDaemonID(obj *RecordObj) string {
+	if obj.DaemonID != "" {
+		return obj.DaemonID
+	}
+	return r.DaemonID
+}
+
+func (r *Record) mixed() bool {
+	for _, obj := range r.Objects {
+		if obj.DaemonID != "" && obj.DaemonID != r.DaemonID {
+			return true
+		}
+	}
+	return false
+}
+
+// SampleName returns the name of the record without the name of the input shard,
+// that is the name shared by all the components of a sample.
+func (r *Record) SampleName() string {
+	return r.Name[strings.IndexByte(r.Name, '|')+1:]
+}
+
+// SplitByDaemon returns, for each target maintaining the contents of some of
+// the record's objects, a record which consists of only those objects.
+func (r *Record) SplitByDaemon() map[string]*Record {
+	records := make(map[string]*Record, 1)
+	if !r.mixed() {
+		records[r.DaemonID] = r
+		return records
+	}
+	for _, obj := range r.Objects {
+		daemonID := r.ObjDaemonID(obj)
+		rec, ok := records[daemonID]
+		if !ok {
+			rec = &Record{Key: r.Key, Name: r.Name, DaemonID: daemonID}
+			records[daemonID] = rec
+		}
+		o := *obj
+		o.DaemonID = ""
+		rec.Objects = append(rec.Objects, &o)
+	}
+	return records
+}
+
 // NewRecords creates new instance of Records struct and allocates n places for
 // the actual Record's
 func NewRecords(n int) *Records {
@@ -193,6 +244,43 @@ func (r *Records) Exists(name, ext string) (exists bool) {
 	return
 }
 
+// GroupBySample merges records that belong to the same sample (see `Record.SampleName`)
+// but come from different input shards, so that all components of a sample end up in
+// the same output shard. The resulting order of records is sorted by name. Returns
+// unique names of the duplicated components, if any (the latter are not removed).
+func (r *Records) GroupBySample() (dups []string) {
+	r.Lock()
+	defer r.Unlock()
+	sort.Slice(r.arr, func(i, j int) bool { return r.arr[i].Name < r.arr[j].Name })
+	var (
+		arr     = r.arr[:0]
+		samples = make(map[string]*Record, len(r.arr))
+	)
+	for _, record := range r.arr {
+		sample, ok := samples[record.SampleName()]
+		if !ok {
+			samples[record.SampleName()] = record
+			arr = append(arr, record)
+			continue
+		}
+		for _, obj := range record.Objects {
+			if sample.exists(obj.Extension) {
+				dups = append(dups, sample.MakeUniqueName(obj))
+			}
+			if obj.DaemonID == "" && record.DaemonID != sample.DaemonID {
+				obj.DaemonID = record.DaemonID
+			}
+			sample.Objects = append(sample.Objects, obj)
+		}
+		delete(r.m, record.Name)
+	}
+	for i := len(arr); i < len(r.arr); i++ {
+		r.arr[i] = nil
+	}
+	r.arr = arr
+	return
+}
+
 func (r *Records) merge(records *Records) {
 	r.Insert(records.arr...)
 }
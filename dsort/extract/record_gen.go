@@ -219,6 +219,12 @@ func (z *RecordObj) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Extension")
 				return
 			}
+		case "d":
+			z.DaemonID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DaemonID")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -233,12 +239,16 @@ func (z *RecordObj) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *RecordObj) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(7)
-	var zb0001Mask uint8 /* 7 bits */
+	zb0001Len := uint32(8)
+	var zb0001Mask uint8 /* 8 bits */
 	if z.Offset == 0 {
 		zb0001Len--
 		zb0001Mask |= 0x8
 	}
+	if z.DaemonID == "" {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
 	// variable map header, size zb0001Len
 	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
@@ -319,12 +329,24 @@ func (z *RecordObj) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Extension")
 		return
 	}
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// write "d"
+		err = en.Append(0xa1, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.DaemonID)
+		if err != nil {
+			err = msgp.WrapError(err, "DaemonID")
+			return
+		}
+	}
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *RecordObj) Msgsize() (s int) {
-	s = 1 + 2 + msgp.StringPrefixSize + len(z.ContentPath) + 3 + msgp.StringPrefixSize + len(z.ObjectFileType) + 3 + msgp.StringPrefixSize + len(z.StoreType) + 2 + msgp.Int64Size + 3 + msgp.Int64Size + 2 + msgp.Int64Size + 2 + msgp.StringPrefixSize + len(z.Extension)
+	s = 1 + 2 + msgp.StringPrefixSize + len(z.ContentPath) + 3 + msgp.StringPrefixSize + len(z.ObjectFileType) + 3 + msgp.StringPrefixSize + len(z.StoreType) + 2 + msgp.Int64Size + 3 + msgp.Int64Size + 2 + msgp.Int64Size + 2 + msgp.StringPrefixSize + len(z.Extension) + 2 + msgp.StringPrefixSize + len(z.DaemonID)
 	return
 }
 
@@ -148,4 +148,65 @@ var _ = Describe("Records", func() {
 			Expect(records.All()[0].TotalSize()).To(BeEquivalentTo(objectSize))
 		})
 	})
+
+	Context("group by sample", func() {
+		It("should group components of the same sample across shards and targets", func() {
+			records := NewRecords(0)
+			records.Insert(
+				&Record{Key: "b", Name: "shard-1|b", DaemonID: "t1", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".jpg"},
+				}},
+				&Record{Key: "a", Name: "shard-2|a", DaemonID: "t2", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".cls"},
+				}},
+				&Record{Key: "a", Name: "shard-1|a", DaemonID: "t1", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".jpg"},
+				}},
+				&Record{Key: "a", Name: "shard-3|a", DaemonID: "t1", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".json"},
+				}},
+			)
+
+			dups := records.GroupBySample()
+			Expect(dups).To(BeEmpty())
+			Expect(records.Len()).To(Equal(2))
+			Expect(records.TotalObjectCount()).To(Equal(4))
+
+			a, b := records.All()[0], records.All()[1]
+			Expect(a.Name).To(Equal("shard-1|a"))
+			Expect(a.SampleName()).To(Equal("a"))
+			Expect(a.Objects).To(HaveLen(3))
+			Expect(a.ObjDaemonID(a.Objects[0])).To(Equal("t1"))
+			Expect(a.ObjDaemonID(a.Objects[1])).To(Equal("t2"))
+			Expect(a.ObjDaemonID(a.Objects[2])).To(Equal("t1"))
+			Expect(b.Name).To(Equal("shard-1|b"))
+			_, exists := records.Find("shard-2|a")
+			Expect(exists).To(BeFalse())
+
+			split := a.SplitByDaemon()
+			Expect(split).To(HaveLen(2))
+			Expect(split["t1"].Objects).To(HaveLen(2))
+			Expect(split["t2"].Objects).To(HaveLen(1))
+			Expect(split["t2"].Objects[0].DaemonID).To(BeEmpty())
+			Expect(split["t2"].Name).To(Equal(a.Name))
+			Expect(b.SplitByDaemon()).To(Equal(map[string]*Record{"t1": b}))
+		})
+
+		It("should report duplicated components", func() {
+			records := NewRecords(0)
+			records.Insert(
+				&Record{Key: "a", Name: "shard-1|a", DaemonID: "t1", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".jpg"},
+				}},
+				&Record{Key: "a", Name: "shard-2|a", DaemonID: "t1", Objects: []*RecordObj{
+					{Size: objectSize, Extension: ".jpg"},
+				}},
+			)
+
+			dups := records.GroupBySample()
+			Expect(dups).To(Equal([]string{"shard-1|a.jpg"}))
+			Expect(records.Len()).To(Equal(1))
+			Expect(records.All()[0].Objects).To(HaveLen(2))
+		})
+	})
 })
@@ -262,6 +262,33 @@ func ProxyAbortSortHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PUT /v1/sort/pause and /v1/sort/resume
+func ProxyPauseSortHandler(w http.ResponseWriter, r *http.Request, action string) {
+	var (
+		query       = r.URL.Query()
+		managerUUID = query.Get(apc.QparamUUID)
+		path        = pauseURLPath(action).Join(managerUUID)
+		responses   = broadcastTargets(http.MethodPut, path, nil, nil, ctx.smapOwner.Get())
+	)
+	allNotFound := true
+	for _, resp := range responses {
+		if resp.statusCode == http.StatusNotFound {
+			continue
+		}
+		allNotFound = false
+
+		if resp.err != nil {
+			cmn.WriteErr(w, r, resp.err, resp.statusCode)
+			return
+		}
+	}
+	if allNotFound {
+		err := cmn.NewErrNotFound("%s job %q", DSortName, managerUUID)
+		cmn.WriteErr(w, r, err, http.StatusNotFound)
+		return
+	}
+}
+
 // DELETE /v1/sort
 func ProxyRemoveSortHandler(w http.ResponseWriter, r *http.Request) {
 	if !checkHTTPMethod(w, r, http.MethodDelete) {
@@ -347,6 +374,8 @@ func SortHandler(w http.ResponseWriter, r *http.Request) {
 		shardsHandler(Managers)(w, r)
 	case apc.Abort:
 		abortSortHandler(w, r)
+	case apc.Pause, apc.Resume:
+		pauseSortHandler(w, r, apiItems[0])
 	case apc.Remove:
 		removeSortHandler(w, r)
 	case apc.List:
@@ -612,6 +641,41 @@ func abortSortHandler(w http.ResponseWriter, r *http.Request) {
 	dsortManager.abort(fmt.Errorf("%s has been aborted via API (remotely)", DSortName))
 }
 
+// pauseSortHandler is the handler called for the HTTP endpoints /v1/sort/pause
+// and /v1/sort/resume.
+func pauseSortHandler(w http.ResponseWriter, r *http.Request, action string) {
+	if !checkHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+	apiItems, err := checkRESTItems(w, r, 1, pauseURLPath(action).L)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	dsortManager, exists := Managers.Get(managerUUID, false /*allowPersisted*/)
+	if !exists {
+		s := fmt.Sprintf("invalid request: job %q does not exist", managerUUID)
+		cmn.WriteErrMsg(w, r, s, http.StatusNotFound)
+		return
+	}
+	if action == apc.Pause {
+		err = dsortManager.pause()
+	} else {
+		err = dsortManager.resume()
+	}
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusGone)
+	}
+}
+
+func pauseURLPath(action string) apc.URLPath {
+	if action == apc.Resume {
+		return apc.URLPathdSortResume
+	}
+	return apc.URLPathdSortPause
+}
+
 func removeSortHandler(w http.ResponseWriter, r *http.Request) {
 	if !checkHTTPMethod(w, r, http.MethodDelete) {
 		return
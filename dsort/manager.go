@@ -82,7 +82,10 @@ type (
 		wg        *sync.WaitGroup
 		// doneCh is closed when the job is aborted so that goroutines know when
 		// they need to stop.
-		doneCh     chan struct{}
+		doneCh chan struct{}
+		// pauseCh is not nil while the job is paused and gets closed upon resume.
+		pauseCh    chan struct{}
+		pauseMu    sync.Mutex
 		inProgress atomic.Bool
 		aborted    atomic.Bool
 		cleaned    uint8 // current state of the cleanliness - no cleanup, initial cleanup, final cleanup
@@ -428,6 +431,54 @@ func (m *Manager) abort(errs ...error) {
 	}()
 }
 
+// pause stops the job from starting to extract (or create) new shards; the ones
+// that are being extracted (created) complete. Metadata exchange (sorting) is
+// not affected: the job pauses once it gets to the creation phase.
+func (m *Manager) pause() error {
+	if !m.inProgress() || m.aborted() {
+		return errors.Errorf("%s job %q is not running", DSortName, m.ManagerUUID)
+	}
+	m.state.pauseMu.Lock()
+	if m.state.pauseCh == nil {
+		m.state.pauseCh = make(chan struct{})
+		m.Metrics.Paused.Store(true)
+		glog.Infof("[dsort] %s has been paused", m.ManagerUUID)
+	}
+	m.state.pauseMu.Unlock()
+	return nil
+}
+
+func (m *Manager) resume() error {
+	if !m.inProgress() || m.aborted() {
+		return errors.Errorf("%s job %q is not running", DSortName, m.ManagerUUID)
+	}
+	m.state.pauseMu.Lock()
+	if m.state.pauseCh != nil {
+		close(m.state.pauseCh)
+		m.state.pauseCh = nil
+		m.Metrics.Paused.Store(false)
+		glog.Infof("[dsort] %s has been resumed", m.ManagerUUID)
+	}
+	m.state.pauseMu.Unlock()
+	return nil
+}
+
+// waitResumed blocks while the job is paused; returns error if aborted meanwhile.
+func (m *Manager) waitResumed() error {
+	m.state.pauseMu.Lock()
+	pauseCh := m.state.pauseCh
+	m.state.pauseMu.Unlock()
+	if pauseCh == nil {
+		return nil
+	}
+	select {
+	case <-pauseCh:
+		return nil
+	case <-m.listenAborted():
+		return newDSortAbortedError(m.ManagerUUID)
+	}
+}
+
 // setDSorter sets what type of dsorter implementation should be used
 func (m *Manager) setDSorter() (err error) {
 	switch m.rs.DSorterType {
@@ -190,6 +190,8 @@ type Metrics struct {
 
 	// Aborted specifies if the DSort has been aborted or not.
 	Aborted atomic.Bool `json:"aborted,omitempty"`
+	// Paused specifies if the DSort has been paused (see Manager.pause).
+	Paused atomic.Bool `json:"paused,omitempty"`
 	// Archived specifies if the DSort has been archived to persistent storage.
 	Archived atomic.Bool `json:"archived,omitempty"`
 
@@ -298,6 +300,7 @@ type JobInfo struct {
 
 	Aborted  bool `json:"aborted"`
 	Archived bool `json:"archived"`
+	Paused   bool `json:"paused,omitempty"`
 
 	Description string `json:"description"`
 }
@@ -315,6 +318,7 @@ func (m *Metrics) ToJobInfo(id string) JobInfo {
 
 		Aborted:     m.Aborted.Load(),
 		Archived:    m.Archived.Load(),
+		Paused:      m.Paused.Load(),
 		Description: m.Description,
 	}
 }
@@ -329,6 +333,7 @@ func (j *JobInfo) Aggregate(other *JobInfo) {
 
 	j.Aborted = j.Aborted || other.Aborted
 	j.Archived = j.Archived && other.Archived
+	j.Paused = j.Paused || other.Paused
 }
 
 func (j *JobInfo) IsRunning() bool {
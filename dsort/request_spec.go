@@ -38,14 +38,29 @@ var (
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = errors.New("concurrency max limit must be 0 (limits will be calculated) or > 0")
+	errNegSamplesPerShard       = errors.New("number of samples per shard must be >= 0")
+	errSamplesAndShardSize      = errors.New("output shard size and number of samples per shard are mutually exclusive")
+	errSamplesWithOrderFile     = errors.New("number of samples per shard cannot be used with order file")
 
 	errInvalidOrderParam = errors.New("could not parse order format, required URL")
 
 	errInvalidAlgorithm          = errors.New("invalid algorithm specified")
 	errInvalidSeed               = errors.New("invalid seed provided, should be int")
 	errInvalidAlgorithmExtension = errors.New("invalid extension provided, should be in the format: .ext")
+	errInvalidEpoch              = errors.New("invalid epoch provided, should be >= 0 and requires 'shuffle' algorithm with seed")
 )
 
+// record grouping
+const (
+	// (default) record is a set of files (objects) from a given input shard that share the same
+	// name up to the first dot, e.g.: `0001.jpg`, `0001.cls`, `0001.meta.json`
+	RecordGroupingShard = "shard"
+	// same as above but across all input shards (a.k.a. WebDataset sample)
+	RecordGroupingSample = "sample"
+)
+
+var supportedRecordGroupings = []string{RecordGroupingShard, RecordGroupingSample}
+
 // supportedExtensions is a list of extensions (archives) supported by dSort
 var supportedExtensions = cos.ArchExtensions
 
@@ -85,6 +100,10 @@ type RequestSpec struct {
 	OutputBck cmn.Bck `json:"output_bck" yaml:"output_bck"`
 	// Default: alphanumeric, increasing
 	Algorithm SortAlgorithm `json:"algorithm" yaml:"algorithm"`
+	// Default: "shard"
+	RecordGrouping string `json:"record_grouping" yaml:"record_grouping"`
+	// Default: 0 (use `output_shard_size`)
+	SamplesPerShard int `json:"samples_per_shard" yaml:"samples_per_shard"`
 	// Default: ""
 	OrderFileURL string `json:"order_file" yaml:"order_file"`
 	// Default: "\t"
@@ -113,6 +132,8 @@ type ParsedRequestSpec struct {
 	OutputBck           cmn.Bck               `json:"output_bck"`
 	Extension           string                `json:"extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
+	SamplesPerShard     int                   `json:"samples_per_shard"`
+	RecordGrouping      string                `json:"record_grouping"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
 	Algorithm           *SortAlgorithm        `json:"algorithm"`
@@ -139,7 +160,8 @@ type SortAlgorithm struct {
 	Decreasing bool `json:"decreasing"`
 
 	// Kind: shuffle
-	Seed string `json:"seed"` // seed provided to random generator
+	Seed  string `json:"seed"`  // seed provided to random generator
+	Epoch int64  `json:"epoch"` // (reproducibly) reshuffle the same records with the same seed
 
 	// Kind: content
 	Extension  string `json:"extension"`
@@ -196,8 +218,26 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errNegOutputShardSize
 	}
 
+	if rs.SamplesPerShard < 0 {
+		return nil, errNegSamplesPerShard
+	}
+	if rs.SamplesPerShard > 0 && parsedRS.OutputShardSize > 0 {
+		return nil, errSamplesAndShardSize
+	}
+	parsedRS.SamplesPerShard = rs.SamplesPerShard
+
+	parsedRS.RecordGrouping = rs.RecordGrouping
+	if parsedRS.RecordGrouping == "" {
+		parsedRS.RecordGrouping = RecordGroupingShard
+	} else if !cos.StringInSlice(parsedRS.RecordGrouping, supportedRecordGroupings) {
+		return nil, fmt.Errorf("invalid record grouping %q, expecting one of: %+v", rs.RecordGrouping, supportedRecordGroupings)
+	}
+
 	parsedRS.Algorithm, err = parseAlgorithm(rs.Algorithm)
 	if err != nil {
+		if err == errInvalidEpoch {
+			return nil, err
+		}
 		return nil, errInvalidAlgorithm
 	}
 
@@ -208,12 +248,15 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 			return nil, err
 		}
 		if parsedRS.OutputFormat.Template.Count() > math.MaxInt32 {
-			// If the count is not defined then the output shard size must be set.
-			if parsedRS.OutputShardSize == 0 {
+			// If the count is not defined then the output shard size (or number of samples) must be set.
+			if parsedRS.OutputShardSize == 0 && parsedRS.SamplesPerShard == 0 {
 				return nil, errEmptyOutputShardSize
 			}
 		}
 	} else { // Valid and not empty.
+		if parsedRS.SamplesPerShard > 0 {
+			return nil, errSamplesWithOrderFile
+		}
 		// For the order file the output shard size must be set.
 		if parsedRS.OutputShardSize == 0 {
 			return nil, errEmptyOutputShardSize
@@ -317,6 +360,10 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 		}
 	}
 
+	if algo.Epoch < 0 || (algo.Epoch > 0 && (algo.Kind != SortKindShuffle || algo.Seed == "")) {
+		return nil, errInvalidEpoch
+	}
+
 	if algo.Kind == SortKindContent {
 		algo.Extension = strings.TrimSpace(algo.Extension)
 		if algo.Extension == "" {
@@ -291,6 +291,40 @@ var _ = Describe("RequestSpec", func() {
 			_, err = rs.Parse()
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("should parse spec with samples per shard, sample grouping, and epoch", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111..2}-suffix",
+				OutputFormat:    "prefix-%06d-suffix",
+				MaxMemUsage:     "80%",
+				RecordGrouping:  RecordGroupingSample,
+				SamplesPerShard: 1000,
+				Algorithm:       SortAlgorithm{Kind: SortKindShuffle, Seed: "42", Epoch: 3},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputShardSize).To(BeZero())
+			Expect(parsed.SamplesPerShard).To(Equal(1000))
+			Expect(parsed.RecordGrouping).To(Equal(RecordGroupingSample))
+			Expect(parsed.Algorithm.Epoch).To(BeEquivalentTo(3))
+		})
+
+		It("should set default record grouping", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111..2}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.RecordGrouping).To(Equal(RecordGroupingShard))
+			Expect(parsed.SamplesPerShard).To(BeZero())
+		})
 	})
 
 	Context("request specs which shall NOT pass", func() {
@@ -485,5 +519,64 @@ var _ = Describe("RequestSpec", func() {
 			_, err := rs.Parse()
 			Expect(err).Should(HaveOccurred())
 		})
+
+		It("should fail when both output shard size and samples per shard are set", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111..2}-suffix",
+				OutputFormat:    "prefix-%06d-suffix",
+				OutputShardSize: "10KB",
+				SamplesPerShard: 100,
+				MaxMemUsage:     "80%",
+			}
+			_, err := rs.Parse()
+			Expect(err).To(Equal(errSamplesAndShardSize))
+		})
+
+		It("should fail due to negative samples per shard", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				InputFormat:     "prefix-{0010..0111..2}-suffix",
+				OutputFormat:    "prefix-{10..111}-suffix",
+				SamplesPerShard: -1,
+				MaxMemUsage:     "80%",
+			}
+			_, err := rs.Parse()
+			Expect(err).To(Equal(errNegSamplesPerShard))
+		})
+
+		It("should fail due to invalid record grouping", func() {
+			rs := RequestSpec{
+				Bck:            cmn.Bck{Name: "test"},
+				Extension:      cos.ExtTar,
+				InputFormat:    "prefix-{0010..0111..2}-suffix",
+				OutputFormat:   "prefix-{10..111}-suffix",
+				RecordGrouping: "something",
+				MaxMemUsage:    "80%",
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail due to invalid epoch", func() {
+			for _, algo := range []SortAlgorithm{
+				{Kind: SortKindShuffle, Seed: "42", Epoch: -1},
+				{Kind: SortKindShuffle, Epoch: 1},
+				{Kind: SortKindAlphanumeric, Epoch: 1},
+			} {
+				rs := RequestSpec{
+					Bck:          cmn.Bck{Name: "test"},
+					Extension:    cos.ExtTar,
+					InputFormat:  "prefix-{0010..0111..2}-suffix",
+					OutputFormat: "prefix-{10..111}-suffix",
+					MaxMemUsage:  "80%",
+					Algorithm:    algo,
+				}
+				_, err := rs.Parse()
+				Expect(err).To(Equal(errInvalidEpoch))
+			}
+		})
 	})
 })
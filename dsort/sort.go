@@ -70,11 +70,15 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 			// We assert error since we know that the seed should be validated
 			// during request spec validation.
 			cos.AssertNoErr(err)
+			// The order in which records get merged is not deterministic -
+			// start from the one that is.
+			all := r.All()
+			sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
 		}
 
-		rand.Seed(seed)
+		rnd := rand.New(rand.NewSource(epochSeed(seed, algo.Epoch)))
 		for i := 0; i < r.Len(); i++ { // https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
-			j := rand.Intn(i + 1)
+			j := rnd.Intn(i + 1)
 			r.Swap(i, j)
 		}
 	} else {
@@ -88,3 +92,16 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 
 	return nil
 }
+
+// epochSeed derives a seed for a given epoch, so that each epoch produces a different
+// (but reproducible) permutation of the same records; epoch zero is the seed itself
+func epochSeed(seed, epoch int64) int64 {
+	if epoch == 0 {
+		return seed
+	}
+	// splitmix64 finalizer
+	z := uint64(seed) + uint64(epoch)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
@@ -88,6 +88,40 @@ var _ = Describe("SortRecords", func() {
 		Expect(fm).To(Equal(expected))
 	})
 
+	It("should shuffle records reproducibly regardless of the initial order", func() {
+		keys := make([]interface{}, 0, 100)
+		for i := 0; i < 100; i++ {
+			keys = append(keys, fmt.Sprintf("%03d", i))
+		}
+		algo := &SortAlgorithm{Kind: SortKindShuffle, Seed: "1010102", Epoch: 7, FormatType: extract.FormatTypeString}
+		fm := createRecords(keys...)
+		err := sortRecords(fm, algo)
+		Expect(err).ToNot(HaveOccurred())
+
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+		other := createRecords(keys...)
+		err = sortRecords(other, algo)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(other.All()).To(Equal(fm.All()))
+	})
+
+	It("should shuffle records differently in each epoch", func() {
+		keys := make([]interface{}, 0, 100)
+		for i := 0; i < 100; i++ {
+			keys = append(keys, fmt.Sprintf("%03d", i))
+		}
+		var prev []*extract.Record
+		for epoch := int64(0); epoch < 3; epoch++ {
+			fm := createRecords(keys...)
+			err := sortRecords(fm, &SortAlgorithm{Kind: SortKindShuffle, Seed: "1010102", Epoch: epoch, FormatType: extract.FormatTypeString})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fm.All()).NotTo(Equal(prev))
+			prev = fm.All()
+		}
+	})
+
 	It("should return error when some keys are missing", func() {
 		fm := createRecords("def", "abc")
 		fm.All()[0].Key = nil
@@ -97,6 +97,9 @@ func (r *XactBckEncode) Run(wg *sync.WaitGroup) {
 		CTs:      []string{fs.ObjectType},
 		VisitObj: r.bckEncode,
 		DoLoad:   mpather.Load,
+		// NOTE: pausable but not checkpointed - encoding is asynchronous, and
+		// a restarted job skips already encoded objects anyway (see bckEncode)
+		Pauser: &r.Base,
 	}
 	opts.Bck.Copy(r.bck.Bucket())
 	jg := mpather.NewJoggerGroup(opts)
@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/transport"
@@ -138,6 +139,9 @@ func (c *getJogger) ec(req *request) {
 		c.parent.stats.updateObjTime(time.Since(req.putTime))
 		err = ctx.lom.Persist()
 	}
+	if err == nil {
+		events.Emit(cmn.EvECRestore, ctx.lom)
+	}
 	c.freeCtx(ctx)
 	c.finalizeReq(req, err)
 }
@@ -241,6 +245,7 @@ func (c *getJogger) restoreReplicatedFromMemory(ctx *restoreCtx) error {
 	}
 
 	ctx.lom.SetSize(writer.Size())
+	ctx.meta.restoreSSE(ctx.lom)
 	args := &WriteArgs{
 		Reader:     memsys.NewReader(writer),
 		MD:         ctx.meta.NewPack(),
@@ -290,6 +295,7 @@ func (c *getJogger) restoreReplicatedFromDisk(ctx *restoreCtx) error {
 				break
 			}
 			ctx.lom.SetSize(n)
+			ctx.meta.restoreSSE(ctx.lom)
 			writer = w
 			break
 		}
@@ -596,6 +602,7 @@ func (c *getJogger) restoreMainObj(ctx *restoreCtx) ([]*slice, error) {
 		ctx.lom.SetVersion(version)
 	}
 	ctx.lom.SetSize(ctx.meta.Size)
+	ctx.meta.restoreSSE(ctx.lom)
 	mainMeta := *ctx.meta
 	mainMeta.SliceID = 0
 	args := &WriteArgs{
@@ -10,12 +10,20 @@ import (
 	"os"
 
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/OneOfOne/xxhash"
 )
 
-const MDVersionLast = 1 // current version of metadata
+// Metadata of objects that are not encrypted at rest is written in the v1 format, so that
+// (during rolling upgrade) the older targets can read it; v2 is v1 followed by object's
+// encryption metadata, and is written only when there's one. Both are supported when reading.
+const (
+	MDVersionLast = 2 // current version of metadata
+	mdVersionV1   = 1
+	mdVersionSSE  = 2 // encrypted at rest
+)
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
@@ -32,6 +40,7 @@ type Metadata struct {
 	SliceID     int              `json:"slice_id"`      // 0 for full replica, 1 to N for slices
 	MDVersion   uint32           `json:"md_version"`    // Metadata format version
 	IsCopy      bool             `json:"is_copy"`       // object is replicated(true) or encoded(false)
+	SSE         string           `json:"sse,omitempty"` // object's encryption metadata (cmn.SSEObjMD), if encrypted at rest
 }
 
 // interface guard
@@ -91,6 +100,15 @@ func (md *Metadata) RemoteTargets(t cluster.Target) []*cluster.Snode {
 	return nodes
 }
 
+// restored object is encrypted at rest iff the original one was
+func (md *Metadata) restoreSSE(lom *cluster.LOM) {
+	if md.SSE != "" {
+		lom.SetCustomKey(cmn.SSEObjMD, md.SSE)
+	} else {
+		lom.ObjAttrs().DelCustomKeys(cmn.SSEObjMD)
+	}
+}
+
 // Do not use MM.SGL for a byte buffer: as the buffer is sent via
 // HTTP, it can result in hard to debug errors when SGL is freed.
 // For details:  https://gitlab-master.nvidia.com/aistorage/aistore/issues/472#note_4212419
@@ -125,10 +143,14 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 		return
 	}
 	switch md.MDVersion {
-	case MDVersionLast:
-		err = md.unpackLastVersion(unpacker)
+	case mdVersionSSE:
+		if err = md.unpackV1(unpacker); err == nil {
+			md.SSE, err = unpacker.ReadString()
+		}
+	case mdVersionV1:
+		err = md.unpackV1(unpacker)
 	default:
-		err = fmt.Errorf("unsupported metadata format version %d. Only %d supported",
+		err = fmt.Errorf("unsupported metadata format version %d. Only %d and older supported",
 			md.MDVersion, MDVersionLast)
 	}
 	if err != nil {
@@ -146,7 +168,7 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	return err
 }
 
-func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
+func (md *Metadata) unpackV1(unpacker *cos.ByteUnpack) (err error) {
 	var i16 uint16
 	if md.Generation, err = unpacker.ReadInt64(); err != nil {
 		return
@@ -188,8 +210,17 @@ func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
 	return
 }
 
+// the version to write (see MDVersionLast above)
+func (md *Metadata) packVersion() uint32 {
+	if md.SSE != "" {
+		return mdVersionSSE
+	}
+	return mdVersionV1
+}
+
 func (md *Metadata) Pack(packer *cos.BytePack) {
-	packer.WriteUint32(md.MDVersion)
+	ver := md.packVersion()
+	packer.WriteUint32(ver)
 	packer.WriteInt64(md.Generation)
 	packer.WriteInt64(md.Size)
 	packer.WriteUint16(uint16(md.Data))
@@ -202,6 +233,9 @@ func (md *Metadata) Pack(packer *cos.BytePack) {
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
 	packer.WriteMapStrUint16(md.Daemons)
+	if ver == mdVersionSSE {
+		packer.WriteString(md.SSE)
+	}
 	h := xxhash.Checksum64S(packer.Bytes(), cos.MLCG32)
 	packer.WriteUint64(h)
 }
@@ -211,8 +245,12 @@ func (md *Metadata) PackedSize() int {
 	for k := range md.Daemons {
 		daemonListSz += cos.PackedStrLen(k) + cos.SizeofI16
 	}
-	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
+	size := cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
 		cos.PackedStrLen(md.ObjCksum) + cos.PackedStrLen(md.ObjVersion) +
 		cos.PackedStrLen(md.CksumType) + cos.PackedStrLen(md.CksumValue) +
 		cos.PackedStrLen(md.FullReplica) + daemonListSz + cos.SizeofI64 /*md cksum*/
+	if md.packVersion() == mdVersionSSE {
+		size += cos.PackedStrLen(md.SSE)
+	}
+	return size
 }
@@ -7,7 +7,9 @@ package etl
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -30,11 +32,32 @@ type (
 
 	InitCodeMsg struct {
 		InitMsgBase
+		ProcOpts
 		Code    []byte `json:"code"`
 		Deps    []byte `json:"dependencies"`
 		Runtime string `json:"runtime"`
 	}
 
+	// InitProcMsg starts user-supplied transformer (executable) as a local process
+	// on each target - see "local runtime" in process.go
+	InitProcMsg struct {
+		InitMsgBase
+		ProcOpts
+		Command []string          `json:"command"`
+		Env     map[string]string `json:"env,omitempty"`
+	}
+
+	// ProcOpts configure local (Kubernetes-free) runtime - ignored when running in Kubernetes
+	ProcOpts struct {
+		// number of transformer processes (io:// - max number of concurrently running commands)
+		Procs int `json:"procs,omitempty"`
+		// transformer listens on a Unix socket (default) or loopback TCP port
+		Listen string `json:"listen,omitempty"`
+		// per-process resource limits (cgroup v2) - ignored (with a warning) when not available
+		CPU float64 `json:"cpu,omitempty"` // number of CPUs
+		Mem int64   `json:"mem,omitempty"` // bytes
+	}
+
 	InfoList []Info
 	Info     struct {
 		ID string `json:"id"`
@@ -42,6 +65,26 @@ type (
 		ObjCount int64 `json:"obj_count"`
 		InBytes  int64 `json:"in_bytes"`
 		OutBytes int64 `json:"out_bytes"`
+
+		// this ETL as a stage of (recently used) pipelines
+		Stages []StageInfo `json:"stages,omitempty"`
+	}
+	StageInfo struct {
+		Pipeline string `json:"pipeline"`
+		Stage    int    `json:"stage"` // 0-based
+		ObjCount int64  `json:"obj_count"`
+		InBytes  int64  `json:"in_bytes"`
+		OutBytes int64  `json:"out_bytes"`
+		ErrCount int64  `json:"err_count"`
+	}
+
+	// Pipeline is an ordered list of ETLs, each transforming the output of the previous one
+	// on the same target. Its string form (e.g. "decode,augment?angle=30&flip=true,encode")
+	// is accepted anywhere a single ETL ID is: inline GET, apc.TCBMsg, and cmn.TCObjsMsg.
+	Pipeline      []PipelineStage
+	PipelineStage struct {
+		ID   string     `json:"id"`
+		Args url.Values `json:"args,omitempty"` // per-stage arguments (see docs/etl.md)
 	}
 
 	PodsLogsMsg []PodLogsMsg
@@ -79,6 +122,7 @@ type (
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*InitProcMsg)(nil)
 )
 
 func (m InitMsgBase) CommType() string { return m.CommTypeX }
@@ -99,6 +143,11 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf["command"]; ok {
+		msg = &InitProcMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid response body: %s", b)
 	return
 }
@@ -122,11 +171,41 @@ func (m *InitCodeMsg) Validate() error {
 	if !cos.StringInSlice(m.CommTypeX, commTypes) {
 		return fmt.Errorf("unsupported communication type provided: %s", m.CommTypeX)
 	}
-	return nil
+	return m.ProcOpts.validate()
 }
 
 func (*InitCodeMsg) InitType() string { return apc.ETLInitCode }
 func (*InitSpecMsg) InitType() string { return apc.ETLInitSpec }
+func (*InitProcMsg) InitType() string { return apc.ETLInitProc }
+
+func (m *InitProcMsg) Validate() error {
+	if err := cos.ValidateEtlID(m.IDX); err != nil {
+		return fmt.Errorf("invalid etl ID: %v", err)
+	}
+	if len(m.Command) == 0 || m.Command[0] == "" {
+		return fmt.Errorf("command is empty")
+	}
+	if m.CommTypeX == "" {
+		m.CommTypeX = PushCommType
+	}
+	if !cos.StringInSlice(m.CommTypeX, procCommTypes) {
+		return fmt.Errorf("unsupported communication type provided: %s (expecting one of: %v)", m.CommTypeX, procCommTypes)
+	}
+	return m.ProcOpts.validate()
+}
+
+func (o *ProcOpts) validate() error {
+	if o.Procs < 0 {
+		return fmt.Errorf("invalid number of processes: %d", o.Procs)
+	}
+	if o.Listen != "" && o.Listen != ListenUnix && o.Listen != ListenTCP {
+		return fmt.Errorf("invalid listen type %q (expecting %q or %q)", o.Listen, ListenUnix, ListenTCP)
+	}
+	if o.CPU < 0 || o.Mem < 0 {
+		return fmt.Errorf("invalid resource limits (cpu %.2f, mem %d)", o.CPU, o.Mem)
+	}
+	return nil
+}
 
 func (m *InitSpecMsg) Validate() (err error) {
 	errCtx := &cmn.ETLErrorContext{}
@@ -181,6 +260,54 @@ func (m *InitSpecMsg) Validate() (err error) {
 	return nil
 }
 
+//////////////
+// Pipeline //
+//////////////
+
+const (
+	pipelineSep = ","
+	stageArgSep = "?"
+)
+
+// IsPipeline returns true if the ETL ID is, in fact, a pipeline of one or more ETLs with arguments.
+func IsPipeline(id string) bool { return strings.ContainsAny(id, pipelineSep+stageArgSep) }
+
+func ParsePipeline(s string) (pipeline Pipeline, err error) {
+	for _, stage := range strings.Split(s, pipelineSep) {
+		var (
+			ps               PipelineStage
+			id, rawArgs, has = strings.Cut(stage, stageArgSep)
+		)
+		ps.ID = strings.TrimSpace(id)
+		if err = cos.ValidateEtlID(ps.ID); err != nil {
+			return nil, fmt.Errorf("invalid ETL pipeline %q: %v", s, err)
+		}
+		if has {
+			if ps.Args, err = url.ParseQuery(rawArgs); err != nil {
+				return nil, fmt.Errorf("invalid ETL pipeline %q: stage %q: %v", s, ps.ID, err)
+			}
+		}
+		pipeline = append(pipeline, ps)
+	}
+	return
+}
+
+// String returns canonical string form (e.g., to use as apc.TCBMsg.ID)
+func (p Pipeline) String() string {
+	var sb strings.Builder
+	for i, ps := range p {
+		if i > 0 {
+			sb.WriteString(pipelineSep)
+		}
+		sb.WriteString(ps.ID)
+		if len(ps.Args) > 0 {
+			sb.WriteString(stageArgSep)
+			sb.WriteString(ps.Args.Encode())
+		}
+	}
+	return sb.String()
+}
+
 /////////////////
 // PodsLogsMsg //
 /////////////////
@@ -0,0 +1,17 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import "errors"
+
+func newCgroup(_ string, opts *ProcOpts) (string, error) {
+	if opts.CPU == 0 && opts.Mem == 0 {
+		return "", nil
+	}
+	return "", errors.New("cgroups are not supported")
+}
+
+func cgroupAdd(string, int) error { return nil }
+func removeCgroup(string)         {}
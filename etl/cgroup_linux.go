@@ -0,0 +1,69 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// cgroup v2 (unified hierarchy) - the local runtime only
+const (
+	cgroupRoot   = "/sys/fs/cgroup"
+	cgroupParent = "ais-etl"
+	cgroupPeriod = 100000 // cpu.max period (microseconds)
+)
+
+// newCgroup creates a cgroup with the specified limits, or returns "" when there are none.
+func newCgroup(name string, opts *ProcOpts) (cg string, err error) {
+	if opts.CPU == 0 && opts.Mem == 0 {
+		return
+	}
+	if _, err = os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", errors.New("cgroup v2 is not available")
+	}
+	parent := filepath.Join(cgroupRoot, cgroupParent)
+	if err = cos.CreateDir(parent); err != nil {
+		return
+	}
+	// enable controllers for the children (noop if already enabled)
+	for _, dir := range []string{cgroupRoot, parent} {
+		if err = writeCgroup(dir, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+			return
+		}
+	}
+	cg = filepath.Join(parent, name)
+	if err = cos.CreateDir(cg); err != nil {
+		return "", err
+	}
+	if opts.CPU > 0 {
+		err = writeCgroup(cg, "cpu.max", fmt.Sprintf("%d %d", int64(opts.CPU*cgroupPeriod), cgroupPeriod))
+	}
+	if err == nil && opts.Mem > 0 {
+		err = writeCgroup(cg, "memory.max", strconv.FormatInt(opts.Mem, 10))
+	}
+	if err != nil {
+		removeCgroup(cg)
+		return "", err
+	}
+	return cg, nil
+}
+
+func cgroupAdd(cg string, pid int) error { return writeCgroup(cg, "cgroup.procs", strconv.Itoa(pid)) }
+
+func removeCgroup(cg string) {
+	if cg != "" {
+		os.Remove(cg) // (must be empty of processes)
+	}
+}
+
+func writeCgroup(dir, name, value string) error {
+	return os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644)
+}
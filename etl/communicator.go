@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,6 +50,12 @@ type (
 		OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error)
 		Stop()
 
+		// pipeline stage (see pipeline.go)
+		transform(in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error)
+
+		// local runtime only (nil otherwise)
+		procs() *procPool
+
 		CommStats
 	}
 
@@ -65,6 +72,9 @@ type (
 		podName string
 
 		xctn cluster.Xact
+
+		client *http.Client
+		pool   *procPool // local runtime
 	}
 
 	pushComm struct {
@@ -73,6 +83,11 @@ type (
 		uri     string
 		command []string
 	}
+	// io:// of the local runtime: executes the command for each object
+	execComm struct {
+		baseComm
+		mem *memsys.MMSA
+	}
 	redirectComm struct {
 		baseComm
 		uri string
@@ -95,6 +110,7 @@ var (
 	_ Communicator = (*pushComm)(nil)
 	_ Communicator = (*redirectComm)(nil)
 	_ Communicator = (*revProxyComm)(nil)
+	_ Communicator = (*execComm)(nil)
 
 	_ io.Writer = (*cbWriter)(nil)
 )
@@ -110,6 +126,7 @@ func makeCommunicator(args commArgs) Communicator {
 		name:      args.bootstraper.originalPodName,
 		podName:   args.bootstraper.pod.Name,
 		xctn:      args.bootstraper.xctn,
+		client:    args.bootstraper.t.DataClient(),
 	}
 
 	switch args.bootstraper.msg.CommTypeX {
@@ -122,20 +139,7 @@ func makeCommunicator(args commArgs) Communicator {
 	case RedirectCommType:
 		return &redirectComm{baseComm: baseComm, uri: args.bootstraper.uri}
 	case RevProxyCommType:
-		transformerURL, err := url.Parse(args.bootstraper.uri)
-		cos.AssertNoErr(err)
-		rp := &httputil.ReverseProxy{
-			Director: func(req *http.Request) {
-				// Replacing the `req.URL` host with ETL container host
-				req.URL.Scheme = transformerURL.Scheme
-				req.URL.Host = transformerURL.Host
-				req.URL.RawQuery = pruneQuery(req.URL.RawQuery)
-				if _, ok := req.Header["User-Agent"]; !ok {
-					// Explicitly disable `User-Agent` so it's not set to default value.
-					req.Header.Set("User-Agent", "")
-				}
-			},
-		}
+		rp := newReverseProxy(args.bootstraper.uri, nil /*default transport*/)
 		return &revProxyComm{baseComm: baseComm, rp: rp, uri: args.bootstraper.uri}
 	case IOCommType:
 		return &pushComm{
@@ -150,6 +154,49 @@ func makeCommunicator(args commArgs) Communicator {
 	return nil
 }
 
+// local runtime (see process.go)
+func makeProcCommunicator(pp *procPool, listener cluster.Slistener, xctn cluster.Xact, name string) Communicator {
+	baseComm := baseComm{
+		Slistener: listener,
+		t:         pp.t,
+		name:      name,
+		podName:   name,
+		xctn:      xctn,
+		client:    pp.client,
+		pool:      pp,
+	}
+	switch pp.commType {
+	case PushCommType:
+		return &pushComm{baseComm: baseComm, mem: pp.t.PageMM(), uri: procURI}
+	case RevProxyCommType:
+		rp := newReverseProxy(procURI, pp.client.Transport)
+		return &revProxyComm{baseComm: baseComm, rp: rp, uri: procURI}
+	case IOCommType:
+		return &execComm{baseComm: baseComm, mem: pp.t.PageMM()}
+	default:
+		cos.AssertMsg(false, pp.commType)
+	}
+	return nil
+}
+
+func newReverseProxy(uri string, transport http.RoundTripper) *httputil.ReverseProxy {
+	transformerURL, err := url.Parse(uri)
+	cos.AssertNoErr(err)
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			// Replacing the `req.URL` host with ETL container host
+			req.URL.Scheme = transformerURL.Scheme
+			req.URL.Host = transformerURL.Host
+			req.URL.RawQuery = pruneQuery(req.URL.RawQuery)
+			if _, ok := req.Header["User-Agent"]; !ok {
+				// Explicitly disable `User-Agent` so it's not set to default value.
+				req.Header.Set("User-Agent", "")
+			}
+		},
+		Transport: transport,
+	}
+}
+
 func (c baseComm) Name() string    { return c.name }
 func (c baseComm) PodName() string { return c.podName }
 func (c baseComm) SvcName() string { return c.podName /*pod name is same as service name*/ }
@@ -158,7 +205,12 @@ func (c baseComm) ObjCount() int64 { return c.xctn.Objs() }
 func (c baseComm) InBytes() int64  { return c.xctn.InBytes() }
 func (c baseComm) OutBytes() int64 { return c.xctn.OutBytes() }
 
+func (c *baseComm) procs() *procPool { return c.pool }
+
 func (c *baseComm) Stop() {
+	if c.pool != nil {
+		c.pool.stop()
+	}
 	c.xctn.Finish(nil)
 }
 
@@ -166,7 +218,7 @@ func (c *baseComm) Stop() {
 // pushComm //
 //////////////
 
-func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, args url.Values, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
 
@@ -174,18 +226,18 @@ func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Dur
 		return nil, err
 	}
 
-	r, err = pc.tryDoRequest(lom, timeout)
+	r, err = pc.tryDoRequest(lom, args, timeout)
 	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
 		_, err = pc.t.GetCold(context.Background(), lom, cmn.OwtGetLock)
 		if err != nil {
 			return nil, err
 		}
-		r, err = pc.tryDoRequest(lom, timeout)
+		r, err = pc.tryDoRequest(lom, args, timeout)
 	}
 	return
 }
 
-func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (pc *pushComm) tryDoRequest(lom *cluster.LOM, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	if err := pc.xctn.AbortErr(); err != nil {
 		return nil, cmn.NewErrAborted(pc.xctn.Name(), "try-push-comm", err)
 	}
@@ -196,38 +248,55 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
 		return nil, err
 	}
-	size := lom.SizeBytes()
 
-	// `fh` is closed by Do(req).
-	fh, err := cos.NewFileHandle(lom.FQN)
+	// `fh` is closed by Do(req); (decrypts if encrypted at rest)
+	fh, oah, err := lom.PlainReader()
 	if err != nil {
 		return nil, err
 	}
+	return pc.put(fh, oah.SizeBytes(), args, timeout)
+}
 
+func (pc *pushComm) transform(in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if in.r == nil {
+		return pc.doRequest(in.bck, in.objName, args, timeout)
+	}
+	return pc.put(in.r, in.size, args, timeout)
+}
+
+func (pc *pushComm) put(body io.Reader, size int64, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	var (
 		req    *http.Request
 		resp   *http.Response
 		cancel func()
+		err    error
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.uri, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, pc.uri, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, pc.uri, fh)
+		req, err = http.NewRequest(http.MethodPut, pc.uri, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		if rc, ok := body.(io.Closer); ok {
+			cos.Close(rc)
+		}
 		goto finish
 	}
-	if len(pc.command) != 0 {
+	if len(pc.command) != 0 || len(args) != 0 {
 		q := req.URL.Query()
-		q["command"] = []string{"bash", "-c", strings.Join(pc.command, " ")}
+		for k, v := range args {
+			q[k] = v
+		}
+		if len(pc.command) != 0 {
+			q["command"] = []string{"bash", "-c", strings.Join(pc.command, " ")}
+		}
 		req.URL.RawQuery = q.Encode()
 	}
 	req.ContentLength = size
 	req.Header.Set(cmn.HdrContentType, cmn.ContentBinary)
-	resp, err = pc.t.DataClient().Do(req) // nolint:bodyclose // Closed by the caller.
+	resp, err = pc.client.Do(req) // nolint:bodyclose // Closed by the caller.
 finish:
 	if err != nil {
 		if cancel != nil {
@@ -252,7 +321,7 @@ finish:
 func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
 	var (
 		size   int64
-		r, err = pc.doRequest(bck, objName, 0 /*timeout*/)
+		r, err = pc.doRequest(bck, objName, nil /*args*/, 0 /*timeout*/)
 	)
 	if err != nil {
 		return err
@@ -268,7 +337,91 @@ func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck
 }
 
 func (pc *pushComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
-	return pc.doRequest(bck, objName, timeout)
+	return pc.doRequest(bck, objName, nil /*args*/, timeout)
+}
+
+//////////////
+// execComm //
+//////////////
+
+func (ec *execComm) doRequest(bck *cluster.Bck, objName string, args url.Values, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return nil, err
+	}
+
+	r, err = ec.tryDoRequest(lom, args, timeout)
+	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
+		_, err = ec.t.GetCold(context.Background(), lom, cmn.OwtGetLock)
+		if err != nil {
+			return nil, err
+		}
+		r, err = ec.tryDoRequest(lom, args, timeout)
+	}
+	return
+}
+
+func (ec *execComm) tryDoRequest(lom *cluster.LOM, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := ec.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(ec.xctn.Name(), "try-exec-comm", err)
+	}
+
+	lom.Lock(false)
+	defer lom.Unlock(false)
+
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return nil, err
+	}
+	fh, oah, err := lom.PlainReader()
+	if err != nil {
+		return nil, err
+	}
+	return ec.execute(fh, oah.SizeBytes(), args, timeout)
+}
+
+func (ec *execComm) transform(in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if in.r == nil {
+		return ec.doRequest(in.bck, in.objName, args, timeout)
+	}
+	return ec.execute(in.r, in.size, args, timeout)
+}
+
+// NOTE: closes the reader
+func (ec *execComm) execute(r io.ReadCloser, size int64, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	// buffering the output to report the size (and the error, if any) upfront
+	sgl := ec.mem.NewSGL(cos.MaxI64(size, 0))
+	err := ec.pool.exec(r, sgl, args, timeout)
+	cos.Close(r)
+	if err != nil {
+		sgl.Free()
+		return nil, cmn.NewErrETL(ec.pool.errCtx, err.Error())
+	}
+	ec.xctn.InObjsAdd(1, size)
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:       sgl,
+		Size:    sgl.Size(),
+		ReadCb:  func(i int, err error) { ec.xctn.OutObjsAdd(1, int64(i)) },
+		DeferCb: func() { sgl.Free() },
+	}), nil
+}
+
+func (ec *execComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, err := ec.doRequest(bck, objName, nil /*args*/, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w.Header().Set(cmn.HdrContentLength, strconv.FormatInt(r.Size(), 10))
+	buf, slab := ec.mem.AllocSize(r.Size())
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	return err
+}
+
+func (ec *execComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return ec.doRequest(bck, objName, nil /*args*/, timeout)
 }
 
 //////////////////
@@ -302,6 +455,10 @@ func (rc *redirectComm) OfflineTransform(bck *cluster.Bck, objName string, timeo
 	return rc.getWithTimeout(etlURL, size, timeout)
 }
 
+func (rc *redirectComm) transform(in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return rc.getTransformed(rc.uri, in, args, timeout)
+}
+
 //////////////////
 // revProxyComm //
 //////////////////
@@ -331,6 +488,10 @@ func (pc *revProxyComm) OfflineTransform(bck *cluster.Bck, objName string, timeo
 	return pc.getWithTimeout(etlURL, size, timeout)
 }
 
+func (pc *revProxyComm) transform(in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return pc.getTransformed(pc.uri, in, args, timeout)
+}
+
 //////////////
 // cbWriter //
 //////////////
@@ -384,7 +545,7 @@ func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration)
 	if err != nil {
 		goto finish
 	}
-	resp, err = c.t.DataClient().Do(req) // nolint:bodyclose // Closed by the caller.
+	resp, err = c.client.Do(req) // nolint:bodyclose // Closed by the caller.
 finish:
 	if err != nil {
 		if cancel != nil {
@@ -406,6 +567,39 @@ finish:
 	}), nil
 }
 
+// hpull:// and hrev:// pipeline stage: the transformer GETs its input from the target -
+// either the object itself or the output of the previous stage (see stash)
+func (c *baseComm) getTransformed(uri string, in *stageIn, args url.Values, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	var (
+		path  string
+		token string
+		size  = in.size
+	)
+	if in.r == nil {
+		var err error
+		if size, err = determineSize(in.bck, in.objName); err != nil {
+			return nil, err
+		}
+		path = transformerPath(in.bck, in.objName)
+	} else {
+		token = stashes.add(in.r, size)
+		path = "/" + token
+	}
+	etlURL := cos.JoinPath(uri, path)
+	if len(args) > 0 {
+		etlURL += "?" + args.Encode()
+	}
+	r, err := c.getWithTimeout(etlURL, size, timeout)
+	if token == "" {
+		return r, err
+	}
+	if err != nil {
+		stashes.del(token)
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: r, Size: r.Size(), DeferCb: func() { stashes.del(token) }}), nil
+}
+
 func determineSize(bck *cluster.Bck, objName string) (int64, error) {
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
@@ -0,0 +1,291 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Pipelines
+//
+// A pipeline (see Pipeline in api.go) chains existing ETLs on the same target:
+// the first stage transforms the object, and each subsequent stage transforms the
+// output of the previous one, with the data streamed between stages. Stage
+// arguments, if any, are passed on to the transformer as URL query parameters
+// (io:// of the local runtime: `AIS_ETL_ARGS` environment).
+//
+// Stages that fetch their input from the target (hpull://, hrev://) get the output
+// of the previous stage via the same target URL (`AIS_TARGET_URL`) - see stash below.
+//
+// Pipelines are resolved (by GetCommunicator) on first use and cached until any
+// of their ETLs stops; the cache also keeps per-stage stats reported by List.
+
+const (
+	maxPipelines = 64 // max cached pipelines (see pipes.get)
+
+	stashPrefix = "_stash-"
+)
+
+type (
+	// pipeline stage input: either an object or the output of the previous stage
+	stageIn struct {
+		bck     *cluster.Bck
+		objName string
+		r       io.ReadCloser // (closed by the stage)
+		size    int64
+	}
+
+	pipeComm struct {
+		name   string
+		stages []*pipeStage
+	}
+	pipeStage struct {
+		comm Communicator
+		id   string
+		args url.Values
+		objs atomic.Int64
+		in   atomic.Int64 // (first stage only - see stats)
+		out  atomic.Int64
+		errs atomic.Int64
+	}
+
+	pipes struct {
+		mtx sync.Mutex
+		m   map[string]*pipeComm // by canonical name (Pipeline.String)
+	}
+
+	// outputs of pipeline stages waiting to be fetched by hpull:// and hrev:// transformers
+	stash struct {
+		mtx  sync.Mutex
+		m    map[string]*stashed
+		next atomic.Uint64
+	}
+	stashed struct {
+		r    io.ReadCloser
+		size int64
+	}
+)
+
+// interface guard
+var _ Communicator = (*pipeComm)(nil)
+
+var (
+	pipelines = &pipes{m: make(map[string]*pipeComm)}
+	stashes   = &stash{m: make(map[string]*stashed)}
+)
+
+//////////////
+// pipeComm //
+//////////////
+
+func (pc *pipeComm) Name() string    { return pc.name }
+func (pc *pipeComm) PodName() string { return pc.name }
+func (pc *pipeComm) SvcName() string { return pc.name }
+func (pc *pipeComm) String() string  { return "etl-pipeline[" + pc.name + "]" }
+
+// pipelines are not registered as cluster listeners (the stages are)
+func (*pipeComm) ListenSmapChanged() {}
+
+func (pc *pipeComm) ObjCount() int64 { return pc.stages[len(pc.stages)-1].objs.Load() }
+func (pc *pipeComm) InBytes() int64  { return pc.stages[0].in.Load() }
+func (pc *pipeComm) OutBytes() int64 { return pc.stages[len(pc.stages)-1].out.Load() }
+
+func (*pipeComm) Stop()            {} // (see pipes.del)
+func (*pipeComm) procs() *procPool { return nil }
+
+func (pc *pipeComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	var (
+		size   int64
+		r, err = pc.OfflineTransform(bck, objName, 0 /*timeout*/)
+	)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if size = r.Size(); size < 0 {
+		size = memsys.DefaultBufSize
+	} else {
+		w.Header().Set(cmn.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	buf, slab := memsys.PageMM().AllocSize(size)
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	return err
+}
+
+func (pc *pipeComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if size, err := determineSize(bck, objName); err == nil {
+		pc.stages[0].in.Add(size)
+	}
+	return pc.transform(&stageIn{bck: bck, objName: objName}, nil, timeout)
+}
+
+// NOTE: each stage gets the same timeout (as it would when used on its own)
+func (pc *pipeComm) transform(in *stageIn, _ url.Values, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+	for i, ps := range pc.stages {
+		ps := ps
+		if r, err = ps.comm.transform(in, ps.args, timeout); err != nil {
+			ps.errs.Inc()
+			return nil, cmn.NewErrETL(&cmn.ETLErrorContext{ETLName: ps.id}, "%s: stage %d failed: %v", pc, i, err)
+		}
+		ps.objs.Inc()
+		r = cos.NewReaderWithArgs(cos.ReaderArgs{
+			R:      r,
+			Size:   r.Size(),
+			ReadCb: func(n int, _ error) { ps.out.Add(int64(n)) },
+		})
+		in = &stageIn{r: r, size: r.Size()}
+	}
+	return
+}
+
+func (pc *pipeComm) hasStage(id string) bool {
+	for _, ps := range pc.stages {
+		if ps.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (pc *pipeComm) stats(id string) (stats []StageInfo) {
+	for i, ps := range pc.stages {
+		if ps.id != id {
+			continue
+		}
+		si := StageInfo{
+			Pipeline: pc.name,
+			Stage:    i,
+			ObjCount: ps.objs.Load(),
+			OutBytes: ps.out.Load(),
+			ErrCount: ps.errs.Load(),
+		}
+		if i == 0 {
+			si.InBytes = ps.in.Load()
+		} else {
+			si.InBytes = pc.stages[i-1].out.Load()
+		}
+		stats = append(stats, si)
+	}
+	return
+}
+
+///////////
+// pipes //
+///////////
+
+func (p *pipes) get(pipeline Pipeline) (*pipeComm, error) {
+	name := pipeline.String()
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if pc, ok := p.m[name]; ok {
+		return pc, nil
+	}
+	pc := &pipeComm{name: name, stages: make([]*pipeStage, 0, len(pipeline))}
+	for _, stage := range pipeline {
+		c, exists := reg.getByUUID(stage.ID)
+		if !exists {
+			return nil, cmn.NewErrNotFound("ETL %q (pipeline %q)", stage.ID, name)
+		}
+		pc.stages = append(pc.stages, &pipeStage{comm: c, id: stage.ID, args: stage.Args})
+	}
+	if len(p.m) >= maxPipelines {
+		for k := range p.m { // evict random
+			delete(p.m, k)
+			break
+		}
+	}
+	p.m[name] = pc
+	return pc, nil
+}
+
+// forget all pipelines that include the (stopped) ETL
+func (p *pipes) del(id string) {
+	p.mtx.Lock()
+	for name, pc := range p.m {
+		if pc.hasStage(id) {
+			delete(p.m, name)
+		}
+	}
+	p.mtx.Unlock()
+}
+
+func (p *pipes) stats(id string) (stats []StageInfo) {
+	p.mtx.Lock()
+	for _, pc := range p.m {
+		stats = append(stats, pc.stats(id)...)
+	}
+	p.mtx.Unlock()
+	return
+}
+
+///////////
+// stash //
+///////////
+
+func (s *stash) add(r io.ReadCloser, size int64) (token string) {
+	token = stashPrefix + strconv.FormatUint(s.next.Inc(), 36)
+	s.mtx.Lock()
+	s.m[token] = &stashed{r: r, size: size}
+	s.mtx.Unlock()
+	return
+}
+
+func (s *stash) take(token string) (st *stashed) {
+	s.mtx.Lock()
+	if st = s.m[token]; st != nil {
+		delete(s.m, token)
+	}
+	s.mtx.Unlock()
+	return
+}
+
+// cleanup (noop if already fetched)
+func (s *stash) del(token string) {
+	if st := s.take(token); st != nil {
+		cos.Close(st.r)
+	}
+}
+
+// ServeStashed handles GET /v1/etl/_objects/<secret>/<stash token> - requests from
+// hpull:// and hrev:// transformers for the output of the previous pipeline stage.
+// Returns false if the request is not one of those.
+func ServeStashed(w http.ResponseWriter, r *http.Request) bool {
+	items, err := cmn.MatchRESTItems(r.URL.Path, 2, false, apc.URLPathETLObject.L)
+	if err != nil || !strings.HasPrefix(items[1], stashPrefix) {
+		return false
+	}
+	if err := CheckSecret(items[0]); err != nil {
+		cmn.WriteErr(w, r, err)
+		return true
+	}
+	st := stashes.take(items[1])
+	if st == nil {
+		cmn.WriteErr(w, r, cmn.NewErrNotFound("ETL pipeline input %q", items[1]), http.StatusNotFound)
+		return true
+	}
+	if st.size >= 0 {
+		w.Header().Set(cmn.HdrContentLength, strconv.FormatInt(st.size, 10))
+	}
+	if _, err := io.Copy(w, st.r); err != nil {
+		glog.Errorf("ETL pipeline input %q: %v", items[1], err)
+	}
+	cos.Close(st.r)
+	return true
+}
@@ -0,0 +1,156 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline", func() {
+	var (
+		upperServer   *httptest.Server // hpush://
+		reverseServer *httptest.Server // hrev://
+		targetServer  *httptest.Server
+	)
+
+	reverse := func(b []byte) []byte {
+		out := make([]byte, len(b))
+		for i := range b {
+			out[len(b)-1-i] = b[i]
+		}
+		return out
+	}
+
+	register := func(id string, c Communicator) {
+		Expect(reg.put(id, c)).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		upperServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			w.Write(append(bytes.ToUpper(b), r.URL.Query().Get("suffix")...))
+		}))
+		targetServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(ServeStashed(w, r)).To(BeTrue())
+		}))
+		reverseServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp, err := http.Get(targetServer.URL + apc.URLPathETLObject.Join(reqSecret) + r.URL.Path)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			b, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			w.Write(reverse(b))
+		}))
+
+		tMock := mock.NewTarget(nil)
+		base := baseComm{t: tMock, client: http.DefaultClient}
+		for _, id := range []string{"upper1", "upper2"} {
+			base.name, base.xctn = id, mock.NewXact(apc.ActETLInline)
+			register(id, &pushComm{baseComm: base, mem: tMock.PageMM(), uri: upperServer.URL})
+		}
+		base.name, base.xctn = "reverse", mock.NewXact(apc.ActETLInline)
+		register("reverse", &revProxyComm{baseComm: base, uri: reverseServer.URL})
+	})
+
+	AfterEach(func() {
+		for _, id := range []string{"upper1", "upper2", "reverse"} {
+			reg.removeByUUID(id)
+			pipelines.del(id)
+		}
+		upperServer.Close()
+		reverseServer.Close()
+		targetServer.Close()
+	})
+
+	It("should parse pipeline", func() {
+		Expect(IsPipeline("upper1")).To(BeFalse())
+		Expect(IsPipeline("upper1?suffix=1")).To(BeTrue())
+		Expect(IsPipeline("upper1,reverse")).To(BeTrue())
+
+		pipeline, err := ParsePipeline("upper1?suffix=!&a=b, reverse")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pipeline).To(Equal(Pipeline{
+			{ID: "upper1", Args: url.Values{"suffix": []string{"!"}, "a": []string{"b"}}},
+			{ID: "reverse"},
+		}))
+		Expect(pipeline.String()).To(Equal("upper1?a=b&suffix=%21,reverse"))
+
+		again, err := ParsePipeline(pipeline.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(pipeline))
+
+		for _, invalid := range []string{"", "upper1,", "up,reverse", "upper1?suffix=%zz"} {
+			_, err := ParsePipeline(invalid)
+			Expect(err).To(HaveOccurred(), invalid)
+		}
+	})
+
+	It("should chain stages and keep per-stage stats", func() {
+		c, err := GetCommunicator("upper1?suffix=-x,reverse,upper2", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			r, err := c.transform(&stageIn{r: io.NopCloser(strings.NewReader("abc")), size: 3}, nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("X-CBA"))
+		}
+		Expect(stashes.m).To(BeEmpty())
+		Expect(c.ObjCount()).To(BeEquivalentTo(2))
+		Expect(c.OutBytes()).To(BeEquivalentTo(10))
+
+		// same pipeline (canonical form)
+		same, err := GetCommunicator("upper1?suffix=-x, reverse,upper2", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(same).To(BeIdenticalTo(c))
+
+		for _, info := range List() {
+			switch info.ID {
+			case "upper1":
+				Expect(info.Stages).To(ConsistOf(StageInfo{
+					Pipeline: c.Name(), Stage: 0, ObjCount: 2, OutBytes: 10,
+				}))
+			case "reverse":
+				Expect(info.Stages).To(ConsistOf(StageInfo{
+					Pipeline: c.Name(), Stage: 1, ObjCount: 2, InBytes: 10, OutBytes: 10,
+				}))
+			}
+		}
+	})
+
+	It("should fail when stage fails or does not exist", func() {
+		_, err := GetCommunicator("upper1,nonexisting", nil)
+		Expect(err).To(HaveOccurred())
+
+		c, err := GetCommunicator("upper1,reverse", nil)
+		Expect(err).NotTo(HaveOccurred())
+		reverseServer.Close()
+		_, err = c.transform(&stageIn{r: io.NopCloser(strings.NewReader("abc")), size: 3}, nil, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("stage 1"))
+		Expect(stashes.m).To(BeEmpty())
+		Expect(pipelines.stats("reverse")[0].ErrCount).To(BeEquivalentTo(1))
+
+		// stopped ETL
+		reg.removeByUUID("reverse")
+		pipelines.del("reverse")
+		_, err = GetCommunicator("upper1,reverse", nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,645 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Local runtime
+//
+// When the cluster is not deployed in Kubernetes, each target runs its ETL
+// transformer(s) as local processes:
+// - InitCodeMsg: the code is served by the runtime's built-in server (see
+//   etl/runtime/python_server.py); dependencies, if any, get installed with pip
+//   into the ETL's working directory;
+// - InitProcMsg: user-supplied executable.
+//
+// Transformers listen on a Unix socket or loopback port (`AIS_ETL_SOCKET` or
+// `AIS_ETL_PORT` environment, respectively) and speak the same hpush:// and
+// hrev:// protocols as ETL pods. With io:// there's no server - the command gets
+// executed for each object (the object on its stdin, transformed object on stdout).
+//
+// The target supervises its transformers: restarts those that exit and those that
+// stop responding to health checks (GET /health). When cgroup v2 is available,
+// transformers are also confined by the configured CPU and memory limits.
+
+const (
+	ListenUnix = "unix"
+	ListenTCP  = "tcp"
+)
+
+const (
+	procReadyTimeout = time.Minute     // when InitMsgBase.WaitTimeout is not specified
+	procDepsTimeout  = 5 * time.Minute // ditto, to install dependencies
+	procHealthIval   = 10 * time.Second
+	procHealthFails  = 3 // consecutive failed health checks prior to restarting transformer
+	procMaxBackoff   = 30 * time.Second
+	procHealthyRun   = time.Minute  // running that long resets the restart backoff
+	procLogSize      = 64 * cos.KiB // (last) output of a transformer kept in memory
+	procErrSize      = 512          // (last) stderr of a failed io:// command to include in the error
+
+	procURI = "http://etl" // (not resolved - see procPool.dial)
+)
+
+// The only environment variables that transformers (and pip) inherit from the target; the rest
+// of the target's environment (e.g., backend credentials and AuthN secrets) is withheld -
+// ETL pods never receive it either. HOME is set to the ETL's working directory.
+var procEnvAllowed = []string{
+	"PATH", "LANG", "LC_ALL", "TZ", "TMPDIR",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", // (pip install)
+}
+
+type (
+	procPool struct {
+		t        cluster.Target
+		errCtx   *cmn.ETLErrorContext
+		commType string
+		opts     ProcOpts
+		dir      string // working directory
+		command  []string
+		env      []string
+		procs    []*proc
+		client   *http.Client
+		next     atomic.Uint32
+		stopCh   *cos.StopCh
+		wg       sync.WaitGroup
+
+		// io://
+		sema   *cos.Semaphore
+		cgroup string
+		logs   *logBuf
+	}
+	proc struct {
+		pool     *procPool
+		idx      int
+		network  string
+		addr     string
+		env      []string
+		cgroup   string
+		logs     *logBuf
+		health   *http.Client
+		mu       sync.Mutex
+		cmd      *exec.Cmd
+		done     chan struct{} // closed when the process exits
+		started  time.Time
+		waitErr  error
+		fails    int
+		healthy  atomic.Bool
+		restarts atomic.Int64
+	}
+
+	// keeps the tail of the output
+	logBuf struct {
+		mu  sync.Mutex
+		buf []byte
+		max int
+	}
+)
+
+// interface guard
+var _ io.Writer = (*logBuf)(nil)
+
+func InitProc(t cluster.Target, msg InitProcMsg) error {
+	return startProcs(t, &msg.InitMsgBase, &msg.ProcOpts, func(pp *procPool) error {
+		pp.command = msg.Command
+		for k, v := range msg.Env {
+			pp.env = append(pp.env, k+"="+v)
+		}
+		return nil
+	})
+}
+
+func initCodeProcs(t cluster.Target, msg *InitCodeMsg, interpreter, server string) error {
+	return startProcs(t, &msg.InitMsgBase, &msg.ProcOpts, func(pp *procPool) error {
+		if err := pp.writeFile("code.py", msg.Code); err != nil {
+			return err
+		}
+		if len(msg.Deps) > 0 {
+			if err := pp.writeFile("requirements.txt", msg.Deps); err != nil {
+				return err
+			}
+			timeout := procDepsTimeout
+			if msg.WaitTimeout != 0 {
+				timeout = time.Duration(msg.WaitTimeout)
+			}
+			if err := pp.run(timeout, interpreter, "-m", "pip", "install", "--target", "deps", "-r", "requirements.txt"); err != nil {
+				return cmn.NewErrETL(pp.errCtx, "failed to install dependencies: %v", err)
+			}
+		}
+		pp.env = append(pp.env, "MOD_NAME=code", "FUNC_HANDLER=transform", "PYTHONPATH="+filepath.Join(pp.dir, "deps"))
+		if msg.CommTypeX == IOCommType {
+			pp.command = []string{interpreter, "code.py"}
+			return nil
+		}
+		pp.command = []string{interpreter, "server.py"}
+		return pp.writeFile("server.py", []byte(server))
+	})
+}
+
+func startProcs(t cluster.Target, msg *InitMsgBase, opts *ProcOpts, setup func(pp *procPool) error) (err error) {
+	errCtx := &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.ID()}
+	if err = checkLocal(errCtx); err != nil {
+		return err
+	}
+	if !cos.StringInSlice(msg.CommType(), procCommTypes) {
+		return cmn.NewErrETL(errCtx, "communication type %q is not supported by the local runtime", msg.CommType())
+	}
+	dir := filepath.Join(cmn.GCO.Get().ConfigDir, apc.ETL, msg.ID())
+	if err = os.RemoveAll(dir); err == nil {
+		err = cos.CreateDir(dir)
+	}
+	if err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	pp := newProcPool(t, errCtx, msg.CommType(), opts, dir)
+	pp.env = append(pp.env, "AIS_TARGET_URL="+t.Snode().URL(cmn.NetPublic)+apc.URLPathETLObject.Join(reqSecret))
+
+	timeout := procReadyTimeout
+	if msg.WaitTimeout != 0 {
+		timeout = time.Duration(msg.WaitTimeout)
+	}
+	if err = setup(pp); err == nil {
+		err = pp.start(timeout)
+	}
+	if err != nil {
+		pp.stop()
+		return err
+	}
+
+	rns := xreg.RenewETL(t, msg)
+	if rns.Err != nil {
+		pp.stop()
+		return rns.Err
+	}
+	c := makeProcCommunicator(pp, newAborter(t, msg.ID()), rns.Entry.Get(), msg.ID())
+	if err = reg.put(msg.ID(), c); err != nil {
+		c.Stop()
+		return err
+	}
+	t.Sowner().Listeners().Reg(c)
+	return nil
+}
+
+// NOTE: the local runtime is used when not running in Kubernetes
+func isLocal() bool { return k8s.Detect() != nil }
+
+// IsLocal returns true if a given ETL runs as local processes - that is, executes
+// user-supplied code or commands on the target hosts (with no isolation other than
+// optional cgroup limits)
+func IsLocal(msg InitMsg) bool {
+	switch msg.(type) {
+	case *InitProcMsg:
+		return true
+	case *InitCodeMsg:
+		return isLocal()
+	default:
+		return false
+	}
+}
+
+// CheckRuntime returns an error if ETLs cannot run - neither in Kubernetes nor
+// as local processes (the latter must be explicitly enabled - see cmn.ETLConf)
+func CheckRuntime() error {
+	err := k8s.Detect()
+	if err != nil && cmn.GCO.Get().ETL.LocalRuntime {
+		return nil
+	}
+	return err
+}
+
+// CheckLocal returns an error if a given ETL would run as local processes while the
+// local runtime is disabled
+func CheckLocal(msg InitMsg) error {
+	if !IsLocal(msg) {
+		return nil
+	}
+	return checkLocal(&cmn.ETLErrorContext{UUID: msg.ID()})
+}
+
+func checkLocal(errCtx *cmn.ETLErrorContext) error {
+	if cmn.GCO.Get().ETL.LocalRuntime {
+		return nil
+	}
+	return cmn.NewErrETL(errCtx, "local (process) runtime is disabled - see configuration 'etl.local_runtime'")
+}
+
+//////////////
+// procPool //
+//////////////
+
+func newProcPool(t cluster.Target, errCtx *cmn.ETLErrorContext, commType string, opts *ProcOpts, dir string) *procPool {
+	return &procPool{
+		t:        t,
+		errCtx:   errCtx,
+		commType: commType,
+		opts:     *opts,
+		dir:      dir,
+		stopCh:   cos.NewStopCh(),
+	}
+}
+
+func (pp *procPool) String() string { return "etl[" + pp.errCtx.UUID + "]" }
+
+func (pp *procPool) writeFile(name string, b []byte) error {
+	return os.WriteFile(filepath.Join(pp.dir, name), b, cos.PermRWR)
+}
+
+// minimal environment (see procEnvAllowed) + ETL's own
+func (pp *procPool) environ() []string {
+	env := make([]string, 0, len(procEnvAllowed)+len(pp.env)+1)
+	for _, name := range procEnvAllowed {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	env = append(env, "HOME="+pp.dir)
+	return append(env, pp.env...)
+}
+
+// runs command to completion (in the working directory)
+func (pp *procPool) run(timeout time.Duration, name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = pp.dir
+	cmd.Env = pp.environ()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %v (%s)", name, err, tail(out, procErrSize))
+	}
+	return nil
+}
+
+func (pp *procPool) newCmd(ctx context.Context) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, pp.command[0], pp.command[1:]...)
+	cmd.Dir = pp.dir
+	cmd.Env = pp.environ()
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // to kill the entire group
+	return cmd
+}
+
+func (pp *procPool) start(timeout time.Duration) (err error) {
+	n := pp.opts.Procs
+	if pp.commType == IOCommType {
+		if n == 0 {
+			n = sys.NumCPU()
+		}
+		pp.sema = cos.NewSemaphore(n)
+		pp.logs = &logBuf{max: procLogSize}
+		pp.cgroup = pp.newCgroup(pp.errCtx.UUID)
+		return nil
+	}
+	if n == 0 {
+		n = 1
+	}
+	pp.procs = make([]*proc, 0, n)
+	for i := 0; i < n; i++ {
+		p := &proc{pool: pp, idx: i, logs: &logBuf{max: procLogSize}}
+		if err = p.init(); err != nil {
+			return cmn.NewErrETL(pp.errCtx, "%s: %v", p, err)
+		}
+		pp.procs = append(pp.procs, p)
+		if err = p.start(); err != nil {
+			return err
+		}
+	}
+	pp.client = &http.Client{Transport: &http.Transport{
+		DialContext:         pp.dial,
+		MaxIdleConnsPerHost: 4 * n,
+		IdleConnTimeout:     time.Minute,
+	}}
+
+	// wait for all to become ready
+	deadline := time.Now().Add(timeout)
+	for _, p := range pp.procs {
+		for !p.checkHealth() {
+			select {
+			case <-p.done:
+				return cmn.NewErrETL(pp.errCtx, "%s exited (%v): %s", p, p.waitErr, tail(p.logs.Bytes(), procErrSize))
+			case <-time.After(200 * time.Millisecond):
+			}
+			if time.Now().After(deadline) {
+				return cmn.NewErrETL(pp.errCtx, "timed out waiting for %s to become ready (%v)", p, timeout)
+			}
+		}
+		p.healthy.Store(true)
+	}
+
+	pp.wg.Add(len(pp.procs) + 1)
+	for _, p := range pp.procs {
+		go pp.supervise(p)
+	}
+	go pp.checkHealth()
+	return nil
+}
+
+func (pp *procPool) stop() {
+	pp.stopCh.Close()
+	for _, p := range pp.procs {
+		if done := p.kill(); done != nil {
+			<-done
+		}
+	}
+	pp.wg.Wait()
+	for _, p := range pp.procs {
+		removeCgroup(p.cgroup)
+	}
+	removeCgroup(pp.cgroup)
+	if err := os.RemoveAll(pp.dir); err != nil {
+		glog.Error(err)
+	}
+}
+
+// round-robin across healthy transformers
+func (pp *procPool) dial(ctx context.Context, _, _ string) (net.Conn, error) {
+	var (
+		n = uint32(len(pp.procs))
+		i = pp.next.Inc()
+		p = pp.procs[i%n]
+	)
+	for j := uint32(1); j < n && !p.healthy.Load(); j++ {
+		p = pp.procs[(i+j)%n]
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, p.network, p.addr)
+}
+
+func (pp *procPool) newCgroup(name string) string {
+	cg, err := newCgroup(k8s.CleanName(pp.t.SID()+"-"+name), &pp.opts)
+	if err != nil {
+		glog.Warningf("%s: running without resource limits: %v", pp, err)
+	}
+	return cg
+}
+
+// restarts the process when it exits
+func (pp *procPool) supervise(p *proc) {
+	defer pp.wg.Done()
+	for {
+		p.mu.Lock()
+		done, started := p.done, p.started
+		p.mu.Unlock()
+		select {
+		case <-done:
+		case <-pp.stopCh.Listen():
+			return
+		}
+		p.healthy.Store(false)
+		if time.Since(started) >= procHealthyRun {
+			p.restarts.Store(0)
+		}
+		glog.Errorf("%s: %s exited (%v) - restarting...", pp, p, p.waitErr)
+		for {
+			backoff := cos.MinDuration(time.Duration(p.restarts.Inc())*time.Second, procMaxBackoff)
+			select {
+			case <-time.After(backoff):
+			case <-pp.stopCh.Listen():
+				return
+			}
+			err := p.start()
+			if err == nil {
+				break
+			}
+			glog.Errorf("%s: %v", pp, err)
+		}
+	}
+}
+
+// periodically checks the health of all processes and kills those that do not respond
+// (to be restarted by the supervisor)
+func (pp *procPool) checkHealth() {
+	defer pp.wg.Done()
+	ticker := time.NewTicker(procHealthIval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-pp.stopCh.Listen():
+			return
+		}
+		for _, p := range pp.procs {
+			if p.checkHealth() {
+				p.fails = 0
+				p.healthy.Store(true)
+				continue
+			}
+			p.fails++
+			p.healthy.Store(false)
+			if p.fails >= procHealthFails {
+				glog.Errorf("%s: %s is not responding - killing it", pp, p)
+				p.fails = 0
+				p.kill()
+			}
+		}
+	}
+}
+
+// io:// (pipeline stage arguments, if any, are passed via `AIS_ETL_ARGS` environment)
+func (pp *procPool) exec(stdin io.Reader, stdout io.Writer, args url.Values, timeout time.Duration) error {
+	pp.sema.Acquire()
+	defer pp.sema.Release()
+	ctx := context.Background()
+	if timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var (
+		cmd    = pp.newCmd(ctx)
+		stderr = &logBuf{max: procErrSize}
+	)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, io.MultiWriter(pp.logs, stderr)
+	if len(args) > 0 {
+		cmd.Env = append(cmd.Env, "AIS_ETL_ARGS="+args.Encode())
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if pp.cgroup != "" {
+		if err := cgroupAdd(pp.cgroup, cmd.Process.Pid); err != nil {
+			glog.Errorf("%s: %v", pp, err)
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%q: %v (%s)", strings.Join(pp.command, " "), err, stderr.Bytes())
+	}
+	return nil
+}
+
+func (pp *procPool) getLogs() []byte {
+	if pp.logs != nil {
+		return pp.logs.Bytes()
+	}
+	var b []byte
+	for _, p := range pp.procs {
+		b = append(b, fmt.Sprintf("--- %s (restarts: %d) ---\n", p, p.restarts.Load())...)
+		b = append(b, p.logs.Bytes()...)
+	}
+	return b
+}
+
+func (pp *procPool) getHealth() (cpuCores float64, mem int64, err error) {
+	for _, p := range pp.procs {
+		if !p.healthy.Load() {
+			continue // (restarting)
+		}
+		p.mu.Lock()
+		pid := p.cmd.Process.Pid
+		p.mu.Unlock()
+		stats, err := sys.ProcessStats(pid)
+		if err != nil {
+			return 0, 0, err
+		}
+		cpuCores += stats.CPU.Percent / 100
+		mem += int64(stats.Mem.Resident)
+	}
+	return
+}
+
+//////////
+// proc //
+//////////
+
+func (p *proc) String() string { return "transformer #" + strconv.Itoa(p.idx) }
+
+func (p *proc) init() error {
+	pp := p.pool
+	if pp.opts.Listen == ListenTCP {
+		// NOTE: the port is (re)used by the process and its restarts
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return err
+		}
+		p.network, p.addr = "tcp", l.Addr().String()
+		l.Close()
+		p.env = []string{"AIS_ETL_PORT=" + strconv.Itoa(l.Addr().(*net.TCPAddr).Port)}
+	} else {
+		p.network, p.addr = "unix", filepath.Join(pp.dir, "etl-"+strconv.Itoa(p.idx)+".sock")
+		p.env = []string{"AIS_ETL_SOCKET=" + p.addr}
+	}
+	p.health = &http.Client{
+		Timeout: cmn.Timeout.MaxKeepalive(),
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, p.network, p.addr)
+			},
+			DisableKeepAlives: true,
+		},
+	}
+	p.cgroup = pp.newCgroup(pp.errCtx.UUID + "-" + strconv.Itoa(p.idx))
+	return nil
+}
+
+func (p *proc) start() error {
+	pp := p.pool
+	if p.network == "unix" {
+		os.Remove(p.addr) // stale socket, if any
+	}
+	cmd := pp.newCmd(context.Background())
+	cmd.Env = append(cmd.Env, p.env...)
+	cmd.Stdout, cmd.Stderr = p.logs, p.logs
+
+	// (serialized with kill - see procPool.stop)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-pp.stopCh.Listen():
+		return cmn.NewErrETL(pp.errCtx, "%s: stopped", p)
+	default:
+	}
+	if err := cmd.Start(); err != nil {
+		return cmn.NewErrETL(pp.errCtx, "failed to start %s: %v", p, err)
+	}
+	if p.cgroup != "" {
+		if err := cgroupAdd(p.cgroup, cmd.Process.Pid); err != nil {
+			glog.Errorf("%s: %v", pp, err)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		p.waitErr = cmd.Wait()
+		close(done)
+	}()
+	p.cmd, p.done, p.started = cmd, done, time.Now()
+	return nil
+}
+
+// kills the process (and its children, if any); returns channel that gets closed
+// upon exit (nil if never started)
+func (p *proc) kill() (done chan struct{}) {
+	p.mu.Lock()
+	if p.cmd != nil {
+		select {
+		case <-p.done:
+			// already exited (and reaped) - the pid may have been reused
+		default:
+			syscall.Kill(-p.cmd.Process.Pid, syscall.SIGKILL)
+		}
+	}
+	done = p.done
+	p.mu.Unlock()
+	return
+}
+
+func (p *proc) checkHealth() bool {
+	resp, err := p.health.Get(procURI + "/health")
+	if err != nil {
+		return false
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+////////////
+// logBuf //
+////////////
+
+func (lb *logBuf) Write(b []byte) (int, error) {
+	lb.mu.Lock()
+	lb.buf = append(lb.buf, b...)
+	if over := len(lb.buf) - lb.max; over > 0 {
+		lb.buf = append(lb.buf[:0], lb.buf[over:]...)
+	}
+	lb.mu.Unlock()
+	return len(b), nil
+}
+
+func (lb *logBuf) Bytes() []byte {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return append([]byte(nil), lb.buf...)
+}
+
+func tail(b []byte, size int) []byte {
+	if len(b) > size {
+		return b[len(b)-size:]
+	}
+	return b
+}
@@ -0,0 +1,181 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/etl/runtime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalRuntime", func() {
+	const code = "def transform(data):\n    return data.upper()\n"
+
+	var (
+		dir string
+		pp  *procPool
+	)
+
+	newPool := func(commType string, opts *ProcOpts) *procPool {
+		errCtx := &cmn.ETLErrorContext{TID: "mock-id", UUID: "local-etl"}
+		return newProcPool(mock.NewTarget(nil), errCtx, commType, opts, dir)
+	}
+
+	// see initCodeProcs
+	newPyPool := func(commType string, opts *ProcOpts) *procPool {
+		if _, err := exec.LookPath("python3"); err != nil {
+			Skip("python3 is not installed")
+		}
+		pp := newPool(commType, opts)
+		Expect(pp.writeFile("code.py", []byte(code))).NotTo(HaveOccurred())
+		Expect(pp.writeFile("server.py", []byte(runtime.Runtimes[runtime.Python3].Server()))).NotTo(HaveOccurred())
+		pp.env = []string{"MOD_NAME=code", "FUNC_HANDLER=transform"}
+		pp.command = []string{"python3", "server.py"}
+		return pp
+	}
+
+	transform := func(data string) string {
+		req, err := http.NewRequest(http.MethodPut, procURI, strings.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := pp.client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "etl-local")
+		Expect(err).NotTo(HaveOccurred())
+		pp = nil
+	})
+
+	AfterEach(func() {
+		if pp != nil {
+			pp.stop()
+			Expect(dir).NotTo(BeADirectory())
+		}
+		os.RemoveAll(dir)
+	})
+
+	for _, listen := range []string{ListenUnix, ListenTCP} {
+		listen := listen
+		It("should start transformers and serve hpush requests over "+listen, func() {
+			pp = newPyPool(PushCommType, &ProcOpts{Procs: 2, Listen: listen})
+			Expect(pp.start(time.Minute)).NotTo(HaveOccurred())
+			Expect(pp.procs).To(HaveLen(2))
+			for i := 0; i < 4; i++ {
+				Expect(transform("hello")).To(Equal("HELLO"))
+			}
+			cpu, mem, err := pp.getHealth()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cpu).To(BeNumerically(">=", 0))
+			Expect(mem).To(BeNumerically(">", 0))
+		})
+	}
+
+	It("should restart transformer that exits", func() {
+		pp = newPyPool(PushCommType, &ProcOpts{})
+		Expect(pp.start(time.Minute)).NotTo(HaveOccurred())
+		p := pp.procs[0]
+
+		<-p.kill()
+		Eventually(p.restarts.Load, 10*time.Second, 100*time.Millisecond).Should(BeEquivalentTo(1))
+		Eventually(p.checkHealth, 10*time.Second, 100*time.Millisecond).Should(BeTrue())
+		Expect(transform("restarted")).To(Equal("RESTARTED"))
+	})
+
+	It("should fail to start transformer that exits", func() {
+		pp = newPool(PushCommType, &ProcOpts{})
+		pp.command = []string{"sh", "-c", "echo no-such-server >&2; exit 1"}
+		err := pp.start(time.Minute)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no-such-server"))
+	})
+
+	It("should execute io:// command for each object", func() {
+		pp = newPool(IOCommType, &ProcOpts{Procs: 2})
+		pp.command = []string{"tr", "a-z", "A-Z"}
+		Expect(pp.start(time.Minute)).NotTo(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(pp.exec(strings.NewReader("object"), out, nil, 0)).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal("OBJECT"))
+
+		pp.command = []string{"sh", "-c", "echo failed-to-transform >&2; exit 2"}
+		err := pp.exec(strings.NewReader("object"), io.Discard, nil, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed-to-transform"))
+		Expect(string(pp.getLogs())).To(ContainSubstring("failed-to-transform"))
+	})
+
+	It("should not pass target's environment to transformers", func() {
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "target-secret")
+		defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+		pp = newPool(IOCommType, &ProcOpts{})
+		pp.env = []string{"LEVEL=1"}
+		pp.command = []string{"sh", "-c", "env"}
+		Expect(pp.start(time.Minute)).NotTo(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(pp.exec(strings.NewReader(""), out, nil, 0)).NotTo(HaveOccurred())
+		Expect(out.String()).To(ContainSubstring("LEVEL=1"))
+		Expect(out.String()).To(ContainSubstring("HOME=" + dir))
+		Expect(out.String()).NotTo(ContainSubstring("target-secret"))
+	})
+
+	It("should validate local runtime options", func() {
+		msg := &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "local-etl"}, Command: []string{"./transformer"}}
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+		Expect(msg.CommTypeX).To(Equal(PushCommType))
+		Expect(msg.InitType()).To(Equal(apc.ETLInitProc))
+
+		msg.CommTypeX = RedirectCommType
+		Expect(msg.Validate()).To(HaveOccurred())
+		msg.CommTypeX = IOCommType
+		msg.Listen = "pipe"
+		Expect(msg.Validate()).To(HaveOccurred())
+		msg.Listen = ListenTCP
+		msg.CPU = -1
+		Expect(msg.Validate()).To(HaveOccurred())
+		msg.CPU = 0.5
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+
+		msg.Command = nil
+		Expect(msg.Validate()).To(HaveOccurred())
+	})
+
+	It("should require local runtime to be enabled", func() {
+		setLocal := func(enabled bool) {
+			config := cmn.GCO.BeginUpdate()
+			config.ETL.LocalRuntime = enabled
+			cmn.GCO.CommitUpdate(config)
+		}
+		defer setLocal(false)
+
+		msg := &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "local-etl"}, Command: []string{"./transformer"}}
+		Expect(IsLocal(msg)).To(BeTrue())
+		Expect(IsLocal(&InitSpecMsg{})).To(BeFalse())
+		setLocal(false)
+		Expect(CheckLocal(msg)).To(HaveOccurred())
+		Expect(startProcs(mock.NewTarget(nil), &msg.InitMsgBase, &msg.ProcOpts, nil)).To(HaveOccurred())
+		setLocal(true)
+		Expect(CheckLocal(msg)).NotTo(HaveOccurred())
+	})
+})
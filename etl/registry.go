@@ -27,7 +27,11 @@ const (
 	IOCommType = "io://"
 )
 
-var commTypes = []string{PushCommType, RedirectCommType, RevProxyCommType, IOCommType}
+var (
+	commTypes = []string{PushCommType, RedirectCommType, RevProxyCommType, IOCommType}
+	// local runtime: transformers are not reachable from outside the target (no redirects)
+	procCommTypes = []string{PushCommType, RevProxyCommType, IOCommType}
+)
 
 type (
 	registry struct {
@@ -88,6 +92,10 @@ func (r *registry) list() []Info {
 		})
 	}
 	r.mtx.RUnlock()
+	// (not holding the lock - see pipes.get)
+	for i := range etls {
+		etls[i].Stages = pipelines.stats(etls[i].ID)
+	}
 	return etls
 }
 
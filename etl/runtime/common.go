@@ -21,6 +21,10 @@ type (
 		PodSpec() string
 		CodeEnvName() string
 		DepsEnvName() string
+
+		// local (Kubernetes-free) runtime
+		Interpreter() string
+		Server() string
 	}
 )
 
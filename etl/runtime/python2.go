@@ -17,3 +17,5 @@ func (py2) Type() string        { return Python2 }
 func (py2) CodeEnvName() string { return "AISTORE_CODE" }
 func (py2) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py2) PodSpec() string     { return strings.ReplaceAll(pyPodSpec, "<VERSION>", "2") }
+func (py2) Interpreter() string { return "python2" }
+func (py2) Server() string      { return pyServer }
@@ -17,3 +17,5 @@ func (py310) Type() string        { return Python310 }
 func (py310) CodeEnvName() string { return "AISTORE_CODE" }
 func (py310) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py310) PodSpec() string     { return strings.ReplaceAll(pyPodSpec, "<VERSION>", "3.10") }
+func (py310) Interpreter() string { return "python3.10" }
+func (py310) Server() string      { return pyServer }
@@ -17,3 +17,5 @@ func (py36) Type() string        { return Python36 }
 func (py36) CodeEnvName() string { return "AISTORE_CODE" }
 func (py36) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py36) PodSpec() string     { return strings.ReplaceAll(pyPodSpec, "<VERSION>", "3.6") }
+func (py36) Interpreter() string { return "python3.6" }
+func (py36) Server() string      { return pyServer }
@@ -17,3 +17,5 @@ func (py38) Type() string        { return Python38 }
 func (py38) CodeEnvName() string { return "AISTORE_CODE" }
 func (py38) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py38) PodSpec() string     { return strings.ReplaceAll(pyPodSpec, "<VERSION>", "3.8") }
+func (py38) Interpreter() string { return "python3.8" }
+func (py38) Server() string      { return pyServer }
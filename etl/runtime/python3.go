@@ -17,3 +17,5 @@ func (py3) Type() string        { return Python3 }
 func (py3) CodeEnvName() string { return "AISTORE_CODE" }
 func (py3) DepsEnvName() string { return "AISTORE_DEPS" }
 func (py3) PodSpec() string     { return strings.ReplaceAll(pyPodSpec, "<VERSION>", "3") }
+func (py3) Interpreter() string { return "python3" }
+func (py3) Server() string      { return pyServer }
@@ -8,3 +8,6 @@ import _ "embed"
 
 //go:embed python_common.yaml
 var pyPodSpec string
+
+//go:embed python_server.py
+var pyServer string
@@ -0,0 +1,97 @@
+#
+# Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+#
+# Transforming server of the local (Kubernetes-free) ETL runtime: loads
+# `FUNC_HANDLER` from the `MOD_NAME` module and serves hpush:// (PUT /) and
+# hrev:// (GET /<bucket/object>) requests on a Unix socket (`AIS_ETL_SOCKET`)
+# or loopback port (`AIS_ETL_PORT`), whichever is set.
+#
+# Pipeline stage arguments (URL query parameters), if any, are passed on to the
+# function as keyword arguments - those that the function accepts.
+#
+import importlib
+import inspect
+import os
+
+try:
+    from http.server import BaseHTTPRequestHandler, HTTPServer
+    from socketserver import ThreadingMixIn, UnixStreamServer
+    from urllib.parse import parse_qsl
+    from urllib.request import urlopen
+except ImportError:  # python2
+    from BaseHTTPServer import BaseHTTPRequestHandler, HTTPServer
+    from SocketServer import ThreadingMixIn, UnixStreamServer
+    from urllib2 import urlopen
+    from urlparse import parse_qsl
+
+transform = getattr(importlib.import_module(os.environ["MOD_NAME"]), os.environ["FUNC_HANDLER"])
+target_url = os.environ.get("AIS_TARGET_URL", "")
+
+try:
+    spec = inspect.getfullargspec(transform)
+except AttributeError:  # python2
+    spec = inspect.getargspec(transform)
+arg_names, any_args = spec[0][1:], spec[2] is not None
+
+
+def stage_args(path):
+    if "?" not in path:
+        return {}
+    args = parse_qsl(path.split("?", 1)[1])
+    return dict((k, v) for k, v in args if any_args or k in arg_names)
+
+
+class Handler(BaseHTTPRequestHandler):
+    protocol_version = "HTTP/1.1"
+
+    def do_PUT(self):
+        length = int(self.headers.get("Content-Length", 0))
+        self._transform(self.rfile.read(length))
+
+    def do_GET(self):
+        path = self.path.split("?", 1)[0]
+        if path == "/health":
+            self._reply(200, b"OK")
+            return
+        try:
+            data = urlopen(target_url + path).read()
+        except Exception as e:
+            self._reply(502, str(e).encode())
+            return
+        self._transform(data)
+
+    def _transform(self, data):
+        try:
+            out = transform(data, **stage_args(self.path))
+        except Exception as e:
+            self._reply(500, str(e).encode())
+            return
+        self._reply(200, out)
+
+    def _reply(self, code, body):
+        self.send_response(code)
+        self.send_header("Content-Length", str(len(body)))
+        self.end_headers()
+        self.wfile.write(body)
+
+    def address_string(self):
+        return "local"
+
+    def log_request(self, code="-", size="-"):
+        pass  # errors are still logged (stderr)
+
+
+class UnixServer(ThreadingMixIn, UnixStreamServer):
+    daemon_threads = True
+
+
+class TCPServer(ThreadingMixIn, HTTPServer):
+    daemon_threads = True
+
+
+if __name__ == "__main__":
+    if os.environ.get("AIS_ETL_SOCKET"):
+        server = UnixServer(os.environ["AIS_ETL_SOCKET"], Handler)
+    else:
+        server = TCPServer(("127.0.0.1", int(os.environ["AIS_ETL_PORT"])), Handler)
+    server.serve_forever()
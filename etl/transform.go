@@ -147,6 +147,10 @@ func (e *Aborter) ListenSmapChanged() {
 }
 
 func InitSpec(t cluster.Target, msg InitSpecMsg, opts StartOpts) (err error) {
+	if err := k8s.Detect(); err != nil {
+		return cmn.NewErrETL(&cmn.ETLErrorContext{TID: t.SID(), UUID: msg.ID()},
+			"pod spec requires Kubernetes (%v)", err)
+	}
 	errCtx, podName, svcName, err := tryStart(t, msg, opts)
 	if err != nil {
 		glog.Warning(cmn.NewErrETL(errCtx, "Performing cleanup after unsuccessful Start"))
@@ -163,6 +167,10 @@ func InitCode(t cluster.Target, msg InitCodeMsg) error {
 	r, exists := runtime.Runtimes[msg.Runtime]
 	cos.Assert(exists) // Runtime should be checked in proxy during validation.
 
+	if isLocal() {
+		return initCodeProcs(t, &msg, r.Interpreter(), r.Server())
+	}
+
 	var (
 		// We clean up the `msg.ID` as K8s doesn't allow `_` and uppercase
 		// letters in the names.
@@ -367,6 +375,10 @@ func Stop(t cluster.Target, id string, errCause error) error {
 		UUID: id,
 	}
 
+	if IsPipeline(id) {
+		return cmn.NewErrETL(errCtx, "cannot stop pipeline (stop its ETLs instead)")
+	}
+
 	// Abort any running offline ETLs.
 	xreg.AbortAll(errCause, apc.ActETLBck)
 
@@ -377,13 +389,16 @@ func Stop(t cluster.Target, id string, errCause error) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
-		return err
+	if c.procs() == nil {
+		if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+			return err
+		}
 	}
 
 	if c := reg.removeByUUID(id); c != nil {
 		t.Sowner().Listeners().Unreg(c)
 	}
+	pipelines.del(id)
 
 	c.Stop()
 
@@ -392,9 +407,6 @@ func Stop(t cluster.Target, id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll(t cluster.Target) {
-	if k8s.Detect() != nil {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(t, e.ID, nil); err != nil {
 			glog.Error(err)
@@ -402,7 +414,16 @@ func StopAll(t cluster.Target) {
 	}
 }
 
+// GetCommunicator returns the ETL's communicator or, if the ID is a pipeline
+// (see Pipeline), the communicator that chains the pipeline's ETLs.
 func GetCommunicator(transformID string, lsnode *cluster.Snode) (Communicator, error) {
+	if IsPipeline(transformID) {
+		pipeline, err := ParsePipeline(transformID)
+		if err != nil {
+			return nil, err
+		}
+		return pipelines.get(pipeline)
+	}
 	c, exists := reg.getByUUID(transformID)
 	if !exists {
 		return nil, cmn.NewErrNotFound("%s: ETL %q", lsnode, transformID)
@@ -417,6 +438,9 @@ func PodLogs(t cluster.Target, transformID string) (logs PodLogsMsg, err error)
 	if err != nil {
 		return logs, err
 	}
+	if pp := c.procs(); pp != nil {
+		return PodLogsMsg{TargetID: t.SID(), Logs: pp.getLogs()}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -439,6 +463,13 @@ func PodHealth(t cluster.Target, etlID string) (stats *PodHealthMsg, err error)
 	if c, err = GetCommunicator(etlID, t.Snode()); err != nil {
 		return
 	}
+	if pp := c.procs(); pp != nil {
+		cpuUsed, memUsed, err := pp.getHealth()
+		if err != nil {
+			return nil, err
+		}
+		return &PodHealthMsg{TargetID: t.SID(), CPU: cpuUsed, Mem: memUsed}, nil
+	}
 	if client, err = k8s.GetClient(); err != nil {
 		return
 	}
@@ -0,0 +1,99 @@
+// Package events provides bucket event notifications: targets send (POST) events - object
+// created, appended, deleted, restored, and downloaded - to HTTP webhook endpoints
+// configured on a per-bucket basis (see cmn.EventsConf).
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/sse"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Delivery
+//
+// Events are queued on the target that generates them - one queue per webhook URL.
+// Each queued event is first persisted in the target's local database, so that
+// undelivered events survive restarts. Events are sent in batches (Batch) of up to
+// `webhook.batch_size` events, or whatever's accumulated during `webhook.batch_time`.
+// Any response other than 2xx is a failure, in which case the batch gets retried
+// (with exponential backoff) up to `webhook.max_retries` times; client errors (4xx,
+// other than 408 and 429) are not retried. Delivery is at-least-once: receivers
+// should use event IDs to deduplicate. The queues are implemented by the backlog package.
+//
+// When the number of undelivered events for a given webhook URL reaches `webhook.max_queue`
+// new events are dropped; batches that fail permanently or exceed the retries are dropped
+// as well (all counted, see stats.WebhookDropCount).
+
+type (
+	// Event is a single bucket event
+	Event struct {
+		ID      string `json:"id"`     // unique: <target ID>-<sequence number>
+		Kind    string `json:"event"`  // enum { cmn.Ev* }
+		Bucket  string `json:"bucket"` // e.g. "ais://abc"
+		ObjName string `json:"name"`
+		Size    int64  `json:"size,omitempty"`
+		Version string `json:"version,omitempty"`
+		Time    string `json:"time"` // RFC 3339
+	}
+	// Batch is the body of each webhook request
+	Batch struct {
+		Events []*Event `json:"events"`
+	}
+)
+
+// global dispatcher
+var gd *dispatcher
+
+func Init(tid string, db dbdriver.Driver, statsT stats.Tracker) {
+	gd = newDispatcher(tid, db, statsT, cmn.NewClient(cmn.TransportArgs{UseHTTPS: true}))
+	if err := gd.load(); err != nil {
+		glog.Errorf("%s: failed to load undelivered events: %v", tid, err)
+	}
+}
+
+func Stop() {
+	if gd != nil {
+		gd.stop()
+	}
+}
+
+// Emit generates and queues the event if the object's bucket has it configured
+func Emit(kind string, lom *cluster.LOM) {
+	if gd == nil {
+		return
+	}
+	bck := lom.Bck()
+	if bck.Props == nil || !bck.Props.Events.Enabled {
+		return
+	}
+	var ev *Event
+	for i := range bck.Props.Events.Webhooks {
+		wh := &bck.Props.Events.Webhooks[i]
+		if !wh.Match(kind, lom.ObjName) {
+			continue
+		}
+		if ev == nil {
+			ev = &Event{
+				Kind:    kind,
+				Bucket:  bck.Bucket().String(),
+				ObjName: lom.ObjName,
+				Time:    time.Now().UTC().Format(time.RFC3339Nano),
+			}
+			if kind != cmn.EvDelete {
+				ev.Size, ev.Version = sse.Size(lom), lom.Version()
+			}
+		}
+		gd.enqueue(wh.URL, *ev) // (copy)
+	}
+}
+
+func eventID(tid string, seq int64) string { return tid + "-" + strconv.FormatInt(seq, 10) }
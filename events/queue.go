@@ -0,0 +1,120 @@
+// Package events provides bucket event notifications.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/backlog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+const dbCollection = "events"
+
+type (
+	dispatcher struct {
+		q      *backlog.Queue
+		statsT stats.Tracker
+		client *http.Client
+		tid    string
+	}
+	// undelivered event (as stored in the db)
+	record struct {
+		URL   string `json:"url"`
+		Event Event  `json:"event"`
+	}
+)
+
+func newDispatcher(tid string, db dbdriver.Driver, statsT stats.Tracker, client *http.Client) *dispatcher {
+	d := &dispatcher{statsT: statsT, client: client, tid: tid}
+	d.q = backlog.New(&backlog.Args{
+		DB:         db,
+		Collection: dbCollection,
+		Tag:        tid,
+		Conf:       d.conf,
+		New:        func() interface{} { return &record{} },
+		// one queue (and one worker) per webhook URL
+		Route: func(v interface{}) (string, string) { return v.(*record).URL, "" },
+		Exec:  d.send,
+		Stamp: func(v interface{}, seq int64) { v.(*record).Event.ID = eventID(tid, seq) },
+		Done:  func(recs []interface{}) { d.statsAdd(stats.WebhookCount, int64(len(recs))) },
+		Drop:  func(recs []interface{}, _ error) { d.statsAdd(stats.WebhookDropCount, int64(len(recs))) },
+		Error: func(error) { d.statsAdd(stats.ErrWebhookCount, 1) },
+	})
+	return d
+}
+
+func (*dispatcher) conf() backlog.Conf {
+	conf := cmn.GCO.Get().Webhook.Defaults()
+	return backlog.Conf{
+		MaxQueue:   conf.MaxQueue,
+		MaxRetries: conf.MaxRetries,
+		BatchSize:  conf.BatchSize,
+		BatchTime:  conf.BatchTime.D(),
+	}
+}
+
+// load undelivered events (upon startup)
+func (d *dispatcher) load() error {
+	n, err := d.q.Load()
+	if n > 0 {
+		glog.Infof("%s: loaded %d undelivered event%s", d.tid, n, cos.Plural(n))
+	}
+	return err
+}
+
+func (d *dispatcher) enqueue(url string, ev Event) {
+	if !d.q.Push(&record{URL: url, Event: ev}) {
+		d.statsAdd(stats.WebhookDropCount, 1)
+	}
+}
+
+func (d *dispatcher) stop() { d.q.Stop() }
+
+func (d *dispatcher) statsAdd(name string, val int64) {
+	if d.statsT != nil {
+		d.statsT.Add(name, val)
+	}
+}
+
+// send POSTs a batch of events to a given webhook URL; client errors (other than
+// timeouts and throttling) are permanent - the batch won't be retried
+func (d *dispatcher) send(url string, recs []interface{}) error {
+	batch := Batch{Events: make([]*Event, len(recs))}
+	for i, v := range recs {
+		batch.Events[i] = &v.(*record).Event
+	}
+	body := cos.MustMarshal(batch)
+	ctx, cancel := context.WithTimeout(context.Background(), cmn.GCO.Get().Webhook.Defaults().Timeout.D())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return backlog.Permanent(err)
+	}
+	req.Header.Set(cmn.HdrContentType, cmn.ContentJSON)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	err = fmt.Errorf("%s: %s", url, resp.Status)
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		err = backlog.Permanent(err)
+	}
+	return err
+}
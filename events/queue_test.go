@@ -0,0 +1,180 @@
+// Package events provides bucket event notifications.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type receiver struct {
+	srv     *httptest.Server
+	fail    atomic.Int32 // number of requests to fail
+	mu      sync.Mutex
+	batches []Batch
+}
+
+func init() {
+	config := cmn.GCO.BeginUpdate()
+	config.Webhook.BatchTime = cos.Duration(10 * time.Millisecond)
+	config.Webhook.BatchSize = 4
+	config.Webhook.Timeout = cos.Duration(time.Second)
+	cmn.GCO.CommitUpdate(config)
+}
+
+func newReceiver() *receiver {
+	rcv := &receiver{}
+	rcv.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rcv.fail.Dec() >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch Batch
+		if err := jsoniter.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rcv.mu.Lock()
+		rcv.batches = append(rcv.batches, batch)
+		rcv.mu.Unlock()
+	}))
+	return rcv
+}
+
+func (rcv *receiver) events() (evs []*Event) {
+	rcv.mu.Lock()
+	for _, batch := range rcv.batches {
+		evs = append(evs, batch.Events...)
+	}
+	rcv.mu.Unlock()
+	return
+}
+
+func (rcv *receiver) wait(t *testing.T, n int) []*Event {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if evs := rcv.events(); len(evs) >= n {
+			return evs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d events (received %d)", n, len(rcv.events()))
+	return nil
+}
+
+func newDB(t *testing.T, dir string) dbdriver.Driver {
+	db, err := dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+	return db
+}
+
+func TestDispatcherBatching(t *testing.T) {
+	rcv := newReceiver()
+	defer rcv.srv.Close()
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	d := newDispatcher("t1", db, nil, rcv.srv.Client())
+	for i := 0; i < 10; i++ {
+		d.enqueue(rcv.srv.URL, Event{Kind: cmn.EvPut, Bucket: "ais://b", ObjName: "o" + strconv.Itoa(i)})
+	}
+	evs := rcv.wait(t, 10)
+	d.stop()
+
+	for i, ev := range evs {
+		tassert.Errorf(t, ev.ObjName == "o"+strconv.Itoa(i), "out of order: %d => %s", i, ev.ObjName)
+		tassert.Errorf(t, ev.ID == eventID("t1", int64(i+1)), "unexpected ID %q", ev.ID)
+	}
+	rcv.mu.Lock()
+	for _, batch := range rcv.batches {
+		tassert.Errorf(t, len(batch.Events) <= 4, "batch size %d exceeds 4", len(batch.Events))
+	}
+	rcv.mu.Unlock()
+	keys, err := db.List(dbCollection, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected delivered events to be removed, got %d", len(keys))
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	rcv := newReceiver()
+	defer rcv.srv.Close()
+	rcv.fail.Store(1)
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	d := newDispatcher("t1", db, nil, rcv.srv.Client())
+	d.enqueue(rcv.srv.URL, Event{Kind: cmn.EvDelete, Bucket: "ais://b", ObjName: "o"})
+	evs := rcv.wait(t, 1)
+	d.stop()
+	tassert.Errorf(t, len(evs) == 1 && evs[0].Kind == cmn.EvDelete, "unexpected events %+v", evs)
+}
+
+func TestDispatcherPersistence(t *testing.T) {
+	rcv := newReceiver()
+	defer rcv.srv.Close()
+	rcv.fail.Store(1 << 20) // receiver "down"
+	dir := t.TempDir()
+
+	db := newDB(t, dir)
+	d := newDispatcher("t1", db, nil, rcv.srv.Client())
+	for i := 0; i < 3; i++ {
+		d.enqueue(rcv.srv.URL, Event{Kind: cmn.EvPut, Bucket: "ais://b", ObjName: "o" + strconv.Itoa(i)})
+	}
+	d.stop()
+	tassert.CheckFatal(t, db.Close())
+
+	// "restart"
+	rcv.fail.Store(0)
+	db = newDB(t, dir)
+	defer db.Close()
+	d = newDispatcher("t1", db, nil, rcv.srv.Client())
+	tassert.CheckFatal(t, d.load())
+	evs := rcv.wait(t, 3)
+	for i, ev := range evs {
+		tassert.Errorf(t, ev.ObjName == "o"+strconv.Itoa(i), "out of order: %d => %s", i, ev.ObjName)
+	}
+	// sequence numbers continue where they left off
+	d.enqueue(rcv.srv.URL, Event{Kind: cmn.EvPut, Bucket: "ais://b", ObjName: "o3"})
+	evs = rcv.wait(t, 4)
+	d.stop()
+	tassert.Errorf(t, evs[3].ID == eventID("t1", 4), "unexpected ID %q", evs[3].ID)
+}
+
+// client errors are not retried, and a failing webhook does not hold up the others
+func TestDispatcherPermanent(t *testing.T) {
+	rcv := newReceiver()
+	defer rcv.srv.Close()
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer dead.Close()
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	d := newDispatcher("t1", db, nil, rcv.srv.Client())
+	d.enqueue(dead.URL, Event{Kind: cmn.EvPut, Bucket: "ais://b", ObjName: "o0"})
+	d.enqueue(rcv.srv.URL, Event{Kind: cmn.EvPut, Bucket: "ais://b", ObjName: "o1"})
+	rcv.wait(t, 1)
+	deadline := time.Now().Add(10 * time.Second)
+	for d.q.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.stop()
+	keys, err := db.List(dbCollection, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected undeliverable event to be dropped, got %d", len(keys))
+}
@@ -37,6 +37,7 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	ObjVerType   = "ov" // previous (non-current) object versions
 )
 
 type (
@@ -167,6 +168,7 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	ObjVerContentResolver   struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -235,3 +237,33 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// NOTE: previous object versions (see versioning.max_history) are stored next to (on the same
+// mountpath as) the current version; resilvering moves them along with the object, while
+// the ones that got misplaced by rebalance are removed by the storage cleanup.
+func (*ObjVerContentResolver) PermToMove() bool    { return true }
+func (*ObjVerContentResolver) PermToEvict() bool   { return false }
+func (*ObjVerContentResolver) PermToProcess() bool { return false }
+
+func (*ObjVerContentResolver) GenUniqueFQN(base, ver string) string { return base + objVerSepa + ver }
+
+func (*ObjVerContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = ParseObjVer(base)
+	return
+}
+
+const objVerSepa = ".v"
+
+// ParseObjVer splits the base name of a previous object version into the object's
+// (original) base name and the version.
+func ParseObjVer(base string) (orig, ver string, ok bool) {
+	i := strings.LastIndex(base, objVerSepa)
+	if i <= 0 {
+		return
+	}
+	ver = base[i+len(objVerSepa):]
+	if _, err := strconv.ParseUint(ver, 10, 64); err != nil {
+		return "", "", false
+	}
+	return base[:i], ver, true
+}
@@ -29,6 +29,9 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileMptPart      = "mpt-part"       // S3 multipart upload: staged part
+	WorkfileInventory    = "inventory"      // bucket inventory manifest
+	WorkfileDownload     = "download"       // chunked download (see downloader)
 )
 
 type ParsedFQN struct {
@@ -414,3 +414,35 @@ func BenchmarkParseFQN(b *testing.B) {
 		parsedFQN, _ = fs.ParseFQN(fqn)
 	}
 }
+
+func TestObjVerContentResolver(t *testing.T) {
+	r := &fs.ObjVerContentResolver{}
+	tests := []struct {
+		base    string
+		orig    string
+		ver     string
+		invalid bool
+	}{
+		{base: r.GenUniqueFQN("obj", "1"), orig: "obj", ver: "1"},
+		{base: r.GenUniqueFQN("obj.v3", "12"), orig: "obj.v3", ver: "12"},
+		{base: r.GenUniqueFQN("a.tar", "0"), orig: "a.tar", ver: "0"},
+		{base: "obj", invalid: true},
+		{base: "obj.vx", invalid: true},
+		{base: ".v1", invalid: true},
+	}
+	for _, tt := range tests {
+		orig, ver, ok := fs.ParseObjVer(tt.base)
+		if tt.invalid {
+			if ok {
+				t.Errorf("%q: expected parsing to fail, got (%q, %q)", tt.base, orig, ver)
+			}
+			continue
+		}
+		if !ok || orig != tt.orig || ver != tt.ver {
+			t.Errorf("%q: expected (%q, %q), got (%q, %q, %t)", tt.base, tt.orig, tt.ver, orig, ver, ok)
+		}
+		if o, _, ok := r.ParseUniqueFQN(tt.base); !ok || o != tt.orig {
+			t.Errorf("%q: resolver parsed %q (%t), expected %q", tt.base, o, ok, tt.orig)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package mpather provides per-mountpath concepts.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package mpather
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Checkpoints
+//
+// Ckpt keeps the progress of a (resumable) jogger group in the target's database,
+// one record per mountpath, where the value is the FQN of the last visited object.
+// Joggers walk the bucket in lexicographical order (see `Sorted`), so that the next
+// run of the same job can skip everything that precedes its checkpoint.
+//
+// Progress is saved only when all previously dispatched visits are done: every so
+// many visits (see ckptInterval) and when paused. Therefore, an interrupted job
+// never skips an object that was not visited in full - at the cost of (idempotently)
+// revisiting up to ckptInterval objects per mountpath.
+//
+// Resuming is explicit: a job that is not asked to resume discards whatever progress
+// the previous (failed or interrupted) run of the same job may have left behind.
+
+const (
+	ckptCollection = "jogger-ckpt"
+	ckptSepa       = "|"
+	ckptInterval   = 1000 // persist progress every so many (per-mountpath) visits
+)
+
+type (
+	Ckpt struct {
+		db     dbdriver.Driver
+		id     string
+		mpaths map[string]*mpathCkpt // each updated by a single (mountpath) jogger
+	}
+	mpathCkpt struct {
+		saved string // loaded upon start: last visited FQN (empty when none)
+		last  string // last dispatched (and, after a barrier, visited) FQN
+		cnt   int64
+	}
+)
+
+// NewCkpt loads the (previously saved) progress of the job identified by `id` if
+// requested to resume - otherwise, removes it and starts from scratch;
+// the `id` must not change across restarts (and must not contain glob characters).
+func NewCkpt(db dbdriver.Driver, id string, resume bool) *Ckpt {
+	avail, _ := fs.Get()
+	c := &Ckpt{db: db, id: id, mpaths: make(map[string]*mpathCkpt, len(avail))}
+	for mpath := range avail {
+		c.mpaths[mpath] = &mpathCkpt{}
+	}
+	if !resume {
+		c.Clear()
+		return c
+	}
+	all, err := db.GetAll(ckptCollection, id+ckptSepa)
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return c
+	}
+	for dbkey, fqn := range all {
+		mpath := strings.TrimPrefix(dbkey, id+ckptSepa)
+		if mp, ok := c.mpaths[mpath]; ok {
+			mp.saved = fqn
+		}
+	}
+	return c
+}
+
+func (c *Ckpt) ID() string { return c.id }
+
+// returns true if the FQN was visited by one of the previous runs
+func (c *Ckpt) visited(mpath, fqn string) bool {
+	mp := c.mpaths[mpath]
+	if mp == nil || mp.saved == "" {
+		return false
+	}
+	if fs.WalkedBefore(mp.saved, fqn) {
+		mp.saved = "" // moved past the checkpoint
+		return false
+	}
+	return true
+}
+
+// returns true when it's time to persist progress (see jogger.ckpt)
+func (c *Ckpt) update(mpath, fqn string) bool {
+	mp := c.mpaths[mpath]
+	if mp == nil {
+		return false // (mountpath added at runtime)
+	}
+	mp.last = fqn
+	mp.cnt++
+	return mp.cnt%ckptInterval == 0
+}
+
+func (c *Ckpt) save(mpath string) {
+	mp := c.mpaths[mpath]
+	if mp == nil || mp.last == "" {
+		return
+	}
+	if err := c.db.SetString(ckptCollection, c.id+ckptSepa+mpath, mp.last); err != nil {
+		glog.Error(err)
+	}
+}
+
+// Clear removes all saved progress; to be called upon successful completion
+// (or when the job gets aborted by user, or starts without resuming).
+func (c *Ckpt) Clear() {
+	keys, err := c.db.List(ckptCollection, c.id+ckptSepa)
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	for _, dbkey := range keys { // including mountpaths that are no longer available
+		if err := c.db.Delete(ckptCollection, dbkey); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package mpather
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
@@ -59,6 +60,19 @@ type (
 		IncludeCopy           bool // Traverses LOMs that are copies.
 		SkipGloballyMisplaced bool // Skips content types that are globally misplaced.
 		Throttle              bool // Determines if the jogger should throttle itself.
+		Sorted                bool // Traverses (each bucket, each content type) in lexicographical order.
+
+		// Optional: stop visiting (at a safe point) while paused - see xact.Base.Pause.
+		Pauser Pauser
+		// Optional: skip objects visited by the previous run of the same job and
+		// keep track of progress (requires `Sorted`, single bucket and content type).
+		Ckpt *Ckpt
+	}
+
+	// Pauser is implemented by pausable xactions (see xact.Base).
+	Pauser interface {
+		// returns nil when not paused; otherwise, the channel that gets closed upon resume
+		ChanResume() <-chan struct{}
 	}
 
 	// JoggerGroup runs jogger per mountpath which walk the entire bucket and
@@ -102,6 +116,7 @@ func NewJoggerGroup(opts *JoggerGroupOpts, selectedMpaths ...string) *JoggerGrou
 		l                             = len(selectedMpaths)
 	)
 	debug.Assert(!opts.IncludeCopy || (opts.IncludeCopy && opts.DoLoad > noLoad))
+	debug.Assert(opts.Ckpt == nil || (opts.Sorted && !opts.Bck.IsEmpty() && len(opts.CTs) == 1))
 
 	if l == 0 {
 		joggers = make(map[string]*jogger, len(availablePaths))
@@ -231,7 +246,7 @@ func (j *jogger) runBck(bck *cmn.Bck) (aborted bool, err error) {
 		Mi:       j.mi,
 		CTs:      j.opts.CTs,
 		Callback: j.jog,
-		Sorted:   false,
+		Sorted:   j.opts.Sorted,
 	}
 	opts.Bck.Copy(bck)
 
@@ -241,8 +256,8 @@ func (j *jogger) runBck(bck *cmn.Bck) (aborted bool, err error) {
 		// We have to wait for them and check if there was any error.
 		if err == nil {
 			err = j.syncGroup.waitForAsyncTasks()
-		} else {
-			j.syncGroup.abortAsyncTasks()
+		} else if errAsync := j.syncGroup.abortAsyncTasks(); errAsync != nil && errors.Is(err, context.Canceled) {
+			err = errAsync // the reason for cancellation
 		}
 	}
 
@@ -263,9 +278,19 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 		return nil
 	}
 
+	if j.opts.Pauser != nil {
+		if ch := j.opts.Pauser.ChanResume(); ch != nil {
+			if err := j.pause(ch); err != nil {
+				return err
+			}
+		}
+	}
 	if err := j.checkStopped(); err != nil {
 		return err
 	}
+	if j.opts.Ckpt != nil && j.opts.Ckpt.visited(j.mi.Path, fqn) {
+		return nil
+	}
 
 	if j.syncGroup == nil {
 		if err := j.visitFQN(fqn, j.getBuf(0)); err != nil {
@@ -288,6 +313,12 @@ func (j *jogger) jog(fqn string, de fs.DirEntry) error {
 		})
 	}
 
+	if j.opts.Ckpt != nil && j.opts.Ckpt.update(j.mi.Path, fqn) {
+		if err := j.ckpt(); err != nil {
+			return err
+		}
+	}
+
 	if j.opts.Throttle {
 		j.num++
 		if (j.num % throttleNumObjects) == 0 {
@@ -375,6 +406,59 @@ func (j *jogger) checkStopped() error {
 	}
 }
 
+// waits for all dispatched visits to complete and persists the progress
+func (j *jogger) ckpt() error {
+	if j.syncGroup != nil {
+		if err := j.syncGroup.drain(j.ctx); err != nil {
+			return err
+		}
+	}
+	j.opts.Ckpt.save(j.mi.Path)
+	return nil
+}
+
+func (j *jogger) pause(resumeCh <-chan struct{}) error {
+	if j.opts.Ckpt != nil {
+		if err := j.ckpt(); err != nil {
+			return err
+		}
+	} else if j.syncGroup != nil {
+		if err := j.syncGroup.drain(j.ctx); err != nil {
+			return err
+		}
+	}
+	glog.Infof("%s paused", j)
+	select {
+	case <-resumeCh:
+		glog.Infof("%s resumed", j)
+		return nil
+	case <-j.ctx.Done():
+		return j.ctx.Err()
+	case <-j.stopCh.Listen():
+		return cmn.NewErrAborted(j.String(), "mpath-jog", nil)
+	}
+}
+
+// barrier: acquires (and then releases) all buffer positions, which is only
+// possible when none of the dispatched visits is still running
+func (sg *joggerSyncGroup) drain(ctx context.Context) error {
+	positions := make([]int, 0, cap(sg.sema))
+	defer func() {
+		for _, pos := range positions {
+			sg.sema <- pos
+		}
+	}()
+	for len(positions) < cap(sg.sema) {
+		select {
+		case pos := <-sg.sema:
+			positions = append(positions, pos)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (sg *joggerSyncGroup) waitForAsyncTasks() error {
 	return sg.group.Wait()
 }
@@ -14,7 +14,9 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/devtools/tassert"
 	"github.com/NVIDIA/aistore/devtools/tutils"
@@ -311,3 +313,106 @@ func TestJoggerGroupMultiContentTypes(t *testing.T) {
 	err := jg.Stop()
 	tassert.CheckFatal(t, err)
 }
+
+// Interrupted job (same checkpoint ID) must skip what's been visited and saved - but only
+// when explicitly resumed.
+func TestJoggerGroupCkpt(t *testing.T) {
+	const ckptInterval = 1000 // (see mpather.ckptInterval)
+	var (
+		objectsCnt = 2500
+		failAt     = int32(1500)
+		desc       = tutils.ObjectsDesc{
+			CTs: []tutils.ContentTypeDesc{
+				{Type: fs.ObjectType, ContentCnt: objectsCnt},
+			},
+			MountpathsCnt: 1,
+			ObjectSize:    cos.KiB,
+		}
+		out = tutils.PrepareObjects(t, desc)
+		db  = mock.NewDBDriver()
+	)
+	defer os.RemoveAll(out.Dir)
+
+	run := func(parallel int, fail, resume bool) (visited int32, err error) {
+		counter := atomic.NewInt32(0)
+		jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+			T:   out.T,
+			Bck: out.Bck,
+			CTs: []string{fs.ObjectType},
+			VisitObj: func(lom *cluster.LOM, buf []byte) error {
+				if counter.Inc() > failAt && fail {
+					return fmt.Errorf("oops")
+				}
+				return nil
+			},
+			Parallel: parallel,
+			Sorted:   true,
+			Ckpt:     mpather.NewCkpt(db, "ckpt-test", resume),
+		})
+		jg.Run()
+		<-jg.ListenFinished()
+		return counter.Load(), jg.Stop()
+	}
+
+	for _, parallel := range []int{0, 4} {
+		_, err := run(parallel, true, false)
+		tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "oops"), "parallel %d: expected an error, got %v", parallel, err)
+
+		// resume (from the last saved checkpoint)
+		visited, err := run(parallel, false, true)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, int(visited) == objectsCnt-ckptInterval,
+			"parallel %d: expected to visit %d objects, visited %d", parallel, objectsCnt-ckptInterval, visited)
+
+		// fail again but then start from scratch (not resuming)
+		_, err = run(parallel, true, false)
+		tassert.Errorf(t, err != nil, "parallel %d: expected an error", parallel)
+		visited, err = run(parallel, false, false)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, int(visited) == objectsCnt, "expected to visit %d objects, visited %d", objectsCnt, visited)
+	}
+}
+
+func TestJoggerGroupPause(t *testing.T) {
+	var (
+		desc = tutils.ObjectsDesc{
+			CTs: []tutils.ContentTypeDesc{
+				{Type: fs.ObjectType, ContentCnt: 500},
+			},
+			MountpathsCnt: 4,
+			ObjectSize:    cos.KiB,
+		}
+		out     = tutils.PrepareObjects(t, desc)
+		xctn    = mock.NewXact(apc.ActCopyBck)
+		counter = atomic.NewInt32(0)
+	)
+	defer os.RemoveAll(out.Dir)
+
+	tassert.CheckFatal(t, xctn.Pause())
+	tassert.Fatalf(t, xctn.IsPaused(), "expected paused")
+
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:   out.T,
+		Bck: out.Bck,
+		CTs: []string{fs.ObjectType},
+		VisitObj: func(lom *cluster.LOM, buf []byte) error {
+			counter.Inc()
+			return nil
+		},
+		Parallel: 2,
+		Pauser:   &xctn.Base,
+	})
+	jg.Run()
+
+	time.Sleep(100 * time.Millisecond)
+	tassert.Errorf(t, counter.Load() == 0, "paused joggers visited %d objects", counter.Load())
+
+	tassert.CheckFatal(t, xctn.Resume())
+	<-jg.ListenFinished()
+	tassert.CheckFatal(t, jg.Stop())
+	tassert.Errorf(t, int(counter.Load()) == len(out.FQNs[fs.ObjectType]),
+		"invalid number of objects visited (%d vs %d)", counter.Load(), len(out.FQNs[fs.ObjectType]))
+
+	// not pausable
+	tassert.Errorf(t, mock.NewXact(apc.ActLRU).Pause() != nil, "expected LRU not to be pausable")
+}
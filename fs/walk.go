@@ -195,6 +195,27 @@ func AllMpathBcks(opts *WalkOpts) (bcks []cmn.Bck, err error) {
 	return
 }
 
+// WalkedBefore returns true if `a` gets visited before `b` by the sorted walk (`WalkOpts.Sorted`).
+// Sorted walk visits directory entries in lexicographical order, one directory at a time.
+// Therefore, "a/b" precedes "a-b" - unlike plain string comparison, the path separator
+// must sort before any other character.
+func WalkedBefore(a, b string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := a[i], b[i]
+		switch {
+		case ca == cb:
+			continue
+		case ca == '/':
+			return true
+		case cb == '/':
+			return false
+		default:
+			return ca < cb
+		}
+	}
+	return len(a) < len(b)
+}
+
 func mpathChildren(opts *WalkOpts) (children []string, err error) {
 	var (
 		fqn           = opts.Mi.MakePathBck(&opts.Bck)
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -194,3 +195,32 @@ func TestWalkBckSkipDir(t *testing.T) {
 	}
 	tassert.Fatalf(t, expectedTotal == len(fqns), "expected %d objects, got %d", expectedTotal, len(fqns))
 }
+
+// checkpoint comparison must agree with the order of the sorted walk
+func TestWalkedBefore(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"z", "b/a/z", "a.b", "a-c", "b/a-", "a/b/c", "ab"} {
+		fqn := filepath.Join(root, name)
+		tassert.CheckFatal(t, os.MkdirAll(filepath.Dir(fqn), 0o755))
+		tassert.CheckFatal(t, os.WriteFile(fqn, nil, 0o644))
+	}
+	var walked []string
+	err := fs.Walk(&fs.WalkOpts{
+		Dir:    root,
+		Sorted: true,
+		Callback: func(fqn string, de fs.DirEntry) error {
+			if !de.IsDir() {
+				walked = append(walked, strings.TrimPrefix(fqn, root+"/"))
+			}
+			return nil
+		},
+	})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(walked) == 7, "expected 7 files, got %v", walked)
+	for i := range walked {
+		for j := range walked {
+			tassert.Errorf(t, fs.WalkedBefore(walked[i], walked[j]) == (i < j),
+				"%q vs %q: expected WalkedBefore=%t (walk order %v)", walked[i], walked[j], i < j, walked)
+		}
+	}
+}
@@ -16,6 +16,7 @@ require (
 	github.com/jacobsa/fuse v0.0.0-20220303083136-48612565d5c8
 	github.com/json-iterator/go v1.1.12
 	github.com/karrick/godirwalk v1.17.0
+	github.com/klauspost/compress v1.15.4
 	github.com/klauspost/reedsolomon v1.9.16
 	github.com/lufia/iostat v1.2.1
 	github.com/onsi/ginkgo v1.16.5
@@ -83,7 +84,6 @@ require (
 	github.com/jcmturner/rpc/v2 v2.0.3 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
-	github.com/klauspost/compress v1.15.4 // indirect
 	github.com/klauspost/cpuid/v2 v2.0.12 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/mattn/go-colorable v0.1.12 // indirect
@@ -168,6 +168,12 @@ func newXactTCB(e *tcbFactory, slab *memsys.Slab) (r *XactTCB) {
 		Throttle: true,
 	}
 	mpopts.Bck.Copy(e.args.BckFrom.Bucket())
+	if msg := e.args.Msg; !msg.DryRun {
+		// resumable: same source and destination, same ETL and naming
+		mpopts.Sorted = true
+		mpopts.Ckpt = mpather.NewCkpt(e.T.DB(), xact.CkptID(e.kind,
+			e.args.BckFrom.String(), e.args.BckTo.String(), msg.ID, msg.Prefix, fmt.Sprint(msg.Ext)), msg.Resume)
+	}
 	r.BckJog.Init(e.UUID(), e.kind, e.args.BckTo, mpopts)
 	return
 }
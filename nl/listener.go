@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -49,6 +50,11 @@ type NotifListener interface {
 	LastUpdated(si *cluster.Snode) int64
 	ProgressInterval() time.Duration
 
+	// initiating request and user (job history)
+	SetInitiator(msg *apc.ActionMsg, user string)
+	Initiator() (msg *apc.ActionMsg, user string)
+	StartTime() int64
+
 	// detailed ref-counting
 	ActiveNotifiers() cluster.NodeMap
 	FinCount() int
@@ -74,6 +80,9 @@ type (
 			Owned       string // "": not owned | equalIC: IC | otherwise, pid + IC
 			SmapVersion int64  // smap version in which NL is added
 			Bck         []*cmn.Bck
+			Msg         *apc.ActionMsg `json:",omitempty"` // initiating request
+			User        string         `json:",omitempty"` // initiating user (when authenticated)
+			Started     int64          // wall-clock time of construction (Unix nanoseconds)
 		}
 		// construction
 		Srcs        cluster.NodeMap  // all notifiers
@@ -113,6 +122,7 @@ func NewNLB(uuid, action string, smap *cluster.Smap, srcs cluster.NodeMap, progr
 	nlb.Common.Action = action
 	nlb.Common.SmapVersion = smap.Version
 	nlb.Common.Bck = bck
+	nlb.Common.Started = time.Now().UnixNano()
 	nlb.ActiveSrcs = srcs.ActiveMap()
 	return nlb
 }
@@ -136,6 +146,15 @@ func (nlb *NotifListenerBase) Kind() string                    { return nlb.Comm
 func (nlb *NotifListenerBase) Bcks() []*cmn.Bck                { return nlb.Common.Bck }
 func (nlb *NotifListenerBase) AddedTime() int64                { return nlb.addedTime.Load() }
 func (nlb *NotifListenerBase) SetAddedTime()                   { nlb.addedTime.Store(mono.NanoTime()) }
+func (nlb *NotifListenerBase) StartTime() int64                { return nlb.Common.Started }
+
+func (nlb *NotifListenerBase) SetInitiator(msg *apc.ActionMsg, user string) {
+	nlb.Common.Msg, nlb.Common.User = msg, user
+}
+
+func (nlb *NotifListenerBase) Initiator() (*apc.ActionMsg, string) {
+	return nlb.Common.Msg, nlb.Common.User
+}
 
 func (nlb *NotifListenerBase) ActiveNotifiers() cluster.NodeMap { return nlb.ActiveSrcs }
 func (nlb *NotifListenerBase) ActiveCount() int                 { return len(nlb.ActiveSrcs) }
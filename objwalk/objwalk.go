@@ -45,6 +45,13 @@ func (w *Walk) DefaultLocalObjPage() (*cmn.BucketList, error) {
 			return cmn.NewErrAborted(w.t.String()+" ResultSetXact", "query", err)
 		}
 		bckList.Entries = append(bckList.Entries, entry)
+		if w.msg.IsFlagSet(apc.LsVerHistory) {
+			vers, err := wi.ListVersions(fqn, entry)
+			if err != nil {
+				return cmn.NewErrAborted(w.t.String()+" ResultSetXact", "query", err)
+			}
+			bckList.Entries = append(bckList.Entries, vers...)
+		}
 		return nil
 	}
 
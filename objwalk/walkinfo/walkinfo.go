@@ -8,13 +8,17 @@ package walkinfo
 import (
 	"context"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/objwalk/where"
+	"github.com/NVIDIA/aistore/sse"
 )
 
 type (
@@ -34,9 +38,16 @@ type (
 		markerDir    string
 		msg          *apc.ListObjsMsg
 		timeFormat   string
+		// previous versions of the objects in the recently visited directories (see ListVersions)
+		verIdx map[string]map[string][]string
 	}
 
 	PostCallbackFunc func(lom *cluster.LOM)
+
+	// where.Object (filter expressions)
+	lomObj struct {
+		lom *cluster.LOM
+	}
 )
 
 const (
@@ -77,6 +88,16 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, msg *apc.ListObjsMsg) *W
 	for _, prop := range wiProps {
 		propNeeded[prop] = msg.WantProp(prop)
 	}
+	var filter objFilter
+	if msg.Where != "" {
+		// (the expression is validated by the proxy)
+		if expr, err := where.Parse(msg.Where); err != nil {
+			glog.Errorf("%s: %v", t, err)
+			filter = func(*cluster.LOM) bool { return false }
+		} else {
+			filter = func(lom *cluster.LOM) bool { return expr.Eval(lomObj{lom}) }
+		}
+	}
 	return &WalkInfo{
 		t:            t, // targetrunner
 		smap:         t.Sowner().Get(),
@@ -87,6 +108,7 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, msg *apc.ListObjsMsg) *W
 		msg:          msg,
 		timeFormat:   msg.TimeFormat,
 		propNeeded:   propNeeded,
+		objectFilter: filter,
 	}
 }
 
@@ -166,7 +188,7 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.needAtime() {
 		fileInfo.Atime = cos.FormatUnixNano(lom.AtimeUnix(), wi.timeFormat)
 	}
-	if wi.needCksum() && lom.Checksum() != nil {
+	if wi.needCksum() && lom.Checksum() != nil && !lom.IsEncrypted() { // (ciphertext checksum is not shown)
 		fileInfo.Checksum = lom.Checksum().Value()
 	}
 	if wi.needVersion() {
@@ -179,7 +201,7 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 		fileInfo.TargetURL = wi.t.Snode().URL(cmn.NetPublic)
 	}
 	if wi.needSize() {
-		fileInfo.Size = lom.SizeBytes()
+		fileInfo.Size = sse.Size(lom) // (plaintext)
 	}
 	if wi.postCallback != nil {
 		wi.postCallback(lom)
@@ -235,3 +257,77 @@ func (wi *WalkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.BucketEntry, error) {
 	}
 	return wi.lsObject(lom, objStatus), nil
 }
+
+// ListVersions returns previous versions of the object (see apc.LsVerHistory),
+// sorted by name.
+func (wi *WalkInfo) ListVersions(fqn string, entry *cmn.BucketEntry) ([]*cmn.BucketEntry, error) {
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return nil, err
+	}
+	if !lom.Bck().IsAIS() {
+		return nil, nil
+	}
+	vers, err := wi.versions(lom)
+	if err != nil || len(vers) == 0 {
+		return nil, err
+	}
+	entries := make([]*cmn.BucketEntry, 0, len(vers))
+	for _, ver := range vers {
+		e := &cmn.BucketEntry{
+			Name:    entry.Name + apc.LsVerSepa + ver,
+			Flags:   entry.Flags | apc.EntryIsPrevVer,
+			Version: ver,
+		}
+		if !wi.msg.IsFlagSet(apc.LsNameOnly) {
+			vlom, err := lom.LoadVersion(ver)
+			if err != nil {
+				continue // (trimmed or deleted in the meantime)
+			}
+			if wi.needSize() {
+				e.Size = sse.Size(vlom)
+			}
+			if wi.needAtime() {
+				e.Atime = cos.FormatUnixNano(vlom.AtimeUnix(), wi.timeFormat)
+			}
+			if wi.needCksum() && vlom.Checksum() != nil && !vlom.IsEncrypted() {
+				e.Checksum = vlom.Checksum().Value()
+			}
+			cluster.FreeLOM(vlom)
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// reading each version directory only once per walk (rather than once per object);
+// the (sorted) walk visits objects directory by directory but interleaves mountpaths
+// and subdirectories - hence, keeping a few recent ones
+func (wi *WalkInfo) versions(lom *cluster.LOM) ([]string, error) {
+	const maxDirs = 64
+	dir := lom.VersionDir()
+	idx, ok := wi.verIdx[dir]
+	if !ok {
+		var err error
+		if idx, err = cluster.VersionIndex(dir); err != nil {
+			return nil, err
+		}
+		if wi.verIdx == nil || len(wi.verIdx) >= maxDirs {
+			wi.verIdx = make(map[string]map[string][]string, 4)
+		}
+		wi.verIdx[dir] = idx
+	}
+	return idx[filepath.Base(lom.ObjName)], nil
+}
+
+////////////
+// lomObj //
+////////////
+
+func (o lomObj) Name() string                        { return o.lom.ObjName }
+func (o lomObj) Size() int64                         { return sse.Size(o.lom) } // (plaintext)
+func (o lomObj) AtimeUnix() int64                    { return o.lom.AtimeUnix() }
+func (o lomObj) Version() string                     { return o.lom.Version() }
+func (o lomObj) CustomKey(key string) (string, bool) { return o.lom.GetCustomKey(key) }
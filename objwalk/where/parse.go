@@ -0,0 +1,263 @@
+// Package where implements filter expressions over object metadata: name, size,
+// access time, version, and custom (user-defined) metadata.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package where
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tokIdent = iota
+	tokString
+	tokNumber
+	tokOp   // comparison operator
+	tokAnd  // &&
+	tokOr   // ||
+	tokNot  // !
+	tokLpar // (
+	tokRpar // )
+)
+
+type (
+	token struct {
+		val  string
+		kind int
+		pos  int
+	}
+	parser struct {
+		src  string
+		toks []token
+		pos  int
+	}
+)
+
+func (p *parser) errf(format string, a ...interface{}) error {
+	at := len(p.src)
+	if p.pos < len(p.toks) {
+		at = p.toks[p.pos].pos
+	}
+	return fmt.Errorf("where %q: %s (at position %d)", p.src, fmt.Sprintf(format, a...), at)
+}
+
+//////////////
+// tokenize //
+//////////////
+
+func isIdentStart(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool      { return c >= '0' && c <= '9' }
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '-'
+}
+
+func (p *parser) tokenize() error {
+	s := p.src
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.toks = append(p.toks, token{val: "(", kind: tokLpar, pos: i})
+			i++
+		case c == ')':
+			p.toks = append(p.toks, token{val: ")", kind: tokRpar, pos: i})
+			i++
+		case strings.HasPrefix(s[i:], "&&"):
+			p.toks = append(p.toks, token{val: "&&", kind: tokAnd, pos: i})
+			i += 2
+		case strings.HasPrefix(s[i:], "||"):
+			p.toks = append(p.toks, token{val: "||", kind: tokOr, pos: i})
+			i += 2
+		case strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="), strings.HasPrefix(s[i:], "<="),
+			strings.HasPrefix(s[i:], ">="), strings.HasPrefix(s[i:], "=~"), strings.HasPrefix(s[i:], "!~"):
+			p.toks = append(p.toks, token{val: s[i : i+2], kind: tokOp, pos: i})
+			i += 2
+		case c == '<' || c == '>':
+			p.toks = append(p.toks, token{val: s[i : i+1], kind: tokOp, pos: i})
+			i++
+		case c == '!':
+			p.toks = append(p.toks, token{val: "!", kind: tokNot, pos: i})
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return fmt.Errorf("where %q: unterminated string (at position %d)", s, i)
+			}
+			val := s[i+1 : j]
+			if c == '"' {
+				unq, err := strconv.Unquote(s[i : j+1])
+				if err != nil {
+					return fmt.Errorf("where %q: invalid string %s (at position %d)", s, s[i:j+1], i)
+				}
+				val = unq
+			}
+			p.toks = append(p.toks, token{val: val, kind: tokString, pos: i})
+			i = j + 1
+		case isDigit(c) || ((c == '-' || c == '.') && i+1 < len(s) && isDigit(s[i+1])):
+			j := i + 1
+			for ; j < len(s) && (isIdent(s[j])); j++ {
+			}
+			p.toks = append(p.toks, token{val: s[i:j], kind: tokNumber, pos: i})
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for ; j < len(s) && isIdent(s[j]); j++ {
+			}
+			p.toks = append(p.toks, token{val: s[i:j], kind: tokIdent, pos: i})
+			i = j
+		default:
+			return fmt.Errorf("where %q: unexpected %q (at position %d)", s, c, i)
+		}
+	}
+	return nil
+}
+
+///////////
+// parse //
+///////////
+
+func (p *parser) peek() *token {
+	if p.pos < len(p.toks) {
+		return &p.toks[p.pos]
+	}
+	return nil
+}
+
+func (p *parser) expr() (Expr, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokOr; tok = p.peek() {
+		p.pos++
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l = &or{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokAnd; tok = p.peek() {
+		p.pos++
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l = &and{l, r}
+	}
+	return l, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, p.errf("unexpected end of expression")
+	}
+	switch tok.kind {
+	case tokNot:
+		p.pos++
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{e}, nil
+	case tokLpar:
+		p.pos++
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.peek(); tok == nil || tok.kind != tokRpar {
+			return nil, p.errf("missing ')'")
+		}
+		p.pos++
+		return e, nil
+	case tokIdent:
+		return p.cmp()
+	default:
+		return nil, p.errf("expecting field name, got %q", tok.val)
+	}
+}
+
+func (p *parser) cmp() (Expr, error) {
+	field := p.toks[p.pos].val
+	p.pos++
+	op := p.peek()
+	if op == nil || op.kind != tokOp {
+		return nil, p.errf("expecting comparison operator after %q", field)
+	}
+	p.pos++
+	lit := p.peek()
+	if lit == nil || (lit.kind != tokString && lit.kind != tokNumber) {
+		return nil, p.errf("expecting value after %q", field+" "+op.val)
+	}
+	p.pos++
+	isRegex := op.val == "=~" || op.val == "!~"
+
+	switch {
+	case field == FieldName || field == FieldVersion || strings.HasPrefix(field, FieldCustom):
+		if field == FieldCustom {
+			return nil, p.errf("custom metadata key is missing (expecting \"custom.<key>\")")
+		}
+		e := &cmpS{field: field, op: op.val, val: lit.val}
+		if isRegex {
+			re, err := regexp.Compile(lit.val)
+			if err != nil {
+				return nil, p.errf("invalid regular expression %q: %v", lit.val, err)
+			}
+			e.re = re
+		} else if lit.kind == tokNumber {
+			f, err := strconv.ParseFloat(lit.val, 64)
+			if err != nil {
+				return nil, p.errf("invalid number %q", lit.val)
+			}
+			e.num = &f
+		}
+		return e, nil
+	case field == FieldSize || field == FieldAtime || field == FieldAge:
+		if isRegex {
+			return nil, p.errf("operator %q applies to strings only", op.val)
+		}
+		var (
+			val int64
+			err error
+		)
+		switch field {
+		case FieldSize:
+			val, err = parseSize(lit.val)
+		case FieldAtime:
+			val, err = parseTime(lit.val)
+		case FieldAge:
+			var d time.Duration
+			if d, err = time.ParseDuration(lit.val); err == nil {
+				val = int64(d)
+			}
+		}
+		if err != nil {
+			return nil, p.errf("%v", err)
+		}
+		return &cmpN{field: field, op: op.val, val: val}, nil
+	default:
+		return nil, p.errf("unknown field %q (expecting one of: name, size, atime, age, version, custom.<key>)", field)
+	}
+}
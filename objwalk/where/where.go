@@ -0,0 +1,237 @@
+// Package where implements filter expressions over object metadata: name, size,
+// access time, version, and custom (user-defined) metadata. The expressions are
+// used to select objects when listing buckets (see apc.ListObjsMsg.Where).
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package where
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Grammar:
+//
+//   expr    := and ( "||" and )*
+//   and     := unary ( "&&" unary )*
+//   unary   := "!" unary | "(" expr ")" | cmp
+//   cmp     := field op literal
+//   field   := "name" | "size" | "atime" | "age" | "version" | "custom." <key>
+//   op      := "==" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "!~"
+//   literal := quoted string | number (optionally with units, e.g. "1MiB", "24h")
+//
+// Semantics:
+//   * size: integer number of bytes, or size with units ("10KiB", "1.5GB")
+//   * atime: time in RFC 3339 ("2022-10-17T12:00:00Z") or date ("2022-10-17") format
+//   * age: time since last access, e.g. "age > 168h"
+//   * name, version, and custom keys are strings; a missing custom key is an empty
+//     string, and numeric literals compare numerically with numeric values
+//   * "=~" and "!~" match (don't match) Go regular expressions
+//
+// Example: custom.label == "cat" && size > 1MiB && !(name =~ "^tmp/")
+
+const (
+	FieldName    = "name"
+	FieldSize    = "size"
+	FieldAtime   = "atime"
+	FieldAge     = "age"
+	FieldVersion = "version"
+	FieldCustom  = "custom." // prefix
+)
+
+type (
+	// Object provides (read-only) access to the object's metadata
+	Object interface {
+		Name() string
+		Size() int64
+		AtimeUnix() int64 // nanoseconds
+		Version() string
+		CustomKey(key string) (string, bool)
+	}
+
+	// Expr is a parsed filter expression
+	Expr interface {
+		Eval(o Object) bool
+		String() string
+	}
+
+	and  struct{ l, r Expr }
+	or   struct{ l, r Expr }
+	not  struct{ e Expr }
+	cmpS struct { // string
+		field string // FieldName, FieldVersion, or custom key
+		op    string
+		val   string
+		re    *regexp.Regexp // "=~", "!~"
+		num   *float64       // numeric literal (custom and version only)
+	}
+	cmpN struct { // number: size, atime, age
+		field string
+		op    string
+		val   int64
+	}
+)
+
+// interface guard
+var (
+	_ Expr = (*and)(nil)
+	_ Expr = (*or)(nil)
+	_ Expr = (*not)(nil)
+	_ Expr = (*cmpS)(nil)
+	_ Expr = (*cmpN)(nil)
+)
+
+// Parse parses a filter expression
+func Parse(s string) (Expr, error) {
+	p := &parser{src: s}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.toks) == 0 {
+		return nil, fmt.Errorf("where %q: empty expression", s)
+	}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, p.errf("unexpected %q", p.toks[p.pos].val)
+	}
+	return e, nil
+}
+
+func (e *and) Eval(o Object) bool { return e.l.Eval(o) && e.r.Eval(o) }
+func (e *and) String() string     { return "(" + e.l.String() + " && " + e.r.String() + ")" }
+func (e *or) Eval(o Object) bool  { return e.l.Eval(o) || e.r.Eval(o) }
+func (e *or) String() string      { return "(" + e.l.String() + " || " + e.r.String() + ")" }
+func (e *not) Eval(o Object) bool { return !e.e.Eval(o) }
+func (e *not) String() string     { return "!" + e.e.String() }
+
+//////////
+// cmpS //
+//////////
+
+func (e *cmpS) Eval(o Object) bool {
+	var v string
+	switch e.field {
+	case FieldName:
+		v = o.Name()
+	case FieldVersion:
+		v = o.Version()
+	default:
+		v, _ = o.CustomKey(strings.TrimPrefix(e.field, FieldCustom))
+	}
+	switch e.op {
+	case "=~":
+		return e.re.MatchString(v)
+	case "!~":
+		return !e.re.MatchString(v)
+	}
+	if e.num != nil {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return e.op == "!="
+		}
+		return cmpOp(e.op, cmpFloat(f, *e.num))
+	}
+	return cmpOp(e.op, strings.Compare(v, e.val))
+}
+
+func (e *cmpS) String() string {
+	if e.num != nil {
+		return e.field + " " + e.op + " " + e.val
+	}
+	return e.field + " " + e.op + " " + strconv.Quote(e.val)
+}
+
+//////////
+// cmpN //
+//////////
+
+func (e *cmpN) Eval(o Object) bool {
+	var v int64
+	switch e.field {
+	case FieldSize:
+		v = o.Size()
+	case FieldAtime:
+		v = o.AtimeUnix()
+	case FieldAge:
+		v = time.Now().UnixNano() - o.AtimeUnix()
+	}
+	switch {
+	case v < e.val:
+		return cmpOp(e.op, -1)
+	case v > e.val:
+		return cmpOp(e.op, 1)
+	default:
+		return cmpOp(e.op, 0)
+	}
+}
+
+func (e *cmpN) String() string {
+	switch e.field {
+	case FieldAtime:
+		return e.field + " " + e.op + " " + strconv.Quote(time.Unix(0, e.val).UTC().Format(time.RFC3339Nano))
+	case FieldAge:
+		return e.field + " " + e.op + " " + time.Duration(e.val).String()
+	default:
+		return e.field + " " + e.op + " " + strconv.FormatInt(e.val, 10)
+	}
+}
+
+//
+// helpers
+//
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// given the result of comparison (-1, 0, 1) evaluate the operator
+func cmpOp(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func parseTime(s string) (int64, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UnixNano(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q (expecting RFC 3339 or YYYY-MM-DD)", s)
+}
+
+func parseSize(s string) (int64, error) {
+	n, err := cos.S2B(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,113 @@
+// Package where implements filter expressions over object metadata: name, size,
+// access time, version, and custom (user-defined) metadata.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package where_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/objwalk/where"
+)
+
+type obj struct {
+	name    string
+	size    int64
+	atime   time.Time
+	version string
+	custom  cos.SimpleKVs
+}
+
+func (o *obj) Name() string     { return o.name }
+func (o *obj) Size() int64      { return o.size }
+func (o *obj) AtimeUnix() int64 { return o.atime.UnixNano() }
+func (o *obj) Version() string  { return o.version }
+
+func (o *obj) CustomKey(key string) (v string, ok bool) {
+	v, ok = o.custom[key]
+	return
+}
+
+func TestWhereEval(t *testing.T) {
+	o := &obj{
+		name:    "images/cat-001.jpg",
+		size:    2 * cos.MiB,
+		atime:   time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+		version: "3",
+		custom:  cos.SimpleKVs{"label": "cat", "epoch": "12", "split": "train"},
+	}
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{`custom.label == "cat"`, true},
+		{`custom.label == 'cat' && size > 1MiB`, true},
+		{`custom.label == "cat" && size > 10MiB`, false},
+		{`custom.label != "cat" || size >= 2097152`, true},
+		{`custom.missing == ""`, true},
+		{`custom.missing != ""`, false},
+		{`custom.epoch > 9`, true},    // numeric
+		{`custom.epoch > "9"`, false}, // lexicographic
+		{`custom.split > 1`, false},   // not a number
+		{`custom.split != 1`, true},   // ditto
+		{`name =~ "^images/cat-\\d+"`, true},
+		{`name !~ "\\.jpg$"`, false},
+		{`name < "j"`, true},
+		{`version == 3`, true},
+		{`version >= 4`, false},
+		{`atime < "2022-10-02"`, true},
+		{`atime > "2022-10-01T11:59:59Z"`, true},
+		{`atime > "2022-10-01T12:00:01Z"`, false},
+		{`age > 24h`, true},
+		{`!(age > 24h)`, false},
+		{`!(size < 1KB) && (custom.label == "dog" || custom.split == "train")`, true},
+		{`size == 2MiB && !custom.label == "dog"`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			expr, err := where.Parse(test.expr)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, expr.Eval(o) == test.expected, "%q (parsed as %s): expected %t",
+				test.expr, expr, test.expected)
+		})
+	}
+}
+
+func TestWhereParseErrors(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`label == "cat"`,         // unknown field
+		`custom. == "cat"`,       // missing key
+		`size > "big"`,           // invalid size
+		`size =~ "1"`,            // regex on a number
+		`atime < "yesterday"`,    // invalid time
+		`age > 1x`,               // invalid duration
+		`name == "a" &&`,         // incomplete
+		`(name == "a"`,           // missing ')'
+		`name == "a")`,           // unexpected ')'
+		`name "a"`,               // missing operator
+		`name ==`,                // missing value
+		`name == "a`,             // unterminated string
+		`name =~ "("`,            // invalid regex
+		`name == "a" & size > 1`, // invalid operator
+		`"a" == name`,            // value first
+		`name == "a" size > 1`,   // missing "&&"
+	} {
+		if _, err := where.Parse(s); err == nil {
+			t.Errorf("%q: expected parse error", s)
+		}
+	}
+}
+
+func TestWhereAnd(t *testing.T) {
+	// "&&" takes precedence over "||"
+	expr, err := where.Parse(`name == "a" || name == "b" && size > 1`)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, expr.Eval(&obj{name: "a"}), "expected %s to match", expr)
+	tassert.Errorf(t, !expr.Eval(&obj{name: "b"}), "expected %s not to match", expr)
+	tassert.Errorf(t, expr.Eval(&obj{name: "b", size: 2}), "expected %s to match", expr)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/repair"
 	"github.com/NVIDIA/aistore/transport"
 )
 
@@ -183,6 +184,9 @@ func (reb *Reb) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unpacke
 	// stats
 	xreb.InObjsAdd(1, hdr.ObjAttrs.Size)
 
+	// migrated objects are not mirrored
+	repair.CheckCopies(lom, repair.SrcReb)
+
 	// ACK
 	tsi := smap.GetTarget(tsid)
 	if tsi == nil {
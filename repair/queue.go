@@ -0,0 +1,124 @@
+// Package repair restores under-protected objects: objects that have fewer mirror copies
+// than configured, or lost some of their erasure-coded slices.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package repair
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type (
+	// queued item
+	qitem struct {
+		cmn.RepairItem
+		key   string
+		index int // in the heap
+	}
+	itemHeap []*qitem
+
+	// priority queue (not thread-safe) with de-duplication: the same object
+	// (of the same kind) is queued only once
+	queue struct {
+		h   itemHeap
+		idx map[string]*qitem
+		max int
+	}
+)
+
+// interface guard
+var _ heap.Interface = (*itemHeap)(nil)
+
+// least remaining redundancy first; then, most missing; then, FIFO
+func urgent(a, b *cmn.RepairItem) bool {
+	if a.Redundancy != b.Redundancy {
+		return a.Redundancy < b.Redundancy
+	}
+	if a.Missing != b.Missing {
+		return a.Missing > b.Missing
+	}
+	return a.Added < b.Added
+}
+
+//////////////
+// itemHeap //
+//////////////
+
+func (h itemHeap) Len() int           { return len(h) }
+func (h itemHeap) Less(i, j int) bool { return urgent(&h[i].RepairItem, &h[j].RepairItem) }
+
+func (h itemHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *itemHeap) Push(x interface{}) {
+	qi := x.(*qitem)
+	qi.index = len(*h)
+	*h = append(*h, qi)
+}
+
+func (h *itemHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	qi := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return qi
+}
+
+///////////
+// queue //
+///////////
+
+func newQueue(max int) *queue {
+	return &queue{idx: make(map[string]*qitem, 64), max: max}
+}
+
+func (q *queue) len() int { return len(q.h) }
+
+// returns false when the queue is full and the item is new; an item that is already
+// queued gets its priority updated (when the new one is more urgent)
+func (q *queue) push(it *cmn.RepairItem) (added, ok bool) {
+	key := it.Kind + "|" + it.Bck.MakeUname(it.ObjName)
+	if qi, ok := q.idx[key]; ok {
+		if it.Redundancy < qi.Redundancy || (it.Redundancy == qi.Redundancy && it.Missing > qi.Missing) {
+			qi.Redundancy, qi.Missing, qi.Source = it.Redundancy, it.Missing, it.Source
+			heap.Fix(&q.h, qi.index)
+		}
+		return false, true
+	}
+	if len(q.h) >= q.max {
+		return false, false
+	}
+	qi := &qitem{RepairItem: *it, key: key}
+	heap.Push(&q.h, qi)
+	q.idx[key] = qi
+	return true, true
+}
+
+func (q *queue) pop() (it cmn.RepairItem, ok bool) {
+	if len(q.h) == 0 {
+		return
+	}
+	qi := heap.Pop(&q.h).(*qitem)
+	delete(q.idx, qi.key)
+	return qi.RepairItem, true
+}
+
+// up to n most urgent items, in priority order
+func (q *queue) top(n int) []cmn.RepairItem {
+	items := make([]cmn.RepairItem, 0, len(q.h))
+	for _, qi := range q.h {
+		items = append(items, qi.RepairItem)
+	}
+	sort.Slice(items, func(i, j int) bool { return urgent(&items[i], &items[j]) })
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
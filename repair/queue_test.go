@@ -0,0 +1,95 @@
+// Package repair restores under-protected objects: objects that have fewer mirror copies
+// than configured, or lost some of their erasure-coded slices.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package repair
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+var testBck = cmn.Bck{Name: "repair", Provider: apc.ProviderAIS}
+
+func newItem(name, kind string, redundancy, missing int, added int64) *cmn.RepairItem {
+	return &cmn.RepairItem{
+		Bck:        testBck,
+		ObjName:    name,
+		Kind:       kind,
+		Source:     SrcGet,
+		Redundancy: redundancy,
+		Missing:    missing,
+		Added:      added,
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	q := newQueue(100)
+	q.push(newItem("fifo-2", cmn.RepairCopies, 1, 1, 2))
+	q.push(newItem("fifo-1", cmn.RepairCopies, 1, 1, 1))
+	q.push(newItem("more-missing", cmn.RepairEC, 1, 2, 3))
+	q.push(newItem("no-redundancy", cmn.RepairCopies, 0, 1, 4))
+	q.push(newItem("most-redundant", cmn.RepairEC, 2, 1, 0))
+
+	expected := []string{"no-redundancy", "more-missing", "fifo-1", "fifo-2", "most-redundant"}
+
+	top := q.top(3)
+	tassert.Fatalf(t, len(top) == 3, "expected 3 items, got %d", len(top))
+	for i, it := range top {
+		tassert.Errorf(t, it.ObjName == expected[i], "top[%d]: expected %q, got %q", i, expected[i], it.ObjName)
+	}
+	for _, name := range expected {
+		it, ok := q.pop()
+		tassert.Fatalf(t, ok, "expected %q, got empty queue", name)
+		tassert.Errorf(t, it.ObjName == name, "expected %q, got %q", name, it.ObjName)
+	}
+	_, ok := q.pop()
+	tassert.Errorf(t, !ok, "expected empty queue")
+	tassert.Errorf(t, len(q.idx) == 0, "expected empty index, got %d", len(q.idx))
+}
+
+func TestQueueDedup(t *testing.T) {
+	q := newQueue(100)
+	added, ok := q.push(newItem("a", cmn.RepairCopies, 1, 1, 1))
+	tassert.Errorf(t, added && ok, "expected %q to be added", "a")
+	q.push(newItem("b", cmn.RepairCopies, 1, 1, 2))
+
+	// same object, different kind: queued separately
+	added, _ = q.push(newItem("a", cmn.RepairEC, 2, 1, 3))
+	tassert.Errorf(t, added, "expected EC item to be added")
+
+	// same object, same kind, less urgent: not updated
+	added, ok = q.push(newItem("b", cmn.RepairCopies, 2, 1, 4))
+	tassert.Errorf(t, !added && ok, "expected duplicate not to be added")
+	tassert.Errorf(t, q.len() == 3, "expected 3 items, got %d", q.len())
+
+	// same object, same kind, more urgent: moves to the front
+	q.push(newItem("b", cmn.RepairCopies, 0, 2, 5))
+	tassert.Errorf(t, q.len() == 3, "expected 3 items, got %d", q.len())
+	it, _ := q.pop()
+	tassert.Errorf(t, it.ObjName == "b" && it.Redundancy == 0 && it.Missing == 2,
+		"expected updated %q first, got %+v", "b", it)
+	tassert.Errorf(t, it.Added == 2, "expected the original time queued, got %d", it.Added)
+}
+
+func TestQueueFull(t *testing.T) {
+	q := newQueue(2)
+	q.push(newItem("a", cmn.RepairCopies, 1, 1, 1))
+	q.push(newItem("b", cmn.RepairCopies, 1, 1, 2))
+
+	added, ok := q.push(newItem("c", cmn.RepairCopies, 0, 1, 3))
+	tassert.Errorf(t, !added && !ok, "expected %q to be dropped", "c")
+
+	// already queued items can still be updated
+	added, ok = q.push(newItem("b", cmn.RepairCopies, 0, 1, 4))
+	tassert.Errorf(t, !added && ok, "expected %q to be updated", "b")
+	it, _ := q.pop()
+	tassert.Errorf(t, it.ObjName == "b", "expected %q, got %q", "b", it.ObjName)
+
+	added, ok = q.push(newItem("c", cmn.RepairCopies, 0, 1, 5))
+	tassert.Errorf(t, added && ok, "expected %q to be added", "c")
+}
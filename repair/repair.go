@@ -0,0 +1,395 @@
+// Package repair restores under-protected objects: objects that have fewer mirror copies
+// than configured, or lost some of their erasure-coded slices.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package repair
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Repair
+//
+// Each target tracks its own under-protected objects:
+// - mirrored objects with fewer copies than cmn.MirrorConf.Copies;
+// - erasure-coded objects (main replicas) with slices (or replicas) stored on
+//   targets that are no longer in the cluster map, or are in maintenance.
+//
+// Under-protected objects are detected:
+// - on GET (the number of copies is checked in memory; EC slices - when the object
+//   itself had to be restored);
+// - when receiving objects from rebalance (that does not mirror);
+// - when a mountpath gets disabled (e.g., by FSHC) or detached without resilvering: all
+//   mirrored and erasure-coded buckets on the remaining mountpaths are scanned.
+//
+// Detected objects are queued and repaired, one at a time, in priority order: objects with
+// the least remaining redundancy first. The queue is in-memory; when it is full new objects
+// are dropped (and counted) - the next scan, or the next GET, will find them again.
+//
+// See also: stats.RepairPending (the queue depth) and `ais show repair`.
+
+// sources
+const (
+	SrcGet   = "get"
+	SrcReb   = "rebalance"
+	SrcMpath = "mountpath"
+)
+
+const (
+	MaxQueued = 64 * 1024 // max number of queued objects
+	MaxTop    = 100       // max number of (most urgent) objects in the status
+
+	ecTimeout = 2 * time.Minute // max time to re-encode an object
+	oosSleep  = 10 * time.Second
+)
+
+type repairer struct {
+	t        cluster.Target
+	statsT   stats.Tracker
+	mu       sync.Mutex
+	q        *queue
+	workCh   chan struct{}
+	stopCh   *cos.StopCh
+	wg       sync.WaitGroup
+	repaired atomic.Int64
+	failed   atomic.Int64
+	dropped  atomic.Int64
+	scanning atomic.Bool
+}
+
+var errNoMpath = errors.New("no available mountpath to place a copy")
+
+// global repairer
+var gr *repairer
+
+func Start(t cluster.Target, statsT stats.Tracker) {
+	gr = &repairer{
+		t:      t,
+		statsT: statsT,
+		q:      newQueue(MaxQueued),
+		workCh: make(chan struct{}, 1),
+		stopCh: cos.NewStopCh(),
+	}
+	gr.wg.Add(1)
+	go gr.run()
+}
+
+func Stop() {
+	if gr != nil {
+		gr.stopCh.Close()
+		gr.wg.Wait()
+	}
+}
+
+// CheckCopies queues the object if its bucket is mirrored and the object has fewer copies
+// than configured. The object must be loaded.
+func CheckCopies(lom *cluster.LOM, src string) {
+	if gr == nil {
+		return
+	}
+	mirror := lom.MirrorConf()
+	if !mirror.Enabled || !lom.IsHRW() {
+		return
+	}
+	copies, n := int(mirror.Copies), lom.NumCopies()
+	if n >= copies || len(fs.GetAvail()) <= n { // (can't do anything about the latter)
+		return
+	}
+	gr.add(&cmn.RepairItem{
+		Bck:        *lom.Bucket(),
+		ObjName:    lom.ObjName,
+		Kind:       cmn.RepairCopies,
+		Source:     src,
+		Redundancy: n - 1,
+		Missing:    copies - n,
+	})
+}
+
+// CheckEC queues the object if its bucket is erasure coded and some of the object's
+// slices (or replicas) are lost. The object must be loaded (and its EC metadata stored
+// locally, which is always the case with the main replica).
+func CheckEC(lom *cluster.LOM, src string) {
+	if gr == nil || !lom.Bprops().EC.Enabled || !lom.IsHRW() {
+		return
+	}
+	missing, redundancy, ok := gr.ecMissing(lom)
+	if !ok || missing == 0 {
+		return
+	}
+	gr.add(&cmn.RepairItem{
+		Bck:        *lom.Bucket(),
+		ObjName:    lom.ObjName,
+		Kind:       cmn.RepairEC,
+		Source:     src,
+		Redundancy: redundancy,
+		Missing:    missing,
+	})
+}
+
+// Scan (asynchronously) all available mountpaths for under-protected objects; a scan
+// that is already running is not restarted
+func Scan(reason string) {
+	if gr == nil || !gr.scanning.CAS(false, true) {
+		return
+	}
+	go gr.scan(reason)
+}
+
+func GetStatus() *cmn.RepairStatus {
+	st := &cmn.RepairStatus{}
+	if gr == nil {
+		return st
+	}
+	gr.mu.Lock()
+	st.Queued = int64(gr.q.len())
+	st.Top = gr.q.top(MaxTop)
+	gr.mu.Unlock()
+	st.Repaired, st.Failed, st.Dropped = gr.repaired.Load(), gr.failed.Load(), gr.dropped.Load()
+	st.Scanning = gr.scanning.Load()
+	return st
+}
+
+//////////////
+// repairer //
+//////////////
+
+func (r *repairer) add(it *cmn.RepairItem) {
+	it.Added = time.Now().UnixNano()
+	r.mu.Lock()
+	added, ok := r.q.push(it)
+	r.mu.Unlock()
+	if !ok {
+		r.dropped.Inc()
+		return
+	}
+	if !added {
+		return
+	}
+	r.statsAdd(stats.RepairPending, 1)
+	select {
+	case r.workCh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *repairer) pop() (it cmn.RepairItem, ok bool) {
+	r.mu.Lock()
+	it, ok = r.q.pop()
+	r.mu.Unlock()
+	if ok {
+		r.statsAdd(stats.RepairPending, -1)
+	}
+	return
+}
+
+func (r *repairer) run() {
+	defer r.wg.Done()
+	buf, slab := r.t.PageMM().AllocSize(memsys.MaxPageSlabSize)
+	defer slab.Free(buf)
+	for {
+		it, ok := r.pop()
+		if !ok {
+			select {
+			case <-r.workCh:
+				continue
+			case <-r.stopCh.Listen():
+				return
+			}
+		}
+		if cs := fs.GetCapStatus(); cs.OOS {
+			glog.Errorf("%s: not repairing %s %s/%s: %v", r.t, it.Kind, it.Bck, it.ObjName, cs.Err)
+			r.failed.Inc()
+			r.statsAdd(stats.ErrRepairCount, 1)
+			select {
+			case <-time.After(oosSleep):
+			case <-r.stopCh.Listen():
+				return
+			}
+			continue
+		}
+		r.repair(&it, buf)
+		select {
+		case <-r.stopCh.Listen():
+			return
+		default:
+		}
+	}
+}
+
+func (r *repairer) repair(it *cmn.RepairItem, buf []byte) {
+	lom := cluster.AllocLOM(it.ObjName)
+	err := lom.InitBck(&it.Bck)
+	if err == nil {
+		switch it.Kind {
+		case cmn.RepairCopies:
+			err = r.addCopies(lom, buf)
+		default:
+			err = r.encode(lom)
+			if err == errEcTimeout {
+				lom = nil // still in use - not freeing
+			}
+		}
+	}
+	if lom != nil {
+		cluster.FreeLOM(lom)
+	}
+	switch {
+	case err == nil:
+		r.repaired.Inc()
+		r.statsAdd(stats.RepairCount, 1)
+		if verbose() {
+			glog.Infof("%s: repaired %s %s/%s (source %s, missing %d)", r.t, it.Kind, it.Bck, it.ObjName, it.Source, it.Missing)
+		}
+	case cmn.IsObjNotExist(err) || cmn.IsErrBucketNought(err):
+		// deleted in the meantime
+	default:
+		r.failed.Inc()
+		r.statsAdd(stats.ErrRepairCount, 1)
+		glog.Errorf("%s: failed to repair %s %s/%s: %v", r.t, it.Kind, it.Bck, it.ObjName, err)
+	}
+}
+
+// add missing mirror copies; noop if the object is (no longer) under-protected
+func (*repairer) addCopies(lom *cluster.LOM, buf []byte) error {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	lom.Uncache(false /*delDirty*/)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	mirror := lom.MirrorConf()
+	if !mirror.Enabled {
+		return nil
+	}
+	for lom.NumCopies() < int(mirror.Copies) {
+		mi := lom.LeastUtilNoCopy()
+		if mi == nil {
+			return errNoMpath
+		}
+		if err := lom.Copy(mi, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errEcTimeout = fmt.Errorf("timed out waiting for EC encoding (%v)", ecTimeout)
+
+// (re)encode the object - that is, rebuild all its slices (or replicas)
+func (r *repairer) encode(lom *cluster.LOM) error {
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	if missing, _, ok := r.ecMissing(lom); !ok || missing == 0 {
+		return nil
+	}
+	errCh := make(chan error, 1)
+	if err := ec.ECM.EncodeObject(lom, func(_ *cluster.LOM, err error) { errCh <- err }); err != nil {
+		return err
+	}
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(ecTimeout):
+		return errEcTimeout
+	}
+}
+
+// returns the number of slices (or replicas) that are lost, and the remaining redundancy
+func (r *repairer) ecMissing(lom *cluster.LOM) (missing, redundancy int, ok bool) {
+	md, err := ec.LoadMetadata(cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN())
+	if err != nil || md.SliceID != 0 {
+		return // not encoded yet (or not the main replica)
+	}
+	smap := r.t.Sowner().Get()
+	for tid := range md.Daemons {
+		if tid == r.t.SID() {
+			continue
+		}
+		if smap.GetNodeNotMaint(tid) == nil {
+			missing++
+		}
+	}
+	return missing, md.Parity - missing, true
+}
+
+func (r *repairer) scan(reason string) {
+	defer r.scanning.Store(false)
+	// only mirrored and erasure-coded buckets
+	var bcks []cmn.Bck
+	r.t.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.Mirror.Enabled || bck.Props.EC.Enabled {
+			bcks = append(bcks, bck.Clone())
+		}
+		return false
+	})
+	if len(bcks) == 0 {
+		return
+	}
+	glog.Infof("%s: scanning %d bucket(s) for under-protected objects (%s)", r.t, len(bcks), reason)
+	slab, err := r.t.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+	for i := range bcks {
+		opts := &mpather.JoggerGroupOpts{
+			T:        r.t,
+			Bck:      bcks[i],
+			CTs:      []string{fs.ObjectType},
+			VisitObj: scanObj,
+			Slab:     slab,
+			DoLoad:   mpather.Load,
+			Throttle: true,
+		}
+		jg := mpather.NewJoggerGroup(opts)
+		jg.Run()
+		select {
+		case <-jg.ListenFinished():
+			err = jg.Stop()
+		case <-r.stopCh.Listen():
+			jg.Stop()
+			return
+		}
+		if err != nil {
+			glog.Errorf("%s: scan %s for under-protected objects failed: %v", r.t, bcks[i], err)
+		}
+	}
+	r.mu.Lock()
+	n := r.q.len()
+	r.mu.Unlock()
+	glog.Infof("%s: scan for under-protected objects done (queued %d)", r.t, n)
+}
+
+func scanObj(lom *cluster.LOM, _ []byte) error {
+	if lom.MirrorConf().Enabled {
+		CheckCopies(lom, SrcMpath)
+	}
+	if lom.Bprops().EC.Enabled {
+		CheckEC(lom, SrcMpath)
+	}
+	return nil
+}
+
+func (r *repairer) statsAdd(name string, val int64) {
+	if r.statsT != nil {
+		r.statsT.Add(name, val)
+	}
+}
+
+func verbose() bool { return bool(glog.FastV(4, glog.SmoduleFS)) }
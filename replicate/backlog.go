@@ -0,0 +1,119 @@
+// Package replicate provides asynchronous (continuous) replication of buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package replicate
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/backlog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+const dbCollection = "replication"
+
+type (
+	replicator struct {
+		q      *backlog.Queue
+		statsT stats.Tracker
+		exec   func(rec *record) (size int64, err error)
+		tid    string
+	}
+	// pending operation (as stored in the db)
+	record struct {
+		Op      string  `json:"op"`   // enum { OpPut, OpDelete }
+		Src     cmn.Bck `json:"src"`  // source bucket
+		Dst     string  `json:"dst"`  // destination bucket (see cmn.ReplicationConf)
+		ObjName string  `json:"name"` // object name
+		Time    int64   `json:"time"` // when the operation took place (Unix nanoseconds)
+		key     string
+	}
+)
+
+func newReplicator(tid string, db dbdriver.Driver, statsT stats.Tracker) *replicator {
+	r := &replicator{statsT: statsT, tid: tid}
+	r.q = backlog.New(&backlog.Args{
+		DB:         db,
+		Collection: dbCollection,
+		Tag:        tid,
+		Conf:       r.conf,
+		New:        func() interface{} { return &record{} },
+		// per destination bucket; same object => same worker (to keep the order of operations)
+		Route: func(v interface{}) (string, string) { rec := v.(*record); return rec.Dst, rec.ObjName },
+		Exec:  r.execute,
+		Stamp: func(v interface{}, seq int64) { v.(*record).key = dbKey(seq) },
+		Done:  r.done,
+		Drop:  func(recs []interface{}, _ error) { r.dropped(int64(len(recs))) },
+		Error: func(error) { r.statsAdd(stats.ErrReplCount, 1) },
+	})
+	return r
+}
+
+func dbKey(seq int64) string { return backlog.DBKey(seq) }
+
+func (*replicator) conf() backlog.Conf {
+	conf := cmn.GCO.Get().Replicator.Defaults()
+	return backlog.Conf{MaxQueue: conf.MaxQueue, MaxRetries: conf.MaxRetries, Workers: conf.Workers}
+}
+
+// load pending operations (upon startup)
+func (r *replicator) load() error {
+	n, err := r.q.Load()
+	if n > 0 {
+		r.statsAdd(stats.ReplPending, int64(n))
+		glog.Infof("%s: loaded %d pending replication operation%s", r.tid, n, cos.Plural(n))
+	}
+	return err
+}
+
+func (r *replicator) enqueue(rec *record) {
+	if !r.q.Push(rec) {
+		r.statsAdd(stats.ReplDropCount, 1)
+		return
+	}
+	r.statsAdd(stats.ReplPending, 1)
+}
+
+func (r *replicator) execute(_ string, recs []interface{}) error {
+	for _, v := range recs {
+		rec := v.(*record)
+		size, err := r.exec(rec)
+		if err != nil {
+			glog.Errorf("%s: failed to replicate %s %s/%s => %s: %v", r.tid, rec.Op, rec.Src, rec.ObjName, rec.Dst, err)
+			return err
+		}
+		if size > 0 {
+			r.statsAdd(stats.ReplSize, size)
+		}
+	}
+	return nil
+}
+
+func (r *replicator) done(recs []interface{}) {
+	now := time.Now().UnixNano()
+	r.statsAdd(stats.ReplPending, -int64(len(recs)))
+	r.statsAdd(stats.ReplCount, int64(len(recs)))
+	for _, v := range recs {
+		r.statsAdd(stats.ReplLatency, now-v.(*record).Time)
+	}
+}
+
+func (r *replicator) dropped(n int64) {
+	r.statsAdd(stats.ReplPending, -n)
+	r.statsAdd(stats.ReplDropCount, n)
+}
+
+func (r *replicator) pending() int64 { return r.q.Len() }
+
+func (r *replicator) stop() { r.q.Stop() }
+
+func (r *replicator) statsAdd(name string, val int64) {
+	if r.statsT != nil {
+		r.statsT.Add(name, val)
+	}
+}
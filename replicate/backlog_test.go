@@ -0,0 +1,152 @@
+// Package replicate provides asynchronous (continuous) replication of buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package replicate
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+)
+
+// destination "cluster"
+type remote struct {
+	fail atomic.Int32 // number of operations to fail
+	mu   sync.Mutex
+	ops  []*record
+}
+
+func init() {
+	config := cmn.GCO.BeginUpdate()
+	config.Replicator.Workers = 2
+	config.Replicator.MaxQueue = 100
+	cmn.GCO.CommitUpdate(config)
+}
+
+func newDB(t *testing.T, dir string) dbdriver.Driver {
+	db, err := dbdriver.NewBuntDB(filepath.Join(dir, "test.db"))
+	tassert.CheckFatal(t, err)
+	return db
+}
+
+func newRec(op, objName string) *record {
+	return &record{
+		Op:      op,
+		Src:     cmn.Bck{Name: "src", Provider: apc.ProviderAIS},
+		Dst:     "ais://@remais/dst",
+		ObjName: objName,
+		Time:    time.Now().UnixNano(),
+	}
+}
+
+func (rem *remote) exec(rec *record) (int64, error) {
+	if rem.fail.Dec() >= 0 {
+		return 0, errors.New("remote cluster unavailable")
+	}
+	rem.mu.Lock()
+	rem.ops = append(rem.ops, rec)
+	rem.mu.Unlock()
+	return 1, nil
+}
+
+func (rem *remote) wait(t *testing.T, n int) (ops []*record) {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		rem.mu.Lock()
+		ops = append(ops[:0], rem.ops...)
+		rem.mu.Unlock()
+		if len(ops) >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d operations (executed %d)", n, len(ops))
+	return
+}
+
+func TestReplicatorOrder(t *testing.T) {
+	rem := &remote{}
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	r := newReplicator("t1", db, nil)
+	r.exec = rem.exec
+	for i := 0; i < 10; i++ {
+		r.enqueue(newRec(OpPut, "o"+strconv.Itoa(i%3)))
+		if i%3 == 2 {
+			r.enqueue(newRec(OpDelete, "o"+strconv.Itoa(i%3)))
+		}
+	}
+	ops := rem.wait(t, 13)
+	r.stop()
+
+	// per-object order
+	last := make(map[string]*record, 3)
+	for _, rec := range ops {
+		if prev, ok := last[rec.ObjName]; ok {
+			tassert.Errorf(t, prev.key < rec.key, "out of order: %s after %s (%s)", rec.key, prev.key, rec.ObjName)
+		}
+		last[rec.ObjName] = rec
+	}
+	tassert.Errorf(t, r.pending() == 0, "expected no pending operations, got %d", r.pending())
+	keys, err := db.List(dbCollection, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected executed operations to be removed, got %d", len(keys))
+}
+
+func TestReplicatorRetry(t *testing.T) {
+	rem := &remote{}
+	rem.fail.Store(1)
+	db := newDB(t, t.TempDir())
+	defer db.Close()
+
+	r := newReplicator("t1", db, nil)
+	r.exec = rem.exec
+	r.enqueue(newRec(OpDelete, "o"))
+	ops := rem.wait(t, 1)
+	r.stop()
+	tassert.Errorf(t, len(ops) == 1 && ops[0].Op == OpDelete, "unexpected operations %+v", ops)
+}
+
+func TestReplicatorBacklog(t *testing.T) {
+	rem := &remote{}
+	rem.fail.Store(1 << 20) // remote cluster "down"
+	dir := t.TempDir()
+
+	db := newDB(t, dir)
+	r := newReplicator("t1", db, nil)
+	r.exec = rem.exec
+	for i := 0; i < 110; i++ {
+		r.enqueue(newRec(OpPut, "o"+strconv.Itoa(i)))
+	}
+	// max queue
+	tassert.Errorf(t, r.pending() == 100, "expected 100 pending operations, got %d", r.pending())
+	r.stop()
+	tassert.CheckFatal(t, db.Close())
+
+	// "restart"
+	rem.fail.Store(0)
+	db = newDB(t, dir)
+	defer db.Close()
+	r = newReplicator("t1", db, nil)
+	r.exec = rem.exec
+	tassert.CheckFatal(t, r.load())
+	rem.wait(t, 100)
+
+	// sequence numbers continue where they left off
+	rec := newRec(OpPut, "o100")
+	r.enqueue(rec)
+	rem.wait(t, 101)
+	r.stop()
+	tassert.Errorf(t, rec.key == dbKey(101), "unexpected key %q", rec.key)
+}
@@ -0,0 +1,144 @@
+// Package replicate provides asynchronous (continuous) replication of buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package replicate
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/backlog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+// execute pending operation
+func execute(t cluster.Target, rec *record) (int64, error) {
+	src := cluster.CloneBck(&rec.Src)
+	if err := src.Init(t.Bowner()); err != nil {
+		if cmn.IsErrBucketNought(err) {
+			glog.Warningf("%s: source bucket %s does not exist - not replicating %s %s",
+				t, rec.Src, rec.Op, rec.ObjName)
+			return 0, nil
+		}
+		return 0, err
+	}
+	dst, err := initDest(t, rec.Dst)
+	if err != nil {
+		return 0, err
+	}
+	switch rec.Op {
+	case OpPut:
+		lom := cluster.AllocLOM(rec.ObjName)
+		defer cluster.FreeLOM(lom)
+		if err := lom.InitBck(src.Bucket()); err != nil {
+			return 0, backlog.Permanent(err)
+		}
+		return put(t, lom, dst)
+	case OpDelete:
+		return 0, del(t, rec.ObjName, dst)
+	default:
+		glog.Errorf("%s: invalid replication operation %q (%s/%s) - skipping", t, rec.Op, rec.Src, rec.ObjName)
+		return 0, nil
+	}
+}
+
+// destination bucket must be known (see proxy's handling of `replication.bucket`);
+// invalid or nonexistent destination is a permanent error (not to retry)
+func initDest(t cluster.Target, bucket string) (*cluster.Bck, error) {
+	conf := cmn.ReplicationConf{Bucket: bucket}
+	bck, err := conf.Dest()
+	if err != nil {
+		return nil, backlog.Permanent(err)
+	}
+	dst := cluster.CloneBck(&bck)
+	if err := dst.Init(t.Bowner()); err != nil {
+		if cmn.IsErrBucketNought(err) {
+			err = backlog.Permanent(err)
+		}
+		return nil, err
+	}
+	return dst, nil
+}
+
+// client errors (e.g., access denied) are permanent, while timeouts, throttling,
+// and server errors are not
+func permanent(errCode int) bool {
+	return errCode >= http.StatusBadRequest && errCode < http.StatusInternalServerError &&
+		errCode != http.StatusRequestTimeout && errCode != http.StatusTooManyRequests
+}
+
+// put sends the current content of a given (local) object to the destination;
+// is a no-op if the object does not exist (any longer)
+func put(t cluster.Target, lom *cluster.LOM, dst *cluster.Bck) (size int64, err error) {
+	lom.Lock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cmn.IsObjNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	roc, oah, err := lom.PlainReader()
+	lom.Unlock(false)
+	if err != nil {
+		return
+	}
+	dlom := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(dlom)
+	if err = dlom.InitBck(dst.Bucket()); err != nil {
+		cos.Close(roc)
+		return
+	}
+	encrypted := lom.IsEncrypted()
+	dlom.CopyAttrs(oah, encrypted /*skip checksum (of the ciphertext)*/)
+	if encrypted {
+		dlom.SetCksum(cos.NoneCksum)
+	}
+	size = oah.SizeBytes()
+	if errCode, errPut := t.Backend(dst).PutObj(roc, dlom); errPut != nil {
+		err = fmt.Errorf("PUT %s => %s: %w", lom, dst, errPut)
+		if permanent(errCode) {
+			err = backlog.Permanent(err)
+		}
+	}
+	return
+}
+
+// del deletes the object from the destination (ignoring "does not exist")
+func del(t cluster.Target, objName string, dst *cluster.Bck) error {
+	dlom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(dlom)
+	if err := dlom.InitBck(dst.Bucket()); err != nil {
+		return backlog.Permanent(err)
+	}
+	errCode, err := t.Backend(dst).DeleteObj(dlom)
+	if err == nil || errCode == http.StatusNotFound || cmn.IsObjNotExist(err) {
+		return nil
+	}
+	err = fmt.Errorf("DELETE %s/%s: %w", dst, objName, err)
+	if permanent(errCode) {
+		err = backlog.Permanent(err)
+	}
+	return err
+}
+
+// equal returns true if the (loaded) object and its (listed) replica have the same
+// size and, if comparable, the same checksum
+func equal(lom *cluster.LOM, oa *cmn.ObjAttrs) bool {
+	if oa == nil || sse.Size(lom) != oa.Size {
+		return false
+	}
+	if lom.IsEncrypted() {
+		return true // (checksum of the ciphertext)
+	}
+	cksum := lom.Checksum()
+	if cksum == nil || cksum.Type() == cos.ChecksumNone || oa.Cksum == nil || oa.Cksum.Type() != cksum.Type() {
+		return true
+	}
+	return cksum.Equal(oa.Cksum)
+}
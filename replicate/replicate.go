@@ -0,0 +1,82 @@
+// Package replicate provides asynchronous (continuous) replication of buckets to remote
+// AIS clusters: targets forward PUTs, APPENDs, and DELETEs of the objects to the destination
+// bucket configured on a per-bucket basis (see cmn.ReplicationConf).
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package replicate
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Replication
+//
+// Each target replicates the objects it stores. Every operation - PUT (that also
+// covers APPEND) or DELETE - is first persisted in the target's local database (the
+// backlog), so that pending operations survive restarts. Operations are queued per
+// destination bucket and executed by up to `replicator.workers` workers; operations
+// on the same object are always executed by the same worker, in order.
+//
+// Replicating PUT sends the object's current content (or nothing if the object has
+// since been deleted), which makes APPENDs and repeated PUTs idempotent. Failed
+// operations are retried (with exponential backoff) up to `replicator.max_retries`
+// times; permanent errors (e.g., the destination bucket does not exist, access denied)
+// are not retried. The backlog itself is implemented by the backlog package.
+//
+// When the number of operations pending for a given destination bucket reaches
+// `replicator.max_queue` new operations are dropped; operations that fail permanently
+// or exceed the retries are dropped as well (all counted, see stats.ReplDropCount). The resync xaction (see XactResync)
+// reconciles the destination with the source bucket, and is the way to catch up after
+// drops, as well as to replicate the objects that existed prior to enabling replication.
+
+// operations
+const (
+	OpPut    = "put" // including APPEND, S3 multipart upload, etc.
+	OpDelete = "delete"
+)
+
+// global replicator
+var gr *replicator
+
+// Init registers the resync xaction
+func Init() { xreg.RegBckXact(&resyncFactory{}) }
+
+// Start loads pending operations (if any) and starts replicating
+func Start(t cluster.Target, db dbdriver.Driver, statsT stats.Tracker) {
+	gr = newReplicator(t.SID(), db, statsT)
+	gr.exec = func(rec *record) (int64, error) { return execute(t, rec) }
+	if err := gr.load(); err != nil {
+		glog.Errorf("%s: failed to load pending replication: %v", t, err)
+	}
+}
+
+func Stop() {
+	if gr != nil {
+		gr.stop()
+	}
+}
+
+// Emit queues the operation if the object's bucket is replicated
+func Emit(op string, lom *cluster.LOM) {
+	if gr == nil {
+		return
+	}
+	bck := lom.Bck()
+	if bck.Props == nil || !bck.Props.Replication.Match(lom.ObjName) {
+		return
+	}
+	gr.enqueue(&record{
+		Op:      op,
+		Src:     *bck.Bucket(),
+		Dst:     bck.Props.Replication.Bucket,
+		ObjName: lom.ObjName,
+		Time:    time.Now().UnixNano(),
+	})
+}
@@ -0,0 +1,243 @@
+// Package replicate provides asynchronous (continuous) replication of buckets to remote AIS clusters.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package replicate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Resync xaction reconciles the destination bucket with the target's share of the
+// source: each target walks its objects (in sorted order, see fs.WalkBck) and, at the
+// same time, lists the destination - keeping only the names that map to itself (HRW).
+// The two sorted streams are then compared by downloader.DiffResolver:
+// - objects missing at the destination or different (size, checksum) are sent,
+// - objects that exist only at the destination are deleted.
+
+const resyncPageSize = 1000
+
+type (
+	resyncFactory struct {
+		xreg.RenewBase
+		xctn *XactResync
+	}
+	XactResync struct {
+		xact.Base
+		t cluster.Target
+	}
+	// implements downloader.DiffResolverCtx
+	resyncCtx struct{}
+)
+
+// interface guard
+var (
+	_ cluster.Xact               = (*XactResync)(nil)
+	_ xreg.Renewable             = (*resyncFactory)(nil)
+	_ downloader.DiffResolverCtx = (*resyncCtx)(nil)
+)
+
+///////////////////
+// resyncFactory //
+///////////////////
+
+func (*resyncFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &resyncFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *resyncFactory) Start() error {
+	p.xctn = &XactResync{t: p.T}
+	p.xctn.InitBase(p.UUID(), apc.ActReplResync, p.Bck)
+	return nil
+}
+
+func (*resyncFactory) Kind() string        { return apc.ActReplResync }
+func (p *resyncFactory) Get() cluster.Xact { return p.xctn }
+
+func (*resyncFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+////////////////
+// XactResync //
+////////////////
+
+func (r *XactResync) Run(*sync.WaitGroup) {
+	glog.Infoln(r.String())
+	err := r.do()
+	if err != nil {
+		glog.Errorf("%s: %v", r, err)
+	}
+	r.Finish(err)
+	glog.Infof("%s finished (objects %d, size %d)", r, r.Objs(), r.Bytes())
+}
+
+func (r *XactResync) do() error {
+	var (
+		bck  = r.Bck()
+		conf = &bck.Props.Replication
+	)
+	if conf.Bucket == "" {
+		return fmt.Errorf("%s: destination bucket is not configured (see bucket property replication.bucket)", r)
+	}
+	dst, err := initDest(r.t, conf.Bucket)
+	if err != nil {
+		return err
+	}
+	var (
+		dr     = downloader.NewDiffResolver(&resyncCtx{})
+		errs   cos.ErrValue
+		prefix = conf.Prefix
+	)
+	dr.Start()
+	go r.walk(dr, &errs, prefix)
+	go r.list(dr, &errs, dst, prefix)
+
+	// NOTE: keep consuming until EOF so that neither of the producers remains blocked
+	for {
+		res, _ := dr.Next()
+		if res.Action == downloader.DiffResolverEOF {
+			break
+		}
+		if dr.Stopped() {
+			continue
+		}
+		if r.IsAborted() {
+			errs.Store(cmn.NewErrAborted(r.Name(), "", r.AbortErr()))
+			dr.Stop()
+			continue
+		}
+		var (
+			size int64
+			err  error
+		)
+		switch res.Action {
+		case downloader.DiffResolverSend:
+			size, err = put(r.t, res.Src, dst)
+		case downloader.DiffResolverRecv:
+			// exists only at the destination (delete) or differs (send)
+			lom := cluster.AllocLOM(res.Dst.ObjName)
+			if err = lom.InitBck(bck.Bucket()); err == nil {
+				if errLoad := lom.Load(false, false); errLoad == nil {
+					size, err = put(r.t, lom, dst)
+				} else if cmn.IsObjNotExist(errLoad) {
+					err = del(r.t, res.Dst.ObjName, dst)
+				} else {
+					err = errLoad
+				}
+			}
+			cluster.FreeLOM(lom)
+		case downloader.DiffResolverSkip:
+			continue
+		case downloader.DiffResolverErr:
+			err = res.Err
+		}
+		if err != nil {
+			errs.Store(err)
+			dr.Stop()
+			continue
+		}
+		r.ObjsAdd(1, size)
+	}
+	return errs.Err()
+}
+
+// walk the local objects (source)
+func (r *XactResync) walk(dr *downloader.DiffResolver, errs *cos.ErrValue, prefix string) {
+	defer dr.CloseSrc()
+	bck := r.Bck()
+	cb := func(fqn string, _ fs.DirEntry) error {
+		if dr.Stopped() {
+			return cmn.NewErrAborted(r.Name(), "diff-resolver stopped", nil)
+		}
+		lom := &cluster.LOM{}
+		if err := lom.InitFQN(fqn, bck.Bucket()); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(lom.ObjName, prefix) {
+			return nil
+		}
+		dr.PushSrc(lom)
+		return nil
+	}
+	opts := &fs.WalkBckOpts{
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: cb, Sorted: true},
+	}
+	opts.WalkOpts.Bck.Copy(bck.Bucket())
+	if err := fs.WalkBck(opts); err != nil && !cmn.IsErrAborted(err) {
+		errs.Store(err)
+		dr.Stop()
+	}
+}
+
+// list the destination, page by page, keeping only the names that belong to this target
+func (r *XactResync) list(dr *downloader.DiffResolver, errs *cos.ErrValue, dst *cluster.Bck, prefix string) {
+	defer dr.CloseDst()
+	var (
+		src     = r.Bck()
+		backend = r.t.Backend(dst)
+		smap    = r.t.Sowner().Get()
+		msg     = &apc.ListObjsMsg{
+			Props:    apc.GetPropsSize + "," + apc.GetPropsChecksum,
+			Prefix:   prefix,
+			PageSize: resyncPageSize,
+		}
+	)
+	for !dr.Stopped() {
+		bckList, _, err := backend.ListObjects(dst, msg)
+		if err != nil {
+			errs.Store(fmt.Errorf("%s: failed to list %s: %w", r, dst, err))
+			dr.Stop()
+			return
+		}
+		for _, e := range bckList.Entries {
+			si, err := cluster.HrwTarget(src.MakeUname(e.Name), smap)
+			if err != nil {
+				errs.Store(err)
+				dr.Stop()
+				return
+			}
+			if si.ID() != r.t.SID() {
+				continue
+			}
+			oa := &cmn.ObjAttrs{Size: e.Size}
+			if e.Checksum != "" {
+				oa.Cksum = cos.NewCksum(dst.Props.Cksum.Type, e.Checksum)
+			}
+			dr.PushDst(&downloader.BackendResource{ObjName: e.Name, Attrs: oa})
+		}
+		if bckList.ContinuationToken == "" {
+			return
+		}
+		msg.ContinuationToken = bckList.ContinuationToken
+	}
+}
+
+///////////////
+// resyncCtx //
+///////////////
+
+func (*resyncCtx) CompareObjects(lom *cluster.LOM, dst *downloader.DstElement) (bool, error) {
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return equal(lom, dst.Attrs), nil
+}
+
+// local objects are never deleted
+func (*resyncCtx) IsObjFromRemote(*cluster.LOM) (bool, error) { return false, nil }
@@ -105,7 +105,7 @@ func (res *Res) RunResilver(args Args) {
 
 		opts = &mpather.JoggerGroupOpts{
 			T:                     res.t,
-			CTs:                   []string{fs.ObjectType, fs.ECSliceType},
+			CTs:                   []string{fs.ObjectType, fs.ECSliceType, fs.ObjVerType},
 			VisitObj:              jctx.visitObj,
 			VisitCT:               jctx.visitCT,
 			Slab:                  slab,
@@ -359,7 +359,11 @@ func (*joggerCtx) fixHrw(lom *cluster.LOM, mi *fs.MountpathInfo, buf []byte) (hl
 	return
 }
 
-func (*joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
+func (jg *joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
+	if ct.ContentType() == fs.ObjVerType {
+		jg.visitVer(ct, buf)
+		return nil
+	}
 	debug.Assert(ct.ContentType() == fs.ECSliceType)
 	if !ct.Bck().Props.EC.Enabled {
 		// Since `%ec` directory is inside a bucket, it is safe to skip
@@ -369,3 +373,25 @@ func (*joggerCtx) visitCT(ct *cluster.CT, buf []byte) (err error) {
 	_mvSlice(ct, buf)
 	return nil
 }
+
+// previous object version (see versioning.max_history) goes to its object's hrw mountpath
+func (jg *joggerCtx) visitVer(ct *cluster.CT, buf []byte) {
+	objName, ver, ok := cluster.ParseVerCT(ct)
+	if !ok {
+		return
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		return
+	}
+	if lom.MpathInfo().Path == ct.MpathInfo().Path {
+		return
+	}
+	lom.Lock(true)
+	err := cluster.MoveVersion(ct.FQN(), lom.VersionFQN(ver), buf)
+	lom.Unlock(true)
+	if err != nil && !os.IsNotExist(err) {
+		glog.Warningf("%s: failed to move %s version %q to %s: %v", jg.xres.Name(), lom, ver, lom.MpathInfo(), err)
+	}
+}
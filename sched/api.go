@@ -0,0 +1,256 @@
+// Package sched provides cron-like schedules of cluster jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package sched
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// overlap policies: what to do when the previous run is still running
+const (
+	OverlapSkip   = "skip"   // skip this run (default)
+	OverlapQueue  = "queue"  // start as soon as the previous run finishes
+	OverlapCancel = "cancel" // abort the previous run and start a new one
+)
+
+// job kinds (see Spec.JobKind)
+const (
+	JobXaction  = "xaction"
+	JobDownload = "download"
+	JobDsort    = "dsort"
+)
+
+const (
+	MaxHistory = 32 // max number of runs to keep per schedule
+	maxNameLen = 64
+)
+
+type (
+	// user-defined schedule
+	Spec struct {
+		Name     string        `json:"name"`
+		Cron     string        `json:"cron"`     // see ParseCron
+		Action   apc.ActionMsg `json:"action"`   // job to run (see IsSupported)
+		Bck      cmn.Bck       `json:"bck"`      // bucket, for bucket actions
+		BckTo    cmn.Bck       `json:"bck_to"`   // destination bucket: copy-bck and etl-bck
+		Overlap  string        `json:"overlap"`  // enum { OverlapSkip, ... }
+		Disabled bool          `json:"disabled"` // when true, the schedule does not fire
+	}
+	// a single (scheduled) run
+	Run struct {
+		Scheduled int64  `json:"scheduled,string"`  // planned time
+		Started   int64  `json:"started,string"`    // actual time (zero when skipped)
+		JobID     string `json:"job_id,omitempty"`  // xaction, download, or dSort UUID
+		Err       string `json:"err,omitempty"`     // failed to start
+		Skipped   string `json:"skipped,omitempty"` // reason
+	}
+	// schedule with its state and history of runs
+	Entry struct {
+		Spec
+		Created int64 `json:"created,string"`
+		Next    int64 `json:"next,string"`   // next time to fire (zero - never)
+		Queued  int64 `json:"queued,string"` // scheduled time of the run waiting for the previous one (OverlapQueue)
+		History []Run `json:"history"`       // most recent last
+	}
+
+	// schedules metadata (SchedMD): replicated across all proxies
+	MD struct {
+		Version int64             `json:"version"`
+		Entries map[string]*Entry `json:"entries"`
+		Ext     interface{}       `json:"ext,omitempty"` // within meta-version extensions
+	}
+)
+
+var (
+	schedMDJspOpts = jsp.CCSign(cmn.MetaverSchedMD)
+
+	// bucket actions that can be scheduled (POST /v1/buckets/<bck>)
+	bckActions = cos.NewStringSet(
+		apc.ActCopyBck,
+		apc.ActETLBck,
+		apc.ActCopyObjects,
+		apc.ActETLObjects,
+		apc.ActPrefetchObjects,
+		apc.ActEvictObjects,
+		apc.ActDeleteObjects,
+		apc.ActArchive,
+		apc.ActECEncode,
+		apc.ActMakeNCopies,
+	)
+)
+
+// interface guard
+var _ jsp.Opts = (*MD)(nil)
+
+func IsSupported(action string) bool {
+	return bckActions.Contains(action) ||
+		action == apc.ActXactStart || action == apc.ActDownload || action == apc.ActDsort
+}
+
+func IsBckAction(action string) bool { return bckActions.Contains(action) }
+
+// sorted
+func BckActions() (actions []string) {
+	actions = bckActions.ToSlice()
+	sort.Strings(actions)
+	return
+}
+
+//////////
+// Spec //
+//////////
+
+func (s *Spec) Validate() (err error) {
+	if s.Name == "" || len(s.Name) > maxNameLen || !cos.IsAlphaPlus(s.Name, false /*with period*/) {
+		return fmt.Errorf("invalid schedule name %q: must be non-empty, at most %d characters, "+
+			"and can only contain [A-Za-z0-9-_]", s.Name, maxNameLen)
+	}
+	if _, err = ParseCron(s.Cron); err != nil {
+		return
+	}
+	switch s.Overlap {
+	case "":
+		s.Overlap = OverlapSkip
+	case OverlapSkip, OverlapQueue, OverlapCancel:
+	default:
+		return fmt.Errorf("invalid overlap policy %q (expecting one of: %s, %s, %s)",
+			s.Overlap, OverlapSkip, OverlapQueue, OverlapCancel)
+	}
+	action := s.Action.Action
+	switch {
+	case action == apc.ActXactStart:
+		var msg xact.QueryMsg
+		if err = cos.MorphMarshal(s.Action.Value, &msg); err != nil {
+			return fmt.Errorf("invalid %q value: %v", action, err)
+		}
+		if !xact.Table[msg.Kind].Startable {
+			return fmt.Errorf("cannot schedule %q: xaction kind %q is not startable", action, msg.Kind)
+		}
+	case action == apc.ActDownload || action == apc.ActDsort:
+		if s.Action.Value == nil {
+			return fmt.Errorf("cannot schedule %q: missing job specification", action)
+		}
+	case bckActions.Contains(action):
+		if err = s.Bck.Validate(); err != nil {
+			return fmt.Errorf("cannot schedule %q: %v", action, err)
+		}
+		if action == apc.ActCopyBck || action == apc.ActETLBck {
+			if err = s.BckTo.Validate(); err != nil {
+				return fmt.Errorf("cannot schedule %q: invalid destination: %v", action, err)
+			}
+		}
+	default:
+		return fmt.Errorf("cannot schedule %q: unsupported action", action)
+	}
+	return nil
+}
+
+func (s *Spec) JobKind() string {
+	switch s.Action.Action {
+	case apc.ActDownload:
+		return JobDownload
+	case apc.ActDsort:
+		return JobDsort
+	default:
+		return JobXaction
+	}
+}
+
+///////////
+// Entry //
+///////////
+
+func NewEntry(spec *Spec, now time.Time) *Entry {
+	e := &Entry{Spec: *spec, Created: now.UnixNano()}
+	e.SetNext(now)
+	return e
+}
+
+func (e *Entry) String() string { return "schedule[" + e.Name + "]" }
+
+// compute the next time to fire (the spec is presumed validated)
+func (e *Entry) SetNext(now time.Time) {
+	c, err := ParseCron(e.Cron)
+	if err != nil {
+		e.Next = 0
+		return
+	}
+	if next := c.Next(now); !next.IsZero() {
+		e.Next = next.UnixNano()
+	} else {
+		e.Next = 0
+	}
+}
+
+// returns true if the entry must fire (or be considered for firing) at a given time
+func (e *Entry) Due(now int64) bool {
+	return !e.Disabled && (e.Queued != 0 || (e.Next != 0 && e.Next <= now))
+}
+
+func (e *Entry) Last() *Run {
+	for i := len(e.History) - 1; i >= 0; i-- {
+		if e.History[i].Started != 0 {
+			return &e.History[i]
+		}
+	}
+	return nil
+}
+
+func (e *Entry) AddRun(run Run) {
+	if len(e.History) >= MaxHistory {
+		e.History = append(e.History[:0], e.History[len(e.History)-MaxHistory+1:]...)
+	}
+	e.History = append(e.History, run)
+}
+
+// copy-on-write
+func (e *Entry) Clone() *Entry {
+	dst := &Entry{}
+	*dst = *e
+	dst.History = make([]Run, len(e.History), len(e.History)+1)
+	copy(dst.History, e.History)
+	return dst
+}
+
+////////
+// MD //
+////////
+
+func (*MD) JspOpts() jsp.Options { return schedMDJspOpts }
+
+func (md *MD) Init(l int) { md.Entries = make(map[string]*Entry, l) }
+
+func (md *MD) Get(name string) (e *Entry, present bool) {
+	if md == nil {
+		return
+	}
+	e, present = md.Entries[name]
+	return
+}
+
+// sorted by name
+func (md *MD) List() (entries []*Entry) {
+	entries = make([]*Entry, 0, len(md.Entries))
+	for _, e := range md.Entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return
+}
+
+func (md *MD) String() string {
+	if md == nil {
+		return "SchedMD <nil>"
+	}
+	return fmt.Sprintf("SchedMD v%d(%d)", md.Version, len(md.Entries))
+}
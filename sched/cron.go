@@ -0,0 +1,222 @@
+// Package sched provides cron-like schedules of cluster jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package sched
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron expression: five space-separated fields
+//
+//	minute (0-59) hour (0-23) day-of-month (1-31) month (1-12 or JAN-DEC) day-of-week (0-7 or SUN-SAT)
+//
+// where each field is either `*` or a comma-separated list of values and ranges (`a-b`),
+// optionally followed by a step (`*/15`, `1-30/5`). As in the standard cron, when both
+// day-of-month and day-of-week are restricted the job runs when either one matches.
+// Also supported are the descriptors @yearly (@annually), @monthly, @weekly, @daily
+// (@midnight), @hourly, and `@every <duration>` (e.g., `@every 90m`).
+//
+// All times are UTC.
+
+const MinInterval = 10 * time.Second // min `@every` interval
+
+// searching for the next time stops after so many years (e.g., "0 0 30 2 *")
+const maxYears = 5
+
+type (
+	Cron struct {
+		expr   string
+		minute uint64
+		hour   uint64
+		dom    uint64
+		month  uint64
+		dow    uint64
+		every  time.Duration
+		anyDom bool // day-of-month is `*`
+		anyDow bool // day-of-week is `*`
+	}
+	field struct {
+		name     string
+		min, max int
+		names    []string // optional, indexed by value - min
+	}
+)
+
+var (
+	fMinute = field{name: "minute", min: 0, max: 59}
+	fHour   = field{name: "hour", min: 0, max: 23}
+	fDom    = field{name: "day-of-month", min: 1, max: 31}
+	fMonth  = field{name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	fDow = field{name: "day-of-week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+func ParseCron(expr string) (*Cron, error) {
+	var (
+		c = &Cron{expr: expr}
+		s = strings.TrimSpace(expr)
+	)
+	if strings.HasPrefix(s, "@every") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, "@every")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		if d < MinInterval {
+			return nil, fmt.Errorf("invalid cron expression %q: interval must be at least %v", expr, MinInterval)
+		}
+		c.every = d
+		return c, nil
+	}
+	if d, ok := descriptors[strings.ToLower(s)]; ok {
+		s = d
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expecting 5 fields or a descriptor, got %d field(s)",
+			expr, len(fields))
+	}
+	var err error
+	if c.minute, err = fMinute.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if c.hour, err = fHour.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if c.dom, err = fDom.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if c.month, err = fMonth.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if c.dow, err = fDow.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if c.dow&(1<<7) != 0 { // 7 is Sunday as well
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.anyDom, c.anyDow = fields[2] == "*", fields[4] == "*"
+	return c, nil
+}
+
+func (c *Cron) String() string { return c.expr }
+
+// Next returns the first time (strictly) after the given one that satisfies the expression,
+// or zero time if there's none in the foreseeable future
+func (c *Cron) Next(after time.Time) time.Time {
+	if c.every > 0 {
+		return after.Add(c.every)
+	}
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxYears, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	var (
+		dom = c.dom&(1<<uint(t.Day())) != 0
+		dow = c.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+///////////
+// field //
+///////////
+
+// returns a bitmask of the matching values
+func (f *field) parse(s string) (mask uint64, err error) {
+	for _, item := range strings.Split(s, ",") {
+		var (
+			lo, hi = f.min, f.max
+			step   = 1
+			rng    = item
+		)
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			rng = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step in %q", f.name, item)
+			}
+		}
+		switch {
+		case rng == "*":
+		case strings.IndexByte(rng, '-') > 0:
+			i := strings.IndexByte(rng, '-')
+			if lo, err = f.value(rng[:i]); err != nil {
+				return
+			}
+			if hi, err = f.value(rng[i+1:]); err != nil {
+				return
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		default:
+			if lo, err = f.value(rng); err != nil {
+				return
+			}
+			if rng != item {
+				hi = f.max // e.g. "5/15" is the same as "5-59/15"
+			} else {
+				hi = lo
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	if mask == 0 {
+		err = fmt.Errorf("empty %s %q", f.name, s)
+	}
+	return
+}
+
+func (f *field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q (expecting %d to %d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
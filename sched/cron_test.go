@@ -0,0 +1,114 @@
+// Package sched provides cron-like schedules of cluster jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package sched_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/sched"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func TestCronNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2022, time.June, 15, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2022, time.June, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, time.June, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2022, time.June, 16, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2022, time.June, 16, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2022, time.June, 15, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 4 * * sun", time.Date(2022, time.June, 19, 4, 30, 0, 0, time.UTC)},
+		{"30 4 * * 7", time.Date(2022, time.June, 19, 4, 30, 0, 0, time.UTC)},
+		{"0 0 * * mon-fri", time.Date(2022, time.June, 16, 0, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2022, time.June, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2022, time.June, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 dec *", time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		// either day-of-month or day-of-week
+		{"0 0 1 * fri", time.Date(2022, time.June, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+		{"@every 90m", from.Add(90 * time.Minute)},
+	}
+	for _, test := range tests {
+		c, err := sched.ParseCron(test.expr)
+		tassert.CheckFatal(t, err)
+		next := c.Next(from)
+		tassert.Errorf(t, next.Equal(test.expected), "%q: expected %v, got %v", test.expr, test.expected, next)
+	}
+}
+
+func TestCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@every 1s",
+		"@every never",
+		"@weekdays",
+	} {
+		_, err := sched.ParseCron(expr)
+		tassert.Errorf(t, err != nil, "expected %q to fail", expr)
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	bck := cmn.Bck{Name: "src", Provider: apc.ProviderAIS}
+	tests := []struct {
+		spec  sched.Spec
+		valid bool
+	}{
+		{sched.Spec{Name: "lru", Cron: "@daily",
+			Action: apc.ActionMsg{Action: apc.ActXactStart, Value: xact.QueryMsg{Kind: apc.ActLRU}}}, true},
+		{sched.Spec{Name: "copy", Cron: "0 2 * * *", Action: apc.ActionMsg{Action: apc.ActCopyBck},
+			Bck: bck, BckTo: cmn.Bck{Name: "dst", Provider: apc.ProviderAIS}, Overlap: sched.OverlapQueue}, true},
+		{sched.Spec{Name: "copy", Cron: "0 2 * * *", Action: apc.ActionMsg{Action: apc.ActCopyBck}, Bck: bck}, false},
+		{sched.Spec{Name: "rebalance", Cron: "@daily",
+			Action: apc.ActionMsg{Action: apc.ActXactStart, Value: xact.QueryMsg{Kind: apc.ActDownload}}}, false},
+		{sched.Spec{Name: "dl", Cron: "@daily", Action: apc.ActionMsg{Action: apc.ActDownload}}, false},
+		{sched.Spec{Name: "destroy", Cron: "@daily", Action: apc.ActionMsg{Action: apc.ActDestroyBck}, Bck: bck}, false},
+		{sched.Spec{Name: "bad name", Cron: "@daily", Action: apc.ActionMsg{Action: apc.ActECEncode}, Bck: bck}, false},
+		{sched.Spec{Name: "ec", Cron: "@daily", Action: apc.ActionMsg{Action: apc.ActECEncode}, Bck: bck,
+			Overlap: "wait"}, false},
+	}
+	for _, test := range tests {
+		err := test.spec.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.spec, test.valid, err)
+	}
+}
+
+func TestEntryHistory(t *testing.T) {
+	var (
+		now = time.Now()
+		e   = sched.NewEntry(&sched.Spec{Name: "e", Cron: "@every 1m"}, now)
+	)
+	tassert.Errorf(t, e.Next == now.Add(time.Minute).UnixNano(), "unexpected next %d", e.Next)
+	for i := 1; i <= 2*sched.MaxHistory; i++ {
+		e.AddRun(sched.Run{Scheduled: int64(i), Started: int64(i)})
+	}
+	e.AddRun(sched.Run{Scheduled: 1000, Skipped: "still running"})
+	tassert.Fatalf(t, len(e.History) == sched.MaxHistory, "expected %d runs, got %d", sched.MaxHistory, len(e.History))
+	tassert.Errorf(t, e.Last().Started == 2*sched.MaxHistory, "unexpected last run %+v", e.Last())
+
+	clone := e.Clone()
+	clone.AddRun(sched.Run{Scheduled: 1001, Started: 1001})
+	tassert.Errorf(t, e.Last().Started == 2*sched.MaxHistory, "clone must not modify the original")
+}
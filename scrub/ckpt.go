@@ -0,0 +1,148 @@
+// Package scrub provides background data scrubbing: it re-reads all objects and
+// EC slices stored on a target, validates their checksums, and repairs (self-heals)
+// corrupted objects from local mirror copies, erasure-coded slices, or remote backend.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Scrub progress (checkpoints) is kept in the target's database, one record per
+// (mountpath, content type, bucket), where the value is the name of the last verified
+// object (or slice). Joggers walk each directory in lexicographical order (see mpather.Sorted),
+// so that everything that precedes the checkpoint can be skipped upon resumption.
+
+const (
+	ckptCollection = "scrub"
+	ckptSepa       = "|"
+	ckptInterval   = 1000 // persist progress every so many (per-mountpath) visits
+)
+
+type (
+	ckpts struct {
+		db     dbdriver.Driver
+		mpaths map[string]*mpathCkpt // each updated by a single (mountpath) jogger
+	}
+	mpathCkpt struct {
+		saved map[string]string // loaded upon start: (content type, bucket) => last verified name
+		key   string            // current (content type, bucket)
+		name  string            // last visited name
+		cnt   int64
+	}
+)
+
+func newCkpts(db dbdriver.Driver, mpaths fs.MPI, restart bool) *ckpts {
+	c := &ckpts{db: db, mpaths: make(map[string]*mpathCkpt, len(mpaths))}
+	for mpath := range mpaths {
+		c.mpaths[mpath] = &mpathCkpt{}
+	}
+	if restart {
+		if err := db.DeleteCollection(ckptCollection); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return c
+	}
+	all, err := db.GetAll(ckptCollection, "")
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return c
+	}
+	for dbkey, name := range all {
+		for mpath, mp := range c.mpaths {
+			if !strings.HasPrefix(dbkey, mpath+ckptSepa) {
+				continue
+			}
+			if mp.saved == nil {
+				mp.saved = make(map[string]string, 4)
+			}
+			mp.saved[dbkey[len(mpath)+len(ckptSepa):]] = name
+			break
+		}
+	}
+	return c
+}
+
+func ckptKey(ct string, bck *cmn.Bck) string { return ct + ckptSepa + bck.String() }
+
+// returns true if the named object (slice) was verified by one of the previous runs
+func (c *ckpts) verified(mpath, ct string, bck *cmn.Bck, name string) bool {
+	mp := c.mpaths[mpath]
+	if mp == nil || len(mp.saved) == 0 {
+		return false
+	}
+	key := ckptKey(ct, bck)
+	last, ok := mp.saved[key]
+	if !ok {
+		return false
+	}
+	if fs.WalkedBefore(last, name) {
+		delete(mp.saved, key) // moved past the checkpoint
+		return false
+	}
+	return true
+}
+
+func (c *ckpts) update(mpath, ct string, bck *cmn.Bck, name string) {
+	mp := c.mpaths[mpath]
+	if mp == nil {
+		return // (mountpath added at runtime)
+	}
+	key := ckptKey(ct, bck)
+	if mp.key != key && mp.key != "" {
+		c.save(mpath, mp)
+	}
+	mp.key, mp.name = key, name
+	mp.cnt++
+	if mp.cnt%ckptInterval == 0 {
+		c.save(mpath, mp)
+	}
+}
+
+func (c *ckpts) flush() {
+	for mpath, mp := range c.mpaths {
+		if mp.key != "" {
+			c.save(mpath, mp)
+		}
+	}
+}
+
+func (c *ckpts) save(mpath string, mp *mpathCkpt) {
+	if err := c.db.SetString(ckptCollection, mpath+ckptSepa+mp.key, mp.name); err != nil {
+		glog.Error(err)
+	}
+}
+
+// remove checkpoints of the (completely) scrubbed buckets
+func (c *ckpts) clear(bcks []cmn.Bck) {
+	if len(bcks) == 0 {
+		if err := c.db.DeleteCollection(ckptCollection); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return
+	}
+	all, err := c.db.GetAll(ckptCollection, "")
+	if err != nil {
+		return
+	}
+	for dbkey := range all {
+		for i := range bcks {
+			if !strings.HasSuffix(dbkey, ckptSepa+bcks[i].String()) {
+				continue
+			}
+			if err := c.db.Delete(ckptCollection, dbkey); err != nil && !dbdriver.IsErrNotFound(err) {
+				glog.Error(err)
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package scrub provides background data scrubbing: it re-reads all objects and
+// EC slices stored on a target, validates their checksums, and repairs (self-heals)
+// corrupted objects from local mirror copies, erasure-coded slices, or remote backend.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestCkptsResume(t *testing.T) {
+	var (
+		db     = mock.NewDBDriver()
+		mpaths = fs.MPI{"/tmp/mp1": nil, "/tmp/mp2": nil}
+		bck    = cmn.Bck{Name: "scrub-test", Provider: apc.ProviderAIS}
+		other  = cmn.Bck{Name: "other", Provider: apc.ProviderAIS}
+		objs   = []string{"a/x", "a/y", "b", "c"}
+	)
+	// first run: gets interrupted after verifying "a/y" on mp1
+	c := newCkpts(db, mpaths, false)
+	for _, name := range objs[:2] {
+		tassert.Errorf(t, !c.verified("/tmp/mp1", fs.ObjectType, &bck, name), "%s: not expected verified", name)
+		c.update("/tmp/mp1", fs.ObjectType, &bck, name)
+	}
+	c.update("/tmp/mp2", fs.ObjectType, &other, "z")
+	c.flush()
+
+	// second run: skips what's been verified (and only on the same mountpath)
+	c = newCkpts(db, mpaths, false)
+	for i, name := range objs {
+		verified := c.verified("/tmp/mp1", fs.ObjectType, &bck, name)
+		tassert.Errorf(t, verified == (i < 2), "%s: expected verified=%t", name, i < 2)
+	}
+	tassert.Errorf(t, !c.verified("/tmp/mp2", fs.ObjectType, &bck, "a/x"), "mp2: not expected verified")
+	tassert.Errorf(t, !c.verified("/tmp/mp1", fs.ECSliceType, &bck, "a/x"), "slice: not expected verified")
+	tassert.Errorf(t, c.verified("/tmp/mp2", fs.ObjectType, &other, "y"), "other: expected verified")
+
+	// completion (of the bucket) removes its checkpoints
+	c.clear([]cmn.Bck{bck})
+	c = newCkpts(db, mpaths, false)
+	tassert.Errorf(t, !c.verified("/tmp/mp1", fs.ObjectType, &bck, "a/x"), "expected checkpoint removed")
+	tassert.Errorf(t, c.verified("/tmp/mp2", fs.ObjectType, &other, "y"), "other: expected checkpoint kept")
+
+	// restart discards everything
+	c = newCkpts(db, mpaths, true)
+	tassert.Errorf(t, !c.verified("/tmp/mp2", fs.ObjectType, &other, "y"), "expected all checkpoints discarded")
+}
@@ -0,0 +1,213 @@
+// Package scrub provides background data scrubbing: it re-reads all objects and
+// EC slices stored on a target, validates their checksums, and repairs (self-heals)
+// corrupted objects from local mirror copies, erasure-coded slices, or remote backend.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Scrub xaction walks (and re-reads) every object and EC slice on all mountpaths
+// of a given target. An object is considered corrupted when its content does not match
+// the checksum stored in its metadata (LOM xattrs), or when the metadata itself is damaged.
+// Corrupted objects are repaired, in that order, from:
+// - a good local mirror copy;
+// - erasure-coded slices (and replicas) stored on other targets;
+// - remote backend (the object gets cold-GET-ed again).
+// Corrupted mirror copies are recreated from the (good) object; corrupted EC slices are
+// removed, to be rebuilt by the next EC encoding of the bucket.
+//
+// The xaction throttles itself (see mpather) and is resumable: the progress -
+// the last verified name per mountpath, content type, and bucket - is periodically
+// checkpointed in the target's database. A scrub that gets aborted (or interrupted
+// by a restart) will skip already verified content when started next time. Upon successful
+// completion all checkpoints are removed.
+//
+// Findings are reported per bucket - see ExtScrubStats.
+
+type (
+	IniScrub struct {
+		T       cluster.Target
+		Xaction *XactScrub
+		Buckets []cmn.Bck // buckets to scrub (default: all buckets)
+		WG      *sync.WaitGroup
+		Restart bool // discard saved progress
+	}
+	XactScrub struct {
+		xact.Base
+		mu   sync.RWMutex
+		bcks map[string]*bckStats
+	}
+
+	// per-bucket findings (xaction snapshot's extended stats)
+	ExtScrubStats struct {
+		Buckets map[string]*BckScrubStats `json:"buckets"`
+	}
+	BckScrubStats struct {
+		Objs       int64 `json:"obj.n,string"`             // objects verified
+		Bytes      int64 `json:"obj.size,string"`          // total size of the verified objects
+		Corrupted  int64 `json:"corrupted.n,string"`       // objects found corrupted (incl. corrupted copies)
+		Repaired   int64 `json:"repaired.n,string"`        // repaired objects (and copies)
+		Unrepaired int64 `json:"unrepaired.n,string"`      // corrupted objects that could not be repaired
+		Slices     int64 `json:"slice.n,string"`           // EC slices verified
+		BadSlices  int64 `json:"slice.corrupted.n,string"` // corrupted (and removed) EC slices
+	}
+)
+
+// private
+type (
+	scrubFactory struct {
+		xreg.RenewBase
+		xctn *XactScrub
+	}
+	bckStats struct {
+		objs, bytes, corrupted, repaired, unrepaired atomic.Int64
+		slices, badSlices                            atomic.Int64
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*scrubFactory)(nil)
+	_ cluster.Xact   = (*XactScrub)(nil)
+)
+
+var verbose bool
+
+func Init() {
+	xreg.RegNonBckXact(&scrubFactory{})
+
+	verbose = bool(glog.FastV(4, glog.SmoduleXs))
+}
+
+//////////////////
+// scrubFactory //
+//////////////////
+
+func (*scrubFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &scrubFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *scrubFactory) Start() error {
+	p.xctn = &XactScrub{bcks: make(map[string]*bckStats, 4)}
+	p.xctn.InitBase(p.UUID(), apc.ActScrub, nil)
+	return nil
+}
+
+func (*scrubFactory) Kind() string        { return apc.ActScrub }
+func (p *scrubFactory) Get() cluster.Xact { return p.xctn }
+
+func (*scrubFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+func RunScrub(ini *IniScrub) {
+	var (
+		xscr = ini.Xaction
+		j    = newScrubJ(ini)
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(j.mpaths) == 0 {
+		glog.Warning(cmn.ErrNoMountpaths)
+		xscr.Finish(cmn.ErrNoMountpaths)
+		return
+	}
+	slab, err := ini.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	debug.AssertNoErr(err)
+	opts := &mpather.JoggerGroupOpts{
+		T:        ini.T,
+		CTs:      []string{fs.ObjectType, fs.ECSliceType},
+		VisitObj: j.visitObj,
+		VisitCT:  j.visitCT,
+		Slab:     slab,
+		Throttle: true,
+		Sorted:   true, // (resumability)
+	}
+	// NOTE: empty opts.Bck (below) means walking all buckets in the BMD
+	if len(ini.Buckets) == 1 {
+		opts.Bck = ini.Buckets[0]
+	}
+	jg := mpather.NewJoggerGroup(opts)
+	glog.Infof("%s started (buckets %v, restart %t)", xscr, ini.Buckets, ini.Restart)
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+	jg.Run()
+
+	select {
+	case errCause := <-xscr.ChanAbort():
+		if err = jg.Stop(); err != nil {
+			glog.Errorf("%s aborted (cause %v), traversal err %v", xscr, errCause, err)
+		}
+		err = cmn.NewErrAborted(xscr.Name(), "", errCause)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	if err == nil {
+		j.clearCkpts()
+	} else {
+		j.saveCkpts()
+	}
+	xscr.Finish(err)
+	glog.Infof("%s finished (objects %d, size %d)", xscr, xscr.Objs(), xscr.Bytes())
+}
+
+func (*XactScrub) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *XactScrub) Snap() cluster.XactSnap {
+	snap := &xact.SnapExt{}
+	r.ToSnap(&snap.Snap)
+	ext := &ExtScrubStats{Buckets: make(map[string]*BckScrubStats, 4)}
+	r.mu.RLock()
+	for name, st := range r.bcks {
+		ext.Buckets[name] = &BckScrubStats{
+			Objs:       st.objs.Load(),
+			Bytes:      st.bytes.Load(),
+			Corrupted:  st.corrupted.Load(),
+			Repaired:   st.repaired.Load(),
+			Unrepaired: st.unrepaired.Load(),
+			Slices:     st.slices.Load(),
+			BadSlices:  st.badSlices.Load(),
+		}
+	}
+	r.mu.RUnlock()
+	snap.Ext = ext
+	return snap
+}
+
+func (r *XactScrub) bckStats(bck *cmn.Bck) (st *bckStats) {
+	name := bck.String()
+	r.mu.RLock()
+	st = r.bcks[name]
+	r.mu.RUnlock()
+	if st != nil {
+		return
+	}
+	r.mu.Lock()
+	if st = r.bcks[name]; st == nil {
+		st = &bckStats{}
+		r.bcks[name] = st
+	}
+	r.mu.Unlock()
+	return
+}
@@ -0,0 +1,374 @@
+// Package scrub provides background data scrubbing: it re-reads all objects and
+// EC slices stored on a target, validates their checksums, and repairs (self-heals)
+// corrupted objects from local mirror copies, erasure-coded slices, or remote backend.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+type (
+	// scrubbing jogger's callbacks; one instance per xaction (shared by all mountpath joggers)
+	scrubJ struct {
+		ini    *IniScrub
+		xscr   *XactScrub
+		ckpts  *ckpts
+		mpaths fs.MPI
+	}
+	// a (bad) mirror copy of a given object
+	badCopy struct {
+		fqn string
+		mi  *fs.MountpathInfo
+	}
+)
+
+func newScrubJ(ini *IniScrub) *scrubJ {
+	j := &scrubJ{ini: ini, xscr: ini.Xaction, mpaths: fs.GetAvail()}
+	j.ckpts = newCkpts(ini.T.DB(), j.mpaths, ini.Restart)
+	return j
+}
+
+func (j *scrubJ) selected(bck *cmn.Bck) bool {
+	if len(j.ini.Buckets) == 0 {
+		return true
+	}
+	for i := range j.ini.Buckets {
+		if j.ini.Buckets[i].Equal(bck) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *scrubJ) clearCkpts() { j.ckpts.clear(j.ini.Buckets) }
+func (j *scrubJ) saveCkpts()  { j.ckpts.flush() }
+
+/////////////
+// objects //
+/////////////
+
+func (j *scrubJ) visitObj(lom *cluster.LOM, buf []byte) error {
+	mi := lom.MpathInfo()
+	if !j.selected(lom.Bucket()) || j.ckpts.verified(mi.Path, fs.ObjectType, lom.Bucket(), lom.ObjName) {
+		return nil
+	}
+	j.scrubObj(lom, buf)
+	j.ckpts.update(mi.Path, fs.ObjectType, lom.Bucket(), lom.ObjName)
+	return nil
+}
+
+func (j *scrubJ) scrubObj(lom *cluster.LOM, buf []byte) {
+	var (
+		copies []badCopy
+		errObj error
+	)
+	// copies get validated along with their main (HRW) replica;
+	// (locally and globally) misplaced objects are resilver's and rebalance's responsibility, respectively
+	if !lom.IsHRW() {
+		return
+	}
+	if _, local, err := lom.HrwTarget(j.ini.T.Sowner().Get()); err != nil || !local {
+		return
+	}
+	lom.Lock(false)
+	errObj = lom.Load(false /*cache it*/, true /*locked*/)
+	if errObj != nil {
+		lom.Unlock(false)
+		if !cmn.IsErrLmetaCorrupted(errObj) {
+			if !cmn.IsObjNotExist(errObj) && !cmn.IsErrBucketNought(errObj) {
+				glog.Errorf("%s: failed to load %s: %v", j.xscr, lom, errObj)
+			}
+			return
+		}
+		// damaged metadata: nothing to compare with (and no copies to restore from)
+		j.corrupted(lom)
+		return
+	}
+	st := j.xscr.bckStats(lom.Bucket())
+	size := lom.SizeBytes()
+
+	errObj = lom.ValidateMetaChecksum()
+	if errObj == nil {
+		errObj = lom.ValidateContentChecksum()
+	}
+	if lom.HasCopies() {
+		copies = j.badCopies(lom)
+	}
+	lom.Unlock(false)
+
+	st.objs.Inc()
+	st.bytes.Add(size)
+	j.xscr.ObjsAdd(1, size)
+
+	if errObj != nil {
+		if _, ok := errObj.(*cos.ErrBadCksum); !ok {
+			if !cmn.IsObjNotExist(errObj) {
+				glog.Errorf("%s: failed to validate %s: %v", j.xscr, lom, errObj)
+			}
+			return
+		}
+		j.corrupted(lom)
+		return
+	}
+	if len(copies) > 0 {
+		j.healCopies(lom, copies, buf)
+	}
+}
+
+// (under rlock) returns mirror copies that fail validation
+func (j *scrubJ) badCopies(lom *cluster.LOM) (copies []badCopy) {
+	for fqn, mi := range lom.GetCopies() {
+		if fqn == lom.FQN {
+			continue
+		}
+		cplom := lom.CloneMD(fqn)
+		err := cplom.InitFQN(fqn, lom.Bucket())
+		if err == nil {
+			if err = cplom.Load(false /*cache it*/, true /*locked*/); err == nil {
+				err = cplom.ValidateContentChecksum()
+			}
+		}
+		cluster.FreeLOM(cplom)
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(*cos.ErrBadCksum); ok || cmn.IsErrLmetaCorrupted(err) || os.IsNotExist(err) {
+			glog.Errorf("%s: bad copy %s of %s: %v", j.xscr, fqn, lom, err)
+			copies = append(copies, badCopy{fqn: fqn, mi: mi})
+		}
+	}
+	return
+}
+
+// the main replica is fine: remove bad copies and make new ones
+func (j *scrubJ) healCopies(lom *cluster.LOM, copies []badCopy, buf []byte) {
+	st := j.xscr.bckStats(lom.Bucket())
+	st.corrupted.Add(int64(len(copies)))
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		st.unrepaired.Add(int64(len(copies)))
+		return
+	}
+	fqns := make([]string, 0, len(copies))
+	for _, c := range copies {
+		if _, ok := lom.GetCopies()[c.fqn]; ok {
+			fqns = append(fqns, c.fqn)
+		}
+	}
+	if err := lom.DelCopies(fqns...); err != nil {
+		glog.Errorf("%s: failed to remove bad copies of %s: %v", j.xscr, lom, err)
+		st.unrepaired.Add(int64(len(copies)))
+		return
+	}
+	for _, c := range copies {
+		if err := lom.Copy(c.mi, buf); err != nil {
+			glog.Errorf("%s: failed to recreate copy of %s on %s: %v", j.xscr, lom, c.mi, err)
+			st.unrepaired.Inc()
+			continue
+		}
+		st.repaired.Inc()
+	}
+}
+
+// the main replica is corrupted: try to restore it from (in that order)
+// a good local copy, EC slices, or remote backend
+// NOTE: the object may have been overwritten (or deleted) since it was validated under rlock -
+// hence, reloading and revalidating it (and its copies) under wlock prior to removing anything
+func (j *scrubJ) corrupted(lom *cluster.LOM) {
+	var (
+		st       = j.xscr.bckStats(lom.Bucket())
+		copies   []badCopy
+		restored bool
+		how      string
+	)
+	lom.Lock(true)
+	errObj := reload(lom)
+	if !isCorrupted(errObj) {
+		lom.Unlock(true)
+		return
+	}
+	glog.Errorf("%s: %v", j.xscr, errObj)
+	st.corrupted.Inc()
+
+	if lom.HasCopies() {
+		copies = j.badCopies(lom)
+	}
+	var (
+		fromCopy   = lom.HasCopies() && lom.NumCopies() > len(copies)+1
+		fromEC     = lom.Bprops().EC.Enabled
+		fromRemote = lom.Bck().IsRemote()
+	)
+	if fromCopy || fromEC || fromRemote {
+		// remove the corrupted object along with its bad copies (if any)
+		lom.Uncache(true /*delDirty*/)
+		cos.RemoveFile(lom.FQN)
+		for _, c := range copies {
+			cos.RemoveFile(c.fqn)
+		}
+	}
+	lom.Unlock(true)
+
+	if fromCopy {
+		// restore from one of the remaining (good) ones
+		if restored = lom.RestoreToLocation(); restored {
+			how = "local copy"
+		}
+	}
+	if !restored && fromEC && j.missing(lom) {
+		if err := ec.ECM.RestoreObject(lom); err == nil {
+			restored, how = true, "EC"
+		} else {
+			glog.Errorf("%s: failed to restore %s from EC: %v", j.xscr, lom, err)
+		}
+	}
+	if !restored && fromRemote && j.missing(lom) {
+		if _, err := j.ini.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err == nil {
+			restored, how = true, "remote backend"
+		} else {
+			glog.Errorf("%s: failed to restore %s from remote backend: %v", j.xscr, lom, err)
+		}
+	}
+	if restored {
+		restored = j.revalidate(lom)
+	}
+	if !restored {
+		// no redundancy, or failed to restore (or the object has been rewritten in the meantime)
+		st.unrepaired.Inc()
+		return
+	}
+	st.repaired.Inc()
+	glog.Warningf("%s: repaired %s from %s", j.xscr, lom, how)
+}
+
+// (removed) corrupted object is still missing, i.e., hasn't been rewritten in the meantime;
+// the leftovers of a failed restore attempt (if any) get removed
+func (j *scrubJ) missing(lom *cluster.LOM) bool {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	err := reload(lom)
+	switch {
+	case cmn.IsObjNotExist(err):
+		return true
+	case isCorrupted(err):
+		if err := lom.Remove(true /*force*/); err != nil {
+			glog.Errorf("%s: failed to remove corrupted %s: %v", j.xscr, lom, err)
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// (under wlock) reload and revalidate
+func reload(lom *cluster.LOM) (err error) {
+	lom.Uncache(true /*delDirty*/)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	if err = lom.ValidateMetaChecksum(); err == nil {
+		err = lom.ValidateContentChecksum()
+	}
+	return
+}
+
+func isCorrupted(err error) bool {
+	if _, ok := err.(*cos.ErrBadCksum); ok {
+		return true
+	}
+	return cmn.IsErrLmetaCorrupted(err)
+}
+
+func (j *scrubJ) revalidate(lom *cluster.LOM) bool {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	lom.Uncache(true /*delDirty*/)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err == nil {
+		err = lom.ValidateContentChecksum()
+	}
+	if err != nil {
+		glog.Errorf("%s: %s remains corrupted: %v", j.xscr, lom, err)
+		return false
+	}
+	return true
+}
+
+///////////////
+// EC slices //
+///////////////
+
+func (j *scrubJ) visitCT(ct *cluster.CT, buf []byte) error {
+	mi := ct.MpathInfo()
+	if !j.selected(ct.Bucket()) || j.ckpts.verified(mi.Path, ct.ContentType(), ct.Bucket(), ct.ObjectName()) {
+		return nil
+	}
+	j.scrubSlice(ct, buf)
+	j.ckpts.update(mi.Path, ct.ContentType(), ct.Bucket(), ct.ObjectName())
+	return nil
+}
+
+// compare slice's content with the checksum stored in its EC metadata;
+// remove corrupted slice (to be rebuilt when the bucket gets EC-encoded again)
+func (j *scrubJ) scrubSlice(ct *cluster.CT, buf []byte) {
+	md, err := ec.LoadMetadata(ct.Make(fs.ECMetaType))
+	if err != nil {
+		return // (e.g., being written or deleted)
+	}
+	if md.CksumType == "" || md.CksumType == cos.ChecksumNone || md.CksumValue == "" {
+		return
+	}
+	st := j.xscr.bckStats(ct.Bucket())
+	ok, err := sliceCksumOK(ct.FQN(), md, buf)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to read slice %s: %v", j.xscr, ct.FQN(), err)
+		}
+		return
+	}
+	st.slices.Inc()
+	if ok {
+		return
+	}
+	st.badSlices.Inc()
+	glog.Errorf("%s: removing corrupted EC slice %s (id %d)", j.xscr, ct.FQN(), md.SliceID)
+	ct.Lock(true)
+	if err := cos.RemoveFile(ct.FQN()); err != nil {
+		glog.Error(err)
+	}
+	if err := cos.RemoveFile(ct.Make(fs.ECMetaType)); err != nil {
+		glog.Error(err)
+	}
+	ct.Unlock(true)
+}
+
+func sliceCksumOK(fqn string, md *ec.Metadata, buf []byte) (bool, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return false, err
+	}
+	_, cksum, err := cos.CopyAndChecksum(io.Discard, fh, buf, md.CksumType)
+	cos.Close(fh)
+	if err != nil {
+		return false, err
+	}
+	if cksum == nil {
+		return false, errors.New("failed to compute checksum of " + fqn)
+	}
+	return cksum.Value() == md.CksumValue, nil
+}
@@ -0,0 +1,114 @@
+// Package scrub provides background data scrubbing: it re-reads all objects and
+// EC slices stored on a target, validates their checksums, and repairs (self-heals)
+// corrupted objects from local mirror copies, erasure-coded slices, or remote backend.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// the object found corrupted (under rlock) may get overwritten before scrub takes wlock -
+// in which case it must remain intact; otherwise, it gets restored from a good copy
+func TestScrubCorrupted(t *testing.T) {
+	const testDir = "/tmp/scrub-test"
+	var (
+		mpaths = []string{testDir + "/mp1", testDir + "/mp2"}
+		props  = &cmn.BucketProps{
+			Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+			Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+			BID:    1,
+		}
+		bck     = cluster.Bck{Name: "scrub-test", Provider: apc.ProviderAIS, Ns: cmn.NsGlobal, Props: props}
+		content = bytes.Repeat([]byte("0123456789"), 1000)
+	)
+	defer os.RemoveAll(testDir)
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	for _, mpath := range mpaths {
+		tassert.CheckFatal(t, cos.CreateDir(mpath))
+		_, err := fs.Add(mpath, "daeID")
+		tassert.CheckFatal(t, err)
+	}
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = mock.NewTarget(mock.NewBaseBownerMock(&bck))
+
+	j := &scrubJ{xscr: &XactScrub{bcks: make(map[string]*bckStats, 1)}}
+	st := j.xscr.bckStats(bck.Bucket())
+
+	// object with a (good) mirror copy
+	lom := cluster.AllocLOM("obj")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	tassert.CheckFatal(t, os.MkdirAll(lom.MpathInfo().MakePathCT(bck.Bucket(), fs.ObjectType), cos.PermRWXRX))
+	tassert.CheckFatal(t, os.WriteFile(lom.FQN, content, cos.PermRWR))
+	lom.SetSize(int64(len(content)))
+	_, err := lom.ComputeSetCksum()
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, lom.Persist())
+	for _, mi := range fs.GetAvail() {
+		if mi.Path != lom.MpathInfo().Path {
+			buf := make([]byte, cos.KiB*32)
+			lom.Lock(true)
+			err = lom.Copy(mi, buf)
+			lom.Unlock(true)
+			tassert.CheckFatal(t, err)
+		}
+	}
+
+	// 1. overwritten in the meantime (new content, no copies yet), while `lom` still has
+	// the stale metadata
+	newContent := bytes.Repeat([]byte("abcdefghij"), 500)
+	put := cluster.AllocLOM(lom.ObjName)
+	tassert.CheckFatal(t, put.InitBck(bck.Bucket()))
+	put.Lock(true)
+	tassert.CheckFatal(t, put.Load(false /*cache it*/, true /*locked*/))
+	tassert.CheckFatal(t, put.DelAllCopies())
+	tassert.CheckFatal(t, os.WriteFile(put.FQN, newContent, cos.PermRWR))
+	put.SetSize(int64(len(newContent)))
+	_, err = put.ComputeSetCksum()
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, put.Persist())
+	put.Unlock(true)
+	cluster.FreeLOM(put)
+
+	j.corrupted(lom)
+	b, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, newContent), "expected (overwritten) %s to remain intact", lom)
+	tassert.Errorf(t, st.corrupted.Load() == 0 && st.unrepaired.Load() == 0,
+		"expected no corruption (corrupted %d, unrepaired %d)", st.corrupted.Load(), st.unrepaired.Load())
+
+	// 2. corrupted: restored from the copy
+	for _, mi := range fs.GetAvail() {
+		if mi.Path != lom.MpathInfo().Path {
+			buf := make([]byte, cos.KiB*32)
+			lom.Lock(true)
+			err = lom.Copy(mi, buf)
+			lom.Unlock(true)
+			tassert.CheckFatal(t, err)
+		}
+	}
+	content = newContent
+	bad := bytes.Repeat([]byte("x"), len(content))
+	tassert.CheckFatal(t, os.WriteFile(lom.FQN, bad, cos.PermRWR))
+	j.corrupted(lom)
+	b, err = os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, content), "expected %s to be restored from copy", lom)
+	tassert.Errorf(t, st.corrupted.Load() == 1 && st.repaired.Load() == 1,
+		"expected repaired (corrupted %d, repaired %d)", st.corrupted.Load(), st.repaired.Load())
+}
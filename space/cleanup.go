@@ -64,6 +64,7 @@ type (
 		misplaced struct {
 			loms []*cluster.LOM
 			ec   []*cluster.CT // EC slices and replicas without corresponding metafiles (CT FQN -> Meta FQN)
+			vers []string      // previous object versions that belong to other targets (e.g., after rebalance)
 		}
 		bck cmn.Bck
 		now int64
@@ -140,6 +141,7 @@ func RunCleanup(ini *IniCln) fs.CapStatus {
 		}
 		joggers[mpath].misplaced.loms = make([]*cluster.LOM, 0, 64)
 		joggers[mpath].misplaced.ec = make([]*cluster.CT, 0, 64)
+		joggers[mpath].misplaced.vers = make([]string, 0, 64)
 	}
 	parent.jcnt.Store(int32(len(joggers)))
 	providers := apc.Providers.ToSlice()
@@ -309,7 +311,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ObjVerType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -372,11 +374,49 @@ func (j *clnJ) visitCT(parsedFQN fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ObjVerType:
+		// previous object versions:
+		// - belong to another target: remove (see rmLeftovers)
+		// - this target but not the object's hrw mountpath: move
+		ct, err := cluster.NewCTFromFQN(fqn, j.p.ini.T.Bowner())
+		if err != nil {
+			return
+		}
+		j.visitVer(ct)
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
 }
 
+func (j *clnJ) visitVer(ct *cluster.CT) {
+	objName, ver, ok := cluster.ParseVerCT(ct)
+	if !ok {
+		return
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&j.bck); err != nil {
+		return
+	}
+	if _, local, err := lom.HrwTarget(j.ini.T.Sowner().Get()); err != nil || !local {
+		if err == nil {
+			j.misplaced.vers = append(j.misplaced.vers, ct.FQN())
+		}
+		return
+	}
+	if lom.MpathInfo().Path == ct.MpathInfo().Path {
+		return
+	}
+	if !lom.TryLock(true) {
+		return // must be busy
+	}
+	err := cluster.MoveVersion(ct.FQN(), lom.VersionFQN(ver), nil)
+	lom.Unlock(true)
+	if err != nil && !os.IsNotExist(err) {
+		glog.Errorf("%s: failed to move %s version %q to %s: %v", j, lom, ver, lom.MpathInfo(), err)
+	}
+}
+
 // TODO: add stats error counters (stats.ErrLmetaCorruptedCount, ...)
 // TODO: revisit rm-ed byte counting
 func (j *clnJ) visitObj(fqn string) {
@@ -531,6 +571,24 @@ func (j *clnJ) rmLeftovers() (size int64, err error) {
 	}
 	j.misplaced.loms = j.misplaced.loms[:0]
 
+	// 2.1. rm previous object versions misplaced by rebalance
+	if j.p.rmMisplaced() {
+		for _, fqn := range j.misplaced.vers {
+			finfo, erv := os.Stat(fqn)
+			if erv != nil {
+				continue
+			}
+			if os.Remove(fqn) == nil {
+				fevicted++
+				bevicted += finfo.Size()
+				if err = j.yieldTerm(); err != nil {
+					return
+				}
+			}
+		}
+	}
+	j.misplaced.vers = j.misplaced.vers[:0]
+
 	// 3. rm EC slices and replicas that are still without correcponding metafile
 	for _, ct := range j.misplaced.ec {
 		metaFQN := fs.CSM.Gen(ct, fs.ECMetaType, "")
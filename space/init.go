@@ -16,6 +16,7 @@ var verbose bool
 func Init() {
 	xreg.RegNonBckXact(&lruFactory{})
 	xreg.RegNonBckXact(&clnFactory{})
+	xreg.RegNonBckXact(&lcFactory{})
 
 	verbose = bool(glog.FastV(4, glog.SmoduleSpace))
 }
@@ -0,0 +1,193 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Lifecycle xaction evaluates per-bucket lifecycle rules (see cmn.LifecycleConf)
+// and deletes (or evicts) matching objects. Each target runs it periodically -
+// every `space.lifecycle_interval` - provided there's at least one bucket with enabled lifecycle.
+// The xaction can also be started via API/CLI, optionally in a dry-run mode, in which
+// case it only counts (and reports via xaction stats) objects that would've been
+// acted upon.
+
+type (
+	IniLifecycle struct {
+		T       cluster.Target
+		Xaction *XactLifecycle
+		Buckets []cmn.Bck // list of buckets to evaluate (default: all buckets with enabled lifecycle)
+		WG      *sync.WaitGroup
+		DryRun  bool
+	}
+	XactLifecycle struct {
+		xact.Base
+	}
+)
+
+// private
+type (
+	lcFactory struct {
+		xreg.RenewBase
+		xctn *XactLifecycle
+	}
+	lcJ struct {
+		ini *IniLifecycle
+		now int64
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*lcFactory)(nil)
+	_ cluster.Xact   = (*XactLifecycle)(nil)
+)
+
+///////////////
+// lcFactory //
+///////////////
+
+func (*lcFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &lcFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *lcFactory) Start() error {
+	p.xctn = &XactLifecycle{}
+	p.xctn.InitBase(p.UUID(), apc.ActLifecycle, nil)
+	return nil
+}
+
+func (*lcFactory) Kind() string        { return apc.ActLifecycle }
+func (p *lcFactory) Get() cluster.Xact { return p.xctn }
+
+func (*lcFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+func RunLifecycle(ini *IniLifecycle) {
+	var (
+		xlc = ini.Xaction
+		j   = &lcJ{ini: ini, now: time.Now().UnixNano()}
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(fs.GetAvail()) == 0 {
+		glog.Warning(cmn.ErrNoMountpaths)
+		xlc.Finish(cmn.ErrNoMountpaths)
+		return
+	}
+	opts := &mpather.JoggerGroupOpts{
+		T:                     ini.T,
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              j.visitObj,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	}
+	// NOTE: empty opts.Bck (below) means walking all buckets in the BMD
+	if len(ini.Buckets) == 1 {
+		opts.Bck = ini.Buckets[0]
+	}
+	jg := mpather.NewJoggerGroup(opts)
+	glog.Infof("%s started (dry-run %t, buckets %v)", xlc, ini.DryRun, ini.Buckets)
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+	jg.Run()
+
+	var err error
+	select {
+	case errCause := <-xlc.ChanAbort():
+		if err = jg.Stop(); err != nil {
+			glog.Errorf("%s aborted (cause %v), traversal err %v", xlc, errCause, err)
+		}
+		err = cmn.NewErrAborted(xlc.Name(), "", errCause)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	xlc.Finish(err)
+	glog.Infof("%s finished (objects %d, size %d)", xlc, xlc.Objs(), xlc.Bytes())
+}
+
+func (*XactLifecycle) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+/////////
+// lcJ //
+/////////
+
+func (j *lcJ) visitObj(lom *cluster.LOM, _ []byte) error {
+	bck := lom.Bck()
+	if !j.selected(bck.Bucket()) {
+		return nil
+	}
+	lc := &bck.Props.Lifecycle
+	if !lc.Enabled {
+		return nil
+	}
+	// age: time since last modification (note that updating atime preserves mtime)
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return nil
+	}
+	rule := lc.Match(lom.ObjName, lom.SizeBytes(), finfo.ModTime().UnixNano(), j.now)
+	if rule == nil {
+		return nil
+	}
+	size := lom.SizeBytes()
+	if j.ini.DryRun {
+		j.ini.Xaction.ObjsAdd(1, size)
+		return nil
+	}
+	switch rule.Action {
+	case cmn.LcActDelete:
+		_, err = j.ini.T.DeleteObject(lom, false /*evict*/)
+	case cmn.LcActEvict:
+		_, err = j.ini.T.EvictObject(lom)
+	default:
+		debug.Assertf(false, "invalid lifecycle action %q", rule.Action)
+	}
+	if err != nil {
+		if !cmn.IsObjNotExist(err) && !cmn.IsErrObjLocked(err) {
+			glog.Errorf("%s: failed to %s %s (rule %q): %v", j.ini.Xaction, rule.Action, lom, rule, err)
+		}
+		return nil
+	}
+	j.ini.Xaction.ObjsAdd(1, size)
+	if verbose {
+		glog.Infof("%s: %s %s (rule %q)", j.ini.Xaction, rule.Action, lom, rule)
+	}
+	return nil
+}
+
+func (j *lcJ) selected(bck *cmn.Bck) bool {
+	if len(j.ini.Buckets) == 0 {
+		return true
+	}
+	for i := range j.ini.Buckets {
+		if j.ini.Buckets[i].Equal(bck) {
+			return true
+		}
+	}
+	return false
+}
@@ -31,7 +31,16 @@ import (
 // config.Space.HighWM (section "space" in the cluster config).
 //
 // When and if exceeded, AIS target will start gradually evicting objects from its
-// stable storage: oldest first access-time wise.
+// stable storage in the order determined by the bucket's eviction policy (lru.policy):
+//   - "lru"  - oldest first access-time wise (default);
+//   - "lfu"  - least frequently used first (see cluster.LOM.AccessCnt), and then oldest;
+//   - "size" - GDSF-like: lowest (access count / (size * age)) first - that is, large
+//     and old objects that are rarely read get evicted before small and "hot" ones.
+// Buckets are evicted in the order of their (configurable) lru.priority - lowest first -
+// and, within the same priority, larger buckets first.
+//
+// In dry-run mode LRU evicts nothing - it only reports (via xaction stats and the log)
+// objects that would be evicted.
 //
 // LRU is implemented as eXtended Action (xaction, see xact/README.md) that gets
 // triggered when/if a used local capacity exceeds high watermark (config.Space.HighWM). LRU then
@@ -58,6 +67,7 @@ type (
 		GetFSStats          func(path string) (blocks, bavail uint64, bsize int64, err error)
 		WG                  *sync.WaitGroup
 		Force               bool // Ignore LRU prop when set to be true.
+		DryRun              bool // report (count) objects that would be evicted without evicting them
 	}
 	XactLRU struct {
 		xact.Base
@@ -66,8 +76,13 @@ type (
 
 // private
 type (
-	// minHeap keeps fileInfo sorted by access time with oldest on top of the heap.
-	minHeap []*cluster.LOM
+	// minHeap keeps LOMs sorted by eviction score with the first to evict on top of the heap.
+	minHeap []lruItem
+	lruItem struct {
+		lom   *cluster.LOM
+		score float64 // as per eviction policy (see lruJ.score)
+		atime int64
+	}
 
 	// parent (contains mpath joggers)
 	lruP struct {
@@ -80,12 +95,14 @@ type (
 	// that traverses and evicts a single given mountpath.
 	lruJ struct {
 		// runtime
-		curSize   int64
-		totalSize int64 // difference between lowWM size and used size
-		newest    int64
-		heap      *minHeap
-		bck       cmn.Bck
-		now       int64
+		curSize    int64
+		totalSize  int64   // difference between lowWM size and used size
+		dryEvicted int64   // (dry-run) total size that would have been evicted
+		last       lruItem // the last to evict in the heap
+		heap       *minHeap
+		bck        cmn.Bck
+		policy     string // bucket's eviction policy
+		now        int64
 		// init-time
 		p       *lruP
 		ini     *IniLRU
@@ -169,7 +186,7 @@ func RunLRU(ini *IniLRU) {
 		go j.run(providers)
 	}
 	cs := fs.GetCapStatus()
-	glog.Infof("%s started, dont-evict-time %v, %s", xlru, config.LRU.DontEvictTime, cs)
+	glog.Infof("%s started, dont-evict-time %v, dry-run %t, %s", xlru, config.LRU.DontEvictTime, ini.DryRun, cs)
 	if ini.WG != nil {
 		ini.WG.Done()
 		ini.WG = nil
@@ -245,7 +262,7 @@ func (j *lruJ) jogBcks(bcks []cmn.Bck, force bool) (err error) {
 		return
 	}
 	if len(bcks) > 1 {
-		j.sortBsize(bcks)
+		j.sortBcks(bcks)
 	}
 	for _, bck := range bcks { // for each bucket under a given provider
 		var size int64
@@ -278,6 +295,7 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.curSize, j.last = 0, lruItem{}
 
 	// 2. collect
 	opts := &fs.WalkOpts{
@@ -319,19 +337,42 @@ func (j *lruJ) _visit(lom *cluster.LOM) (pushed bool) {
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
+	// object lock: retained and legal-hold objects are never evicted
+	if lom.CheckObjLock(false /*bypass*/) != nil {
+		return
+	}
+	item := lruItem{lom: lom, score: j.score(lom), atime: lom.AtimeUnix()}
 	// do nothing if the heap's curSize >= totalSize and
-	// the file is more recent then the the heap's newest.
-	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
+	// the object would be evicted after the heap's last
+	if j.curSize >= j.totalSize && j.last.less(&item) {
 		return
 	}
-	heap.Push(j.heap, lom)
+	heap.Push(j.heap, item)
 	j.curSize += lom.SizeBytes()
-	if lom.AtimeUnix() > j.newest {
-		j.newest = lom.AtimeUnix()
+	if j.heap.Len() == 1 || j.last.less(&item) {
+		j.last = item
 	}
 	return true
 }
 
+// eviction score as per the bucket's policy - the lower the score, the sooner
+// the object gets evicted (equal scores are further ordered by access time)
+func (j *lruJ) score(lom *cluster.LOM) float64 {
+	switch j.policy {
+	case cmn.LRUPolicyLFU:
+		return float64(lom.AccessCnt())
+	case cmn.LRUPolicySize:
+		age := time.Duration(j.now - lom.AtimeUnix())
+		if age < time.Second {
+			age = time.Second
+		}
+		size := cos.MaxI64(lom.SizeBytes(), 1)
+		return float64(lom.AccessCnt()+1) / (float64(size) * age.Seconds())
+	default: // cmn.LRUPolicyLRU
+		return 0
+	}
+}
+
 func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if de.IsDir() {
 		return nil
@@ -360,8 +401,12 @@ func (j *lruJ) evict() (size int64, err error) {
 
 	// evict(sic!) and house-keep
 	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*cluster.LOM)
-		if !evictObj(lom) {
+		lom := heap.Pop(h).(lruItem).lom
+		if j.ini.DryRun {
+			if verbose {
+				glog.Infof("%s: (dry-run) evict %s", j, lom)
+			}
+		} else if !evictObj(lom) {
 			cluster.FreeLOM(lom)
 			continue
 		}
@@ -374,9 +419,16 @@ func (j *lruJ) evict() (size int64, err error) {
 			return
 		}
 	}
+	xlru.ObjsAdd(int(fevicted), bevicted)
+	if j.ini.DryRun {
+		j.dryEvicted += bevicted
+		if fevicted > 0 {
+			glog.Infof("%s: (dry-run) %s: would evict %d objects (%s)", j, j.bck, fevicted, cos.B2S(bevicted, 2))
+		}
+		return
+	}
 	j.ini.StatsT.Add(stats.LruEvictSize, bevicted)
 	j.ini.StatsT.Add(stats.LruEvictCount, fevicted)
-	xlru.ObjsAdd(int(fevicted), bevicted)
 	return
 }
 
@@ -423,12 +475,19 @@ func (j *lruJ) _throttle(usedPct int64) (err error) {
 // remove local copies that "belong" to different LRU joggers; hence, space accounting may be temporarily not precise
 func evictObj(lom *cluster.LOM) (ok bool) {
 	lom.Lock(true)
+	defer lom.Unlock(true)
+	// (re)check object lock - legal hold could've been set in the meantime
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	if lom.CheckObjLock(false /*bypass*/) != nil {
+		return
+	}
 	if err := lom.Remove(); err == nil {
 		ok = true
 	} else {
 		glog.Errorf("%s: failed to remove, err: %v", lom, err)
 	}
-	lom.Unlock(true)
 	return
 }
 
@@ -444,7 +503,7 @@ func (j *lruJ) evictSize() (err error) {
 		return
 	}
 	lwmBlocks := blocks * uint64(lwm) / 100
-	j.totalSize = int64(used-lwmBlocks) * bsize
+	j.totalSize = int64(used-lwmBlocks)*bsize - j.dryEvicted
 	return
 }
 
@@ -467,18 +526,28 @@ func (j *lruJ) yieldTerm() error {
 	return nil
 }
 
-// sort buckets by size
-func (j *lruJ) sortBsize(bcks []cmn.Bck) {
-	sized := make([]struct {
-		b cmn.Bck
-		v uint64
-	}, len(bcks))
+// sort buckets by priority (lowest first) and then by size (largest first)
+func (j *lruJ) sortBcks(bcks []cmn.Bck) {
+	var (
+		bowner = j.ini.T.Bowner()
+		sized  = make([]struct {
+			b    cmn.Bck
+			v    uint64
+			prio int
+		}, len(bcks))
+	)
 	for i := range bcks {
 		path := j.mi.MakePathCT(&bcks[i], fs.ObjectType)
 		sized[i].b = bcks[i]
 		sized[i].v, _ = ios.GetDirSize(path)
+		if b := cluster.CloneBck(&bcks[i]); b.Init(bowner) == nil {
+			sized[i].prio = b.Props.LRU.Priority
+		}
 	}
 	sort.Slice(sized, func(i, j int) bool {
+		if sized[i].prio != sized[j].prio {
+			return sized[i].prio < sized[j].prio
+		}
 		return sized[i].v > sized[j].v
 	})
 	for i := range bcks {
@@ -494,6 +563,7 @@ func (j *lruJ) allow() (ok bool, err error) {
 	if err = b.Init(bowner); err != nil {
 		return
 	}
+	j.policy = b.Props.LRU.Policy
 	ok = b.Props.LRU.Enabled && b.Allow(apc.AceObjDELETE) == nil
 	return
 }
@@ -503,9 +573,9 @@ func (j *lruJ) allow() (ok bool, err error) {
 //////////////
 
 func (h minHeap) Len() int            { return len(h) }
-func (h minHeap) Less(i, j int) bool  { return h[i].Atime().Before(h[j].Atime()) }
+func (h minHeap) Less(i, j int) bool  { return h[i].less(&h[j]) }
 func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
-func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(*cluster.LOM)) }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(lruItem)) }
 func (h *minHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
@@ -513,3 +583,10 @@ func (h *minHeap) Pop() interface{} {
 	*h = old[0 : n-1]
 	return fi
 }
+
+func (a *lruItem) less(b *lruItem) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.atime < b.atime
+}
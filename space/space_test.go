@@ -35,11 +35,15 @@ const (
 	basePath             = "/tmp/space-tests"
 	bucketName           = "space-bck"
 	bucketNameAnother    = bucketName + "-another"
+	bucketNameLFU        = bucketName + "-lfu"
+	bucketNameSize       = bucketName + "-size"
+	bucketNameLock       = bucketName + "-lock"
 )
 
 type fileMetadata struct {
 	name string
 	size int64
+	acnt int // access count
 }
 
 var gT *testing.T
@@ -60,7 +64,12 @@ var _ = Describe("space evict/cleanup tests", func() {
 			t          *mock.TargetMock
 			filesPath  string
 			fpAnother  string
+			fpLFU      string
+			fpSize     string
+			fpLock     string
 			bckAnother cmn.Bck
+			bckLFU     cmn.Bck
+			bckSize    cmn.Bck
 		)
 
 		BeforeEach(func() {
@@ -70,10 +79,19 @@ var _ = Describe("space evict/cleanup tests", func() {
 			availablePaths := fs.GetAvail()
 			bck := cmn.Bck{Name: bucketName, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
 			bckAnother = cmn.Bck{Name: bucketNameAnother, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+			bckLFU = cmn.Bck{Name: bucketNameLFU, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+			bckSize = cmn.Bck{Name: bucketNameSize, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
 			filesPath = availablePaths[basePath].MakePathCT(&bck, fs.ObjectType)
 			fpAnother = availablePaths[basePath].MakePathCT(&bckAnother, fs.ObjectType)
+			fpLFU = availablePaths[basePath].MakePathCT(&bckLFU, fs.ObjectType)
+			fpSize = availablePaths[basePath].MakePathCT(&bckSize, fs.ObjectType)
+			bckLock := cmn.Bck{Name: bucketNameLock, Provider: apc.ProviderAIS, Ns: cmn.NsGlobal}
+			fpLock = availablePaths[basePath].MakePathCT(&bckLock, fs.ObjectType)
 			cos.CreateDir(filesPath)
+			cos.CreateDir(fpLock)
 			cos.CreateDir(fpAnother)
+			cos.CreateDir(fpLFU)
+			cos.CreateDir(fpSize)
 		})
 
 		AfterEach(func() {
@@ -113,9 +131,9 @@ var _ = Describe("space evict/cleanup tests", func() {
 				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
 
 				oldFiles := []fileMetadata{
-					{getRandomFileName(3), fileSize},
-					{getRandomFileName(4), fileSize},
-					{getRandomFileName(5), fileSize},
+					{getRandomFileName(3), fileSize, 0},
+					{getRandomFileName(4), fileSize, 0},
+					{getRandomFileName(5), fileSize, 0},
 				}
 				saveRandomFilesWithMetadata(filesPath, oldFiles)
 				time.Sleep(1 * time.Second)
@@ -149,10 +167,10 @@ var _ = Describe("space evict/cleanup tests", func() {
 
 				// files sum up to 32Mb
 				files := []fileMetadata{
-					{getRandomFileName(0), int64(4 * cos.MiB)},
-					{getRandomFileName(1), int64(16 * cos.MiB)},
-					{getRandomFileName(2), int64(4 * cos.MiB)},
-					{getRandomFileName(3), int64(8 * cos.MiB)},
+					{getRandomFileName(0), int64(4 * cos.MiB), 0},
+					{getRandomFileName(1), int64(16 * cos.MiB), 0},
+					{getRandomFileName(2), int64(4 * cos.MiB), 0},
+					{getRandomFileName(3), int64(8 * cos.MiB), 0},
 				}
 				saveRandomFilesWithMetadata(filesPath, files)
 
@@ -197,6 +215,128 @@ var _ = Describe("space evict/cleanup tests", func() {
 				// to many files evicted
 				Expect(float64(numFilesLeftAnother+1) / numberOfCreatedFiles * initialDiskUsagePct).To(BeNumerically(">", 0.01*lwm))
 			})
+
+			It("should evict the least frequently used files [lfu]", func() {
+				const numberOfFiles = 6
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				hotFiles := []fileMetadata{
+					{getRandomFileName(0), fileSize, 5},
+					{getRandomFileName(1), fileSize, 3},
+					{getRandomFileName(2), fileSize, 1},
+				}
+				saveRandomFilesWithMetadata(fpLFU, hotFiles)
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(fpLFU, 3)
+
+				ini.Buckets = []cmn.Bck{bckLFU}
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(fpLFU)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+
+				hotFilesNames := namesFromFilesMetadatas(hotFiles)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), hotFilesNames)).To(BeTrue())
+				}
+			})
+
+			It("should evict large files first [size]", func() {
+				const totalSize = 32 * cos.MiB
+
+				ini.GetFSStats = func(string) (blocks, bavail uint64, bsize int64, err error) {
+					bsize = blockSize
+					btaken := uint64(totalSize / blockSize)
+					blocks = uint64(float64(btaken) / initialDiskUsagePct)
+					bavail = blocks - btaken
+					return
+				}
+				files := []fileMetadata{
+					{getRandomFileName(0), int64(4 * cos.MiB), 0},
+					{getRandomFileName(1), int64(16 * cos.MiB), 0},
+					{getRandomFileName(2), int64(4 * cos.MiB), 0},
+					{getRandomFileName(3), int64(8 * cos.MiB), 0},
+				}
+				saveRandomFilesWithMetadata(fpSize, files)
+
+				// the single 16MB file suffices to go under lwm
+				ini.Buckets = []cmn.Bck{bckSize}
+				space.RunLRU(ini)
+
+				filesLeft, err := os.ReadDir(fpSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(3))
+				for _, name := range filesLeft {
+					Expect(name.Name()).NotTo(Equal(files[1].name))
+				}
+			})
+
+			It("should evict lower priority bucket first", func() {
+				// size bucket (priority 10) is larger but gets evicted after lfu bucket (priority 0)
+				saveRandomFiles(fpLFU, 3)
+				saveRandomFiles(fpSize, 4)
+				ini.GetFSStats = getDirsFSStats(fpLFU, fpSize)
+
+				ini.Buckets = []cmn.Bck{bckSize, bckLFU}
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(fpLFU)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(0))
+				files, err = os.ReadDir(fpSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(BeNumerically(">=", 3))
+			})
+
+			It("should not evict locked files [object lock]", func() {
+				const numberOfFiles = 6
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				oldFiles := []fileMetadata{
+					{getRandomFileName(3), fileSize, 0},
+					{getRandomFileName(4), fileSize, 0},
+					{getRandomFileName(5), fileSize, 0},
+				}
+				saveRandomFilesWithMetadata(fpLock, oldFiles)
+				for _, file := range oldFiles {
+					lom := &cluster.LOM{}
+					Expect(lom.InitFQN(path.Join(fpLock, file.name), nil)).NotTo(HaveOccurred())
+					Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+					lom.SetCustomKey(cmn.LegalHoldObjMD, "true")
+					Expect(lom.Persist()).NotTo(HaveOccurred())
+				}
+				time.Sleep(1 * time.Second)
+				saveRandomFiles(fpLock, 3)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(fpLock)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+
+				oldFilesNames := namesFromFilesMetadatas(oldFiles)
+				for _, name := range files {
+					Expect(cos.StringInSlice(name.Name(), oldFilesNames)).To(BeTrue())
+				}
+			})
+
+			It("should only report files to evict [dry-run]", func() {
+				saveRandomFiles(filesPath, numberOfCreatedFiles)
+
+				ini.DryRun = true
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(numberOfCreatedFiles))
+
+				// (45 * 10MB) at 90% usage => 200MB to evict to reach lwm (50%)
+				Expect(ini.Xaction.Objs()).To(BeNumerically("==", 20))
+				Expect(ini.Xaction.Bytes()).To(BeNumerically("==", 20*fileSize))
+			})
 		})
 
 		Describe("not evict files", func() {
@@ -312,6 +452,29 @@ func getMockGetFSStats(currentFilesNum int) func(string) (uint64, uint64, int64,
 	}
 }
 
+// total capacity computed once (from the initial usage), available - dynamically
+func getDirsFSStats(dirs ...string) func(string) (uint64, uint64, int64, error) {
+	used := func() (size int64) {
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			for _, e := range entries {
+				finfo, err := e.Info()
+				Expect(err).NotTo(HaveOccurred())
+				size += finfo.Size()
+			}
+		}
+		return
+	}
+	total := uint64(float64(used()/blockSize) / initialDiskUsagePct)
+	return func(string) (blocks, bavail uint64, bsize int64, err error) {
+		bsize = blockSize
+		blocks = total
+		bavail = blocks - uint64(used()/blockSize)
+		return
+	}
+}
+
 func newTargetLRUMock() *mock.TargetMock {
 	// Bucket owner mock, required for LOM
 	var (
@@ -334,6 +497,34 @@ func newTargetLRUMock() *mock.TargetMock {
 					BID:    0xf4e3d2c1,
 				},
 			),
+			cluster.NewBck(
+				bucketNameLFU, apc.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Policy: cmn.LRUPolicyLFU},
+					Access: apc.AccessAll,
+					BID:    0xb1c2d3e4,
+				},
+			),
+			cluster.NewBck(
+				bucketNameSize, apc.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, Policy: cmn.LRUPolicySize, Priority: 10},
+					Access: apc.AccessAll,
+					BID:    0xc1d2e3f4,
+				},
+			),
+			cluster.NewBck(
+				bucketNameLock, apc.ProviderAIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:        cmn.LRUConf{Enabled: true},
+					ObjectLock: cmn.ObjectLockConf{Enabled: true, Mode: cmn.LockModeGovernance},
+					Access:     apc.AccessAll,
+					BID:        0xd1e2f3a4,
+				},
+			),
 		)
 		tMock = mock.NewTarget(bmdMock)
 	)
@@ -384,7 +575,7 @@ func getRandomFileName(fileCounter int) string {
 	return fmt.Sprintf("%v-%v.txt", cos.RandString(13), fileCounter)
 }
 
-func saveRandomFile(filename string, size int64) {
+func saveRandomFile(filename string, size int64, acnt int) {
 	buff := make([]byte, size)
 	_, err := cos.SaveReader(filename, rand.Reader, buff, cos.ChecksumNone, size, "")
 	Expect(err).NotTo(HaveOccurred())
@@ -394,12 +585,15 @@ func saveRandomFile(filename string, size int64) {
 	lom.SetSize(size)
 	lom.IncVersion()
 	lom.SetAtimeUnix(time.Now().UnixNano())
+	for i := 0; i < acnt; i++ {
+		lom.IncAccessCnt()
+	}
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
 func saveRandomFilesWithMetadata(filesPath string, files []fileMetadata) {
 	for _, file := range files {
-		saveRandomFile(path.Join(filesPath, file.name), file.size)
+		saveRandomFile(path.Join(filesPath, file.name), file.size, file.acnt)
 	}
 }
 
@@ -407,6 +601,6 @@ func saveRandomFilesWithMetadata(filesPath string, files []fileMetadata) {
 // timestamps and names are not increasing in the same manner
 func saveRandomFiles(filesPath string, filesNumber int) {
 	for i := 0; i < filesNumber; i++ {
-		saveRandomFile(path.Join(filesPath, getRandomFileName(i)), fileSize)
+		saveRandomFile(path.Join(filesPath, getRandomFileName(i)), fileSize, 0)
 	}
 }
@@ -0,0 +1,296 @@
+// Package sse provides server-side encryption at rest: chunked AES-GCM format,
+// streaming encryption, random-access decryption, and encryption key providers.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Format
+//
+// Plaintext is split into ChunkSize chunks, each sealed separately with AES-256-GCM,
+// so that a given (plaintext) range can be read and authenticated without reading
+// (and decrypting) the entire object:
+//
+//   [chunk 0 ciphertext | tag] [chunk 1 ciphertext | tag] ... [last chunk ciphertext | tag]
+//
+// - all chunks but the last one contain exactly ChunkSize bytes of plaintext;
+// - the last chunk contains the remaining 0 to (ChunkSize - 1) bytes and is always present;
+// - chunk nonce is the object's (random) base nonce XOR-ed with the chunk index;
+// - chunk's additional data is a single byte that marks the last chunk (to detect truncation).
+//
+// Encryption key ID and base nonce are stored in the object's metadata as a single
+// custom key (cmn.SSEObjMD). The size of the plaintext is derived from the size of
+// the ciphertext (see PlainSize).
+
+const (
+	ChunkSize = 64 * cos.KiB
+	KeySize   = 32 // AES-256
+	NonceSize = 12
+	TagSize   = 16
+
+	cipherChunk = ChunkSize + TagSize
+)
+
+var (
+	errTruncated = errors.New("sse: truncated ciphertext")
+	errCorrupted = errors.New("sse: message authentication failed")
+)
+
+// CipherSize returns the size of the ciphertext for a given size of plaintext
+func CipherSize(plainSize int64) int64 {
+	return plainSize + (plainSize/ChunkSize+1)*TagSize
+}
+
+// PlainSize returns the size of the plaintext for a given size of ciphertext
+func PlainSize(cipherSize int64) int64 {
+	n, rem := cipherSize/cipherChunk, cipherSize%cipherChunk
+	return n*ChunkSize + cos.MaxI64(rem-TagSize, 0)
+}
+
+// NewNonce returns a random base nonce
+func NewNonce() ([]byte, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// EncodeMD returns the value of the cmn.SSEObjMD custom key
+func EncodeMD(keyID string, nonce []byte) string {
+	return keyID + ":" + base64.StdEncoding.EncodeToString(nonce)
+}
+
+// DecodeMD parses the value of the cmn.SSEObjMD custom key
+func DecodeMD(v string) (keyID string, nonce []byte, err error) {
+	i := strings.LastIndexByte(v, ':')
+	if i <= 0 {
+		return "", nil, fmt.Errorf("sse: invalid object metadata %q", v)
+	}
+	keyID = v[:i]
+	if nonce, err = base64.StdEncoding.DecodeString(v[i+1:]); err == nil && len(nonce) != NonceSize {
+		err = fmt.Errorf("sse: invalid nonce size %d", len(nonce))
+	}
+	return
+}
+
+// Size returns the plaintext size of a given object, encrypted or not
+func Size(oah cmn.ObjAttrsHolder) int64 {
+	if _, ok := oah.GetCustomKey(cmn.SSEObjMD); ok {
+		return PlainSize(oah.SizeBytes())
+	}
+	return oah.SizeBytes()
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("sse: invalid key size %d (expecting %d)", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(dst, nonce []byte, idx uint64) {
+	copy(dst, nonce)
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], idx)
+	for i := 0; i < 8; i++ {
+		dst[NonceSize-8+i] ^= b[i]
+	}
+}
+
+func chunkAD(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+////////////
+// Writer //
+////////////
+
+// Writer encrypts everything written to it and writes the ciphertext to the
+// underlying writer; Close must be called to write the last chunk (and does not
+// close the underlying writer).
+type Writer struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	nonce   []byte
+	cnonce  []byte
+	buf     []byte // plaintext chunk
+	out     []byte // sealed chunk
+	idx     uint64
+	written int64 // ciphertext
+	closed  bool
+}
+
+// interface guard
+var _ io.WriteCloser = (*Writer)(nil)
+
+func NewWriter(w io.Writer, key, nonce []byte) (*Writer, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:      w,
+		aead:   aead,
+		nonce:  nonce,
+		cnonce: make([]byte, NonceSize),
+		buf:    make([]byte, 0, ChunkSize),
+		out:    make([]byte, 0, cipherChunk),
+	}, nil
+}
+
+func (ew *Writer) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		l := cos.Min(ChunkSize-len(ew.buf), len(p))
+		ew.buf = append(ew.buf, p[:l]...)
+		p = p[l:]
+		n += l
+		if len(ew.buf) == ChunkSize {
+			if err = ew.seal(false); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (ew *Writer) Close() error {
+	if ew.closed {
+		return nil
+	}
+	ew.closed = true
+	return ew.seal(true)
+}
+
+// Size returns the number of ciphertext bytes written so far
+func (ew *Writer) Size() int64 { return ew.written }
+
+func (ew *Writer) seal(last bool) error {
+	chunkNonce(ew.cnonce, ew.nonce, ew.idx)
+	ew.out = ew.aead.Seal(ew.out[:0], ew.cnonce, ew.buf, chunkAD(last))
+	n, err := ew.w.Write(ew.out)
+	ew.written += int64(n)
+	ew.buf = ew.buf[:0]
+	ew.idx++
+	return err
+}
+
+////////////
+// Reader //
+////////////
+
+// Reader decrypts a given plaintext range of the encrypted content.
+type Reader struct {
+	ra         io.ReaderAt
+	aead       cipher.AEAD
+	nonce      []byte
+	cnonce     []byte
+	in         []byte // sealed chunk
+	buf        []byte // decrypted chunk
+	pos        int    // within buf
+	cipherSize int64
+	idx        int64 // next chunk to read
+	skip       int   // bytes to skip in the first chunk
+	remain     int64 // plaintext bytes remaining
+}
+
+// interface guard
+var _ io.Reader = (*Reader)(nil)
+
+// NewReader returns a reader of `length` bytes of plaintext starting at offset `off`;
+// negative length means "until the end".
+func NewReader(ra io.ReaderAt, cipherSize int64, key, nonce []byte, off, length int64) (*Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plainSize := PlainSize(cipherSize)
+	if off < 0 || off > plainSize {
+		return nil, fmt.Errorf("sse: invalid offset %d (plaintext size %d)", off, plainSize)
+	}
+	if length < 0 || off+length > plainSize {
+		length = plainSize - off
+	}
+	return &Reader{
+		ra:         ra,
+		aead:       aead,
+		nonce:      nonce,
+		cnonce:     make([]byte, NonceSize),
+		in:         make([]byte, cipherChunk),
+		cipherSize: cipherSize,
+		idx:        off / ChunkSize,
+		skip:       int(off % ChunkSize),
+		remain:     length,
+	}, nil
+}
+
+func (dr *Reader) Read(p []byte) (n int, err error) {
+	for len(p) > 0 && dr.remain > 0 {
+		if dr.pos >= len(dr.buf) {
+			if err = dr.open(); err != nil {
+				return
+			}
+		}
+		l := cos.Min(len(p), len(dr.buf)-dr.pos)
+		if int64(l) > dr.remain {
+			l = int(dr.remain)
+		}
+		copy(p, dr.buf[dr.pos:dr.pos+l])
+		dr.pos += l
+		dr.remain -= int64(l)
+		p = p[l:]
+		n += l
+	}
+	if n == 0 && dr.remain == 0 {
+		err = io.EOF
+	}
+	return
+}
+
+// read and decrypt the next chunk
+func (dr *Reader) open() error {
+	off := dr.idx * cipherChunk
+	size := cos.MinI64(cipherChunk, dr.cipherSize-off)
+	if size < TagSize {
+		return errTruncated
+	}
+	in := dr.in[:size]
+	if n, err := dr.ra.ReadAt(in, off); n < len(in) {
+		if err == nil || err == io.EOF {
+			err = errTruncated
+		}
+		return err
+	}
+	chunkNonce(dr.cnonce, dr.nonce, uint64(dr.idx))
+	last := off+size == dr.cipherSize
+	buf, err := dr.aead.Open(dr.buf[:0], dr.cnonce, in, chunkAD(last))
+	if err != nil {
+		return errCorrupted
+	}
+	dr.buf, dr.pos = buf, dr.skip
+	dr.skip = 0
+	dr.idx++
+	return nil
+}
@@ -0,0 +1,181 @@
+// Package sse provides server-side encryption at rest: chunked AES-GCM format,
+// streaming encryption, random-access decryption, and encryption key providers.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/sse"
+)
+
+func randBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	tassert.CheckFatal(t, err)
+	return b
+}
+
+func encrypt(t *testing.T, plain, key, nonce []byte) []byte {
+	var (
+		out = &bytes.Buffer{}
+		w   *sse.Writer
+		err error
+	)
+	w, err = sse.NewWriter(out, key, nonce)
+	tassert.CheckFatal(t, err)
+	// write in odd-sized pieces to exercise chunk boundaries
+	for b := plain; len(b) > 0; {
+		n := 1000 + len(b)%7777
+		if n > len(b) {
+			n = len(b)
+		}
+		_, err = w.Write(b[:n])
+		tassert.CheckFatal(t, err)
+		b = b[n:]
+	}
+	tassert.CheckFatal(t, w.Close())
+	tassert.Fatalf(t, w.Size() == int64(out.Len()), "size %d vs %d", w.Size(), out.Len())
+	return out.Bytes()
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	var (
+		key   = randBytes(t, sse.KeySize)
+		sizes = []int{0, 1, 100, sse.ChunkSize - 1, sse.ChunkSize, sse.ChunkSize + 1, 3*sse.ChunkSize + 12345}
+	)
+	for _, size := range sizes {
+		nonce, err := sse.NewNonce()
+		tassert.CheckFatal(t, err)
+		plain := randBytes(t, size)
+		cipher := encrypt(t, plain, key, nonce)
+
+		tassert.Errorf(t, int64(len(cipher)) == sse.CipherSize(int64(size)),
+			"size %d: cipher size %d, expected %d", size, len(cipher), sse.CipherSize(int64(size)))
+		tassert.Errorf(t, sse.PlainSize(int64(len(cipher))) == int64(size),
+			"size %d: plain size %d", size, sse.PlainSize(int64(len(cipher))))
+		if size > 0 {
+			tassert.Errorf(t, !bytes.Contains(cipher, plain[:cos.Min(size, 64)]), "size %d: plaintext leaked", size)
+		}
+
+		// full read
+		r, err := sse.NewReader(bytes.NewReader(cipher), int64(len(cipher)), key, nonce, 0, -1)
+		tassert.CheckFatal(t, err)
+		got, err := io.ReadAll(r)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(got, plain), "size %d: decrypted content differs", size)
+
+		// ranges
+		ranges := [][2]int{{0, 1}, {size / 2, size / 3}, {sse.ChunkSize - 10, 20}, {size - 1, 1}, {size, 0}}
+		for _, rng := range ranges {
+			off, length := rng[0], rng[1]
+			if off < 0 || off+length > size {
+				continue
+			}
+			r, err := sse.NewReader(bytes.NewReader(cipher), int64(len(cipher)), key, nonce,
+				int64(off), int64(length))
+			tassert.CheckFatal(t, err)
+			got, err := io.ReadAll(r)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, bytes.Equal(got, plain[off:off+length]),
+				"size %d: range [%d, %d) differs", size, off, off+length)
+		}
+	}
+}
+
+func TestDecryptCorrupted(t *testing.T) {
+	var (
+		key      = randBytes(t, sse.KeySize)
+		nonce, _ = sse.NewNonce()
+		plain    = randBytes(t, 2*sse.ChunkSize+100)
+		cipher   = encrypt(t, plain, key, nonce)
+	)
+	readAll := func(b, key []byte) error {
+		r, err := sse.NewReader(bytes.NewReader(b), int64(len(b)), key, nonce, 0, -1)
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadAll(r)
+		return err
+	}
+	// flipped bit
+	tampered := append([]byte{}, cipher...)
+	tampered[sse.ChunkSize+sse.TagSize+5] ^= 1
+	tassert.Errorf(t, readAll(tampered, key) != nil, "expected error reading tampered ciphertext")
+
+	// truncated at chunk boundary
+	truncated := cipher[:2*(sse.ChunkSize+sse.TagSize)]
+	tassert.Errorf(t, readAll(truncated, key) != nil, "expected error reading truncated ciphertext")
+
+	// wrong key
+	tassert.Errorf(t, readAll(cipher, randBytes(t, sse.KeySize)) != nil, "expected error reading with wrong key")
+
+	// invalid key size
+	_, err := sse.NewWriter(io.Discard, randBytes(t, 16), nonce)
+	tassert.Errorf(t, err != nil, "expected error: invalid key size")
+}
+
+func TestObjMD(t *testing.T) {
+	nonce, err := sse.NewNonce()
+	tassert.CheckFatal(t, err)
+	v := sse.EncodeMD("key-1.a", nonce)
+	keyID, decoded, err := sse.DecodeMD(v)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, keyID == "key-1.a" && bytes.Equal(decoded, nonce), "unexpected %q, %v", keyID, decoded)
+
+	for _, bad := range []string{"", "key", ":" + base64.StdEncoding.EncodeToString(nonce), "key:abc"} {
+		if _, _, err := sse.DecodeMD(bad); err == nil {
+			t.Errorf("expected error decoding %q", bad)
+		}
+	}
+
+	oa := &cmn.ObjAttrs{Size: sse.CipherSize(1000)}
+	tassert.Errorf(t, sse.Size(oa) == oa.Size, "expecting ciphertext size for non-encrypted")
+	oa.SetCustomKey(cmn.SSEObjMD, v)
+	tassert.Errorf(t, sse.Size(oa) == 1000, "expecting plaintext size, got %d", sse.Size(oa))
+}
+
+func TestKeyfile(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		path = filepath.Join(dir, "keys.json")
+		key1 = randBytes(t, sse.KeySize)
+		key2 = randBytes(t, sse.KeySize)
+		conf = &cmn.KMSConf{Provider: cmn.KMSKeyfile, Keyfile: path}
+	)
+	write := func(s string) {
+		tassert.CheckFatal(t, os.WriteFile(path, []byte(s), 0o600))
+	}
+	write(`{"k1": "` + base64.StdEncoding.EncodeToString(key1) + `", "short": "` +
+		base64.StdEncoding.EncodeToString(key1[:16]) + `"}`)
+
+	key, err := sse.GetKey(conf, "k1")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(key, key1), "wrong key")
+
+	_, err = sse.GetKey(conf, "short")
+	tassert.Errorf(t, err != nil, "expected error: invalid key size")
+	_, err = sse.GetKey(conf, "k2")
+	tassert.Errorf(t, cmn.IsErrNotFound(err), "expected not-found, got %v", err)
+
+	// new key gets picked up (reload on miss)
+	write(`{"k1": "` + base64.StdEncoding.EncodeToString(key1) + `", "k2": "` +
+		base64.StdEncoding.EncodeToString(key2) + `"}`)
+	key, err = sse.GetKey(conf, "k2")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(key, key2), "wrong key")
+
+	_, err = sse.GetKey(&cmn.KMSConf{}, "k1")
+	tassert.Errorf(t, err == sse.ErrNoProvider, "expected %v, got %v", sse.ErrNoProvider, err)
+}
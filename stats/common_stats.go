@@ -88,6 +88,7 @@ const (
 	ErrListCount     = "err.list.n"
 	ErrRangeCount    = "err.range.n"
 	ErrDownloadCount = "err.dl.n"
+	RateLimitedCount = "ratelim.n" // requests rejected with 429 (per rate limit - see cmn.RateLimit)
 
 	// KindLatency
 	GetLatency       = "get.ns"
@@ -116,6 +117,7 @@ type (
 	CoreStats struct {
 		Tracker   statsTracker
 		promDesc  promDesc
+		promSfx   promDesc // counters broken down by NameSuffix (see statsValue.label.sfx)
 		statsdC   *statsd.Client
 		statsTime time.Duration
 		sgl       *memsys.SGL
@@ -203,7 +205,9 @@ type (
 			comm string // common part of the metric label (as in: <prefix> . comm . <suffix>)
 			stsd string // StatsD label
 			prom string // Prometheus label
+			sfx  string // optional Prometheus variable label: counter values by NameSuffix
 		}
+		sfxs       map[string]int64 // (label.sfx)
 		numSamples int64
 		cumulative int64
 		isCommon   bool // optional, common to the proxy and target
@@ -247,6 +251,7 @@ func match(s string, prefs []string) bool {
 func (s *CoreStats) init(node *cluster.Snode, size int) {
 	s.Tracker = make(statsTracker, size)
 	s.promDesc = make(promDesc, size)
+	s.promSfx = make(promDesc, 2)
 
 	// debug.NewExpvar & debug.SetExpvar could be placed here and elsewhere to visualize:
 	//     * all counters including errors
@@ -367,6 +372,10 @@ func (s *CoreStats) initProm(node *cluster.Snode) {
 
 		fullqn := prometheus.BuildFQName("ais", node.Type(), id+"_"+v.label.prom)
 		s.promDesc[name] = prometheus.NewDesc(fullqn, help, nil /*variableLabels*/, nil /*constLabels*/)
+		if v.label.sfx != "" {
+			fullqn += "_by_" + v.label.sfx
+			s.promSfx[name] = prometheus.NewDesc(fullqn, help, []string{v.label.sfx}, nil /*constLabels*/)
+		}
 	}
 }
 
@@ -407,6 +416,12 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 	case KindCounter:
 		v.Lock()
 		v.Value += val
+		if v.label.sfx != "" && nameSuffix != "" && s.isPrometheus() {
+			if v.sfxs == nil {
+				v.sfxs = make(map[string]int64, 4)
+			}
+			v.sfxs[nameSuffix] += val
+		}
 		v.Unlock()
 		// NOTE:
 		//      - currently only counters;
@@ -417,6 +432,10 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 			s.statsdC.Send(v.label.comm+"."+nameSuffix,
 				1, metric{Type: statsd.Counter, Name: "count", Value: val})
 		}
+	case KindGauge: // up/down (e.g., the number of pending operations)
+		v.Lock()
+		v.Value += val
+		v.Unlock()
 	default:
 		debug.AssertMsg(false, v.kind)
 	}
@@ -621,6 +640,8 @@ func (tracker statsTracker) regCommonMetrics(node *cluster.Snode) {
 	tracker.register(node, ErrListCount, KindCounter, true)
 	tracker.register(node, ErrRangeCount, KindCounter, true)
 	tracker.register(node, ErrDownloadCount, KindCounter, true)
+	tracker.register(node, RateLimitedCount, KindCounter, true)
+	tracker[RateLimitedCount].label.sfx = "limit"
 
 	tracker.register(node, Uptime, KindSpecial, true)
 }
@@ -646,6 +667,9 @@ func (r *statsRunner) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range r.Core.promDesc {
 		ch <- desc
 	}
+	for _, desc := range r.Core.promSfx {
+		ch <- desc
+	}
 }
 
 func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
@@ -692,6 +716,17 @@ func (r *statsRunner) Collect(ch chan<- prometheus.Metric) {
 		m, err := prometheus.NewConstMetric(desc, promMetricType, fv)
 		debug.AssertNoErr(err)
 		ch <- m
+
+		// 4. counters by suffix, if any
+		if desc, ok := r.Core.promSfx[name]; ok {
+			v.RLock()
+			for sfx, cnt := range v.sfxs {
+				m, err := prometheus.NewConstMetric(desc, prometheus.CounterValue, float64(cnt), sfx)
+				debug.AssertNoErr(err)
+				ch <- m
+			}
+			v.RUnlock()
+		}
 	}
 	r.Core.promRUnlock()
 }
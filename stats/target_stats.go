@@ -46,6 +46,10 @@ const (
 	StreamsInObjCount  = transport.InObjCount
 	StreamsInObjSize   = transport.InObjSize
 
+	// intra-cluster compression: ratio = orig.size / cmpr.size
+	StreamsOutCmprOrigSize = transport.OutCmprOrigSize
+	StreamsOutCmprSize     = transport.OutCmprSize
+
 	// errors
 	ErrCksumCount    = "err.cksum.n"
 	ErrCksumSize     = "err.cksum.size"
@@ -70,6 +74,24 @@ const (
 	// Downloader
 	DownloadSize = "dl.size"
 
+	// bucket event notifications (webhooks)
+	WebhookCount     = "webhook.n"
+	WebhookDropCount = "webhook.drop.n"
+	ErrWebhookCount  = "err.webhook.n"
+
+	// replication to remote AIS clusters
+	ReplCount     = "replication.n"
+	ReplSize      = "replication.size"
+	ReplDropCount = "replication.drop.n"
+	ReplLatency   = "replication.lag.ns"  // time between the operation and its replication
+	ReplPending   = "replication.pending" // (gauge) number of operations waiting to be replicated
+	ErrReplCount  = "err.replication.n"
+
+	// repair of under-protected objects (missing mirror copies and EC slices)
+	RepairCount    = "repair.n"
+	RepairPending  = "repair.pending" // (gauge) number of objects waiting to be repaired
+	ErrRepairCount = "err.repair.n"
+
 	// KindThroughput
 	GetThroughput = "get.bps" // bytes per second
 )
@@ -185,6 +207,8 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	r.reg(StreamsOutObjSize, KindCounter)
 	r.reg(StreamsInObjCount, KindCounter)
 	r.reg(StreamsInObjSize, KindCounter)
+	r.reg(StreamsOutCmprOrigSize, KindCounter)
+	r.reg(StreamsOutCmprSize, KindCounter)
 
 	// special
 	r.reg(RestartCount, KindCounter)
@@ -193,6 +217,24 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	r.reg(DownloadSize, KindCounter)
 	r.reg(DownloadLatency, KindLatency)
 
+	// bucket event notifications
+	r.reg(WebhookCount, KindCounter)
+	r.reg(WebhookDropCount, KindCounter)
+	r.reg(ErrWebhookCount, KindCounter)
+
+	// replication
+	r.reg(ReplCount, KindCounter)
+	r.reg(ReplSize, KindCounter)
+	r.reg(ReplDropCount, KindCounter)
+	r.reg(ReplLatency, KindLatency)
+	r.reg(ReplPending, KindGauge)
+	r.reg(ErrReplCount, KindCounter)
+
+	// repair
+	r.reg(RepairCount, KindCounter)
+	r.reg(RepairPending, KindGauge)
+	r.reg(ErrRepairCount, KindCounter)
+
 	// dsort
 	r.reg(DSortCreationReqCount, KindCounter)
 	r.reg(DSortCreationReqLatency, KindLatency)
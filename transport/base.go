@@ -37,6 +37,9 @@ const (
 	inEOB
 )
 
+// compression: zstd level when not configured (see cmn.TransportConf)
+const zstdDefaultLevel = 3
+
 // termination: reasons
 const (
 	reasonError   = "error"
@@ -49,7 +52,7 @@ const (
 
 type (
 	streamer interface {
-		compressed() bool
+		codec() string // compression codec or "" (when not compressed)
 		dryrun()
 		terminate(error, string) (string, error)
 		doRequest() error
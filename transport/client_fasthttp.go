@@ -61,8 +61,8 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	req.Header.SetMethod(http.MethodPut)
 	req.SetRequestURI(s.dstURL)
 	req.SetBodyStream(body, -1)
-	if s.streamer.compressed() {
-		req.Header.Set(apc.HdrCompress, apc.LZ4Compression)
+	if codec := s.streamer.codec(); codec != "" {
+		req.Header.Set(apc.HdrCompress, codec)
 	}
 	req.Header.Set(apc.HdrSessID, strconv.FormatInt(s.sessID, 10))
 	// do
@@ -77,7 +77,7 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	resp.BodyWriteTo(io.Discard)
 	fasthttp.ReleaseRequest(req)
 	fasthttp.ReleaseResponse(resp)
-	if s.streamer.compressed() {
+	if s.streamer.codec() != "" {
 		s.streamer.resetCompression()
 	}
 	return
@@ -57,8 +57,8 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	if request, err = http.NewRequest(http.MethodPut, s.dstURL, body); err != nil {
 		return
 	}
-	if s.streamer.compressed() {
-		request.Header.Set(apc.HdrCompress, apc.LZ4Compression)
+	if codec := s.streamer.codec(); codec != "" {
+		request.Header.Set(apc.HdrCompress, codec)
 	}
 	request.Header.Set(apc.HdrSessID, strconv.FormatInt(s.sessID, 10))
 
@@ -71,7 +71,7 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	}
 	cos.DrainReader(response.Body)
 	response.Body.Close()
-	if s.streamer.compressed() {
+	if s.streamer.codec() != "" {
 		s.streamer.resetCompression()
 	}
 	return
@@ -0,0 +1,9 @@
+// Package transport provides streaming object-based transport over http for intra-cluster continuous
+// intra-cluster communications (see README for details and usage example).
+/*
+ * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package transport
+
+// test-only: compressor (lz4 or zstd writer) that must be released upon stream termination
+func (s *Stream) Compressor() any { return s.cmpr.zw }
@@ -17,6 +17,7 @@ package transport_test
 // AIS_DEBUG=transport=4 go test -v -run=Multi -tags=debug -logtostderr=true
 
 import (
+	"bytes"
 	"encoding/binary"
 	"flag"
 	"fmt"
@@ -28,6 +29,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"runtime"
 	"strconv"
 	"sync"
 	"testing"
@@ -524,6 +526,138 @@ func Test_CompressedOne(t *testing.T) {
 	printNetworkStats(t)
 }
 
+// round-trip (compressible) objects with each supported codec
+func Test_CompressedCodecs(t *testing.T) {
+	ts := httptest.NewServer(objmux)
+	defer ts.Close()
+
+	for _, codec := range apc.SupportedCodecs {
+		t.Run(codec, func(t *testing.T) {
+			var (
+				trname  = "cmpr-" + codec
+				objs    = make(map[string][]byte, 100)
+				mu      sync.Mutex
+				numRecv int
+				random  = newRand(mono.NanoTime())
+				config  = *cmn.GCO.Get()
+			)
+			config.Transport.Codec = codec
+			config.Transport.ZstdLevel = 5
+			config.Transport.LZ4BlockMaxSize = 256 * cos.KiB
+			tassert.CheckFatal(t, config.Transport.Validate())
+
+			receive := func(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+				tassert.CheckFatal(t, err)
+				b, err := io.ReadAll(objReader)
+				tassert.CheckFatal(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				numRecv++
+				tassert.Errorf(t, bytes.Equal(b, objs[hdr.ObjName]), "%s: content mismatch (size %d, expected %d)",
+					hdr.ObjName, len(b), len(objs[hdr.ObjName]))
+				return nil
+			}
+			err := transport.HandleObjStream(trname, receive)
+			tassert.CheckFatal(t, err)
+			defer transport.Unhandle(trname)
+
+			httpclient := transport.NewIntraDataClient()
+			url := ts.URL + transport.ObjURLPath(trname)
+			extra := &transport.Extra{Compression: apc.CompressAlways, Config: &config}
+			stream := transport.NewObjStream(httpclient, url, cos.GenTie(), extra)
+
+			line := []byte(`{"key": "value", "numbers": [1, 2, 3], "text": "the quick brown fox"}` + "\n")
+			for i := 0; i < 100; i++ {
+				var (
+					name = strconv.Itoa(i)
+					b    = bytes.Repeat(line, random.Intn(4096)+1)
+				)
+				mu.Lock()
+				objs[name] = b
+				mu.Unlock()
+				hdr := transport.ObjHdr{Bck: cmn.Bck{Name: "a", Provider: apc.ProviderAIS}, ObjName: name}
+				hdr.ObjAttrs.Size = int64(len(b))
+				stream.Send(&transport.Obj{Hdr: hdr, Reader: io.NopCloser(bytes.NewReader(b))})
+			}
+			stream.Fin()
+
+			stats := stream.GetStats()
+			tlog.Logf("%s: offset=%d, num=%d, compression-ratio=%.2f\n",
+				stream, stats.Offset.Load(), stats.Num.Load(), stats.CompressionRatio())
+			mu.Lock()
+			tassert.Errorf(t, numRecv == len(objs), "expected %d objects received, got %d", len(objs), numRecv)
+			mu.Unlock()
+			tassert.Errorf(t, stats.CompressionRatio() > 2, "expected compression, got ratio %.2f",
+				stats.CompressionRatio())
+		})
+	}
+}
+
+// open and close (Fin) many compressed streams - terminated streams must release (close) their
+// compressors, and the number of goroutines must not grow with the number of streams
+func Test_CompressedManyStreams(t *testing.T) {
+	const numStreams = 200
+	ts := httptest.NewServer(objmux)
+	defer ts.Close()
+
+	for _, codec := range apc.SupportedCodecs {
+		t.Run(codec, func(t *testing.T) {
+			var (
+				trname  = "cmpr-many-" + codec
+				numRecv atomic.Int64
+				config  = *cmn.GCO.Get()
+				payload = bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\n"), 1000)
+			)
+			config.Transport.Codec = codec
+			config.Transport.ZstdLevel = 3
+			config.Transport.LZ4BlockMaxSize = 256 * cos.KiB
+			tassert.CheckFatal(t, config.Transport.Validate())
+
+			receive := func(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+				tassert.CheckFatal(t, err)
+				b, err := io.ReadAll(objReader)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, bytes.Equal(b, payload), "%s: content mismatch", hdr.ObjName)
+				numRecv.Inc()
+				return nil
+			}
+			err := transport.HandleObjStream(trname, receive)
+			tassert.CheckFatal(t, err)
+			defer transport.Unhandle(trname)
+
+			var (
+				httpclient = transport.NewIntraDataClient()
+				url        = ts.URL + transport.ObjURLPath(trname)
+				extra      = &transport.Extra{Compression: apc.CompressAlways, Config: &config}
+				numBefore  = runtime.NumGoroutine()
+			)
+			for i := 0; i < numStreams; i++ {
+				stream := transport.NewObjStream(httpclient, url, cos.GenTie(), extra)
+				hdr := transport.ObjHdr{Bck: cmn.Bck{Name: "a", Provider: apc.ProviderAIS}, ObjName: strconv.Itoa(i)}
+				hdr.ObjAttrs.Size = int64(len(payload))
+				stream.Send(&transport.Obj{Hdr: hdr, Reader: io.NopCloser(bytes.NewReader(payload))})
+				stream.Fin()
+				tassert.Errorf(t, stream.IsTerminated(), "%s: expected terminated", stream)
+				tassert.Errorf(t, stream.Compressor() == nil, "%s: compressor not released", stream)
+			}
+			tassert.Errorf(t, numRecv.Load() == numStreams, "expected %d objects received, got %d",
+				numStreams, numRecv.Load())
+
+			// allow for (idle) client connections and the like
+			var numAfter int
+			for i := 0; i < 20; i++ {
+				if numAfter = runtime.NumGoroutine(); numAfter <= numBefore+numStreams/10 {
+					break
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			tlog.Logf("%s: goroutines before %d, after %d\n", codec, numBefore, numAfter)
+			tassert.Errorf(t, numAfter <= numBefore+numStreams/10,
+				"goroutine leak: %d before vs %d after closing %d streams", numBefore, numAfter, numStreams)
+		})
+	}
+}
+
 func Test_DryRun(t *testing.T) {
 	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
 
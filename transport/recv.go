@@ -26,6 +26,7 @@ import (
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/OneOfOne/xxhash"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -73,6 +74,7 @@ func RxAnyStream(w http.ResponseWriter, r *http.Request) {
 	var (
 		reader    io.Reader = r.Body
 		lz4Reader *lz4.Reader
+		zstReader *zstd.Decoder
 		trname    = path.Base(r.URL.Path)
 	)
 	mu.RLock()
@@ -88,11 +90,23 @@ func RxAnyStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	mu.RUnlock()
-	// compression
-	if compressionType := r.Header.Get(apc.HdrCompress); compressionType != "" {
-		debug.Assert(compressionType == apc.LZ4Compression)
+	// compression (the codec is negotiated per stream - see Stream.initCompression)
+	switch codec := r.Header.Get(apc.HdrCompress); codec {
+	case "":
+	case apc.LZ4Compression:
 		lz4Reader = lz4.NewReader(r.Body)
 		reader = lz4Reader
+	case apc.ZstdCompression:
+		var err error
+		zstReader, err = zstd.NewReader(r.Body, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			cmn.WriteErr(w, r, fmt.Errorf("%s: failed to initialize %s decoder: %v", trname, codec, err))
+			return
+		}
+		reader = zstReader
+	default:
+		cmn.WriteErr(w, r, fmt.Errorf("%s: unsupported compression %q", trname, codec))
+		return
 	}
 
 	// session
@@ -118,6 +132,8 @@ func RxAnyStream(w http.ResponseWriter, r *http.Request) {
 	// cleanup
 	if lz4Reader != nil {
 		lz4Reader.Reset(nil)
+	} else if zstReader != nil {
+		zstReader.Close()
 	}
 	if it.pdu != nil {
 		it.pdu.free(h.mm)
@@ -52,7 +52,7 @@ func (s *MsgStream) terminate(err error, reason string) (actReason string, actEr
 
 func (*MsgStream) abortPending(error, bool) {}
 func (*MsgStream) errCmpl(error)            {}
-func (*MsgStream) compressed() bool         { return false }
+func (*MsgStream) codec() string            { return "" }
 func (*MsgStream) resetCompression()        { debug.Assert(false) }
 
 func (s *MsgStream) doRequest() error {
@@ -11,9 +11,11 @@ import (
 	"runtime"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -24,15 +26,25 @@ type (
 		cmplCh   chan cmpl // aka SCQ; note that SQ and SCQ together form a FIFO
 		callback ObjSentCB // to free SGLs, close files, etc.
 		sendoff  sendoff
-		lz4s     lz4Stream
+		cmpr     cmprStream
 		streamBase
 	}
-	lz4Stream struct {
-		s             *Stream
-		zw            *lz4.Writer // orig reader => zw
-		sgl           *memsys.SGL // zw => bb => network
-		blockMaxSize  int         // *uncompressed* block max size
-		frameChecksum bool        // true: checksum lz4 frames
+	cmprStream struct {
+		s     *Stream
+		zw    compressor  // orig reader => zw
+		sgl   *memsys.SGL // zw => bb => network
+		codec string      // apc.LZ4Compression, etc.
+		// lz4
+		blockMaxSize  int  // *uncompressed* block max size
+		frameChecksum bool // true: checksum lz4 frames
+		// zstd
+		level int
+	}
+	// lz4 or zstd writer
+	compressor interface {
+		io.Writer
+		Flush() error
+		Reset(w io.Writer)
 	}
 	sendoff struct {
 		obj Obj
@@ -75,36 +87,48 @@ func (s *Stream) terminate(err error, reason string) (actReason string, actErr e
 	gc.remove(&s.streamBase)
 
 	if s.compressed() {
-		s.lz4s.sgl.Free()
-		if s.lz4s.zw != nil {
-			s.lz4s.zw.Reset(nil)
-		}
+		s.cmpr.fini()
 	}
 	return
 }
 
 func (s *Stream) initCompression(extra *Extra) {
-	s.lz4s.s = s
-	s.lz4s.blockMaxSize = int(extra.Config.Transport.LZ4BlockMaxSize)
-	s.lz4s.frameChecksum = extra.Config.Transport.LZ4FrameChecksum
-	mem := extra.MMSA
+	var (
+		conf = &extra.Config.Transport
+		mem  = extra.MMSA
+	)
 	if mem == nil {
 		mem = memsys.PageMM()
 	}
-	if s.lz4s.blockMaxSize >= memsys.MaxPageSlabSize {
-		s.lz4s.sgl = mem.NewSGL(memsys.MaxPageSlabSize, memsys.MaxPageSlabSize)
+	s.cmpr.s = s
+	s.cmpr.codec = conf.Codec
+	if s.cmpr.codec == apc.ZstdCompression {
+		s.cmpr.level = conf.ZstdLevel
+		if s.cmpr.level == 0 {
+			s.cmpr.level = zstdDefaultLevel
+		}
+		s.cmpr.sgl = mem.NewSGL(memsys.MaxPageSlabSize, memsys.MaxPageSlabSize)
+		s.lid = fmt.Sprintf("%s[%d[zstd-%d]]", s.trname, s.sessID, s.cmpr.level)
+		return
+	}
+	s.cmpr.codec = apc.LZ4Compression
+	s.cmpr.blockMaxSize = int(conf.LZ4BlockMaxSize)
+	s.cmpr.frameChecksum = conf.LZ4FrameChecksum
+	if s.cmpr.blockMaxSize >= memsys.MaxPageSlabSize {
+		s.cmpr.sgl = mem.NewSGL(memsys.MaxPageSlabSize, memsys.MaxPageSlabSize)
 	} else {
-		s.lz4s.sgl = mem.NewSGL(cos.KiB*64, cos.KiB*64)
+		s.cmpr.sgl = mem.NewSGL(cos.KiB*64, cos.KiB*64)
 	}
-	s.lid = fmt.Sprintf("%s[%d[%s]]", s.trname, s.sessID, cos.B2S(int64(s.lz4s.blockMaxSize), 0))
+	s.lid = fmt.Sprintf("%s[%d[%s]]", s.trname, s.sessID, cos.B2S(int64(s.cmpr.blockMaxSize), 0))
 }
 
-func (s *Stream) compressed() bool { return s.lz4s.s == s }
+func (s *Stream) compressed() bool { return s.cmpr.s == s }
+func (s *Stream) codec() string    { return s.cmpr.codec } // "" when not compressed
 func (s *Stream) usePDU() bool     { return s.pdu != nil }
 
 func (s *Stream) resetCompression() {
-	s.lz4s.sgl.Reset()
-	s.lz4s.zw.Reset(nil)
+	s.cmpr.sgl.Reset()
+	s.cmpr.zw.Reset(nil)
 }
 
 func (s *Stream) cmplLoop() {
@@ -160,17 +184,19 @@ func (s *Stream) doRequest() error {
 	if !s.compressed() {
 		return s.do(s)
 	}
-	s.lz4s.sgl.Reset()
-	if s.lz4s.zw == nil {
-		s.lz4s.zw = lz4.NewWriter(s.lz4s.sgl)
+	s.cmpr.sgl.Reset()
+	if s.cmpr.zw == nil {
+		s.cmpr.zw = s.cmpr.newWriter()
 	} else {
-		s.lz4s.zw.Reset(s.lz4s.sgl)
+		s.cmpr.zw.Reset(s.cmpr.sgl)
 	}
-	// lz4 framing spec at http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
-	s.lz4s.zw.Header.BlockChecksum = false
-	s.lz4s.zw.Header.NoChecksum = !s.lz4s.frameChecksum
-	s.lz4s.zw.Header.BlockMaxSize = s.lz4s.blockMaxSize
-	return s.do(&s.lz4s)
+	if zw, ok := s.cmpr.zw.(*lz4.Writer); ok {
+		// lz4 framing spec at http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
+		zw.Header.BlockChecksum = false
+		zw.Header.NoChecksum = !s.cmpr.frameChecksum
+		zw.Header.BlockMaxSize = s.cmpr.blockMaxSize
+	}
+	return s.do(&s.cmpr)
 }
 
 // as io.Reader
@@ -409,48 +435,78 @@ func (stats *Stats) CompressionRatio() float64 {
 	return float64(bytesRead) / float64(bytesSent)
 }
 
-///////////////
-// lz4Stream //
-///////////////
+////////////////
+// cmprStream //
+////////////////
+
+func (cs *cmprStream) newWriter() compressor {
+	if cs.codec == apc.ZstdCompression {
+		zw, err := zstd.NewWriter(cs.sgl,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(cs.level)),
+			zstd.WithEncoderConcurrency(1), // (compressing in the caller's goroutine)
+			zstd.WithLowerEncoderMem(true),
+		)
+		debug.AssertNoErr(err)
+		return zw
+	}
+	return lz4.NewWriter(cs.sgl)
+}
+
+// NOTE: unlike lz4, zstd encoder must be closed to release its (block) encoders and buffers;
+// closing writes the final frame - hence, io.Discard
+func (cs *cmprStream) fini() {
+	if zw, ok := cs.zw.(*zstd.Encoder); ok {
+		zw.Reset(io.Discard)
+		zw.Close()
+	} else if cs.zw != nil {
+		cs.zw.Reset(nil)
+	}
+	cs.zw = nil
+	cs.sgl.Free()
+}
 
-func (lz4s *lz4Stream) Read(b []byte) (n int, err error) {
+func (cs *cmprStream) Read(b []byte) (n int, err error) {
 	var (
-		sendoff = &lz4s.s.sendoff
+		sendoff = &cs.s.sendoff
 		last    = sendoff.obj.Hdr.isFin()
-		retry   = 64 // insist on returning n > 0 (note that lz4 compresses /blocks/)
+		retry   = 64 // insist on returning n > 0 (note that both lz4 and zstd compress /blocks/)
+		orig    int
 	)
-	if lz4s.sgl.Len() > 0 {
-		lz4s.zw.Flush()
-		n, err = lz4s.sgl.Read(b)
+	if cs.sgl.Len() > 0 {
+		cs.zw.Flush()
+		n, err = cs.sgl.Read(b)
 		if err == io.EOF { // reusing/rewinding this buf multiple times
 			err = nil
 		}
 		goto ex
 	}
 re:
-	n, err = lz4s.s.Read(b)
-	_, _ = lz4s.zw.Write(b[:n])
+	n, err = cs.s.Read(b)
+	_, _ = cs.zw.Write(b[:n])
+	orig += n
 	if last {
-		lz4s.zw.Flush()
+		cs.zw.Flush()
 		retry = 0
-	} else if lz4s.s.sendoff.ins == inEOB || err != nil {
-		lz4s.zw.Flush()
+	} else if cs.s.sendoff.ins == inEOB || err != nil {
+		cs.zw.Flush()
 		retry = 0
 	}
-	n, _ = lz4s.sgl.Read(b)
+	n, _ = cs.sgl.Read(b)
 	if n == 0 {
 		if retry > 0 {
 			retry--
 			runtime.Gosched()
 			goto re
 		}
-		lz4s.zw.Flush()
-		n, _ = lz4s.sgl.Read(b)
+		cs.zw.Flush()
+		n, _ = cs.sgl.Read(b)
 	}
 ex:
-	lz4s.s.stats.CompressedSize.Add(int64(n))
-	if lz4s.sgl.Len() == 0 {
-		lz4s.sgl.Reset()
+	cs.s.stats.CompressedSize.Add(int64(n))
+	statsTracker.Add(OutCmprOrigSize, int64(orig))
+	statsTracker.Add(OutCmprSize, int64(n))
+	if cs.sgl.Len() == 0 {
+		cs.sgl.Reset()
 	}
 	if last && err == nil {
 		err = io.EOF
@@ -15,6 +15,10 @@ const (
 	OutObjSize  = "streams.out.obj.size"
 	InObjCount  = "streams.in.obj.n"
 	InObjSize   = "streams.in.obj.size"
+
+	// compression (see also Stats.CompressionRatio)
+	OutCmprOrigSize = "streams.out.cmpr.orig.size" // original (uncompressed) bytes
+	OutCmprSize     = "streams.out.cmpr.size"      // compressed bytes sent
 )
 
 type (
@@ -5,20 +5,32 @@
 package xact
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/OneOfOne/xxhash"
 )
 
 type BckJog struct {
 	Base
 	t       cluster.Target
 	joggers *mpather.JoggerGroup
+	ckpt    *mpather.Ckpt
 }
 
+// NOTE: pausable kinds (see Table) get paused and resumed via the joggers;
+// in addition, `opts.Ckpt` (if any) makes the xaction resumable across restarts
 func (r *BckJog) Init(id, kind string, bck *cluster.Bck, opts *mpather.JoggerGroupOpts) {
 	r.t = opts.T
 	r.InitBase(id, kind, bck)
+	if Table[kind].Pausable {
+		opts.Pauser = &r.Base
+	}
+	r.ckpt = opts.Ckpt
 	r.joggers = mpather.NewJoggerGroup(opts)
 }
 
@@ -30,9 +42,25 @@ func (r *BckJog) Wait() error {
 		select {
 		case errCause := <-r.ChanAbort():
 			r.joggers.Stop()
+			// keep the progress unless aborted by user
+			if r.ckpt != nil && errCause == cmn.ErrXactUserAbort {
+				r.ckpt.Clear()
+			}
 			return cmn.NewErrAborted(r.Name(), "x-bck-jog", errCause)
 		case <-r.joggers.ListenFinished():
-			return r.joggers.Stop()
+			err := r.joggers.Stop()
+			if r.ckpt != nil && err == nil {
+				r.ckpt.Clear()
+			}
+			return err
 		}
 	}
 }
+
+// CkptID identifies a bucket-traversing job by its kind and parameters, so that
+// the same job started anew (e.g., after restart) and asked to resume finds its checkpoint
+// (see mpather.Ckpt)
+func CkptID(kind string, params ...string) string {
+	digest := xxhash.ChecksumString64S(strings.Join(params, "\x00"), cos.MLCG32)
+	return kind + "-" + strconv.FormatUint(digest, 36)
+}
@@ -0,0 +1,69 @@
+// Package xact provides core functionality for the AIStore eXtended Actions (xactions).
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xact
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Job history: finished jobs, as recorded by the IC proxies (that monitor them
+// via notifications), get persisted and can be queried later - see xact/xhist
+
+const HistDefaultLimit = 100 // max number of returned records when not specified
+
+type (
+	HistRecord struct {
+		ID        string              `json:"id"`
+		Kind      string              `json:"kind"`
+		Buckets   []cmn.Bck           `json:"buckets,omitempty"`
+		Msg       *apc.ActionMsg      `json:"msg,omitempty"`  // initiating request (when known)
+		User      string              `json:"user,omitempty"` // initiating user (when authenticated)
+		StartTime time.Time           `json:"start-time"`
+		EndTime   time.Time           `json:"end-time"`
+		Aborted   bool                `json:"aborted,omitempty"`
+		ErrMsg    string              `json:"err,omitempty"`
+		Stats     Stats               `json:"stats"`           // summed up across all targets
+		Snaps     map[string]*SnapExt `json:"snaps,omitempty"` // final snapshot by target ID
+	}
+
+	// all specified (non-zero) conditions must hold
+	HistQuery struct {
+		Kind  string    `json:"kind,omitempty"`
+		Bck   cmn.Bck   `json:"bck"`
+		User  string    `json:"user,omitempty"`
+		Since time.Time `json:"since"` // finished at or after
+		Until time.Time `json:"until"` // finished at or before
+		Limit int       `json:"limit,omitempty"`
+	}
+)
+
+func (rec *HistRecord) Duration() time.Duration { return rec.EndTime.Sub(rec.StartTime) }
+
+func (q *HistQuery) Match(rec *HistRecord) bool {
+	if q.Kind != "" && q.Kind != rec.Kind {
+		return false
+	}
+	if q.User != "" && q.User != rec.User {
+		return false
+	}
+	if !q.Since.IsZero() && rec.EndTime.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && rec.EndTime.After(q.Until) {
+		return false
+	}
+	if q.Bck.IsEmpty() {
+		return true
+	}
+	for i := range rec.Buckets {
+		if q.Bck.Equal(&rec.Buckets[i]) {
+			return true
+		}
+	}
+	return false
+}
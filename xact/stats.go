@@ -21,6 +21,7 @@ type (
 		EndTime   time.Time `json:"end-time"`
 		Stats     Stats     `json:"stats"` // common stats counters (see below)
 		AbortedX  bool      `json:"aborted"`
+		PausedX   bool      `json:"paused,omitempty"`
 	}
 
 	Stats struct {
@@ -53,7 +54,16 @@ type (
 	}
 
 	QueryMsgLRU struct {
-		Force bool `json:"force"`
+		Force  bool `json:"force"`
+		DryRun bool `json:"dry_run"` // report (count) objects that would be evicted without evicting them
+	}
+
+	QueryMsgLifecycle struct {
+		DryRun bool `json:"dry_run"` // report (count) matching objects without acting on them
+	}
+
+	QueryMsgScrub struct {
+		Restart bool `json:"restart"` // discard saved progress and start from the beginning
 	}
 )
 
@@ -65,6 +75,7 @@ var _ cluster.XactSnap = (*Snap)(nil)
 ///////////////
 
 func (b *Snap) IsAborted() bool { return b.AbortedX }
+func (b *Snap) IsPaused() bool  { return b.PausedX }
 func (b *Snap) Running() bool   { return b.EndTime.IsZero() }
 func (b *Snap) Finished() bool  { return !b.EndTime.IsZero() }
 
@@ -24,6 +24,7 @@ type (
 		Owned      bool            // true: JTX-owned
 		RefreshCap bool            // true: refresh capacity stats upon completion
 		Mountpath  bool            // true: mountpath-traversing (jogger-based) xaction
+		Pausable   bool            // true: can be paused and resumed via API (see Base.Pause)
 		// see xreg for "limited coexistence"
 		Rebalance  bool // moves data between nodes
 		Resilver   bool // moves data between mountpaths
@@ -38,25 +39,27 @@ var Table = map[string]Descriptor{
 	// bucket-less xactions that will typically have a 'cluster' scope (with resilver being a notable exception)
 	apc.ActLRU:          {Scope: ScopeG, Startable: true, Mountpath: true},
 	apc.ActStoreCleanup: {Scope: ScopeG, Startable: true, Mountpath: true},
+	apc.ActLifecycle:    {Scope: ScopeG, Startable: true, Mountpath: true, RefreshCap: true},
+	apc.ActScrub:        {Scope: ScopeG, Startable: true, Mountpath: true},
 	apc.ActElection:     {Scope: ScopeG, Startable: false},
 	apc.ActResilver:     {Scope: ScopeT, Startable: true, Mountpath: true, Resilver: true},
 	apc.ActRebalance:    {Scope: ScopeG, Startable: true, Metasync: true, Owned: false, Mountpath: true, Rebalance: true},
-	apc.ActDownload:     {Scope: ScopeG, Startable: false, Mountpath: true},
+	apc.ActDownload:     {Scope: ScopeG, Startable: false, Mountpath: true, Pausable: true},
 	apc.ActETLInline:    {Scope: ScopeG, Startable: false, Mountpath: false},
 
 	// xactions that run on a given bucket or buckets
 	apc.ActECGet:           {Scope: ScopeBck, Startable: false},
 	apc.ActECPut:           {Scope: ScopeBck, Startable: false, Mountpath: true, RefreshCap: true},
 	apc.ActECRespond:       {Scope: ScopeBck, Startable: false},
-	apc.ActMakeNCopies:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true},
+	apc.ActMakeNCopies:     {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, Pausable: true},
 	apc.ActPutCopies:       {Scope: ScopeBck, Startable: false, Mountpath: true, RefreshCap: true},
 	apc.ActArchive:         {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActCopyObjects:     {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActETLObjects:      {Scope: ScopeBck, Startable: false, RefreshCap: true},
 	apc.ActMoveBck:         {Scope: ScopeBck, Access: apc.AceMoveBucket, Startable: false, Metasync: true, Owned: false, Mountpath: true, Rebalance: true, MassiveBck: true},
-	apc.ActCopyBck:         {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
-	apc.ActETLBck:          {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
-	apc.ActECEncode:        {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true},
+	apc.ActCopyBck:         {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true, Pausable: true},
+	apc.ActETLBck:          {Scope: ScopeBck, Access: apc.AccessRW, Startable: false, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true, Pausable: true},
+	apc.ActECEncode:        {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Metasync: true, Owned: false, RefreshCap: true, Mountpath: true, MassiveBck: true, Pausable: true},
 	apc.ActEvictObjects:    {Scope: ScopeBck, Access: apc.AceObjDELETE, Startable: false, RefreshCap: true, Mountpath: true},
 	apc.ActDeleteObjects:   {Scope: ScopeBck, Access: apc.AceObjDELETE, Startable: false, RefreshCap: true, Mountpath: true},
 	apc.ActLoadLomCache:    {Scope: ScopeBck, Startable: true, Mountpath: true},
@@ -64,6 +67,8 @@ var Table = map[string]Descriptor{
 	apc.ActPromote:         {Scope: ScopeBck, Access: apc.AcePromote, Startable: false, RefreshCap: true},
 	apc.ActList:            {Scope: ScopeBck, Access: apc.AceObjLIST, Startable: false, Metasync: false, Owned: true},
 	apc.ActInvalListCache:  {Scope: ScopeBck, Access: apc.AceObjLIST, Startable: false},
+	apc.ActInventory:       {Scope: ScopeBck, Access: apc.AceObjLIST, Startable: true, Mountpath: true},
+	apc.ActReplResync:      {Scope: ScopeBck, Access: apc.AccessRW, Startable: true, Mountpath: true},
 
 	// other
 	apc.ActSummaryBck: {Scope: ScopeO, Access: apc.AceObjLIST | apc.AceBckHEAD, Startable: false, Metasync: false, Owned: true, Mountpath: true},
@@ -46,6 +46,10 @@ type (
 			err  error
 			done atomic.Bool
 		}
+		pause struct {
+			mu sync.Mutex
+			ch chan struct{} // non-nil while paused; closed upon resume (and abort)
+		}
 	}
 	Marked struct {
 		Xact        cluster.Xact
@@ -143,12 +147,72 @@ func (xctn *Base) Abort(err error) (ok bool) {
 	close(xctn.abort.ch)
 	xctn.abort.mu.Unlock()
 
+	xctn.unpause() // release those waiting to be resumed
+
 	if xctn.Kind() != apc.ActList {
 		glog.Infof("%s aborted(%v)", xctn.Name(), err)
 	}
 	return true
 }
 
+//
+// pausing (and resuming)
+// - only the xactions marked `Pausable` in the xact.Table;
+// - it is up to the xaction to stop at a safe point (and save its progress)
+//   upon noticing that it's been paused - see ChanResume and mpather.Pauser
+//
+
+func (xctn *Base) Pause() error {
+	if !Table[xctn.kind].Pausable {
+		return fmt.Errorf("%s: pausing %q is not supported", xctn.Name(), xctn.kind)
+	}
+	if xctn.Finished() || xctn.IsAborted() {
+		return fmt.Errorf("%s: cannot pause - not running", xctn.Name())
+	}
+	xctn.pause.mu.Lock()
+	if xctn.pause.ch == nil {
+		xctn.pause.ch = make(chan struct{})
+		glog.Infof("%s paused", xctn.Name())
+	}
+	xctn.pause.mu.Unlock()
+	return nil
+}
+
+func (xctn *Base) Resume() error {
+	if !Table[xctn.kind].Pausable {
+		return fmt.Errorf("%s: pausing (and resuming) %q is not supported", xctn.Name(), xctn.kind)
+	}
+	if xctn.Finished() || xctn.IsAborted() {
+		return fmt.Errorf("%s: cannot resume - not running", xctn.Name())
+	}
+	if xctn.unpause() {
+		glog.Infof("%s resumed", xctn.Name())
+	}
+	return nil
+}
+
+func (xctn *Base) unpause() (ok bool) {
+	xctn.pause.mu.Lock()
+	if ok = xctn.pause.ch != nil; ok {
+		close(xctn.pause.ch)
+		xctn.pause.ch = nil
+	}
+	xctn.pause.mu.Unlock()
+	return
+}
+
+func (xctn *Base) IsPaused() bool { return xctn.ChanResume() != nil }
+
+// returns nil when not paused; otherwise, the channel that gets closed upon resume (or abort)
+func (xctn *Base) ChanResume() (ch <-chan struct{}) {
+	xctn.pause.mu.Lock()
+	if xctn.pause.ch != nil {
+		ch = xctn.pause.ch
+	}
+	xctn.pause.mu.Unlock()
+	return
+}
+
 // count all the way to duration; reset and adjust every time activity is detected
 func (xctn *Base) Quiesce(d time.Duration, cb cluster.QuiCB) cluster.QuiRes {
 	var (
@@ -315,6 +379,7 @@ func (xctn *Base) ToSnap(snap *Snap) {
 	snap.StartTime = xctn.StartTime()
 	snap.EndTime = xctn.EndTime()
 	snap.AbortedX = xctn.IsAborted()
+	snap.PausedX = xctn.IsPaused()
 
 	xctn.ToStats(&snap.Stats)
 }
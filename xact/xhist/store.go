@@ -0,0 +1,266 @@
+// Package xhist provides persistent (on-disk) history of finished jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xhist
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Store keeps job records in a rotating set of files, one JSON-encoded record per line:
+// new records get appended to the current (last) file; once it reaches the configured
+// number of records the store rotates, removing the oldest file(s) beyond the limit
+// (see cmn.JobHistoryConf).
+//
+// Queries read the files in reverse order, returning the newest records first.
+// All operations are serialized (queries are expected to be relatively rare).
+//
+// Records received from another IC member (see Merge) may get appended after the newer
+// ones - that's why records within a file are ordered by their end times upon query.
+
+const (
+	filePrefix = "jobs."
+	fileSuffix = ".json"
+	maxLineLen = 16 * cos.MiB
+)
+
+type Store struct {
+	dir  string
+	fh   *os.File
+	seqs []int64 // existing files, ascending
+	cnt  int     // number of records in the current file
+	mu   sync.Mutex
+}
+
+func fname(seq int64) string { return fmt.Sprintf("%s%08d%s", filePrefix, seq, fileSuffix) }
+
+func Open(dir string) (*Store, error) {
+	if err := cos.CreateDir(dir); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix), 10, 64)
+		if err != nil {
+			glog.Warningf("job history: unexpected file %q in %s", name, dir)
+			continue
+		}
+		s.seqs = append(s.seqs, seq)
+	}
+	sort.Slice(s.seqs, func(i, j int) bool { return s.seqs[i] < s.seqs[j] })
+	if len(s.seqs) == 0 {
+		s.seqs = append(s.seqs, 1)
+	} else if err := s.readFile(s.seqs[len(s.seqs)-1], func(*xact.HistRecord) { s.cnt++ }); err != nil {
+		return nil, err
+	}
+	if s.fh, err = s.openCurrent(); err != nil {
+		return nil, err
+	}
+	if err = s.terminate(); err != nil {
+		s.fh.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// make sure the (possibly, partially written) last line is terminated
+func (s *Store) terminate() error {
+	finfo, err := s.fh.Stat()
+	if err != nil || finfo.Size() == 0 {
+		return err
+	}
+	fh, err := os.Open(s.fh.Name())
+	if err != nil {
+		return err
+	}
+	last := make([]byte, 1)
+	_, err = fh.ReadAt(last, finfo.Size()-1)
+	fh.Close()
+	if err != nil || last[0] == '\n' {
+		return err
+	}
+	_, err = s.fh.Write([]byte{'\n'})
+	return err
+}
+
+func (s *Store) Dir() string { return s.dir }
+
+func (s *Store) openCurrent() (*os.File, error) {
+	fqn := filepath.Join(s.dir, fname(s.seqs[len(s.seqs)-1]))
+	return os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+}
+
+func (s *Store) Add(rec *xact.HistRecord, conf *cmn.JobHistoryConf) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(rec, conf)
+}
+
+// Merge adds the records that the store doesn't have (by ID) in the order of their
+// end times; returns the number of added records
+func (s *Store) Merge(recs []*xact.HistRecord, conf *cmn.JobHistoryConf) (n int, err error) {
+	if len(recs) == 0 {
+		return
+	}
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].EndTime.Before(recs[j].EndTime) })
+	since := recs[0].EndTime
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make(cos.StringSet, len(recs))
+	for i := len(s.seqs) - 1; i >= 0; i-- {
+		var newer bool
+		err = s.readFile(s.seqs[i], func(rec *xact.HistRecord) {
+			if !rec.EndTime.Before(since) {
+				ids.Add(rec.ID)
+				newer = true
+			}
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return
+		}
+		err = nil
+		if !newer {
+			break // (older files have older records)
+		}
+	}
+	for _, rec := range recs {
+		if ids.Contains(rec.ID) {
+			continue
+		}
+		if err = s.add(rec, conf); err != nil {
+			return
+		}
+		ids.Add(rec.ID)
+		n++
+	}
+	return
+}
+
+// under lock
+func (s *Store) add(rec *xact.HistRecord, conf *cmn.JobHistoryConf) error {
+	b, err := jsoniter.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if s.fh == nil {
+		return fmt.Errorf("job history %s: closed", s.dir)
+	}
+	if s.cnt >= conf.RecordsPerFile {
+		if err := s.rotate(conf.MaxFiles); err != nil {
+			return err
+		}
+	}
+	if _, err := s.fh.Write(b); err != nil {
+		return err
+	}
+	s.cnt++
+	return nil
+}
+
+// under lock
+func (s *Store) rotate(maxFiles int) (err error) {
+	if err = s.fh.Close(); err != nil {
+		glog.Error(err)
+	}
+	s.seqs = append(s.seqs, s.seqs[len(s.seqs)-1]+1)
+	for len(s.seqs) > maxFiles {
+		fqn := filepath.Join(s.dir, fname(s.seqs[0]))
+		if err := os.Remove(fqn); err != nil && !os.IsNotExist(err) {
+			glog.Error(err)
+		}
+		s.seqs = s.seqs[1:]
+	}
+	s.cnt = 0
+	s.fh, err = s.openCurrent()
+	return
+}
+
+// Query returns matching records, newest first
+func (s *Store) Query(q *xact.HistQuery) (recs []*xact.HistRecord, err error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = xact.HistDefaultLimit
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.seqs) - 1; i >= 0 && len(recs) < limit; i-- {
+		var matched []*xact.HistRecord
+		err = s.readFile(s.seqs[i], func(rec *xact.HistRecord) {
+			if q.Match(rec) {
+				matched = append(matched, rec)
+			}
+		})
+		if err != nil {
+			if os.IsNotExist(err) {
+				err = nil
+				continue
+			}
+			return
+		}
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].EndTime.Before(matched[j].EndTime) })
+		for j := len(matched) - 1; j >= 0 && len(recs) < limit; j-- {
+			recs = append(recs, matched[j])
+		}
+	}
+	return
+}
+
+// skips (and logs) undecodable lines, e.g. a partially written one upon power loss
+func (s *Store) readFile(seq int64, cb func(rec *xact.HistRecord)) error {
+	fqn := filepath.Join(s.dir, fname(seq))
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	scanner := bufio.NewScanner(fh)
+	scanner.Buffer(nil, maxLineLen)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		rec := &xact.HistRecord{}
+		if err := jsoniter.Unmarshal(line, rec); err != nil {
+			glog.Errorf("job history %s: failed to decode record: %v", fqn, err)
+			continue
+		}
+		cb(rec)
+	}
+	return scanner.Err()
+}
+
+func (s *Store) Close() (err error) {
+	s.mu.Lock()
+	if s.fh != nil {
+		err = s.fh.Close()
+		s.fh = nil
+	}
+	s.mu.Unlock()
+	return
+}
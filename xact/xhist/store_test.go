@@ -0,0 +1,163 @@
+// Package xhist provides persistent (on-disk) history of finished jobs.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xhist
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+func newRec(i int, kind string, bck cmn.Bck, end time.Time) *xact.HistRecord {
+	return &xact.HistRecord{
+		ID:        "id-" + strconv.Itoa(i),
+		Kind:      kind,
+		Buckets:   []cmn.Bck{bck},
+		User:      "user-" + strconv.Itoa(i%2),
+		StartTime: end.Add(-time.Minute),
+		EndTime:   end,
+		Stats:     xact.Stats{Objs: int64(i)},
+	}
+}
+
+func TestStoreRotate(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		conf = &cmn.JobHistoryConf{RecordsPerFile: 10, MaxFiles: 3}
+		bck  = cmn.Bck{Name: "b", Provider: apc.ProviderAIS}
+		now  = time.Now()
+	)
+	s, err := Open(dir)
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 45; i++ {
+		tassert.CheckFatal(t, s.Add(newRec(i, apc.ActEvictObjects, bck, now), conf))
+	}
+	entries, err := os.ReadDir(dir)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(entries) == 3, "expected 3 files, got %d", len(entries))
+
+	recs, err := s.Query(&xact.HistQuery{Limit: 1000})
+	tassert.CheckFatal(t, err)
+	// 2 full files (20..39) plus the current one (40..44)
+	tassert.Fatalf(t, len(recs) == 25, "expected 25 records, got %d", len(recs))
+	tassert.Errorf(t, recs[0].ID == "id-44" && recs[24].ID == "id-20", "expected newest first: %s ... %s",
+		recs[0].ID, recs[24].ID)
+	tassert.CheckFatal(t, s.Close())
+
+	// reopen and continue counting in the current file
+	s, err = Open(dir)
+	tassert.CheckFatal(t, err)
+	for i := 45; i < 51; i++ {
+		tassert.CheckFatal(t, s.Add(newRec(i, apc.ActEvictObjects, bck, now), conf))
+	}
+	recs, err = s.Query(&xact.HistQuery{Limit: 1000})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 21, "expected 21 records (30..50), got %d", len(recs))
+	tassert.CheckFatal(t, s.Close())
+}
+
+func TestStoreQuery(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		conf = &cmn.JobHistoryConf{RecordsPerFile: 4, MaxFiles: 10}
+		bck1 = cmn.Bck{Name: "b1", Provider: apc.ProviderAIS}
+		bck2 = cmn.Bck{Name: "b2", Provider: apc.ProviderAIS}
+		t0   = time.Now().Add(-time.Hour)
+	)
+	s, err := Open(dir)
+	tassert.CheckFatal(t, err)
+	defer s.Close()
+	for i := 0; i < 20; i++ {
+		kind, bck := apc.ActCopyBck, bck1
+		if i%4 == 0 {
+			kind, bck = apc.ActMakeNCopies, bck2
+		}
+		tassert.CheckFatal(t, s.Add(newRec(i, kind, bck, t0.Add(time.Duration(i)*time.Minute)), conf))
+	}
+	tests := []struct {
+		q   xact.HistQuery
+		cnt int
+	}{
+		{xact.HistQuery{}, 20},
+		{xact.HistQuery{Limit: 3}, 3},
+		{xact.HistQuery{Kind: apc.ActMakeNCopies}, 5},
+		{xact.HistQuery{Bck: bck1}, 15},
+		{xact.HistQuery{Bck: bck2, Kind: apc.ActCopyBck}, 0},
+		{xact.HistQuery{User: "user-1"}, 10},
+		{xact.HistQuery{Since: t0.Add(10 * time.Minute)}, 10},
+		{xact.HistQuery{Since: t0.Add(10 * time.Minute), Until: t0.Add(14 * time.Minute)}, 5},
+	}
+	for _, test := range tests {
+		recs, err := s.Query(&test.q)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(recs) == test.cnt, "%+v: expected %d records, got %d", test.q, test.cnt, len(recs))
+	}
+}
+
+func TestStorePartialWrite(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		conf = &cmn.JobHistoryConf{RecordsPerFile: 100, MaxFiles: 2}
+		bck  = cmn.Bck{Name: "b", Provider: apc.ProviderAIS}
+	)
+	s, err := Open(dir)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, s.Add(newRec(0, apc.ActPrefetchObjects, bck, time.Now()), conf))
+	tassert.CheckFatal(t, s.Close())
+
+	// simulate interrupted write
+	fh, err := os.OpenFile(filepath.Join(dir, fname(1)), os.O_WRONLY|os.O_APPEND, 0)
+	tassert.CheckFatal(t, err)
+	_, err = fh.WriteString(`{"id":"id-1","ki`)
+	tassert.CheckFatal(t, err)
+	fh.Close()
+
+	s, err = Open(dir)
+	tassert.CheckFatal(t, err)
+	defer s.Close()
+	tassert.CheckFatal(t, s.Add(newRec(2, apc.ActPrefetchObjects, bck, time.Now()), conf))
+	recs, err := s.Query(&xact.HistQuery{})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 2, "expected 2 records, got %d", len(recs))
+	tassert.Errorf(t, recs[0].ID == "id-2" && recs[1].ID == "id-0", "unexpected %s, %s", recs[0].ID, recs[1].ID)
+}
+
+// records received from another IC member
+func TestStoreMerge(t *testing.T) {
+	var (
+		conf = &cmn.JobHistoryConf{RecordsPerFile: 100, MaxFiles: 3}
+		bck  = cmn.Bck{Name: "b", Provider: apc.ProviderAIS}
+		now  = time.Now()
+	)
+	s, err := Open(t.TempDir())
+	tassert.CheckFatal(t, err)
+	defer s.Close()
+	// local: 5, 6, 7
+	for i := 5; i < 8; i++ {
+		tassert.CheckFatal(t, s.Add(newRec(i, apc.ActEvictObjects, bck, now.Add(time.Duration(i)*time.Second)), conf))
+	}
+	// remote: 3 ... 9 (unordered)
+	var remote []*xact.HistRecord
+	for _, i := range []int{9, 3, 4, 5, 6, 7, 8} {
+		remote = append(remote, newRec(i, apc.ActEvictObjects, bck, now.Add(time.Duration(i)*time.Second)))
+	}
+	n, err := s.Merge(remote, conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 4, "expected 4 merged records, got %d", n)
+
+	recs, err := s.Query(&xact.HistQuery{})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 7, "expected 7 records, got %d", len(recs))
+	for j, rec := range recs {
+		tassert.Errorf(t, rec.ID == "id-"+strconv.Itoa(9-j), "expected newest first, got %s at %d", rec.ID, j)
+	}
+}
@@ -112,6 +112,14 @@ func RenewBckLoadLomCache(t cluster.Target, uuid string, bck *cluster.Bck) Renew
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{T: t, UUID: uuid})
 }
 
+func RenewInventory(t cluster.Target, uuid string, bck *cluster.Bck, run string) RenewRes {
+	return RenewBucketXact(apc.ActInventory, bck, Args{T: t, UUID: uuid, Custom: run})
+}
+
+func RenewReplResync(t cluster.Target, uuid string, bck *cluster.Bck) RenewRes {
+	return RenewBucketXact(apc.ActReplResync, bck, Args{T: t, UUID: uuid})
+}
+
 func RenewPutMirror(t cluster.Target, lom *cluster.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{T: t, Custom: lom})
 }
@@ -43,6 +43,16 @@ func RenewLRU(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewLifecycle(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActLifecycle].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
+func RenewScrub(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActScrub].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewStoreCleanup(id string) RenewRes {
 	e := dreg.nonbckXacts[apc.ActStoreCleanup].New(Args{UUID: id}, nil)
 	return dreg.renew(e, nil)
@@ -217,6 +217,47 @@ func DoAbort(flt XactFilter, err error) (aborted bool) {
 	return
 }
 
+// DoPause pauses or resumes (the running, pausable) xaction(s) selected by the filter;
+// returns ErrXactionNotFound when there are none
+func DoPause(flt XactFilter, resume bool) (err error) {
+	var (
+		found []cluster.Xact
+		onl   = true
+	)
+	flt.OnlyRunning = &onl
+	if flt.ID != "" {
+		if xctn := dreg.getXact(flt.ID); xctn != nil && xctn.Running() {
+			found = append(found, xctn)
+		}
+	} else {
+		dreg.entries.forEach(func(entry Renewable) bool {
+			xctn := entry.Get()
+			if !xact.Table[xctn.Kind()].Pausable || (flt.Bck != nil && !xact.IsBckScope(xctn.Kind())) {
+				return true
+			}
+			if flt.matches(xctn) {
+				found = append(found, xctn)
+			}
+			return true
+		})
+	}
+	if len(found) == 0 {
+		return cmn.NewErrXactNotFoundError(flt.String())
+	}
+	for _, xctn := range found {
+		var errX error
+		if resume {
+			errX = xctn.Resume()
+		} else {
+			errX = xctn.Pause()
+		}
+		if errX != nil && err == nil {
+			err = errX
+		}
+	}
+	return
+}
+
 func GetSnap(flt XactFilter) ([]cluster.XactSnap, error) {
 	var onlyRunning bool
 	if flt.OnlyRunning != nil {
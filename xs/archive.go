@@ -162,7 +162,11 @@ func (r *XactCreateArchMultiObj) Begin(msg *cmn.ArchiveMsg) (err error) {
 		} else if wi.msg.AllowAppendToExisting {
 			switch msg.Mime {
 			case cos.ExtTar:
-				err = wi.openTarForAppend()
+				if wi.lom.Load(false /*cache it*/, false /*locked*/) == nil && wi.lom.IsEncrypted() {
+					err = fmt.Errorf("%s: cannot append to %s that is encrypted at rest", r.p.T, msg.FullName())
+				} else {
+					err = wi.openTarForAppend()
+				}
 			default:
 				err = fmt.Errorf("unsupported archive type %s, only %s is supported", msg.Mime, cos.ExtTar)
 			}
@@ -279,13 +283,13 @@ fin:
 	}
 }
 
-func (r *XactCreateArchMultiObj) doSend(lom *cluster.LOM, wi *archwi, fh cos.ReadOpenCloser) {
+func (r *XactCreateArchMultiObj) doSend(lom *cluster.LOM, oah cmn.ObjAttrsHolder, wi *archwi, fh cos.ReadOpenCloser) {
 	o := transport.AllocSend()
 	hdr := &o.Hdr
 	{
 		hdr.Bck = wi.msg.ToBck
 		hdr.ObjName = lom.ObjName
-		hdr.ObjAttrs.CopyFrom(lom.ObjAttrs())
+		hdr.ObjAttrs.CopyFrom(oah)
 		hdr.Opaque = []byte(wi.msg.TxnUUID)
 	}
 	o.Callback = func(_ transport.ObjHdr, _ io.ReadCloser, _ interface{}, _ error) {
@@ -393,18 +397,18 @@ func (wi *archwi) do(lom *cluster.LOM, lrit *lriterator) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
-	debug.AssertNoErr(err)
+	// (plaintext if encrypted at rest)
+	fh, oah, err := lom.PlainReader()
 	if err != nil {
 		wi.r.raiseErr(err, 0, wi.msg.ContinueOnError)
 		return
 	}
 	if t.SID() != wi.tsi.ID() {
-		wi.r.doSend(lom, wi, fh)
+		wi.r.doSend(lom, oah, wi, fh)
 		return
 	}
 	debug.Assert(wi.fh != nil) // see Begin
-	err = wi.writer.write(wi.nameInArch(lom.ObjName), lom, fh)
+	err = wi.writer.write(wi.nameInArch(lom.ObjName), oah, fh)
 	cluster.FreeLOM(lom)
 	cos.Close(fh)
 	if err != nil {
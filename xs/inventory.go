@@ -0,0 +1,259 @@
+// Package xs contains eXtended actions (xactions) except storage services
+// (mirror, ec) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/objwalk/walkinfo"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Inventory xaction walks the target's share of a bucket - in sorted order (see fs.WalkBck) -
+// and writes a manifest that contains name, size, checksum, atime, and version of each
+// object. The manifest is then stored (promoted) in the destination bucket as:
+//
+//   [prefix/]<provider>/<bucket>/<run>/<target ID>.<csv|msgpack>
+//
+// where <run> is either the xaction ID (inventory on demand) or the start time of the
+// current interval (periodic inventory, see cmn.InventoryConf).
+
+const InvTimeFormat = time.RFC3339Nano // atime in the manifests
+
+type (
+	invFactory struct {
+		xreg.RenewBase
+		xctn *XactInventory
+	}
+	XactInventory struct {
+		xact.Base
+		t   cluster.Target
+		run string
+	}
+
+	// InventoryWriter writes manifest entries in a given format (cmn.InvFormatCSV, etc.)
+	InventoryWriter interface {
+		Write(e *cmn.BucketEntry) error
+		Flush() error
+	}
+	invCSV struct {
+		w   *csv.Writer
+		rec [5]string
+	}
+	invMsgpack struct {
+		w *msgp.Writer
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactInventory)(nil)
+	_ xreg.Renewable = (*invFactory)(nil)
+
+	_ InventoryWriter = (*invCSV)(nil)
+	_ InventoryWriter = (*invMsgpack)(nil)
+)
+
+// InventoryRun returns the name of the periodic inventory run that starts at a given time
+func InventoryRun(started time.Time) string { return started.UTC().Format("20060102T150405Z") }
+
+// InventoryName returns the name of the target's manifest (object)
+func InventoryName(conf *cmn.InventoryConf, bck *cmn.Bck, run, tid string) string {
+	return path.Join(conf.Prefix, bck.Provider, bck.Name, run, tid+conf.Ext())
+}
+
+func NewInventoryWriter(w io.Writer, format string) (InventoryWriter, error) {
+	switch format {
+	case "", cmn.InvFormatCSV:
+		iw := &invCSV{w: csv.NewWriter(w)}
+		return iw, iw.w.Write([]string{"name", "size", "checksum", "atime", "version"})
+	case cmn.InvFormatMsgpack:
+		return &invMsgpack{w: msgp.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("invalid inventory format %q", format)
+	}
+}
+
+////////////////
+// invFactory //
+////////////////
+
+func (*invFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &invFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *invFactory) Start() error {
+	run := p.Args.Custom.(string)
+	if run == "" {
+		run = p.UUID() // on demand
+	}
+	p.xctn = &XactInventory{t: p.T, run: run}
+	p.xctn.InitBase(p.UUID(), apc.ActInventory, p.Bck)
+	return nil
+}
+
+func (*invFactory) Kind() string        { return apc.ActInventory }
+func (p *invFactory) Get() cluster.Xact { return p.xctn }
+
+func (*invFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+///////////////////
+// XactInventory //
+///////////////////
+
+func (r *XactInventory) Run(*sync.WaitGroup) {
+	glog.Infof("%s started (run %s)", r, r.run)
+	err := r.do()
+	if err != nil {
+		glog.Errorf("%s: %v", r, err)
+	}
+	r.Finish(err)
+	glog.Infof("%s finished (objects %d, size %d)", r, r.Objs(), r.Bytes())
+}
+
+func (r *XactInventory) do() error {
+	var (
+		bck  = r.Bck()
+		conf = &bck.Props.Inventory
+	)
+	if conf.Bucket == "" {
+		return fmt.Errorf("%s: destination bucket is not configured (see bucket property inventory.bucket)", r)
+	}
+	dstBck, err := conf.Dest()
+	if err != nil {
+		return err
+	}
+	if dstBck.Equal(bck.Bucket()) {
+		return fmt.Errorf("%s: destination bucket cannot be the same as the source", r)
+	}
+	dst := cluster.CloneBck(&dstBck)
+	if err := dst.Init(r.t.Bowner()); err != nil {
+		return err
+	}
+	lom := cluster.AllocLOM(InventoryName(conf, bck.Bucket(), r.run, r.t.SID()))
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(dst.Bucket()); err != nil {
+		return err
+	}
+	workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileInventory)
+	if err := r.write(lom, workFQN, conf.Format); err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("%s: nested error: %v", r, errRm)
+		}
+		return err
+	}
+	_, err = r.t.Promote(cluster.PromoteParams{
+		Bck: dst,
+		PromoteArgs: cluster.PromoteArgs{
+			SrcFQN:         workFQN,
+			ObjName:        lom.ObjName,
+			OverwriteDst:   true,
+			DeleteSrc:      true,
+			SrcIsNotFshare: true,
+		},
+	})
+	if err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("%s: nested error: %v", r, errRm)
+		}
+	}
+	return err
+}
+
+// walk the bucket and write the manifest
+func (r *XactInventory) write(lom *cluster.LOM, workFQN, format string) error {
+	fh, err := lom.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	var (
+		bw  = bufio.NewWriterSize(fh, 64*cos.KiB)
+		msg = &apc.ListObjsMsg{Props: apc.GetPropsSize + "," + apc.GetPropsChecksum + "," +
+			apc.GetPropsAtime + "," + apc.GetPropsVersion, TimeFormat: InvTimeFormat}
+		wi = walkinfo.NewWalkInfo(context.Background(), r.t, msg)
+	)
+	iw, err := NewInventoryWriter(bw, format)
+	if err != nil {
+		fh.Close()
+		return err
+	}
+	cb := func(fqn string, de fs.DirEntry) error {
+		if r.IsAborted() {
+			return cmn.NewErrAborted(r.Name(), "", r.AbortErr())
+		}
+		entry, err := wi.Callback(fqn, de)
+		if entry == nil || err != nil {
+			return err
+		}
+		if err := iw.Write(entry); err != nil {
+			return err
+		}
+		r.ObjsAdd(1, entry.Size)
+		return nil
+	}
+	opts := &fs.WalkBckOpts{
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: cb, Sorted: true},
+	}
+	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
+	err = fs.WalkBck(opts)
+	if err == nil {
+		err = iw.Flush()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+////////////
+// invCSV //
+////////////
+
+func (iw *invCSV) Write(e *cmn.BucketEntry) error {
+	iw.rec[0] = e.Name
+	iw.rec[1] = strconv.FormatInt(e.Size, 10)
+	iw.rec[2] = e.Checksum
+	iw.rec[3] = e.Atime
+	iw.rec[4] = e.Version
+	return iw.w.Write(iw.rec[:])
+}
+
+func (iw *invCSV) Flush() error {
+	iw.w.Flush()
+	return iw.w.Error()
+}
+
+////////////////
+// invMsgpack //
+////////////////
+
+func (iw *invMsgpack) Write(e *cmn.BucketEntry) error {
+	entry := cmn.BucketEntry{Name: e.Name, Size: e.Size, Checksum: e.Checksum, Atime: e.Atime, Version: e.Version}
+	return entry.EncodeMsg(iw.w)
+}
+
+func (iw *invMsgpack) Flush() error { return iw.w.Flush() }
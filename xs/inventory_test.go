@@ -0,0 +1,74 @@
+// Package xs_test contains xs unit test.
+/*
+ * Copyright (c) 2022, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tassert"
+	"github.com/NVIDIA/aistore/xs"
+	"github.com/tinylib/msgp/msgp"
+)
+
+var invEntries = []*cmn.BucketEntry{
+	{Name: "a/b", Size: 10, Checksum: "cd31", Atime: "2022-10-17T00:00:00Z", Version: "1", Flags: apc.EntryIsCached},
+	{Name: "a,\"quoted\"", Size: 0, Atime: "2022-10-17T00:00:01Z"},
+}
+
+func TestInventoryCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	iw, err := xs.NewInventoryWriter(buf, cmn.InvFormatCSV)
+	tassert.CheckFatal(t, err)
+	for _, e := range invEntries {
+		tassert.CheckFatal(t, iw.Write(e))
+	}
+	tassert.CheckFatal(t, iw.Flush())
+
+	recs, err := csv.NewReader(buf).ReadAll()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == len(invEntries)+1, "expected %d records, got %d", len(invEntries)+1, len(recs))
+	tassert.Errorf(t, recs[0][0] == "name" && recs[0][4] == "version", "unexpected header %v", recs[0])
+	tassert.Errorf(t, recs[1][0] == "a/b" && recs[1][1] == "10" && recs[1][2] == "cd31" && recs[1][4] == "1",
+		"unexpected %v", recs[1])
+	tassert.Errorf(t, recs[2][0] == invEntries[1].Name, "unexpected %v", recs[2])
+}
+
+func TestInventoryMsgpack(t *testing.T) {
+	buf := &bytes.Buffer{}
+	iw, err := xs.NewInventoryWriter(buf, cmn.InvFormatMsgpack)
+	tassert.CheckFatal(t, err)
+	for _, e := range invEntries {
+		tassert.CheckFatal(t, iw.Write(e))
+	}
+	tassert.CheckFatal(t, iw.Flush())
+
+	r := msgp.NewReader(buf)
+	for _, e := range invEntries {
+		var decoded cmn.BucketEntry
+		tassert.CheckFatal(t, decoded.DecodeMsg(r))
+		tassert.Errorf(t, decoded.Name == e.Name && decoded.Size == e.Size && decoded.Checksum == e.Checksum &&
+			decoded.Atime == e.Atime && decoded.Version == e.Version, "expected %+v, got %+v", e, decoded)
+		tassert.Errorf(t, decoded.Flags == 0, "expected no flags, got %x", decoded.Flags)
+	}
+	tassert.Errorf(t, buf.Len() == 0, "unexpected %d trailing bytes", buf.Len())
+
+	_, err = xs.NewInventoryWriter(buf, "parquet")
+	tassert.Errorf(t, err != nil, "expected error: invalid format")
+}
+
+func TestInventoryName(t *testing.T) {
+	var (
+		conf    = &cmn.InventoryConf{Bucket: "ais://inv", Prefix: "daily"}
+		bck     = &cmn.Bck{Name: "src", Provider: apc.ProviderAmazon}
+		started = time.Date(2022, 10, 17, 0, 0, 0, 0, time.UTC)
+		name    = xs.InventoryName(conf, bck, xs.InventoryRun(started), "t1")
+	)
+	tassert.Errorf(t, name == "daily/aws/src/20221017T000000Z/t1.csv", "unexpected %q", name)
+}
@@ -398,6 +398,20 @@ func (r *ObjListXact) traverseBucket(msg *apc.ListObjsMsg) {
 		case <-r.walkStopCh.Listen():
 			return errStopped
 		}
+		if msg.IsFlagSet(apc.LsVerHistory) {
+			vers, err := wi.ListVersions(fqn, entry)
+			if err != nil {
+				return err
+			}
+			for _, e := range vers {
+				select {
+				case r.objCache <- e:
+					/* do nothing */
+				case <-r.walkStopCh.Listen():
+					return errStopped
+				}
+			}
+		}
 		if !msg.IsFlagSet(apc.LsArchDir) {
 			return nil
 		}